@@ -0,0 +1,189 @@
+package gojson
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Order is the direction of a sort.
+type Order int
+
+const (
+	// Ascending sorts from the smallest value to the largest.
+	Ascending Order = iota
+
+	// Descending sorts from the largest value to the smallest.
+	Descending
+)
+
+// SortArray returns a copy of the JSON array data with its elements sorted by the value at the key
+// path byPath within each element. Use empty string ("") to sort by the elements themselves. The sort
+// is stable, and the output is compact.
+//
+// Numbers are compared numerically, strings by their unescaped contents, and false comes before true.
+// Values of different types are ordered null, boolean, number, string, object, then array, and
+// objects and arrays are left in their original order. Elements without the key path always come
+// last, whatever the order.
+//
+// Example:
+//
+//	b, _ := gojson.SortArray([]byte(`[{"n": "b", "age": 30}, {"n": "a", "age": 25}]`), "age", gojson.Descending)
+//	// [{"n":"b","age":30},{"n":"a","age":25}]
+func SortArray(data []byte, byPath string, order Order) ([]byte, error) {
+	root, err := arrayRoot(data)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]parsed, len(root.keys))
+	values := make([]sortValue, len(root.keys))
+	for i, k := range root.keys {
+		elements[i] = root.children[k]
+		values[i] = newSortValue(elements[i], byPath)
+	}
+
+	indexes := make([]int, len(elements))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(a, b int) bool {
+		va, vb := values[indexes[a]], values[indexes[b]]
+		if va.missing || vb.missing {
+			return !va.missing && vb.missing
+		}
+
+		c := va.compare(vb)
+		if order == Descending {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, idx := range indexes {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(compact(elements[idx]))
+	}
+	buf.WriteByte(']')
+
+	return buf.Bytes(), nil
+}
+
+// FilterArray returns a copy of the JSON array data holding only the elements for which the predicate
+// returns true, in their original order. The output is compact.
+//
+// Example:
+//
+//	b, _ := gojson.FilterArray(data, func(e *gojson.JSONReader) bool {
+//		return e.GetBool("published")
+//	})
+func FilterArray(data []byte, predicate func(element *JSONReader) bool) ([]byte, error) {
+	root, err := arrayRoot(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	rewrite(&buf, root, "", func(path string, p parsed) (rewriteAction, []byte) {
+		if strings.IndexByte(path, '.') < 0 && !predicate(readerFor(&p)) {
+			return rewriteRemove, nil
+		}
+		return rewriteKeep, nil
+	})
+
+	return buf.Bytes(), nil
+}
+
+// arrayRoot parses data, which must be a JSON array, and returns its root node.
+func arrayRoot(data []byte) (parsed, error) {
+	if !IsJSON(data) {
+		return parsed{}, ErrMalformedJSON
+	}
+
+	r, err := NewJSONReader(data)
+	if err != nil {
+		return parsed{}, err
+	}
+
+	if r.Type != JSONArray {
+		return parsed{}, fmt.Errorf("expected a JSON array, found JSON type '%s'", r.Type)
+	}
+
+	root, _ := r.child("")
+	return root, nil
+}
+
+// sortValue is the value an element is sorted by.
+type sortValue struct {
+	missing bool
+	rank    int
+	number  float64
+	str     string
+}
+
+// sortRanks orders the JSON types, for comparing values of different types.
+var sortRanks = map[string]int{
+	JSONNull:   0,
+	JSONBool:   1,
+	JSONInt:    2,
+	JSONFloat:  2,
+	JSONString: 3,
+	JSONObject: 4,
+	JSONArray:  5,
+}
+
+// newSortValue returns the value at the key path within the element e.
+func newSortValue(e parsed, path string) sortValue {
+	p, ok := readerFor(&e).child(path)
+	if !ok {
+		return sortValue{missing: true}
+	}
+
+	v := sortValue{rank: sortRanks[p.dtype]}
+	switch p.dtype {
+	case JSONBool:
+		if bytes.Equal(trim(p.bytes), []byte("true")) {
+			v.number = 1
+		}
+	case JSONInt, JSONFloat:
+		v.number, _ = strconv.ParseFloat(string(trim(p.bytes)), 64)
+	case JSONString:
+		v.str = manualUnescapeString(trimString(p.bytes))
+	}
+
+	return v
+}
+
+// compare returns -1, 0 or 1 as v is less than, equal to, or greater than o.
+func (v sortValue) compare(o sortValue) int {
+	switch {
+	case v.rank != o.rank:
+		return compareInts(v.rank, o.rank)
+	case v.rank == sortRanks[JSONString]:
+		return strings.Compare(v.str, o.str)
+	case v.number < o.number:
+		return -1
+	case v.number > o.number:
+		return 1
+	}
+
+	return 0
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
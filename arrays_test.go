@@ -0,0 +1,94 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortArray(t *testing.T) {
+	people := []byte(`[
+		{"name": "Carol", "age": 41, "address": {"city": "Zurich"}},
+		{"name": "alice", "age": 30.5},
+		{"name": "Bob", "age": "n/a", "address": {"city": "Austin"}},
+		{"name": "Dave", "age": 30.5, "address": {"city": "Boston"}},
+		{"name": "Eve", "age": null}
+	]`)
+
+	names := func(b []byte) []string {
+		r, err := NewJSONReader(b)
+		assert.Nil(t, err)
+		return r.Pluck("", "name")
+	}
+
+	testCases := []struct {
+		name     string
+		path     string
+		order    Order
+		expected []string
+	}{
+		{"Number Ascending", "age", Ascending, []string{"Eve", "alice", "Dave", "Carol", "Bob"}},
+		{"Number Descending", "age", Descending, []string{"Bob", "Carol", "alice", "Dave", "Eve"}},
+		{"String", "name", Ascending, []string{"Bob", "Carol", "Dave", "Eve", "alice"}},
+		{"Nested Path With Missing", "address.city", Ascending, []string{"Bob", "Dave", "Carol", "alice", "Eve"}},
+		{"Missing Last Descending", "address.city", Descending, []string{"Carol", "Dave", "Bob", "alice", "Eve"}},
+		{"Objects Are Stable", "address", Ascending, []string{"Carol", "Bob", "Dave", "alice", "Eve"}},
+		{"Missing Key", "missing", Ascending, []string{"Carol", "alice", "Bob", "Dave", "Eve"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := SortArray(people, tc.path, tc.order)
+			assert.Nil(t, err)
+			assert.True(t, IsJSON(out), string(out))
+			assert.Equal(t, tc.expected, names(out))
+		})
+	}
+
+	t.Run("Scalars", func(t *testing.T) {
+		out, err := SortArray([]byte(`[3, "b", true, null, 1.5, "aA", false, [1], {"a": 1}, "a"]`), "", Ascending)
+		assert.Nil(t, err)
+		assert.Equal(t, `[null,false,true,1.5,3,"a","aA","b",{"a":1},[1]]`, string(out))
+
+		out, err = SortArray([]byte(`[]`), "", Descending)
+		assert.Nil(t, err)
+		assert.Equal(t, `[]`, string(out))
+	})
+
+	t.Run("Compact", func(t *testing.T) {
+		out, err := SortArray(people, "name", Ascending)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"name":"Bob","age":"n/a","address":{"city":"Austin"}}`, string(out[1:len(`{"name":"Bob","age":"n/a","address":{"city":"Austin"}}`)+1]))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := SortArray([]byte(`{"a": 1}`), "", Ascending)
+		assert.EqualError(t, err, "expected a JSON array, found JSON type 'object'")
+
+		_, err = SortArray([]byte(`[1, `), "", Ascending)
+		assert.Equal(t, ErrMalformedJSON, err)
+	})
+}
+
+func TestFilterArray(t *testing.T) {
+	data := []byte(`[{"id": 1, "published": true, "tags": ["a", "b"]}, {"id": 2, "published": false}, 3, {"id": 4, "published": true}]`)
+
+	out, err := FilterArray(data, func(e *JSONReader) bool {
+		return e.GetBool("published")
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"id":1,"published":true,"tags":["a","b"]},{"id":4,"published":true}]`, string(out))
+
+	out, err = FilterArray(data, func(e *JSONReader) bool {
+		return e.Type != JSONObject
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `[3]`, string(out))
+
+	out, err = FilterArray(data, func(*JSONReader) bool { return false })
+	assert.Nil(t, err)
+	assert.Equal(t, `[]`, string(out))
+
+	_, err = FilterArray([]byte(`"a"`), func(*JSONReader) bool { return true })
+	assert.EqualError(t, err, "expected a JSON array, found JSON type 'string'")
+}
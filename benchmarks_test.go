@@ -3,11 +3,14 @@ package gojson
 import (
 	"encoding/json"
 	"strconv"
+	"strings"
 	"testing"
 	"unsafe"
 )
 
 var benchData = `{"string":"some string","int":17,"bool":true,"float":22.83,"string_slice":["a","b","c","d","","\""],"bool_slice":[true,false,true,false],"int_slice":[1,2,3,4],"float_slice":[0.0,1.1,2.2,3.3],"object":{"a":"b","c":"d"},"objects":[{"e":"f","g":"h"},{"i":"j","k":"l"},{"m":"n","o":"p"}],"complex":["a", 2, null, false, 2.2, {"c":"d"}, ["s"]]}`
+var tdMapOfSlices = `{"accept": ["text/html", "application/json"], "cache-control": ["no-cache"], "x-forwarded-for": ["10.0.0.1", "10.0.0.2", "10.0.0.3"], "empty": []}`
+var tdSliceOfMaps = `[{"id": 1, "name": "a", "active": true, "score": 1.5}, {"id": 2, "name": "b", "active": false, "score": null}, {"id": 3, "name": "c", "tags": ["x", "y"]}]`
 var largeJSONTestBlobBytes = []byte(largeJSONTestBlob)
 
 func BenchmarkUnmarshalFloat(b *testing.B) {
@@ -120,6 +123,38 @@ func BenchmarkUnmarshalMapDefault(b *testing.B) {
 	}
 }
 
+func BenchmarkUnmarshalMapOfSlices(b *testing.B) {
+	var m map[string][]string
+
+	for i := 0; i < b.N; i++ {
+		Unmarshal([]byte(tdMapOfSlices), &m)
+	}
+}
+
+func BenchmarkUnmarshalMapOfSlicesDefault(b *testing.B) {
+	var m map[string][]string
+
+	for i := 0; i < b.N; i++ {
+		json.Unmarshal([]byte(tdMapOfSlices), &m)
+	}
+}
+
+func BenchmarkUnmarshalSliceOfMaps(b *testing.B) {
+	var m []map[string]interface{}
+
+	for i := 0; i < b.N; i++ {
+		Unmarshal([]byte(tdSliceOfMaps), &m)
+	}
+}
+
+func BenchmarkUnmarshalSliceOfMapsDefault(b *testing.B) {
+	var m []map[string]interface{}
+
+	for i := 0; i < b.N; i++ {
+		json.Unmarshal([]byte(tdSliceOfMaps), &m)
+	}
+}
+
 func BenchmarkUnmarshalInterface(b *testing.B) {
 	var m interface{}
 
@@ -136,6 +171,15 @@ func BenchmarkUnmarshalInterfaceDefault(b *testing.B) {
 	}
 }
 
+func BenchmarkUnmarshalInterfaceInternKeys(b *testing.B) {
+	var m interface{}
+	opts := Options{InternKeys: true}
+
+	for i := 0; i < b.N; i++ {
+		UnmarshalWithOptions([]byte(largeJSONTestBlob), &m, opts)
+	}
+}
+
 func BenchmarkUnmarshalStruct(b *testing.B) {
 	var m TestComponentResponse
 
@@ -158,6 +202,40 @@ func BenchmarkExtract(b *testing.B) {
 	}
 }
 
+var manyPaths = []string{"items.2.id", "items.9.data.assets.0.begins", "items.18.data.assets.0.begins", "items.18.metadata.schema"}
+
+func BenchmarkExtractRepeated(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, p := range manyPaths {
+			Extract(largeJSONTestBlobBytes, p)
+		}
+	}
+}
+
+func BenchmarkExtractReader(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ExtractReader(largeJSONTestBlobBytes, "items.18.data")
+	}
+}
+
+func BenchmarkDocumentSet(b *testing.B) {
+	doc, err := ParseDocument(largeJSONTestBlobBytes)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		doc.Set("items.18.data.assets.0.begins", i)
+	}
+}
+
+func BenchmarkExtractMany(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ExtractMany(largeJSONTestBlobBytes, manyPaths...)
+	}
+}
+
 func BenchmarkParse(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
@@ -165,6 +243,13 @@ func BenchmarkParse(b *testing.B) {
 	}
 }
 
+func BenchmarkParseInternKeys(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+		NewJSONReader([]byte(largeJSONTestBlob), WithInternKeys())
+	}
+}
+
 func BenchmarkGetInterface(b *testing.B) {
 	r, _ := NewJSONReader([]byte(benchData))
 
@@ -302,3 +387,164 @@ func BenchmarkMassivelyQuotesStringDefault(b *testing.B) {
 		json.Unmarshal(s, &out)
 	}
 }
+
+var escapedURLs = []byte(`"https:\/\/images.example-content-delivery-network.com\/production\/assets\/thumbnails\/2019-01-15-featured-article-banner-large.png?width=1200&height=630&format=webp&quality=85&title=\"featured\""`)
+
+func BenchmarkManualUnquoteEscapedSlashes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		manualUnescapeString(escapedURLs)
+	}
+}
+
+func BenchmarkUnquoteEscapedSlashesDefault(b *testing.B) {
+	var out string
+
+	for i := 0; i < b.N; i++ {
+		json.Unmarshal(escapedURLs, &out)
+	}
+}
+
+var longStringJSON = []byte(`{"text": "` + strings.Repeat(`Lorem ipsum dolor sit amet, consectetur adipiscing elit. `, 64) + `", "n": 1}`)
+
+func BenchmarkParseLongString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewJSONReader(longStringJSON)
+	}
+}
+
+func BenchmarkParseIndented(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewJSONReader(largeJSONTestBlobBytes)
+	}
+}
+
+func BenchmarkExtractLongString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Extract(longStringJSON, "n")
+	}
+}
+
+func BenchmarkGetStringSliceInto(b *testing.B) {
+	r, _ := NewJSONReader([]byte(benchData))
+	b.ReportAllocs()
+
+	var s []string
+	for i := 0; i < b.N; i++ {
+		s = r.GetStringSliceInto("string_slice", s[:0])
+	}
+}
+
+func BenchmarkGetIntSliceInto(b *testing.B) {
+	r, _ := NewJSONReader([]byte(benchData))
+	b.ReportAllocs()
+
+	var s []int
+	for i := 0; i < b.N; i++ {
+		s = r.GetIntSliceInto("int_slice", s[:0])
+	}
+}
+
+func BenchmarkGetStringZeroCopy(b *testing.B) {
+	r, _ := NewJSONReader([]byte(benchData), WithZeroCopyStrings())
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		r.GetString("string")
+	}
+}
+
+// compareCases are the payload shapes measured by BenchmarkCompare, each with a key path to read.
+var compareCases = []struct {
+	name string
+	data []byte
+	key  string
+}{
+	{"Object", []byte(benchData), "objects.1.k"},
+	{"MapOfSlices", []byte(tdMapOfSlices), "x-forwarded-for.2"},
+	{"SliceOfMaps", []byte(tdSliceOfMaps), "2.tags.1"},
+	{"Large", largeJSONTestBlobBytes, "items.18.data.assets.0.icon"},
+}
+
+// BenchmarkCompare measures gojson against encoding/json over each payload shape: decoding the whole
+// document, and reading a single key. See the benchsuite package for running the comparison with
+// memory thresholds.
+func BenchmarkCompare(b *testing.B) {
+	for _, c := range compareCases {
+		c := c
+
+		b.Run(c.name+"/Unmarshal", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var v interface{}
+				Unmarshal(c.data, &v)
+			}
+		})
+
+		b.Run(c.name+"/UnmarshalDefault", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var v interface{}
+				json.Unmarshal(c.data, &v)
+			}
+		})
+
+		b.Run(c.name+"/Get", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r, _ := NewJSONReader(c.data)
+				r.GetInterface(c.key)
+			}
+		})
+
+		b.Run(c.name+"/GetDefault", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var v interface{}
+				json.Unmarshal(c.data, &v)
+				lookupDefault(v, c.key)
+			}
+		})
+
+		b.Run(c.name+"/Extract", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				Extract(c.data, c.key)
+			}
+		})
+	}
+}
+
+// lookupDefault returns the value at the key path within v, decoded by encoding/json.
+func lookupDefault(v interface{}, path string) interface{} {
+	for _, k := range strings.Split(path, ".") {
+		switch c := v.(type) {
+		case map[string]interface{}:
+			v = c[k]
+		case []interface{}:
+			i, _ := strconv.Atoi(k)
+			if i < 0 || i >= len(c) {
+				return nil
+			}
+			v = c[i]
+		default:
+			return nil
+		}
+	}
+
+	return v
+}
+
+func TestCompareCases(t *testing.T) {
+	for _, c := range compareCases {
+		r, err := NewJSONReader(c.data)
+		if err != nil || !r.KeyExists(c.key) {
+			t.Errorf("%s: key '%s' not found", c.name, c.key)
+		}
+
+		var v interface{}
+		json.Unmarshal(c.data, &v)
+		if lookupDefault(v, c.key) == nil {
+			t.Errorf("%s: key '%s' not found by encoding/json", c.name, c.key)
+		}
+	}
+}
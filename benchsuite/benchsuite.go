@@ -0,0 +1,289 @@
+// Package benchsuite measures gojson against encoding/json over a set of documents, and checks the
+// memory used by each benchmark against thresholds, so that a CI job can fail on a memory regression.
+//
+// Suite.Bench runs the suite as sub-benchmarks of a Go benchmark, for go test -bench. Suite.Run runs it
+// programmatically, and Suite.Check compares the results with the suite's Thresholds.
+package benchsuite
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/btm6084/gojson"
+)
+
+// Payload is a document measured by a Suite.
+type Payload struct {
+	// Name identifies the payload in benchmark names, e.g. "large".
+	Name string
+
+	// Data is the JSON document.
+	Data []byte
+
+	// Key is a key path within the document, read by the Get and Extract benchmarks.
+	Key string
+}
+
+// Benchmark is an operation measured against each payload of a Suite.
+type Benchmark struct {
+	// Name identifies the benchmark, e.g. "gojson/Unmarshal".
+	Name string
+
+	// Run performs the operation once. An error fails the benchmark.
+	Run func(p Payload) error
+}
+
+// Threshold bounds the memory used by one operation of a benchmark. A bound of zero isn't checked.
+type Threshold struct {
+	AllocsPerOp int64
+	BytesPerOp  int64
+}
+
+// Result is the measurement of a benchmark against a payload.
+type Result struct {
+	Benchmark string
+	Payload   string
+	testing.BenchmarkResult
+}
+
+// Name returns the name of the result, "Benchmark/Payload", as used by go test -bench.
+func (r Result) Name() string {
+	return r.Benchmark + "/" + r.Payload
+}
+
+// Suite is a set of benchmarks, each measured against every payload.
+type Suite struct {
+	Payloads   []Payload
+	Benchmarks []Benchmark
+
+	// Thresholds bounds the memory used by the benchmarks, keyed by the name of a result,
+	// "Benchmark/Payload", or by the name of a benchmark for every payload. The result's own name is
+	// preferred.
+	Thresholds map[string]Threshold
+}
+
+// New returns a suite comparing gojson with encoding/json, by the Comparisons benchmarks, over the
+// given payloads, or over DefaultPayloads if none are given.
+func New(payloads ...Payload) *Suite {
+	if len(payloads) == 0 {
+		payloads = DefaultPayloads()
+	}
+
+	return &Suite{Payloads: payloads, Benchmarks: Comparisons()}
+}
+
+// Comparisons returns the benchmarks comparing gojson with encoding/json: decoding a whole document
+// into an interface{}, and reading the value at a payload's Key, along with gojson's Extract.
+func Comparisons() []Benchmark {
+	return []Benchmark{
+		{Name: "gojson/Unmarshal", Run: func(p Payload) error {
+			var v interface{}
+			return gojson.Unmarshal(p.Data, &v)
+		}},
+		{Name: "encoding_json/Unmarshal", Run: func(p Payload) error {
+			var v interface{}
+			return json.Unmarshal(p.Data, &v)
+		}},
+		{Name: "gojson/Get", Run: func(p Payload) error {
+			r, err := gojson.NewJSONReader(p.Data)
+			if err != nil {
+				return err
+			}
+			if !r.KeyExists(p.Key) {
+				return fmt.Errorf("key '%s' not found", p.Key)
+			}
+			r.GetInterface(p.Key)
+			return nil
+		}},
+		{Name: "encoding_json/Get", Run: func(p Payload) error {
+			var v interface{}
+			if err := json.Unmarshal(p.Data, &v); err != nil {
+				return err
+			}
+			_, err := lookup(v, p.Key)
+			return err
+		}},
+		{Name: "gojson/Extract", Run: func(p Payload) error {
+			_, _, err := gojson.Extract(p.Data, p.Key)
+			return err
+		}},
+	}
+}
+
+// lookup returns the value at the key path within v, decoded by encoding/json.
+func lookup(v interface{}, path string) (interface{}, error) {
+	for _, k := range strings.Split(path, ".") {
+		switch c := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = c[k]; !ok {
+				return nil, fmt.Errorf("key '%s' not found", path)
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, fmt.Errorf("key '%s' not found", path)
+			}
+			v = c[i]
+		default:
+			return nil, fmt.Errorf("key '%s' not found", path)
+		}
+	}
+
+	return v, nil
+}
+
+// Bench runs every benchmark against every payload as a sub-benchmark of b, named "Benchmark/Payload",
+// reporting allocations.
+//
+// Example:
+//
+//	func BenchmarkCompare(b *testing.B) {
+//		benchsuite.New().Bench(b)
+//	}
+func (s *Suite) Bench(b *testing.B) {
+	for _, bm := range s.Benchmarks {
+		for _, p := range s.Payloads {
+			bm, p := bm, p
+			b.Run(bm.Name+"/"+p.Name, func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(p.Data)))
+				for i := 0; i < b.N; i++ {
+					if err := bm.Run(p); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// Run measures every benchmark against every payload with testing.Benchmark, and returns the results
+// in order. Each operation is tried once first, and its error returned, as a failed benchmark has no
+// result.
+func (s *Suite) Run() ([]Result, error) {
+	var results []Result
+	for _, bm := range s.Benchmarks {
+		for _, p := range s.Payloads {
+			if err := bm.Run(p); err != nil {
+				return nil, fmt.Errorf("%s/%s: %w", bm.Name, p.Name, err)
+			}
+
+			bm, p := bm, p
+			r := testing.Benchmark(func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(p.Data)))
+				for i := 0; i < b.N; i++ {
+					_ = bm.Run(p)
+				}
+			})
+
+			results = append(results, Result{Benchmark: bm.Name, Payload: p.Name, BenchmarkResult: r})
+		}
+	}
+
+	return results, nil
+}
+
+// Regression is a result which exceeded its threshold.
+type Regression struct {
+	Result
+	Threshold Threshold
+}
+
+// RegressionError is returned by Check, listing the results which exceeded their thresholds.
+type RegressionError struct {
+	Regressions []Regression
+}
+
+func (e *RegressionError) Error() string {
+	msgs := make([]string, len(e.Regressions))
+	for i, r := range e.Regressions {
+		var over []string
+		if r.Threshold.AllocsPerOp > 0 && r.AllocsPerOp() > r.Threshold.AllocsPerOp {
+			over = append(over, fmt.Sprintf("%d allocs/op exceeds %d", r.AllocsPerOp(), r.Threshold.AllocsPerOp))
+		}
+		if r.Threshold.BytesPerOp > 0 && r.AllocedBytesPerOp() > r.Threshold.BytesPerOp {
+			over = append(over, fmt.Sprintf("%d B/op exceeds %d", r.AllocedBytesPerOp(), r.Threshold.BytesPerOp))
+		}
+		msgs[i] = r.Name() + ": " + strings.Join(over, ", ")
+	}
+
+	return "memory regression: " + strings.Join(msgs, "; ")
+}
+
+// Check compares the results with the suite's Thresholds, and returns a *RegressionError listing
+// those which used more memory than allowed, or nil. Results without a threshold pass.
+//
+// Example:
+//
+//	results, err := suite.Run()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if err := suite.Check(results); err != nil {
+//		log.Fatal(err)
+//	}
+func (s *Suite) Check(results []Result) error {
+	var regressions []Regression
+	for _, r := range results {
+		t, ok := s.Thresholds[r.Name()]
+		if !ok {
+			t, ok = s.Thresholds[r.Benchmark]
+		}
+		if !ok {
+			continue
+		}
+
+		if (t.AllocsPerOp > 0 && r.AllocsPerOp() > t.AllocsPerOp) || (t.BytesPerOp > 0 && r.AllocedBytesPerOp() > t.BytesPerOp) {
+			regressions = append(regressions, Regression{Result: r, Threshold: t})
+		}
+	}
+
+	if len(regressions) == 0 {
+		return nil
+	}
+
+	return &RegressionError{Regressions: regressions}
+}
+
+// Thresholds returns thresholds derived from baseline results, such as those of a release, allowing
+// each result's memory use to grow by the fraction slack (e.g. 0.1 for 10%) before Check fails it.
+// The thresholds are keyed by the name of each result.
+func Thresholds(baseline []Result, slack float64) map[string]Threshold {
+	t := make(map[string]Threshold, len(baseline))
+	for _, r := range baseline {
+		t[r.Name()] = Threshold{
+			AllocsPerOp: allowance(r.AllocsPerOp(), slack),
+			BytesPerOp:  allowance(r.AllocedBytesPerOp(), slack),
+		}
+	}
+
+	return t
+}
+
+// allowance returns n increased by the fraction slack, rounded up, ignoring floating point error. A
+// baseline of zero allows 1, the smallest bound which is checked, since a bound of zero isn't.
+func allowance(n int64, slack float64) int64 {
+	if n == 0 {
+		return 1
+	}
+
+	return int64(math.Ceil(float64(n)*(1+slack) - 1e-9))
+}
+
+// Names returns the names of the results, sorted.
+func Names(results []Result) []string {
+	names := make([]string, len(results))
+	for i, r := range results {
+		names[i] = r.Name()
+	}
+
+	sort.Strings(names)
+	return names
+}
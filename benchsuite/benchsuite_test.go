@@ -0,0 +1,119 @@
+package benchsuite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func BenchmarkSuite(b *testing.B) {
+	New().Bench(b)
+}
+
+func TestComparisons(t *testing.T) {
+	for _, bm := range Comparisons() {
+		for _, p := range DefaultPayloads() {
+			assert.NoError(t, bm.Run(p), bm.Name+"/"+p.Name)
+		}
+	}
+
+	get := Comparisons()[3]
+	assert.Equal(t, "encoding_json/Get", get.Name)
+	assert.Error(t, get.Run(Payload{Data: []byte(`{"a": [1]}`), Key: "a.1"}))
+}
+
+func TestDefaultPayloads(t *testing.T) {
+	a, b := DefaultPayloads(), DefaultPayloads()
+	require.Equal(t, len(a), len(b))
+	for i := range a {
+		assert.Equal(t, a[i].Data, b[i].Data, a[i].Name)
+	}
+}
+
+func TestRunError(t *testing.T) {
+	s := &Suite{
+		Payloads:   []Payload{{Name: "bad", Data: []byte(`{"a": tru}`), Key: "a"}},
+		Benchmarks: Comparisons()[:1],
+	}
+
+	results, err := s.Run()
+	assert.Nil(t, results)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gojson/Unmarshal/bad: ")
+}
+
+func result(bench, payload string, allocs, bytes uint64) Result {
+	r := Result{Benchmark: bench, Payload: payload}
+	r.N = 10
+	r.MemAllocs = allocs * 10
+	r.MemBytes = bytes * 10
+	return r
+}
+
+func TestCheck(t *testing.T) {
+	results := []Result{
+		result("gojson/Get", "large", 100, 4096),
+		result("gojson/Get", "object", 10, 512),
+		result("gojson/Unmarshal", "large", 2000, 65536),
+	}
+
+	s := &Suite{}
+	assert.NoError(t, s.Check(results))
+
+	s.Thresholds = map[string]Threshold{
+		"gojson/Get":                {AllocsPerOp: 50},
+		"gojson/Get/object":         {AllocsPerOp: 20, BytesPerOp: 256},
+		"gojson/Unmarshal/large":    {AllocsPerOp: 2000, BytesPerOp: 65536},
+		"encoding_json/Get/missing": {AllocsPerOp: 1},
+	}
+
+	err := s.Check(results)
+	var re *RegressionError
+	require.True(t, errors.As(err, &re))
+	require.Len(t, re.Regressions, 2)
+	assert.Equal(t, "gojson/Get/large", re.Regressions[0].Name())
+	assert.Equal(t, "gojson/Get/object", re.Regressions[1].Name())
+	assert.EqualError(t, err, "memory regression: gojson/Get/large: 100 allocs/op exceeds 50; gojson/Get/object: 512 B/op exceeds 256")
+}
+
+func TestThresholds(t *testing.T) {
+	baseline := []Result{
+		result("gojson/Get", "large", 100, 4096),
+		result("gojson/Get", "empty", 0, 0),
+	}
+
+	th := Thresholds(baseline, 0.1)
+	assert.Equal(t, Threshold{AllocsPerOp: 110, BytesPerOp: 4506}, th["gojson/Get/large"])
+	assert.Equal(t, Threshold{AllocsPerOp: 1, BytesPerOp: 1}, th["gojson/Get/empty"])
+
+	s := &Suite{Thresholds: th}
+	assert.NoError(t, s.Check(baseline))
+	assert.Error(t, s.Check([]Result{result("gojson/Get", "large", 111, 4096)}))
+
+	assert.Equal(t, []string{"gojson/Get/empty", "gojson/Get/large"}, Names(baseline))
+}
+
+func TestAllowance(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int64
+		slack    float64
+		expected int64
+	}{
+		{"Zero", 0, 0.1, 1},
+		{"Zero No Slack", 0, 0, 1},
+		{"One", 1, 0.1, 2},
+		{"No Slack", 100, 0, 100},
+		{"Exact", 100, 0.1, 110},
+		{"Rounded Up", 101, 0.1, 112},
+		{"Float Error", 10, 0.1, 11},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, allowance(tc.n, tc.slack))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package benchsuite
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// The payload shapes below match those of gojson's own benchmarks.
+const (
+	objectPayload      = `{"string":"some string","int":17,"bool":true,"float":22.83,"string_slice":["a","b","c","d","","\""],"bool_slice":[true,false,true,false],"int_slice":[1,2,3,4],"float_slice":[0.0,1.1,2.2,3.3],"object":{"a":"b","c":"d"},"objects":[{"e":"f","g":"h"},{"i":"j","k":"l"},{"m":"n","o":"p"}],"complex":["a", 2, null, false, 2.2, {"c":"d"}, ["s"]]}`
+	mapOfSlicesPayload = `{"accept": ["text/html", "application/json"], "cache-control": ["no-cache"], "x-forwarded-for": ["10.0.0.1", "10.0.0.2", "10.0.0.3"], "empty": []}`
+	sliceOfMapsPayload = `[{"id": 1, "name": "a", "active": true, "score": 1.5}, {"id": 2, "name": "b", "active": false, "score": null}, {"id": 3, "name": "c", "tags": ["x", "y"]}]`
+)
+
+// DefaultPayloads returns the payloads measured by New when none are given: a small object of mixed
+// types, a map of slices, a slice of maps, and large documents of generated records, both compact and
+// indented, and an array of scalars. The large documents are the same on every call.
+func DefaultPayloads() []Payload {
+	records := largeRecords(1000)
+	compact, _ := json.Marshal(records)
+	indented, _ := json.MarshalIndent(records, "", "\t")
+	scalars, _ := json.Marshal(largeScalars(10000))
+
+	return []Payload{
+		{Name: "object", Data: []byte(objectPayload), Key: "objects.1.k"},
+		{Name: "map_of_slices", Data: []byte(mapOfSlicesPayload), Key: "x-forwarded-for.2"},
+		{Name: "slice_of_maps", Data: []byte(sliceOfMapsPayload), Key: "2.tags.1"},
+		{Name: "large", Data: compact, Key: "items.900.assets.1.url"},
+		{Name: "large_indented", Data: indented, Key: "items.900.assets.1.url"},
+		{Name: "large_array", Data: scalars, Key: "9000"},
+	}
+}
+
+type record struct {
+	ID        int               `json:"id"`
+	Title     string            `json:"title"`
+	Published bool              `json:"published"`
+	Score     float64           `json:"score"`
+	Tags      []string          `json:"tags"`
+	Meta      map[string]string `json:"meta"`
+	Assets    []asset           `json:"assets"`
+	Parent    *int              `json:"parent"`
+}
+
+type asset struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// largeRecords returns an object holding n generated records under "items".
+func largeRecords(n int) map[string]interface{} {
+	items := make([]record, n)
+	for i := range items {
+		items[i] = record{
+			ID:        i,
+			Title:     fmt.Sprintf("Record \"%d\" – generated", i),
+			Published: i%3 != 0,
+			Score:     float64(i) * 1.25,
+			Tags:      []string{"tag", fmt.Sprintf("tag-%d", i%10)},
+			Meta:      map[string]string{"source": "benchsuite", "region": fmt.Sprintf("r%d", i%4)},
+			Assets: []asset{
+				{URL: fmt.Sprintf("https://example.com/%d/small.jpg", i), Width: 320, Height: 240},
+				{URL: fmt.Sprintf("https://example.com/%d/large.jpg", i), Width: 1920, Height: 1080},
+			},
+		}
+		if i > 0 {
+			parent := i - 1
+			items[i].Parent = &parent
+		}
+	}
+
+	return map[string]interface{}{"count": n, "items": items}
+}
+
+// largeScalars returns n generated scalars of alternating types.
+func largeScalars(n int) []interface{} {
+	s := make([]interface{}, n)
+	for i := range s {
+		switch i % 4 {
+		case 0:
+			s[i] = i
+		case 1:
+			s[i] = float64(i) / 3
+		case 2:
+			s[i] = fmt.Sprintf("value %d", i)
+		default:
+			s[i] = i%2 == 0
+		}
+	}
+
+	return s
+}
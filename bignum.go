@@ -0,0 +1,232 @@
+package gojson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var typeBigFloat = reflect.TypeOf(big.Float{})
+
+// maxExactDigits is the most significant digits of a decimal number which a float64 always holds
+// exactly enough to give the number back.
+const maxExactDigits = 15
+
+// GetBigInt retrieves a given key as a *big.Int, if it exists, without the range limit of an int. A
+// number with a fraction is truncated toward zero. A string holding a valid number gives the number,
+// unless StrictStandards is set. Any other value gives nil.
+func (jr *JSONReader) GetBigInt(key string) *big.Int {
+	b, t, _ := jr.getDataByKey(key)
+	if b == nil {
+		return nil
+	}
+	return bigIntOf(jr.numberOf(key, b, t, "*big.Int"))
+}
+
+// ToBigInt returns the top-level JSON as a *big.Int, as GetBigInt does.
+func (jr *JSONReader) ToBigInt() *big.Int {
+	if jr.Empty {
+		return nil
+	}
+	return bigIntOf(jr.numberOf("", jr.rawData, jr.Type, "*big.Int"))
+}
+
+// GetBigFloat retrieves a given key as a *big.Float, if it exists, with enough precision for every
+// digit of the number, and without the range limit of a float64. Strings are treated as GetBigInt
+// treats them. Any other value gives nil.
+func (jr *JSONReader) GetBigFloat(key string) *big.Float {
+	b, t, _ := jr.getDataByKey(key)
+	if b == nil {
+		return nil
+	}
+	return bigFloatOf(jr.numberOf(key, b, t, "*big.Float"))
+}
+
+// ToBigFloat returns the top-level JSON as a *big.Float, as GetBigFloat does.
+func (jr *JSONReader) ToBigFloat() *big.Float {
+	if jr.Empty {
+		return nil
+	}
+	return bigFloatOf(jr.numberOf("", jr.rawData, jr.Type, "*big.Float"))
+}
+
+// GetBigIntE retrieves a given key as a *big.Int, returning an error if it does not exist or is not a
+// whole number.
+func (jr *JSONReader) GetBigIntE(key string) (*big.Int, error) {
+	return checkedValue(jr, key, checkedBigInt)
+}
+
+// ToBigIntE returns the top-level JSON as a *big.Int, returning an error if it is not a whole number.
+func (jr *JSONReader) ToBigIntE() (*big.Int, error) {
+	return jr.GetBigIntE("")
+}
+
+// GetBigFloatE retrieves a given key as a *big.Float, returning an error if it does not exist or is not
+// a number.
+func (jr *JSONReader) GetBigFloatE(key string) (*big.Float, error) {
+	return checkedValue(jr, key, checkedBigFloat)
+}
+
+// ToBigFloatE returns the top-level JSON as a *big.Float, returning an error if it is not a number.
+func (jr *JSONReader) ToBigFloatE() (*big.Float, error) {
+	return jr.GetBigFloatE("")
+}
+
+// Convert to *big.Int. Strings must contain a number, and the number must be whole.
+func checkedBigInt(key string, b []byte, t string) (*big.Int, error) {
+	n, err := checkedNumber(key, b, t)
+	if err != nil {
+		return nil, conversionError(key, b, t, "*big.Int")
+	}
+
+	if i, ok := new(big.Int).SetString(n.String(), 10); ok {
+		return i, nil
+	}
+
+	if f := bigFloatOf(n); f != nil && f.IsInt() {
+		i, _ := f.Int(nil)
+		return i, nil
+	}
+
+	return nil, conversionError(key, b, t, "*big.Int")
+}
+
+// Convert to *big.Float. Strings must contain a number.
+func checkedBigFloat(key string, b []byte, t string) (*big.Float, error) {
+	n, err := checkedNumber(key, b, t)
+	if err != nil {
+		return nil, conversionError(key, b, t, "*big.Float")
+	}
+
+	f := bigFloatOf(n)
+	if f == nil {
+		return nil, conversionError(key, b, t, "*big.Float")
+	}
+
+	return f, nil
+}
+
+func bigIntOf(n json.Number) *big.Int {
+	s := n.String()
+	if s == "" {
+		return nil
+	}
+
+	if i, ok := new(big.Int).SetString(s, 10); ok {
+		return i
+	}
+
+	if f := bigFloatOf(n); f != nil {
+		i, _ := f.Int(nil)
+		return i
+	}
+
+	return nil
+}
+
+func bigFloatOf(n json.Number) *big.Float {
+	s := n.String()
+	if s == "" {
+		return nil
+	}
+
+	f, err := parseBigFloat(s)
+	if err != nil {
+		return nil
+	}
+	return f
+}
+
+// parseBigFloat parses the decimal number s with enough precision to hold each of its digits.
+func parseBigFloat(s string) (*big.Float, error) {
+	// Each decimal digit needs log2(10) bits, which is less than 4.
+	prec := uint(len(s)) * 4
+	if prec < 64 {
+		prec = 64
+	}
+
+	f, _, err := big.ParseFloat(s, 10, prec, big.ToNearestEven)
+	return f, err
+}
+
+// bigNumber returns the number b, of type t, as a *big.Int or *big.Float if an int or float64 can't
+// hold it, as Options.BigNumbers describes. ok is false if an int or float64 can hold it.
+func bigNumber(b []byte, t string) (n interface{}, ok bool) {
+	s := string(trim(b))
+
+	if t == JSONInt {
+		if _, err := strconv.ParseInt(s, 10, 0); !errors.Is(err, strconv.ErrRange) {
+			return nil, false
+		}
+		return new(big.Int).SetString(s, 10)
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); !errors.Is(err, strconv.ErrRange) && significantDigits(s) <= maxExactDigits {
+		return nil, false
+	}
+
+	f, err := parseBigFloat(s)
+	return f, err == nil
+}
+
+// significantDigits counts the digits of the mantissa of the decimal number s, without its leading
+// and trailing zeros.
+func significantDigits(s string) int {
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		s = s[:i]
+	}
+
+	s = strings.Trim(strings.Replace(strings.TrimLeft(s, "+-"), ".", "", 1), "0")
+	return len(s)
+}
+
+// ifaceOptions returns the settings for decoding interface{} values.
+func (u *unmarshaler) ifaceOptions() ifaceOptions {
+	return ifaceOptions{strict: u.StrictStandards, policy: u.InvalidUTF8, bigNumbers: u.BigNumbers, keys: u.interner, nonFinite: u.nonFinite, pairs: u.OrderedObjects}
+}
+
+// unmarshalBigFloat extracts the number b into the big.Float p, with enough precision for each of its
+// digits. Null leaves p untouched. A string holding a number is accepted, unless strict standards are
+// in effect.
+func (u *unmarshaler) unmarshalBigFloat(b []byte, t string, p reflect.Value) error {
+	if t == JSONNull {
+		return nil
+	}
+
+	if f, ok := u.nonFinite.lookup(b); ok {
+		if math.IsNaN(f) {
+			return fmt.Errorf("NaN can not be held by a big.Float")
+		}
+		p.Addr().Interface().(*big.Float).SetInf(f < 0)
+		return nil
+	}
+
+	if u.StrictStandards && t != JSONInt && t != JSONFloat {
+		return u.strictError(b, JSONFloat, t)
+	}
+
+	s := string(trim(b))
+	if t == JSONString {
+		s = string(trimString(trim(b)))
+		if nt := GetJSONType([]byte(s), 0); nt == JSONInt || nt == JSONFloat {
+			t = nt
+		}
+	}
+
+	if t != JSONInt && t != JSONFloat {
+		return fmt.Errorf("attempt to unmarshal JSON value with type '%s' into big.Float", t)
+	}
+
+	f, err := parseBigFloat(s)
+	if err != nil {
+		return fmt.Errorf("invalid number '%s' for big.Float: %w", truncate(b, 50), err)
+	}
+
+	p.Addr().Interface().(*big.Float).Copy(f)
+	return nil
+}
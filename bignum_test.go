@@ -0,0 +1,116 @@
+package gojson
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigNumbers(t *testing.T) {
+	data := []byte(`{"small": 17, "huge": 123456789012345678901234567890, "exp": 1e400, "precise": 6.754210771357157538e18, "fraction": 2.5, "quoted": "99999999999999999999", "word": "many", "null": null}`)
+
+	t.Run("Interface", func(t *testing.T) {
+		var v map[string]interface{}
+		assert.Nil(t, UnmarshalWithOptions(data, &v, Options{BigNumbers: true}))
+
+		huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		assert.Equal(t, 17, v["small"])
+		assert.Equal(t, 2.5, v["fraction"])
+		assert.Equal(t, huge, v["huge"])
+		assert.Equal(t, "1e+400", v["exp"].(*big.Float).Text('g', 10))
+		assert.Equal(t, "6754210771357157538", v["precise"].(*big.Float).Text('f', 0))
+
+		// Without the option, the numbers are lost.
+		assert.Nil(t, Unmarshal(data, &v))
+		assert.Equal(t, 0, v["huge"])
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		var v struct {
+			Huge    *big.Int   `json:"huge"`
+			Precise big.Float  `json:"precise"`
+			Exp     *big.Float `json:"exp"`
+			Quoted  big.Float  `json:"quoted"`
+			Null    *big.Float `json:"null"`
+		}
+		assert.Nil(t, Unmarshal(data, &v))
+		assert.Equal(t, "123456789012345678901234567890", v.Huge.String())
+		assert.Equal(t, "6754210771357157538", v.Precise.Text('f', 0))
+		assert.Equal(t, "1e+400", v.Exp.Text('g', 10))
+		assert.Equal(t, "99999999999999999999", v.Quoted.Text('f', 0))
+		assert.Equal(t, 0, v.Null.Sign())
+
+		var word struct {
+			Word big.Float `json:"word"`
+		}
+		assert.EqualError(t, Unmarshal(data, &word), "attempt to unmarshal JSON value with type 'string' into big.Float")
+
+		var quoted struct {
+			Quoted big.Float `json:"quoted"`
+		}
+		assert.EqualError(t, UnmarshalStrict(data, &quoted), "strict standards error, expected float, got string for field 'Quoted' at path 'quoted', offset 131")
+	})
+
+	t.Run("Reader", func(t *testing.T) {
+		r, err := NewJSONReader(data)
+		assert.Nil(t, err)
+
+		assert.Equal(t, "123456789012345678901234567890", r.GetBigInt("huge").String())
+		assert.Equal(t, "17", r.GetBigInt("small").String())
+		assert.Equal(t, "2", r.GetBigInt("fraction").String())
+		assert.Equal(t, "99999999999999999999", r.GetBigInt("quoted").String())
+		assert.Nil(t, r.GetBigInt("word"))
+		assert.Nil(t, r.GetBigInt("null"))
+		assert.Nil(t, r.GetBigInt("missing"))
+		assert.Len(t, r.GetBigInt("exp").String(), 401)
+
+		assert.Equal(t, "6754210771357157538", r.GetBigFloat("precise").Text('f', 0))
+		assert.Equal(t, "1e+400", r.GetBigFloat("exp").Text('g', 10))
+		assert.Nil(t, r.GetBigFloat("word"))
+
+		root, _ := NewJSONReader([]byte(`123456789012345678901234567890`))
+		assert.Equal(t, "123456789012345678901234567890", root.ToBigInt().String())
+		assert.Equal(t, "1.23456789e+29", root.ToBigFloat().Text('g', 9))
+
+		r.StrictStandards = true
+		assert.Nil(t, r.GetBigInt("quoted"))
+		assert.EqualError(t, r.Err(), "key 'quoted' with string value '99999999999999999999' can not be converted to *big.Int")
+	})
+
+	t.Run("Checked", func(t *testing.T) {
+		r, err := NewJSONReader(data)
+		assert.Nil(t, err)
+
+		i, err := r.GetBigIntE("huge")
+		assert.Nil(t, err)
+		assert.Equal(t, "123456789012345678901234567890", i.String())
+		i, err = r.GetBigIntE("quoted")
+		assert.Nil(t, err)
+		assert.Equal(t, "99999999999999999999", i.String())
+		i, err = r.GetBigIntE("exp")
+		assert.Nil(t, err)
+		assert.Len(t, i.String(), 401)
+
+		_, err = r.GetBigIntE("fraction")
+		assert.IsType(t, &ConversionError{}, err)
+		_, err = r.GetBigIntE("word")
+		assert.IsType(t, &ConversionError{}, err)
+		_, err = r.GetBigIntE("missing")
+		assert.ErrorIs(t, err, ErrNoSuchKey)
+
+		f, err := r.GetBigFloatE("precise")
+		assert.Nil(t, err)
+		assert.Equal(t, "6754210771357157538", f.Text('f', 0))
+		_, err = r.GetBigFloatE("null")
+		assert.EqualError(t, err, "key 'null' with null value 'null' can not be converted to *big.Float")
+
+		root, _ := NewJSONReader([]byte(`123456789012345678901234567890`))
+		i, err = root.ToBigIntE()
+		assert.Nil(t, err)
+		assert.Equal(t, "123456789012345678901234567890", i.String())
+		f, err = root.ToBigFloatE()
+		assert.Nil(t, err)
+		assert.Equal(t, "1.23456789e+29", f.Text('g', 9))
+	})
+}
@@ -0,0 +1,195 @@
+package gojson
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Builder constructs a JSON document without defining structs, for dynamic bodies such as API
+// requests. Obj and Arr start a document, and Set, Append and AppendTo add to it. Objects keep their
+// keys in the order they were first set.
+//
+// The methods return the Builder so that calls can be chained. The first error is kept, and later
+// calls do nothing, so only the error from Bytes needs checking.
+//
+// Values are encoded by AppendMarshal when Bytes is called. Another Builder may be used as a value,
+// and is nested as it stands at that point. A Builder implements json.Marshaler, so it can be passed
+// to WriteJSON.
+//
+// Example:
+//
+//	body, err := gojson.Obj().
+//		Set("query.term", term).
+//		Set("query.fields", gojson.Arr("title", "body")).
+//		AppendTo("filters", gojson.Obj().Set("published", true)).
+//		Set("size", 20).
+//		Bytes()
+//	// {"query":{"term":"...","fields":["title","body"]},"filters":[{"published":true}],"size":20}
+type Builder struct {
+	// root is *OrderedMap for an object, *[]interface{} for an array, or a scalar set with Set("").
+	root interface{}
+	err  error
+}
+
+// Obj returns a Builder for an empty object.
+func Obj() *Builder {
+	return &Builder{root: &OrderedMap{Keys: []string{}, Values: make(map[string]interface{})}}
+}
+
+// Arr returns a Builder for an array holding the given values.
+func Arr(values ...interface{}) *Builder {
+	a := append([]interface{}{}, values...)
+	return &Builder{root: &a}
+}
+
+// Set sets the value at the given key path. Missing objects along the path are created. Array
+// elements are addressed by index, and the index one past the last element appends. Use empty string
+// ("") to replace the whole document.
+func (b *Builder) Set(path string, value interface{}) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	keys := pathToKeys(path)
+	if len(keys) == 0 {
+		b.root = value
+		return b
+	}
+
+	parent, err := b.walk(path, keys[:len(keys)-1])
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	k := keys[len(keys)-1]
+	switch c := parent.(type) {
+	case *OrderedMap:
+		c.Set(k, value)
+	case *[]interface{}:
+		i, err := strconv.Atoi(k)
+		switch {
+		case err != nil || i < 0 || i > len(*c):
+			b.err = fmt.Errorf("key '%s' is out of range for an array of length %d", path, len(*c))
+		case i == len(*c):
+			*c = append(*c, value)
+		default:
+			(*c)[i] = value
+		}
+	default:
+		b.err = fmt.Errorf("key '%s' is not within an object or array", path)
+	}
+
+	return b
+}
+
+// Append adds the values to the end of a Builder made with Arr.
+func (b *Builder) Append(values ...interface{}) *Builder {
+	return b.AppendTo("", values...)
+}
+
+// AppendTo adds the values to the end of the array at the given key path, which is created if it
+// doesn't exist. Missing objects along the path are created, as with Set.
+func (b *Builder) AppendTo(path string, values ...interface{}) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	keys := pathToKeys(path)
+	if len(keys) > 0 {
+		parent, err := b.walk(path, keys[:len(keys)-1])
+		if err != nil {
+			b.err = err
+			return b
+		}
+
+		if m, ok := parent.(*OrderedMap); ok {
+			if _, isset := m.Values[keys[len(keys)-1]]; !isset {
+				m.Set(keys[len(keys)-1], &[]interface{}{})
+			}
+		}
+	}
+
+	target, err := b.walk(path, keys)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	a, ok := target.(*[]interface{})
+	if !ok {
+		b.err = fmt.Errorf("key '%s' is not an array", path)
+		return b
+	}
+
+	*a = append(*a, values...)
+	return b
+}
+
+// walk returns the container at the given keys, creating missing objects. path is the full key path,
+// for errors.
+func (b *Builder) walk(path string, keys []string) (interface{}, error) {
+	cur := b.root
+	for _, k := range keys {
+		var err error
+		if cur, err = unwrapBuilder(cur); err != nil {
+			return nil, err
+		}
+
+		switch c := cur.(type) {
+		case *OrderedMap:
+			next, ok := c.Values[k]
+			if !ok {
+				next = &OrderedMap{Keys: []string{}, Values: make(map[string]interface{})}
+				c.Set(k, next)
+			}
+			cur = next
+		case *[]interface{}:
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 0 || i >= len(*c) {
+				return nil, keyNotFound(path)
+			}
+			cur = (*c)[i]
+		default:
+			return nil, fmt.Errorf("key '%s' is not within an object or array", path)
+		}
+	}
+
+	return unwrapBuilder(cur)
+}
+
+// unwrapBuilder returns the root of a nested Builder, so that Set and AppendTo can reach into it.
+func unwrapBuilder(v interface{}) (interface{}, error) {
+	for {
+		nested, ok := v.(*Builder)
+		if !ok || nested == nil {
+			return v, nil
+		}
+		if nested.err != nil {
+			return nil, nested.err
+		}
+		v = nested.root
+	}
+}
+
+// Err returns the first error encountered while building, if any.
+func (b *Builder) Err() error {
+	return b.err
+}
+
+// Bytes returns the document as JSON, or the first error encountered while building it.
+func (b *Builder) Bytes() ([]byte, error) {
+	return b.appendTo(nil, encoder{})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b *Builder) MarshalJSON() ([]byte, error) {
+	return b.Bytes()
+}
+
+func (b *Builder) appendTo(dst []byte, e encoder) ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return e.appendValue(dst, b.root)
+}
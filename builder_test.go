@@ -0,0 +1,99 @@
+package gojson
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Run("Build", func(t *testing.T) {
+		b := Obj().
+			Set("query.term", "go\"json").
+			Set("query.fields", Arr("title", "body")).
+			AppendTo("filters", Obj().Set("published", true)).
+			AppendTo("filters", Obj().Set("tags", []string{"a", "b"})).
+			Set("size", 20).
+			Set("boost", 1.5).
+			Set("missing", nil)
+
+		out, err := b.Bytes()
+		assert.Nil(t, err)
+		assert.Equal(t, `{"query":{"term":"go\"json","fields":["title","body"]},"filters":[{"published":true},{"tags":["a","b"]}],"size":20,"boost":1.5,"missing":null}`, string(out))
+		assert.True(t, IsJSON(out))
+	})
+
+	t.Run("Arrays", func(t *testing.T) {
+		b := Arr(1, "two").Append(3.0, Obj().Set("a", 1)).Set("1", 2).Set("4", uint8(5)).Set("3.b", 2)
+
+		out, err := b.Bytes()
+		assert.Nil(t, err)
+		assert.Equal(t, `[1,2,3,{"a":1,"b":2},5]`, string(out))
+	})
+
+	t.Run("Nested Builders", func(t *testing.T) {
+		inner := Obj().Set("a", 1)
+		b := Obj().Set("inner", inner).Set("inner.b", 2)
+		inner.Set("c", 3)
+
+		out, err := json.Marshal(b)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"inner":{"a":1,"b":2,"c":3}}`, string(out))
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		m := &OrderedMap{}
+		m.Set("z", 1)
+		m.Set("a", int64(-2))
+
+		b := Obj().
+			Set("raw", RawMessage(` {"x": [1, 2]} `)).
+			Set("ordered", m).
+			Set("struct", struct {
+				Name string `json:"name"`
+			}{"n"}).
+			Set("small", 1e-7).
+			Set("large", float32(1e21)).
+			Set("whole", 3.0).
+			Set("escaped", "a\n\t\x01\u2028\xff<")
+
+		out, err := b.Bytes()
+		assert.Nil(t, err)
+		assert.Equal(t, `{"raw":{"x": [1, 2]},"ordered":{"z":1,"a":-2},"struct":{"name":"n"},"small":1e-7,"large":1e+21,"whole":3,"escaped":"a\n\t\u0001\u2028`+"\ufffd"+`<"}`, string(out))
+
+		var back map[string]interface{}
+		assert.Nil(t, json.Unmarshal(out, &back))
+	})
+
+	t.Run("Replace Root", func(t *testing.T) {
+		out, err := Obj().Set("a", 1).Set("", "scalar").Bytes()
+		assert.Nil(t, err)
+		assert.Equal(t, `"scalar"`, string(out))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			b    *Builder
+			err  string
+		}{
+			{"Through Scalar", Obj().Set("a", 1).Set("a.b", 2), "key 'a.b' is not within an object or array"},
+			{"Index", Arr(1).Set("3", 2), "key '3' is out of range for an array of length 1"},
+			{"Missing Index", Arr(1).Set("2.a", 2), "key '2.a' not found"},
+			{"Append Object", Obj().Append(1), "key '' is not an array"},
+			{"Append Scalar", Obj().Set("a", "x").AppendTo("a", 1), "key 'a' is not an array"},
+			{"Raw Message", Obj().Set("a", RawMessage(`{`)), "raw message '{' is not valid json"},
+			{"NaN", Arr(math.NaN()), "unsupported float value: NaN"},
+			{"First Error Kept", Arr(1).Set("5", 1).Set("a", 1), "key '5' is out of range for an array of length 1"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := tc.b.Bytes()
+				assert.EqualError(t, err, tc.err)
+			})
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package gojson
+
+// The functions below are variants of the Get functions which take their key path as a byte slice,
+// for hot paths which build keys dynamically, e.g. by appending an index to a reused buffer. Looking up
+// a key doesn't allocate, nor does reading a number or bool, or a string with WithZeroCopyStrings. The
+// key may be modified once the function returns.
+//
+// Example:
+//
+//	key := make([]byte, 0, 32)
+//	for i := 0; i < jr.Count("items"); i++ {
+//		key = append(strconv.AppendInt(append(key[:0], "items."...), int64(i), 10), ".sku"...)
+//		skus = append(skus, jr.GetStringB(key))
+//	}
+
+// KeyExistsB is KeyExists, for a key given as a byte slice.
+func (jr *JSONReader) KeyExistsB(key []byte) bool {
+	if len(key) == 0 {
+		return false
+	}
+
+	b, _, _ := jr.getDataByKeyB(key)
+	return b != nil
+}
+
+// GetStringB is GetString, for a key given as a byte slice.
+func (jr *JSONReader) GetStringB(key []byte) string {
+	b, t, _ := jr.getDataByKeyB(key)
+	if b == nil || !jr.strictValueB(key, b, t, JSONString, "string") {
+		return ""
+	}
+
+	s, err := jr.stringValue(b, t)
+	if err != nil {
+		jr.reject(string(key), b, t, "string")
+		return ""
+	}
+	return s
+}
+
+// GetIntB is GetInt, for a key given as a byte slice.
+func (jr *JSONReader) GetIntB(key []byte) int {
+	b, t, _ := jr.getDataByKeyB(key)
+	if b == nil || !jr.strictValueB(key, b, t, JSONInt, "int") {
+		return 0
+	}
+
+	i, err := convertInt(b, t, jr.StrictStandards, jr.NumberConversion)
+	if err != nil {
+		jr.reject(string(key), b, t, "int")
+		return 0
+	}
+	return i
+}
+
+// GetFloatB is GetFloat, for a key given as a byte slice.
+func (jr *JSONReader) GetFloatB(key []byte) float64 {
+	b, t, _ := jr.getDataByKeyB(key)
+	if b == nil || !jr.strictValueB(key, b, t, JSONFloat, "float64") {
+		return 0
+	}
+	return toFloat(b, t, jr.StrictStandards)
+}
+
+// GetBoolB is GetBool, for a key given as a byte slice.
+func (jr *JSONReader) GetBoolB(key []byte) bool {
+	b, t, _ := jr.getDataByKeyB(key)
+	if b == nil || !jr.strictValueB(key, b, t, JSONBool, "bool") {
+		return false
+	}
+	return toBool(b, t, jr.StrictStandards)
+}
+
+// GetStringBE is GetStringE, for a key given as a byte slice.
+func (jr *JSONReader) GetStringBE(key []byte) (string, error) {
+	return checkedValueB(jr, key, checkedString)
+}
+
+// GetIntBE is GetIntE, for a key given as a byte slice.
+func (jr *JSONReader) GetIntBE(key []byte) (int, error) {
+	return checkedValueB(jr, key, checkedInt)
+}
+
+// GetFloatBE is GetFloatE, for a key given as a byte slice.
+func (jr *JSONReader) GetFloatBE(key []byte) (float64, error) {
+	return checkedValueB(jr, key, checkedFloat)
+}
+
+// GetBoolBE is GetBoolE, for a key given as a byte slice.
+func (jr *JSONReader) GetBoolBE(key []byte) (bool, error) {
+	return checkedValueB(jr, key, checkedBool)
+}
+
+// checkedValueB is checkedValue, for a key given as a byte slice. The value is converted again when it
+// is rejected, so the key is only copied to a string for the error.
+func checkedValueB[T any](jr *JSONReader, key []byte, conv func(string, []byte, string) (T, error)) (T, error) {
+	var zero T
+
+	if jr.Empty {
+		return zero, ErrEmpty
+	}
+
+	b, t, _ := jr.getDataByKeyB(key)
+	if b == nil {
+		return zero, keyNotFound(string(key))
+	}
+
+	v, err := conv("", b, t)
+	if err != nil {
+		return conv(string(key), b, t)
+	}
+	return v, nil
+}
+
+// strictValueB is strictValue, for a key given as a byte slice, which is only copied to a string when
+// a value is rejected.
+func (jr *JSONReader) strictValueB(key, b []byte, t, want, target string) bool {
+	if !jr.StrictStandards || t == want {
+		return true
+	}
+	return jr.strictValue("", string(key), b, t, want, target)
+}
+
+// getDataByKeyB is getDataByKey, for a key given as a byte slice. Map lookups by string(key) don't
+// allocate.
+func (jr *JSONReader) getDataByKeyB(key []byte) ([]byte, string, []string) {
+	if len(key) == 0 {
+		return jr.rawData, jr.Type, jr.Keys
+	}
+
+	var p parsed
+	isset := false
+	search := jr.parsed
+
+	a := 0
+	for b := range key {
+		if b == len(key)-1 {
+			if p, isset = search[string(key[a:b+1])]; !isset {
+				return nil, "", nil
+			}
+		}
+
+		if key[b] == '.' {
+			if p, isset = search[string(key[a:b])]; !isset {
+				return nil, "", nil
+			}
+
+			search = p.children
+			a = b + 1
+		}
+	}
+
+	return p.bytes, p.dtype, p.keys
+}
@@ -0,0 +1,85 @@
+package gojson
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByteKeys(t *testing.T) {
+	data := []byte(`{"items": [{"sku": "a1", "qty": 2, "price": 1.5, "ok": true}, {"sku": "b2", "qty": "x"}], "empty": ""}`)
+
+	jr, err := NewJSONReader(data, WithZeroCopyStrings())
+	assert.Nil(t, err)
+
+	for _, key := range []string{"", "items", "items.0", "items.0.sku", "items.1.qty", "items.0.price", "items.0.ok", "items.2", "items.0.missing", "empty", "missing", "items."} {
+		t.Run(key, func(t *testing.T) {
+			k := []byte(key)
+			assert.Equal(t, jr.KeyExists(key), jr.KeyExistsB(k))
+			assert.Equal(t, jr.GetString(key), jr.GetStringB(k))
+			assert.Equal(t, jr.GetInt(key), jr.GetIntB(k))
+			assert.Equal(t, jr.GetFloat(key), jr.GetFloatB(k))
+			assert.Equal(t, jr.GetBool(key), jr.GetBoolB(k))
+
+			s, sErr := jr.GetStringE(key)
+			sb, sbErr := jr.GetStringBE(k)
+			assert.Equal(t, s, sb)
+			assert.Equal(t, sErr, sbErr)
+
+			i, iErr := jr.GetIntE(key)
+			ib, ibErr := jr.GetIntBE(k)
+			assert.Equal(t, i, ib)
+			assert.Equal(t, iErr, ibErr)
+
+			f, fErr := jr.GetFloatE(key)
+			fb, fbErr := jr.GetFloatBE(k)
+			assert.Equal(t, f, fb)
+			assert.Equal(t, fErr, fbErr)
+
+			b, bErr := jr.GetBoolE(key)
+			bb, bbErr := jr.GetBoolBE(k)
+			assert.Equal(t, b, bb)
+			assert.Equal(t, bErr, bbErr)
+		})
+	}
+
+	t.Run("Allocations", func(t *testing.T) {
+		key := make([]byte, 0, 32)
+		allocs := testing.AllocsPerRun(100, func() {
+			for i := 0; i < 2; i++ {
+				key = append(strconv.AppendInt(append(key[:0], "items."...), int64(i), 10), ".sku"...)
+				jr.GetStringB(key)
+				jr.KeyExistsB(key)
+			}
+			jr.GetIntB([]byte("items.0.qty"))
+		})
+		if zeroCopyViews {
+			assert.Equal(t, 0.0, allocs)
+		}
+	})
+
+	t.Run("Checked", func(t *testing.T) {
+		key := []byte("items.1.qty")
+		_, err := jr.GetIntBE(key)
+		copy(key, "XXXXX")
+		assert.Equal(t, "key 'items.1.qty' with string value 'x' can not be converted to int", err.Error())
+
+		_, err = jr.GetStringBE([]byte("items.0.missing"))
+		assert.ErrorIs(t, err, ErrNoSuchKey)
+
+		_, err = (&JSONReader{Empty: true}).GetBoolBE([]byte("ok"))
+		assert.Equal(t, ErrEmpty, err)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		strict, err := NewJSONReader(data)
+		assert.Nil(t, err)
+		strict.StrictStandards = true
+
+		key := []byte("items.1.qty")
+		assert.Equal(t, 0, strict.GetIntB(key))
+		copy(key, "XXXXX")
+		assert.Equal(t, ConversionErrors{{Key: "items.1.qty", Type: JSONString, Target: "int", Value: "x"}}, strict.Err())
+	})
+}
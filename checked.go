@@ -0,0 +1,568 @@
+package gojson
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The checked accessors mirror the JSONReader accessors, but return an error rather than a zero value
+// or a forgiving conversion. They behave the same regardless of StrictStandards, so that new code can
+// opt into checked conversions without changing the behavior of existing call sites.
+
+var (
+	// ErrNoSuchKey is matched by errors.Is for the errors returned by the checked accessors when the
+	// requested key does not exist. It is ErrKeyNotFound, under the name it was first given.
+	ErrNoSuchKey = ErrKeyNotFound
+)
+
+// ConversionError is returned by the checked accessors when a value can not be converted to the
+// requested type without loss, e.g. 1.5 to an int, "abc" to a float, or null to a string.
+type ConversionError struct {
+	// Key is the key path of the offending value. The root is represented by "".
+	Key string
+
+	// Type is the JSON type of the offending value.
+	Type string
+
+	// Target is the name of the Go type requested.
+	Target string
+
+	// Value is the raw value, truncated to 50 bytes.
+	Value string
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("key '%s' with %s value '%s' can not be converted to %s", e.Key, e.Type, e.Value, e.Target)
+}
+
+// ConversionErrors is returned by Err for a reader with StrictStandards set, listing every value its
+// accessors rejected.
+type ConversionErrors []*ConversionError
+
+func (e ConversionErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = v.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func conversionError(key string, b []byte, t, target string) error {
+	return &ConversionError{Key: key, Type: t, Target: target, Value: string(truncate(b, 50))}
+}
+
+/**
+ * Checked Conversions
+ */
+
+// Convert to string. Strings, numbers, and booleans can all be represented as a string.
+func checkedString(key string, b []byte, t string) (string, error) {
+	switch t {
+	case JSONString:
+		return manualUnescapeString(b), nil
+	case JSONInt, JSONFloat, JSONBool:
+		return string(b), nil
+	}
+
+	return "", conversionError(key, b, t, "string")
+}
+
+// Convert to int. Floats must be whole numbers, strings must contain a number, and the value must fit in an int.
+func checkedInt(key string, b []byte, t string) (int, error) {
+	switch t {
+	case JSONInt, JSONFloat:
+		if i, err := strconv.ParseInt(string(b), 10, 0); err == nil {
+			return int(i), nil
+		}
+
+		f, err := strconv.ParseFloat(string(b), 64)
+		if err != nil || f != math.Trunc(f) || f < math.MinInt || f >= math.MaxInt {
+			return 0, conversionError(key, b, t, "int")
+		}
+
+		return int(f), nil
+	case JSONString:
+		s := []byte(manualUnescapeString(b))
+		if st := GetJSONType(s, 0); (st == JSONInt || st == JSONFloat) && IsJSONNumber(s) {
+			if i, err := checkedInt(key, s, st); err == nil {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, conversionError(key, b, t, "int")
+}
+
+// Convert to float64. Strings must contain a number, and the value must fit in a float64.
+func checkedFloat(key string, b []byte, t string) (float64, error) {
+	switch t {
+	case JSONInt, JSONFloat:
+		if f, err := strconv.ParseFloat(string(b), 64); err == nil {
+			return f, nil
+		}
+	case JSONString:
+		s := []byte(manualUnescapeString(b))
+		if st := GetJSONType(s, 0); (st == JSONInt || st == JSONFloat) && IsJSONNumber(s) {
+			if f, err := checkedFloat(key, s, st); err == nil {
+				return f, nil
+			}
+		}
+	}
+
+	return 0, conversionError(key, b, t, "float64")
+}
+
+// Convert to bool. Strings must be parsable by strconv.ParseBool, and numbers must be 0 or 1.
+func checkedBool(key string, b []byte, t string) (bool, error) {
+	switch t {
+	case JSONBool:
+		return IsJSONTrue(b), nil
+	case JSONInt:
+		switch string(b) {
+		case "0":
+			return false, nil
+		case "1":
+			return true, nil
+		}
+	case JSONString:
+		if v, err := strconv.ParseBool(manualUnescapeString(b)); err == nil {
+			return v, nil
+		}
+	}
+
+	return false, conversionError(key, b, t, "bool")
+}
+
+// Convert to json.Number. Numbers keep their literal text, and strings must contain a number.
+func checkedNumber(key string, b []byte, t string) (json.Number, error) {
+	if t == JSONInt || t == JSONFloat {
+		return json.Number(trim(b)), nil
+	}
+
+	if n, ok := quotedNumber(b, t); ok {
+		return n, nil
+	}
+
+	return "", conversionError(key, b, t, "json.Number")
+}
+
+// checkedSlice converts the value at key with conv. Arrays and objects have each child converted, while
+// any other value is converted as a single element slice, as with the unchecked Get*Slice functions.
+func checkedSlice[T any](jr *JSONReader, key string, conv func(string, []byte, string) (T, error)) ([]T, error) {
+	p := jr.getChildByKey(key)
+	if p == nil || jr.Empty {
+		return nil, keyNotFound(key)
+	}
+
+	out := make([]T, 0, len(p.keys))
+
+	switch p.dtype {
+	case JSONArray, JSONObject:
+		for _, k := range p.keys {
+			c := p.children[k]
+			v, err := conv(joinPath(key, k), c.bytes, c.dtype)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+	default:
+		v, err := conv(key, p.bytes, p.dtype)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+// checkedMap converts the value at key with conv. Arrays and objects have each child converted, while
+// any other value is converted as the single key "0", as with the unchecked GetMapString* functions.
+func checkedMap[T any](jr *JSONReader, key string, conv func(string, []byte, string) (T, error)) (map[string]T, error) {
+	if jr.Empty {
+		return nil, ErrEmpty
+	}
+
+	p := jr.getChildByKey(key)
+	if p == nil {
+		return nil, keyNotFound(key)
+	}
+
+	out := make(map[string]T, len(p.keys))
+
+	switch p.dtype {
+	case JSONArray, JSONObject:
+		for _, k := range p.keys {
+			c := p.children[k]
+			v, err := conv(joinPath(key, k), c.bytes, c.dtype)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = v
+		}
+	default:
+		v, err := conv(key, p.bytes, p.dtype)
+		if err != nil {
+			return nil, err
+		}
+		out["0"] = v
+	}
+
+	return out, nil
+}
+
+// checkedValue converts the value at key with conv.
+func checkedValue[T any](jr *JSONReader, key string, conv func(string, []byte, string) (T, error)) (T, error) {
+	var zero T
+
+	if jr.Empty {
+		return zero, ErrEmpty
+	}
+
+	b, t, _ := jr.getDataByKey(key)
+	if b == nil {
+		return zero, keyNotFound(key)
+	}
+
+	return conv(key, b, t)
+}
+
+/**
+ * Nesting Functions
+ */
+
+// GetE retrieves a nested object, returning an ErrNoSuchKey error if the key does not exist.
+func (jr *JSONReader) GetE(key string) (*JSONReader, error) {
+	if jr.Empty || jr.getChildByKey(key) == nil {
+		return &JSONReader{Empty: true}, keyNotFound(key)
+	}
+
+	return jr.Get(key), nil
+}
+
+// GetCollectionE extracts a nested JSONArray, returning an ErrNoSuchKey error if the key does not exist.
+func (jr *JSONReader) GetCollectionE(key string) ([]JSONReader, error) {
+	if jr.Empty || jr.getChildByKey(key) == nil {
+		return nil, keyNotFound(key)
+	}
+
+	return jr.GetCollection(key), nil
+}
+
+/**
+ * String Functions
+ */
+
+// GetStringE retrieves a given key as a string, returning an error if it does not exist or can not be converted.
+func (jr *JSONReader) GetStringE(key string) (string, error) {
+	return checkedValue(jr, key, checkedString)
+}
+
+// ToStringE returns the top-level JSON as a string, returning an error if it can not be converted.
+func (jr *JSONReader) ToStringE() (string, error) {
+	return jr.GetStringE("")
+}
+
+// GetStringSliceE retrieves a given key as a string slice, returning an error if it does not exist or any element can not be converted.
+func (jr *JSONReader) GetStringSliceE(key string) ([]string, error) {
+	return checkedSlice(jr, key, checkedString)
+}
+
+// ToStringSliceE returns all top-level data as a string slice, returning an error if any element can not be converted.
+func (jr *JSONReader) ToStringSliceE() ([]string, error) {
+	return jr.GetStringSliceE("")
+}
+
+// GetMapStringStringE retrieves a given key as a map of string onto string, returning an error if it does not exist or any element can not be converted.
+func (jr *JSONReader) GetMapStringStringE(key string) (map[string]string, error) {
+	return checkedMap(jr, key, checkedString)
+}
+
+// ToMapStringStringE returns all top-level data as map of string onto string, returning an error if any element can not be converted.
+func (jr *JSONReader) ToMapStringStringE() (map[string]string, error) {
+	return jr.GetMapStringStringE("")
+}
+
+/**
+ * Boolean Functions
+ */
+
+// GetBoolE retrieves a given key as a bool, returning an error if it does not exist or can not be converted.
+func (jr *JSONReader) GetBoolE(key string) (bool, error) {
+	return checkedValue(jr, key, checkedBool)
+}
+
+// ToBoolE returns the top-level JSON as a bool, returning an error if it can not be converted.
+func (jr *JSONReader) ToBoolE() (bool, error) {
+	return jr.GetBoolE("")
+}
+
+// GetBoolSliceE retrieves a given key as a bool slice, returning an error if it does not exist or any element can not be converted.
+func (jr *JSONReader) GetBoolSliceE(key string) ([]bool, error) {
+	return checkedSlice(jr, key, checkedBool)
+}
+
+// ToBoolSliceE returns all top-level data as a bool slice, returning an error if any element can not be converted.
+func (jr *JSONReader) ToBoolSliceE() ([]bool, error) {
+	return jr.GetBoolSliceE("")
+}
+
+// GetMapStringBoolE retrieves a given key as a map of string onto bool, returning an error if it does not exist or any element can not be converted.
+func (jr *JSONReader) GetMapStringBoolE(key string) (map[string]bool, error) {
+	return checkedMap(jr, key, checkedBool)
+}
+
+// ToMapStringBoolE returns all top-level data as map of string onto bool, returning an error if any element can not be converted.
+func (jr *JSONReader) ToMapStringBoolE() (map[string]bool, error) {
+	return jr.GetMapStringBoolE("")
+}
+
+/**
+ * Integer Functions
+ */
+
+// GetIntE retrieves a given key as an int, returning an error if it does not exist or can not be converted.
+func (jr *JSONReader) GetIntE(key string) (int, error) {
+	return checkedValue(jr, key, checkedInt)
+}
+
+// ToIntE returns the top-level JSON as an int, returning an error if it can not be converted.
+func (jr *JSONReader) ToIntE() (int, error) {
+	return jr.GetIntE("")
+}
+
+// GetIntSliceE retrieves a given key as an int slice, returning an error if it does not exist or any element can not be converted.
+func (jr *JSONReader) GetIntSliceE(key string) ([]int, error) {
+	return checkedSlice(jr, key, checkedInt)
+}
+
+// ToIntSliceE returns all top-level data as an int slice, returning an error if any element can not be converted.
+func (jr *JSONReader) ToIntSliceE() ([]int, error) {
+	return jr.GetIntSliceE("")
+}
+
+// GetMapStringIntE retrieves a given key as a map of string onto int, returning an error if it does not exist or any element can not be converted.
+func (jr *JSONReader) GetMapStringIntE(key string) (map[string]int, error) {
+	return checkedMap(jr, key, checkedInt)
+}
+
+// ToMapStringIntE returns all top-level data as map of string onto int, returning an error if any element can not be converted.
+func (jr *JSONReader) ToMapStringIntE() (map[string]int, error) {
+	return jr.GetMapStringIntE("")
+}
+
+/**
+ * Float Functions
+ */
+
+// GetFloatE retrieves a given key as a float64, returning an error if it does not exist or can not be converted.
+func (jr *JSONReader) GetFloatE(key string) (float64, error) {
+	return checkedValue(jr, key, checkedFloat)
+}
+
+// ToFloatE returns the top-level JSON as a float64, returning an error if it can not be converted.
+func (jr *JSONReader) ToFloatE() (float64, error) {
+	return jr.GetFloatE("")
+}
+
+// GetFloatSliceE retrieves a given key as a float64 slice, returning an error if it does not exist or any element can not be converted.
+func (jr *JSONReader) GetFloatSliceE(key string) ([]float64, error) {
+	return checkedSlice(jr, key, checkedFloat)
+}
+
+// ToFloatSliceE returns all top-level data as a float64 slice, returning an error if any element can not be converted.
+func (jr *JSONReader) ToFloatSliceE() ([]float64, error) {
+	return jr.GetFloatSliceE("")
+}
+
+// GetMapStringFloatE retrieves a given key as a map of string onto float64, returning an error if it does not exist or any element can not be converted.
+func (jr *JSONReader) GetMapStringFloatE(key string) (map[string]float64, error) {
+	return checkedMap(jr, key, checkedFloat)
+}
+
+// ToMapStringFloatE returns all top-level data as map of string onto float64, returning an error if any element can not be converted.
+func (jr *JSONReader) ToMapStringFloatE() (map[string]float64, error) {
+	return jr.GetMapStringFloatE("")
+}
+
+/**
+ * Number Functions
+ */
+
+// GetNumberE retrieves a given key as the literal text of a number, returning an error if it does not exist or is not a number.
+func (jr *JSONReader) GetNumberE(key string) (json.Number, error) {
+	return checkedValue(jr, key, checkedNumber)
+}
+
+// ToNumberE returns the top-level JSON as the literal text of a number, returning an error if it is not a number.
+func (jr *JSONReader) ToNumberE() (json.Number, error) {
+	return jr.GetNumberE("")
+}
+
+/**
+ * Byte Slice Functions
+ *
+ * Raw bytes require no conversion, so these only fail when the key does not exist.
+ */
+
+// GetByteSliceE returns the given key and all child elements as a byte array, returning an ErrNoSuchKey error if it does not exist.
+func (jr *JSONReader) GetByteSliceE(key string) ([]byte, error) {
+	if jr.Empty || jr.getChildByKey(key) == nil {
+		return nil, keyNotFound(key)
+	}
+
+	return jr.GetByteSlice(key), nil
+}
+
+// ToByteSliceE returns all top-level data as a byte slice, returning ErrEmpty if the reader is empty.
+func (jr *JSONReader) ToByteSliceE() ([]byte, error) {
+	if jr.Empty {
+		return nil, ErrEmpty
+	}
+
+	return jr.ToByteSlice(), nil
+}
+
+// GetByteSlicesE retrieves a given key as a slice of byte slices, returning an ErrNoSuchKey error if it does not exist.
+func (jr *JSONReader) GetByteSlicesE(key string) ([][]byte, error) {
+	if jr.Empty || jr.getChildByKey(key) == nil {
+		return nil, keyNotFound(key)
+	}
+
+	return jr.GetByteSlices(key), nil
+}
+
+// ToByteSlicesE returns all top-level data as a slice of byte slices, returning ErrEmpty if the reader is empty.
+func (jr *JSONReader) ToByteSlicesE() ([][]byte, error) {
+	if jr.Empty {
+		return nil, ErrEmpty
+	}
+
+	return jr.ToByteSlices(), nil
+}
+
+// GetMapStringBytesE retrieves a given key as a map of string onto []byte, returning an ErrNoSuchKey error if it does not exist.
+func (jr *JSONReader) GetMapStringBytesE(key string) (map[string][]byte, error) {
+	if jr.Empty || jr.getChildByKey(key) == nil {
+		return nil, keyNotFound(key)
+	}
+
+	return jr.GetMapStringBytes(key), nil
+}
+
+// ToMapStringBytesE returns all top-level data as map of string onto []byte, returning ErrEmpty if the reader is empty.
+func (jr *JSONReader) ToMapStringBytesE() (map[string][]byte, error) {
+	if jr.Empty {
+		return nil, ErrEmpty
+	}
+
+	return jr.ToMapStringBytes(), nil
+}
+
+/**
+ * Empty Interface Functions
+ *
+ * Values are converted to their natural Go types, but numbers must still fit the chosen type.
+ */
+
+// checkedIface converts a value to the Go type matching its JSON type.
+func checkedIface(key string, p parsed) (interface{}, error) {
+	switch p.dtype {
+	case JSONInt:
+		return checkedInt(key, p.bytes, p.dtype)
+	case JSONFloat:
+		return checkedFloat(key, p.bytes, p.dtype)
+	case JSONBool:
+		return checkedBool(key, p.bytes, p.dtype)
+	case JSONString:
+		return checkedString(key, p.bytes, p.dtype)
+	case JSONObject:
+		out := make(map[string]interface{}, len(p.keys))
+		for _, k := range p.keys {
+			v, err := checkedIface(joinPath(key, k), p.children[k])
+			if err != nil {
+				return nil, err
+			}
+			out[k] = v
+		}
+		return out, nil
+	case JSONArray:
+		out := make([]interface{}, 0, len(p.keys))
+		for _, k := range p.keys {
+			v, err := checkedIface(joinPath(key, k), p.children[k])
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	}
+
+	return nil, nil
+}
+
+// GetInterfaceE returns the data at the given key as an interface{}, returning an error if it does not exist or a number is out of range.
+func (jr *JSONReader) GetInterfaceE(key string) (interface{}, error) {
+	p := jr.getChildByKey(key)
+	if p == nil || jr.Empty {
+		return nil, keyNotFound(key)
+	}
+
+	return checkedIface(key, *p)
+}
+
+// ToInterfaceE returns the top-level JSON as an interface{}, returning an error if a number is out of range.
+func (jr *JSONReader) ToInterfaceE() (interface{}, error) {
+	return jr.GetInterfaceE("")
+}
+
+// GetInterfaceSliceE returns the given key as an interface{} slice, returning an error if it does not exist or a number is out of range.
+func (jr *JSONReader) GetInterfaceSliceE(key string) ([]interface{}, error) {
+	return checkedSlice(jr, key, func(k string, b []byte, t string) (interface{}, error) {
+		return checkedIface(k, *jr.getChildByKey(k))
+	})
+}
+
+// ToInterfaceSliceE returns all top-level data as an interface{} slice, returning an error if a number is out of range.
+func (jr *JSONReader) ToInterfaceSliceE() ([]interface{}, error) {
+	return jr.GetInterfaceSliceE("")
+}
+
+// GetMapStringInterfaceE retrieves a given key as a map of string onto interface{}, returning an error if it does not exist or a number is out of range.
+func (jr *JSONReader) GetMapStringInterfaceE(key string) (map[string]interface{}, error) {
+	p := jr.getChildByKey(key)
+	if p == nil || jr.Empty {
+		return nil, keyNotFound(key)
+	}
+
+	out := make(map[string]interface{}, len(p.keys))
+
+	switch p.dtype {
+	case JSONArray, JSONObject:
+		for _, k := range p.keys {
+			v, err := checkedIface(joinPath(key, k), p.children[k])
+			if err != nil {
+				return nil, err
+			}
+			out[k] = v
+		}
+	default:
+		v, err := jr.GetInterfaceE(key)
+		if err != nil {
+			return nil, err
+		}
+		out["0"] = v
+	}
+
+	return out, nil
+}
+
+// ToMapStringInterfaceE returns all top-level data as a map of string onto interface{}, returning an error if a number is out of range.
+func (jr *JSONReader) ToMapStringInterfaceE() (map[string]interface{}, error) {
+	return jr.GetMapStringInterfaceE("")
+}
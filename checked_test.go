@@ -0,0 +1,144 @@
+package gojson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var checkedTestData = []byte(`{"int": 17, "whole": 2.0, "frac": 1.5, "big": 1e400, "huge": 99999999999999999999, "nstr": "42", "str": "a\"b", "bool": true, "sbool": "false", "one": 1, "null": null, "ints": [1, "2", 3.0], "mixed": [1, "x"], "obj": {"a": 1, "b": [true]}}`)
+
+func TestCheckedScalars(t *testing.T) {
+	r, err := NewJSONReader(checkedTestData)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		key string
+		fn  func(string) (interface{}, error)
+		out interface{}
+		ok  bool
+	}{
+		{key: "int", fn: func(k string) (interface{}, error) { return r.GetIntE(k) }, out: 17, ok: true},
+		{key: "whole", fn: func(k string) (interface{}, error) { return r.GetIntE(k) }, out: 2, ok: true},
+		{key: "frac", fn: func(k string) (interface{}, error) { return r.GetIntE(k) }, ok: false},
+		{key: "huge", fn: func(k string) (interface{}, error) { return r.GetIntE(k) }, ok: false},
+		{key: "nstr", fn: func(k string) (interface{}, error) { return r.GetIntE(k) }, out: 42, ok: true},
+		{key: "str", fn: func(k string) (interface{}, error) { return r.GetIntE(k) }, ok: false},
+		{key: "bool", fn: func(k string) (interface{}, error) { return r.GetIntE(k) }, ok: false},
+		{key: "null", fn: func(k string) (interface{}, error) { return r.GetIntE(k) }, ok: false},
+		{key: "frac", fn: func(k string) (interface{}, error) { return r.GetFloatE(k) }, out: 1.5, ok: true},
+		{key: "nstr", fn: func(k string) (interface{}, error) { return r.GetFloatE(k) }, out: 42.0, ok: true},
+		{key: "big", fn: func(k string) (interface{}, error) { return r.GetFloatE(k) }, ok: false},
+		{key: "obj", fn: func(k string) (interface{}, error) { return r.GetFloatE(k) }, ok: false},
+		{key: "str", fn: func(k string) (interface{}, error) { return r.GetStringE(k) }, out: `a"b`, ok: true},
+		{key: "frac", fn: func(k string) (interface{}, error) { return r.GetStringE(k) }, out: "1.5", ok: true},
+		{key: "null", fn: func(k string) (interface{}, error) { return r.GetStringE(k) }, ok: false},
+		{key: "ints", fn: func(k string) (interface{}, error) { return r.GetStringE(k) }, ok: false},
+		{key: "bool", fn: func(k string) (interface{}, error) { return r.GetBoolE(k) }, out: true, ok: true},
+		{key: "sbool", fn: func(k string) (interface{}, error) { return r.GetBoolE(k) }, out: false, ok: true},
+		{key: "one", fn: func(k string) (interface{}, error) { return r.GetBoolE(k) }, out: true, ok: true},
+		{key: "int", fn: func(k string) (interface{}, error) { return r.GetBoolE(k) }, ok: false},
+		{key: "str", fn: func(k string) (interface{}, error) { return r.GetBoolE(k) }, ok: false},
+	}
+
+	for _, tc := range testCases {
+		out, err := tc.fn(tc.key)
+		if !tc.ok {
+			var cerr *ConversionError
+			assert.True(t, errors.As(err, &cerr), tc.key)
+			continue
+		}
+
+		assert.Nil(t, err, tc.key)
+		assert.Equal(t, tc.out, out, tc.key)
+	}
+
+	_, err = r.GetIntE("missing")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+	assert.EqualError(t, err, "key 'missing' not found")
+
+	_, err = r.GetIntE("frac")
+	assert.Equal(t, "key 'frac' with float value '1.5' can not be converted to int", err.Error())
+
+	// The unchecked accessors are unaffected.
+	assert.Equal(t, 1, r.GetInt("frac"))
+}
+
+func TestCheckedCollections(t *testing.T) {
+	r, err := NewJSONReader(checkedTestData)
+	assert.Nil(t, err)
+
+	ints, err := r.GetIntSliceE("ints")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ints)
+
+	_, err = r.GetIntSliceE("mixed")
+	assert.Equal(t, "key 'mixed.1' with string value 'x' can not be converted to int", err.Error())
+
+	strs, err := r.GetStringSliceE("mixed")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "x"}, strs)
+
+	single, err := r.GetFloatSliceE("frac")
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{1.5}, single)
+
+	bools, err := r.Get("obj.b").ToBoolSliceE()
+	assert.Nil(t, err)
+	assert.Equal(t, []bool{true}, bools)
+
+	_, err = r.Get("obj").ToMapStringIntE()
+	assert.Equal(t, "key 'b' with array value '[true]' can not be converted to int", err.Error())
+
+	_, err = r.GetMapStringIntE("obj")
+	assert.Equal(t, "key 'obj.b' with array value '[true]' can not be converted to int", err.Error())
+
+	_, err = r.GetMapStringFloatE("nope")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+
+	raw, err := r.GetMapStringBytesE("obj")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{"a": []byte("1"), "b": []byte("[true]")}, raw)
+
+	iface, err := r.GetMapStringInterfaceE("obj")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1, "b": []interface{}{true}}, iface)
+
+	_, err = r.ToInterfaceE()
+	assert.Equal(t, "key 'big' with float value '1e400' can not be converted to float64", err.Error())
+
+	list, err := r.GetInterfaceSliceE("mixed")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, "x"}, list)
+
+	_, err = r.GetCollectionE("nope")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+
+	_, err = r.GetByteSliceE("nope")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+
+	b, err := r.GetByteSliceE("obj")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a": 1, "b": [true]}`, string(b))
+}
+
+func TestCheckedRoot(t *testing.T) {
+	r, err := NewJSONReader([]byte(`"12"`))
+	assert.Nil(t, err)
+
+	i, err := r.ToIntE()
+	assert.Nil(t, err)
+	assert.Equal(t, 12, i)
+
+	s, err := r.ToStringE()
+	assert.Nil(t, err)
+	assert.Equal(t, "12", s)
+
+	m, err := r.ToMapStringStringE()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"0": "12"}, m)
+
+	_, err = (&JSONReader{Empty: true}).ToIntE()
+	assert.Equal(t, ErrEmpty, err)
+}
@@ -0,0 +1,455 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// annotation marks a struct type for generation.
+const annotation = "//gojson:generate"
+
+type fieldKind int
+
+const (
+	// scalarField is a basic type, decoded directly.
+	scalarField fieldKind = iota
+	// pointerField is a pointer to a basic type.
+	pointerField
+	// sliceField is a slice of a basic type.
+	sliceField
+	// generatedField is a struct type with a generated decoder.
+	generatedField
+	// otherField is decoded with gojson.Unmarshal.
+	otherField
+)
+
+type field struct {
+	name string
+	keys []string
+	kind fieldKind
+
+	// elem is the basic type of a scalar, pointer or slice field.
+	elem string
+
+	required bool
+	nonEmpty bool
+}
+
+type structType struct {
+	name   string
+	fields []field
+}
+
+// generate returns the source of the UnmarshalGoJSON methods for the struct types in src. If types is
+// empty, the types annotated with //gojson:generate are used.
+func generate(filename string, src []byte, types []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := make(map[string]*ast.StructType)
+	var order []string
+
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			specs[ts.Name.Name] = st
+
+			doc := ts.Doc
+			if doc == nil && len(gd.Specs) == 1 {
+				doc = gd.Doc
+			}
+			if len(types) == 0 && annotated(doc) {
+				order = append(order, ts.Name.Name)
+			}
+		}
+	}
+
+	if len(types) > 0 {
+		order = types
+	}
+
+	if len(order) == 0 {
+		return nil, fmt.Errorf("%s: no struct types to generate", filename)
+	}
+
+	generated := make(map[string]bool, len(order))
+	for _, name := range order {
+		if _, ok := specs[name]; !ok {
+			return nil, fmt.Errorf("%s: struct type '%s' not found", filename, name)
+		}
+		generated[name] = true
+	}
+
+	var structs []structType
+	for _, name := range order {
+		s, err := parseStruct(name, specs[name], generated)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		structs = append(structs, s)
+	}
+
+	var buf bytes.Buffer
+	writeFile(&buf, f.Name.Name, structs)
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: formatting generated code: %w", filename, err)
+	}
+
+	return out, nil
+}
+
+func annotated(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+
+	for _, c := range doc.List {
+		if strings.TrimSpace(c.Text) == annotation {
+			return true
+		}
+	}
+	return false
+}
+
+func parseStruct(name string, st *ast.StructType, generated map[string]bool) (structType, error) {
+	s := structType{name: name}
+
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			return s, fmt.Errorf("struct '%s': embedded fields are not supported", name)
+		}
+
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			t, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return s, err
+			}
+			tag = reflect.StructTag(t)
+		}
+
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				continue
+			}
+
+			fd, err := parseField(n.Name, f.Type, tag, generated)
+			if err != nil {
+				return s, fmt.Errorf("struct '%s': %w", name, err)
+			}
+
+			if len(fd.keys) > 0 {
+				s.fields = append(s.fields, fd)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// parseField reads the keys and options of a field from its tag, in the same way as gojson does.
+func parseField(name string, expr ast.Expr, tag reflect.StructTag, generated map[string]bool) (field, error) {
+	fd := field{name: name, kind: otherField}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch {
+		case isBasic(t.Name):
+			fd.kind, fd.elem = scalarField, t.Name
+		case generated[t.Name]:
+			fd.kind, fd.elem = generatedField, t.Name
+		}
+	case *ast.StarExpr:
+		if id, ok := t.X.(*ast.Ident); ok && isBasic(id.Name) {
+			fd.kind, fd.elem = pointerField, id.Name
+		}
+	case *ast.ArrayType:
+		// Byte slices are filled with the raw value by Unmarshal.
+		if id, ok := t.Elt.(*ast.Ident); ok && t.Len == nil && isBasic(id.Name) && id.Name != "byte" && id.Name != "uint8" {
+			fd.kind, fd.elem = sliceField, id.Name
+		}
+	}
+
+	source := tag.Get("json")
+	if gj := tag.Get("gojson"); gj != "" {
+		// A gojson tag without a name takes its name from the json tag.
+		if name := strings.Split(source, ",")[0]; strings.HasPrefix(gj, ",") && name != "" && name != "-" {
+			gj = name + gj
+		}
+		source = gj
+	}
+
+	if source == "" {
+		fd.keys = []string{name, strings.ToLower(name), firstCharLower(name)}
+		return fd, nil
+	}
+
+	if source == "-" {
+		return fd, nil
+	}
+
+	for i, k := range strings.Split(source, ",") {
+		switch {
+		case k == "" || strings.EqualFold(k, "omitempty"):
+		case strings.EqualFold(k, "required"):
+			fd.required = true
+		case strings.EqualFold(k, "nonempty"):
+			fd.required, fd.nonEmpty = true, true
+		case k == "string", strings.EqualFold(k, "tuple"), strings.EqualFold(k, "base64"), strings.EqualFold(k, "inline"), strings.EqualFold(k, "remain"), strings.HasPrefix(k, "discriminator="), strings.HasPrefix(k, "default="), isValidation(k), i > 0 && isConverter(k):
+			return fd, fmt.Errorf("field '%s': tag option '%s' is not supported", name, k)
+		default:
+			fd.keys = append(fd.keys, k)
+		}
+	}
+
+	if len(fd.keys) == 0 {
+		fd.keys = []string{strings.ToLower(name)}
+	}
+
+	if len(fd.keys) == 1 && fd.keys[0] == "-" {
+		fd.keys = nil
+	}
+
+	return fd, nil
+}
+
+func isBasic(name string) bool {
+	switch name {
+	case "string", "bool", "float32", "float64", "byte", "rune",
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+func isValidation(opt string) bool {
+	eq := strings.IndexByte(opt, '=')
+	if eq < 0 {
+		return false
+	}
+
+	switch strings.ToLower(opt[:eq]) {
+	case "min", "max", "minlen", "maxlen", "pattern", "oneof":
+		return true
+	}
+	return false
+}
+
+// isConverter reports whether opt selects one of gojson's field conversions, such as unixsec.
+func isConverter(opt string) bool {
+	switch strings.ToLower(opt) {
+	case "unixsec", "unixms", "commasplit", "bytesize":
+		return true
+	}
+	return false
+}
+
+func firstCharLower(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return string(unicode.ToLower(rune(s[0]))) + s[1:]
+}
+
+// decodeExpr returns the expression converting value to the given basic type. Integers and float32s are
+// range checked, as Unmarshal does, so their expressions return an error along with the value.
+func decodeExpr(typ string) (expr string, checked bool) {
+	switch typ {
+	case "string":
+		return "gojson.DecodeString(value, dtype)", false
+	case "bool":
+		return "gojson.DecodeBool(value, dtype)", false
+	case "float64":
+		return "gojson.DecodeFloat(value, dtype)", false
+	case "float32":
+		return "gojson.DecodeFloat32E(value, dtype)", true
+	}
+
+	return "gojson.DecodeIntE[" + typ + "](value, dtype)", true
+}
+
+// isChecked reports whether the basic type typ is range checked by decodeExpr.
+func isChecked(typ string) bool {
+	_, checked := decodeExpr(typ)
+	return checked
+}
+
+func writeFile(buf *bytes.Buffer, pkg string, structs []structType) {
+	fmt.Fprintf(buf, "// Code generated by gojson-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+
+	if usesStrconv(structs) {
+		fmt.Fprintf(buf, "\t\"strconv\"\n")
+	}
+	fmt.Fprintf(buf, "\t\"strings\"\n\n\t\"github.com/btm6084/gojson\"\n)\n")
+
+	for _, s := range structs {
+		writeLookup(buf, s)
+		writeMethod(buf, s)
+	}
+}
+
+// usesStrconv reports whether the generated code needs strconv, which names the elements of range
+// checked slices in errors.
+func usesStrconv(structs []structType) bool {
+	for _, s := range structs {
+		for _, f := range s.fields {
+			if f.kind == sliceField && isChecked(f.elem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeLookup writes the function mapping a key to a field index. Exact matches take precedence over
+// case-insensitive ones. As with gojson, the last field claiming a key wins an exact match, and the
+// first field claiming a folded key wins a case-insensitive match.
+func writeLookup(buf *bytes.Buffer, s structType) {
+	exact := make(map[string]int)
+	folded := make(map[string]int)
+	var exactOrder, foldedOrder []string
+
+	for i, f := range s.fields {
+		for _, k := range f.keys {
+			if _, ok := exact[k]; !ok {
+				exactOrder = append(exactOrder, k)
+			}
+			exact[k] = i
+
+			if _, ok := folded[strings.ToLower(k)]; !ok {
+				folded[strings.ToLower(k)] = i
+				foldedOrder = append(foldedOrder, strings.ToLower(k))
+			}
+		}
+	}
+
+	fmt.Fprintf(buf, "\n// gojsonField%s returns the index of the field of %s holding the given key, or -1.\n", s.name, s.name)
+	fmt.Fprintf(buf, "func gojsonField%s(key string) int {\n", s.name)
+
+	writeCases := func(keys []string, index map[string]int) {
+		for i := range s.fields {
+			var cases []string
+			for _, k := range keys {
+				if index[k] == i {
+					cases = append(cases, strconv.Quote(k))
+				}
+			}
+			if len(cases) > 0 {
+				fmt.Fprintf(buf, "case %s:\nreturn %d\n", strings.Join(cases, ", "), i)
+			}
+		}
+	}
+
+	fmt.Fprintf(buf, "switch key {\n")
+	writeCases(exactOrder, exact)
+	fmt.Fprintf(buf, "}\n\nswitch strings.ToLower(key) {\n")
+	writeCases(foldedOrder, folded)
+	fmt.Fprintf(buf, "}\n\nreturn -1\n}\n")
+}
+
+func writeMethod(buf *bytes.Buffer, s structType) {
+	required := false
+	for _, f := range s.fields {
+		required = required || f.required
+	}
+
+	fmt.Fprintf(buf, "\n// UnmarshalGoJSON decodes a JSON object into the %s without reflection.\n", s.name)
+	fmt.Fprintf(buf, "func (v *%s) UnmarshalGoJSON(data []byte) error {\n", s.name)
+
+	if required {
+		fmt.Fprintf(buf, "var seen [%d]bool\n\n", len(s.fields))
+	}
+
+	if required {
+		fmt.Fprintf(buf, "err := ")
+	} else {
+		fmt.Fprintf(buf, "return ")
+	}
+	fmt.Fprintf(buf, "gojson.EachMember(data, gojson.GetJSONType(data, 0), func(key string, value []byte, dtype string) error {\n")
+	fmt.Fprintf(buf, "i := gojsonField%s(key)\nif i < 0 {\nreturn nil\n}\n", s.name)
+	if required {
+		fmt.Fprintf(buf, "seen[i] = true\n")
+	}
+	fmt.Fprintf(buf, "\nswitch i {\n")
+
+	for i, f := range s.fields {
+		fmt.Fprintf(buf, "case %d:\n", i)
+
+		key := strconv.Quote(f.keys[0])
+		if f.nonEmpty {
+			fmt.Fprintf(buf, "if gojson.IsZeroJSON(value, dtype) {\nreturn &gojson.NonEmptyFieldError{Field: %s, Key: %s, Struct: %s, Type: dtype}\n}\n", key, key, strconv.Quote(s.name))
+		}
+
+		expr, checked := decodeExpr(f.elem)
+		switch f.kind {
+		case scalarField:
+			if !checked {
+				fmt.Fprintf(buf, "v.%s = %s\n", f.name, expr)
+				break
+			}
+			fmt.Fprintf(buf, "n, err := %s\nif err != nil {\nreturn gojson.FieldError(err, %s)\n}\n", expr, key)
+			fmt.Fprintf(buf, "v.%s = n\n", f.name)
+		case pointerField:
+			if checked {
+				fmt.Fprintf(buf, "n, err := %s\nif err != nil {\nreturn gojson.FieldError(err, %s)\n}\n", expr, key)
+				expr = "n"
+			}
+			fmt.Fprintf(buf, "if v.%s == nil {\nv.%s = new(%s)\n}\n", f.name, f.name, f.elem)
+			fmt.Fprintf(buf, "*v.%s = %s\n", f.name, expr)
+		case sliceField:
+			fmt.Fprintf(buf, "var s []%s\n", f.elem)
+			fmt.Fprintf(buf, "err := gojson.EachElement(value, dtype, func(value []byte, dtype string) error {\n")
+			if !checked {
+				fmt.Fprintf(buf, "s = append(s, %s)\nreturn nil\n})\n", expr)
+				fmt.Fprintf(buf, "if s != nil {\nv.%s = s\n}\nreturn err\n", f.name)
+				break
+			}
+			fmt.Fprintf(buf, "e, err := %s\nif err != nil {\nreturn gojson.FieldError(err, strconv.Itoa(len(s)))\n}\n", expr)
+			fmt.Fprintf(buf, "s = append(s, e)\nreturn nil\n})\n")
+			fmt.Fprintf(buf, "if s != nil {\nv.%s = s\n}\nreturn gojson.FieldError(err, %s)\n", f.name, key)
+		case generatedField:
+			fmt.Fprintf(buf, "return gojson.FieldError(v.%s.UnmarshalGoJSON(value), %s)\n", f.name, key)
+		default:
+			fmt.Fprintf(buf, "return gojson.Unmarshal(value, &v.%s)\n", f.name)
+		}
+	}
+
+	fmt.Fprintf(buf, "}\n\nreturn nil\n})\n")
+	if !required {
+		fmt.Fprintf(buf, "}\n")
+		return
+	}
+	fmt.Fprintf(buf, "if err != nil {\nreturn err\n}\n")
+
+	for i, f := range s.fields {
+		if f.required {
+			fmt.Fprintf(buf, "\nif !seen[%d] {\nreturn &gojson.RequiredFieldError{Struct: %s, Keys: []string{%s}}\n}\n", i, strconv.Quote(s.name), strconv.Quote(f.keys[0]))
+		}
+	}
+
+	fmt.Fprintf(buf, "\nreturn nil\n}\n")
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The generated example must be kept up to date with the generator, via go generate.
+func TestGenerateExample(t *testing.T) {
+	src, err := os.ReadFile("internal/example/example.go")
+	assert.Nil(t, err)
+
+	expected, err := os.ReadFile("internal/example/example_gojson.go")
+	assert.Nil(t, err)
+
+	out, err := generate("example.go", src, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected), string(out))
+}
+
+func TestGenerate(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Source   string
+		Types    []string
+		Contains []string
+		Err      string
+	}{
+		{
+			"TypeFlag",
+			"package p\ntype A struct{ N int }\ntype B struct{ S string }",
+			[]string{"B"},
+			[]string{"func (v *B) UnmarshalGoJSON", `case "S", "s":`},
+			"",
+		},
+		{
+			"GroupedDecl",
+			"package p\ntype (\n//gojson:generate\nA struct{ N int }\nB struct{ S string }\n)",
+			nil,
+			[]string{"func (v *A) UnmarshalGoJSON"},
+			"",
+		},
+		{
+			"NoTypes",
+			"package p\ntype A struct{ N int }",
+			nil,
+			nil,
+			"p.go: no struct types to generate",
+		},
+		{
+			"UnknownType",
+			"package p\ntype A struct{ N int }",
+			[]string{"C"},
+			nil,
+			"p.go: struct type 'C' not found",
+		},
+		{
+			"Embedded",
+			"package p\ntype E struct{}\ntype A struct{ E }",
+			[]string{"A"},
+			nil,
+			"p.go: struct 'A': embedded fields are not supported",
+		},
+		{
+			"Validation",
+			"package p\ntype A struct{ N int `json:\"n,min=1\"` }",
+			[]string{"A"},
+			nil,
+			"p.go: struct 'A': field 'N': tag option 'min=1' is not supported",
+		},
+		{
+			"StringOption",
+			"package p\ntype A struct{ N int `json:\"n,string\"` }",
+			[]string{"A"},
+			nil,
+			"p.go: struct 'A': field 'N': tag option 'string' is not supported",
+		},
+		{
+			"TupleOption",
+			"package p\ntype P struct{ X int }\ntype A struct{ P P `json:\"p,tuple\"` }",
+			[]string{"A"},
+			nil,
+			"p.go: struct 'A': field 'P': tag option 'tuple' is not supported",
+		},
+		{
+			"Base64Option",
+			"package p\ntype A struct{ B []byte `json:\"b,base64\"` }",
+			[]string{"A"},
+			nil,
+			"p.go: struct 'A': field 'B': tag option 'base64' is not supported",
+		},
+		{
+			"InlineOption",
+			"package p\ntype A struct{ X map[string]int `json:\",inline\"` }",
+			[]string{"A"},
+			nil,
+			"p.go: struct 'A': field 'X': tag option 'inline' is not supported",
+		},
+		{
+			"GoJSONOptions",
+			"package p\ntype A struct{ N int `json:\"n,omitempty\" gojson:\",required\"` }",
+			[]string{"A"},
+			[]string{`case "n":`, `return &gojson.RequiredFieldError{Struct: "A", Keys: []string{"n"}}`},
+			"",
+		},
+		{
+			"ConverterOption",
+			"package p\ntype A struct{ S int `json:\"size,bytesize\"` }",
+			[]string{"A"},
+			nil,
+			"p.go: struct 'A': field 'S': tag option 'bytesize' is not supported",
+		},
+		{
+			"NamelessOptions",
+			"package p\ntype A struct{ N int `json:\",required\"`; S string `gojson:\",nonempty\"` }",
+			[]string{"A"},
+			[]string{`case "n":`, `Keys: []string{"n"}`, `NonEmptyFieldError{Field: "s", Key: "s"`},
+			"",
+		},
+		{
+			"PercentKey",
+			"package p\ntype A struct{ N int `json:\"100%,required\"` }",
+			[]string{"A"},
+			[]string{`return &gojson.RequiredFieldError{Struct: "A", Keys: []string{"100%"}}`},
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out, err := generate("p.go", []byte(tc.Source), tc.Types)
+			if tc.Err != "" {
+				assert.EqualError(t, err, tc.Err)
+				return
+			}
+
+			assert.Nil(t, err)
+			for _, c := range tc.Contains {
+				assert.Contains(t, string(out), c)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Package example holds struct types with decoders generated by gojson-gen, to test the generated code.
+package example
+
+//go:generate go run github.com/btm6084/gojson/cmd/gojson-gen
+
+//gojson:generate
+type User struct {
+	ID       int               `json:"id,required"`
+	Name     string            `json:"name,nonempty"`
+	Email    string            `json:"email,mail,omitempty"`
+	Age      uint8             `json:"age"`
+	Score    float32           `json:"score"`
+	Active   bool              `json:"active"`
+	Nickname *string           `json:"nickname"`
+	Tags     []string          `json:"tags"`
+	Levels   []int8            `json:"levels"`
+	Address  Address           `json:"address"`
+	Labels   map[string]string `json:"labels"`
+	Raw      []byte            `json:"raw"`
+	Ignored  string            `json:"-"`
+	Untagged int64
+
+	internal string
+}
+
+//gojson:generate
+type Address struct {
+	Street string `gojson:"street" json:"-"`
+	Zip    int    `json:"zip,postcode"`
+}
+
+// Untouched is not annotated, and has no generated decoder.
+type Untouched struct {
+	Value string
+}
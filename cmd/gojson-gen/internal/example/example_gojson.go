@@ -0,0 +1,214 @@
+// Code generated by gojson-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/btm6084/gojson"
+)
+
+// gojsonFieldUser returns the index of the field of User holding the given key, or -1.
+func gojsonFieldUser(key string) int {
+	switch key {
+	case "id":
+		return 0
+	case "name":
+		return 1
+	case "email", "mail":
+		return 2
+	case "age":
+		return 3
+	case "score":
+		return 4
+	case "active":
+		return 5
+	case "nickname":
+		return 6
+	case "tags":
+		return 7
+	case "levels":
+		return 8
+	case "address":
+		return 9
+	case "labels":
+		return 10
+	case "raw":
+		return 11
+	case "Untagged", "untagged":
+		return 12
+	}
+
+	switch strings.ToLower(key) {
+	case "id":
+		return 0
+	case "name":
+		return 1
+	case "email", "mail":
+		return 2
+	case "age":
+		return 3
+	case "score":
+		return 4
+	case "active":
+		return 5
+	case "nickname":
+		return 6
+	case "tags":
+		return 7
+	case "levels":
+		return 8
+	case "address":
+		return 9
+	case "labels":
+		return 10
+	case "raw":
+		return 11
+	case "untagged":
+		return 12
+	}
+
+	return -1
+}
+
+// UnmarshalGoJSON decodes a JSON object into the User without reflection.
+func (v *User) UnmarshalGoJSON(data []byte) error {
+	var seen [13]bool
+
+	err := gojson.EachMember(data, gojson.GetJSONType(data, 0), func(key string, value []byte, dtype string) error {
+		i := gojsonFieldUser(key)
+		if i < 0 {
+			return nil
+		}
+		seen[i] = true
+
+		switch i {
+		case 0:
+			n, err := gojson.DecodeIntE[int](value, dtype)
+			if err != nil {
+				return gojson.FieldError(err, "id")
+			}
+			v.ID = n
+		case 1:
+			if gojson.IsZeroJSON(value, dtype) {
+				return &gojson.NonEmptyFieldError{Field: "name", Key: "name", Struct: "User", Type: dtype}
+			}
+			v.Name = gojson.DecodeString(value, dtype)
+		case 2:
+			v.Email = gojson.DecodeString(value, dtype)
+		case 3:
+			n, err := gojson.DecodeIntE[uint8](value, dtype)
+			if err != nil {
+				return gojson.FieldError(err, "age")
+			}
+			v.Age = n
+		case 4:
+			n, err := gojson.DecodeFloat32E(value, dtype)
+			if err != nil {
+				return gojson.FieldError(err, "score")
+			}
+			v.Score = n
+		case 5:
+			v.Active = gojson.DecodeBool(value, dtype)
+		case 6:
+			if v.Nickname == nil {
+				v.Nickname = new(string)
+			}
+			*v.Nickname = gojson.DecodeString(value, dtype)
+		case 7:
+			var s []string
+			err := gojson.EachElement(value, dtype, func(value []byte, dtype string) error {
+				s = append(s, gojson.DecodeString(value, dtype))
+				return nil
+			})
+			if s != nil {
+				v.Tags = s
+			}
+			return err
+		case 8:
+			var s []int8
+			err := gojson.EachElement(value, dtype, func(value []byte, dtype string) error {
+				e, err := gojson.DecodeIntE[int8](value, dtype)
+				if err != nil {
+					return gojson.FieldError(err, strconv.Itoa(len(s)))
+				}
+				s = append(s, e)
+				return nil
+			})
+			if s != nil {
+				v.Levels = s
+			}
+			return gojson.FieldError(err, "levels")
+		case 9:
+			return gojson.FieldError(v.Address.UnmarshalGoJSON(value), "address")
+		case 10:
+			return gojson.Unmarshal(value, &v.Labels)
+		case 11:
+			return gojson.Unmarshal(value, &v.Raw)
+		case 12:
+			n, err := gojson.DecodeIntE[int64](value, dtype)
+			if err != nil {
+				return gojson.FieldError(err, "Untagged")
+			}
+			v.Untagged = n
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !seen[0] {
+		return &gojson.RequiredFieldError{Struct: "User", Keys: []string{"id"}}
+	}
+
+	if !seen[1] {
+		return &gojson.RequiredFieldError{Struct: "User", Keys: []string{"name"}}
+	}
+
+	return nil
+}
+
+// gojsonFieldAddress returns the index of the field of Address holding the given key, or -1.
+func gojsonFieldAddress(key string) int {
+	switch key {
+	case "street":
+		return 0
+	case "zip", "postcode":
+		return 1
+	}
+
+	switch strings.ToLower(key) {
+	case "street":
+		return 0
+	case "zip", "postcode":
+		return 1
+	}
+
+	return -1
+}
+
+// UnmarshalGoJSON decodes a JSON object into the Address without reflection.
+func (v *Address) UnmarshalGoJSON(data []byte) error {
+	return gojson.EachMember(data, gojson.GetJSONType(data, 0), func(key string, value []byte, dtype string) error {
+		i := gojsonFieldAddress(key)
+		if i < 0 {
+			return nil
+		}
+
+		switch i {
+		case 0:
+			v.Street = gojson.DecodeString(value, dtype)
+		case 1:
+			n, err := gojson.DecodeIntE[int](value, dtype)
+			if err != nil {
+				return gojson.FieldError(err, "zip")
+			}
+			v.Zip = n
+		}
+
+		return nil
+	})
+}
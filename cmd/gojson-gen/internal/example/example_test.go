@@ -0,0 +1,121 @@
+package example
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/btm6084/gojson"
+	"github.com/stretchr/testify/assert"
+)
+
+// reflectUser has the fields of User, but not its generated method, so that it is decoded with reflection.
+type reflectUser User
+
+func TestGeneratedMatchesReflection(t *testing.T) {
+	testCases := []struct {
+		Name string
+		JSON string
+		Err  string
+	}{
+		{"Full", `{"id": 1, "name": "Jo", "email": "jo@example.com", "age": 30, "score": 1.5, "active": true, "nickname": "J", "tags": ["a", "b"], "address": {"street": "Main", "zip": 12345}, "labels": {"x": "y"}, "raw": "abc", "Ignored": "no", "untagged": 7}`, ""},
+		{"AlternateKey", `{"id": 1, "name": "Jo", "mail": "jo@example.com", "address": {"postcode": 1}}`, ""},
+		{"FoldedKeys", `{"ID": 1, "Name": "Jo", "TAGS": ["a"], "UnTagged": 7}`, ""},
+		{"Conversions", `{"id": "12", "name": 5, "age": "7", "score": "2.5", "active": "true", "tags": "solo", "address": null}`, ""},
+		{"TagsObject", `{"id": 1, "name": "Jo", "tags": {"a": "x", "b": "y"}}`, ""},
+		{"Nulls", `{"id": null, "name": "Jo", "nickname": null, "tags": null}`, ""},
+		{"UnknownKeys", `{"id": 1, "name": "Jo", "other": {"deep": [1, 2]}}`, ""},
+		{"MissingRequired", `{"name": "Jo"}`, "required key 'id' for struct 'User' was not found"},
+		{"EmptyNonEmpty", `{"id": 1, "name": ""}`, "nonempty key 'name' for struct 'User' has string zero value"},
+		{"NotObject", `[1, 2]`, ""},
+		{"Malformed", `{"id": 1, "name": }`, ""},
+		{"Truncated", `{"id": 1, "name": "Jo"`, ""},
+		{"Unterminated", `{"id": 1, "tags": ["a"]`, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var generated User
+			genErr := generated.UnmarshalGoJSON([]byte(tc.JSON))
+
+			var reflected reflectUser
+			refErr := gojson.Unmarshal([]byte(tc.JSON), &reflected)
+
+			if tc.Err != "" {
+				assert.EqualError(t, genErr, tc.Err)
+				assert.EqualError(t, refErr, strings.Replace(tc.Err, "'User'", "'reflectUser'", 1))
+				return
+			}
+
+			// Struct decoding stops reading once every field is found, so truncated objects are
+			// compared with map decoding, which reads every member as the generated code does.
+			if tc.Name == "Truncated" || tc.Name == "Unterminated" {
+				var m map[string]interface{}
+				mapErr := gojson.Unmarshal([]byte(tc.JSON), &m)
+				assert.NotNil(t, mapErr)
+				assert.Equal(t, mapErr, genErr)
+				return
+			}
+
+			if tc.Name == "Malformed" || tc.Name == "NotObject" {
+				assert.Equal(t, refErr == nil, genErr == nil, "%v %v", genErr, refErr)
+				return
+			}
+
+			assert.Nil(t, genErr)
+			assert.Nil(t, refErr)
+			assert.Equal(t, User(reflected), generated)
+		})
+	}
+}
+
+func TestGeneratedRangeChecks(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		JSON  string
+		Field string
+		Value string
+	}{
+		{"Uint8", `{"id": 1, "name": "Jo", "age": 300}`, "age", "300"},
+		{"Negative Uint8", `{"id": 1, "name": "Jo", "age": -1}`, "age", "-1"},
+		{"Quoted Uint8", `{"id": 1, "name": "Jo", "age": "256"}`, "age", `"256"`},
+		{"Int", `{"id": 1e20, "name": "Jo"}`, "id", "1e20"},
+		{"Int64", `{"id": 1, "name": "Jo", "untagged": 9223372036854775808}`, "Untagged", "9223372036854775808"},
+		{"Float32", `{"id": 1, "name": "Jo", "score": 1e39}`, "score", "1e39"},
+		{"Slice Element", `{"id": 1, "name": "Jo", "levels": [1, 128]}`, "levels.1", "128"},
+		{"Nested", `{"id": 1, "name": "Jo", "address": {"zip": 1e30}}`, "address.zip", "1e30"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var generated User
+			genErr := generated.UnmarshalGoJSON([]byte(tc.JSON))
+
+			var reflected reflectUser
+			refErr := gojson.Unmarshal([]byte(tc.JSON), &reflected)
+
+			var genType, refType *gojson.UnmarshalTypeError
+			if !assert.ErrorAs(t, genErr, &genType) || !assert.ErrorAs(t, refErr, &refType) {
+				return
+			}
+
+			assert.Equal(t, tc.Field, genType.Field)
+			assert.Equal(t, tc.Value, genType.Value)
+			assert.Equal(t, -1, genType.Offset)
+
+			// Offsets are only known to reflection.
+			refType.Offset = -1
+			assert.Equal(t, refType, genType)
+		})
+	}
+}
+
+func TestUnmarshalUsesGenerated(t *testing.T) {
+	var users []User
+	err := gojson.Unmarshal([]byte(`[{"id": 1, "name": "Jo"}, {"name": "Al"}]`), &users)
+	assert.EqualError(t, err, "required key 'id' for struct 'User' was not found")
+
+	var u User
+	err = gojson.Unmarshal([]byte(`{"id": 1, "name": "Jo", "address": {"street": "Main"}}`), &u)
+	assert.Nil(t, err)
+	assert.Equal(t, "Main", u.Address.Street)
+}
@@ -0,0 +1,86 @@
+// gojson-gen generates UnmarshalGoJSON methods for struct types, so that gojson.Unmarshal can decode
+// them without reflection. The generated methods honor the json and gojson struct tags, including
+// alternate keys and the required and nonempty options, and match keys as Unmarshal does with
+// DefaultOptions: exactly, then case-insensitively.
+//
+// Struct types are selected with the -type flag, or by annotating them with a //gojson:generate
+// comment. Usage with go generate:
+//
+//	//go:generate gojson-gen
+//
+//	//gojson:generate
+//	type User struct {
+//		ID   int    `json:"id,required"`
+//		Name string `json:"name"`
+//	}
+//
+// The methods are written to <file>_gojson.go, next to the source file.
+//
+// Fields of type string, bool, or any integer or float type, and pointers and slices of those, are
+// decoded directly. Fields of other struct types which are generated alongside are decoded through
+// their own generated method. Any other field is decoded with gojson.Unmarshal. Embedded structs,
+// and the string, tuple, base64, inline, discriminator, default, conversion and validation tag
+// options, are not supported.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	types := flag.String("type", "", "comma separated list of struct types to generate; defaults to the types annotated with //gojson:generate")
+	output := flag.String("output", "", "output file name; defaults to <file>_gojson.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: gojson-gen [-type T1,T2] [-output file] [file.go]\n\n")
+		fmt.Fprintf(os.Stderr, "The file defaults to $GOFILE, as set by go generate.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	file := os.Getenv("GOFILE")
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		file = flag.Arg(0)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var names []string
+	if *types != "" {
+		names = strings.Split(*types, ",")
+	}
+
+	if err := run(file, *output, names); err != nil {
+		fmt.Fprintf(os.Stderr, "gojson-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(file, output string, types []string) error {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	out, err := generate(filepath.Base(file), src, types)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		output = strings.TrimSuffix(file, ".go") + "_gojson.go"
+	}
+
+	return os.WriteFile(output, out, 0644)
+}
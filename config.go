@@ -0,0 +1,115 @@
+package gojson
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+)
+
+// ConfigOption configures LoadConfig.
+type ConfigOption func(*configOptions)
+
+type configOptions struct {
+	options  Options
+	comments bool
+	env      map[string]string
+}
+
+// WithConfigOptions decodes the config with the given Options, rather than DefaultOptions.
+func WithConfigOptions(opts Options) ConfigOption {
+	return func(o *configOptions) {
+		o.options = opts
+	}
+}
+
+// WithComments accepts line (//) and block (/* */) comments in the config, as ParseDocument does.
+func WithComments() ConfigOption {
+	return func(o *configOptions) {
+		o.comments = true
+	}
+}
+
+// WithEnv overlays the config with environment variables, given as a map from key path to variable
+// name. Each variable which is set replaces the value at its key path, creating any missing objects
+// along the way. A value which is not valid JSON, such as localhost, is treated as a string, as with
+// the default tag option.
+//
+// Example:
+//
+//	gojson.WithEnv(map[string]string{"database.host": "DB_HOST", "database.port": "DB_PORT"})
+func WithEnv(vars map[string]string) ConfigOption {
+	return func(o *configOptions) {
+		o.env = vars
+	}
+}
+
+// LoadConfig reads the file at path from fsys, and decodes it into v with Unmarshal, so that the
+// default, required and nonempty tag options and tag validations are applied. Values from the
+// environment are overlaid before decoding if WithEnv is given, so they take part in validation, and
+// satisfy required fields.
+//
+// Example:
+//
+//	var cfg Config
+//	err := gojson.LoadConfig(os.DirFS("/etc/myapp"), "config.json", &cfg,
+//		gojson.WithComments(),
+//		gojson.WithEnv(map[string]string{"server.port": "PORT"}),
+//	)
+func LoadConfig(fsys fs.FS, path string, v interface{}, opts ...ConfigOption) error {
+	o := configOptions{options: DefaultOptions}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return err
+	}
+
+	if len(o.env) > 0 {
+		if data, err = overlayEnv(data, o.env); err != nil {
+			return fmt.Errorf("config '%s': %w", path, err)
+		}
+	}
+
+	if o.comments {
+		data = stripComments(data)
+	}
+
+	u := unmarshaler{Options: o.options}
+	if err := u.unmarshal(data, v); err != nil {
+		return fmt.Errorf("config '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// overlayEnv sets the value of each key path whose environment variable is set. Key paths are set in
+// sorted order, so that a parent is set before its children.
+func overlayEnv(data []byte, vars map[string]string) ([]byte, error) {
+	d, err := ParseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(vars))
+	for path := range vars {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		s, ok := os.LookupEnv(vars[path])
+		if !ok {
+			continue
+		}
+
+		value, _ := defaultValue(s)
+		if err := d.Set(path, RawMessage(value)); err != nil {
+			return nil, fmt.Errorf("environment variable '%s': %w", vars[path], err)
+		}
+	}
+
+	return d.Bytes(), nil
+}
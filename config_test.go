@@ -0,0 +1,100 @@
+package gojson
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	type database struct {
+		Host string `json:"host,required"`
+		Port int    `json:"port,default=5432,min=1,max=65535"`
+	}
+
+	type config struct {
+		Name     string   `json:"name,nonempty"`
+		Debug    bool     `json:"debug"`
+		Database database `json:"database"`
+		Tags     []string `json:"tags"`
+	}
+
+	fsys := fstest.MapFS{
+		"config.json": {Data: []byte(`{"name": "app", "database": {"host": "db"}, "tags": ["a"]}`)},
+		"comments.json": {Data: []byte(`{
+			// The service name.
+			"name": "app", /* inline */
+			"database": {"host": "db", "port": 6432}
+		}`)},
+		"missing.json": {Data: []byte(`{"name": "app", "database": {}}`)},
+		"invalid.json": {Data: []byte(`{"name": "app", "database": {"host": "db", "port": 0}}`)},
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		var cfg config
+		assert.Nil(t, LoadConfig(fsys, "config.json", &cfg))
+		assert.Equal(t, config{Name: "app", Database: database{"db", 5432}, Tags: []string{"a"}}, cfg)
+	})
+
+	t.Run("Comments", func(t *testing.T) {
+		var cfg config
+		assert.Nil(t, LoadConfig(fsys, "comments.json", &cfg, WithComments()))
+		assert.Equal(t, database{"db", 6432}, cfg.Database)
+
+		assert.NotNil(t, LoadConfig(fsys, "comments.json", &cfg))
+	})
+
+	t.Run("Environment", func(t *testing.T) {
+		t.Setenv("TEST_DB_HOST", "db.internal")
+		t.Setenv("TEST_DB_PORT", "7000")
+		t.Setenv("TEST_DEBUG", "true")
+		t.Setenv("TEST_TAGS", `["x", "y"]`)
+
+		env := WithEnv(map[string]string{
+			"database.host": "TEST_DB_HOST",
+			"database.port": "TEST_DB_PORT",
+			"debug":         "TEST_DEBUG",
+			"tags":          "TEST_TAGS",
+			"name":          "TEST_UNSET",
+		})
+
+		var cfg config
+		assert.Nil(t, LoadConfig(fsys, "missing.json", &cfg, env))
+		assert.Equal(t, config{Name: "app", Debug: true, Database: database{"db.internal", 7000}, Tags: []string{"x", "y"}}, cfg)
+
+		cfg = config{}
+		assert.Nil(t, LoadConfig(fsys, "comments.json", &cfg, env, WithComments()))
+		assert.Equal(t, database{"db.internal", 7000}, cfg.Database)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		var cfg config
+		err := LoadConfig(fsys, "missing.json", &cfg)
+		assert.EqualError(t, err, "config 'missing.json': missing required keys 'host' for struct 'database'")
+
+		err = LoadConfig(fsys, "invalid.json", &cfg)
+		var verrs ValidationErrors
+		assert.True(t, errors.As(err, &verrs))
+		assert.Equal(t, "min", verrs[0].Rule)
+
+		t.Setenv("TEST_DB_PORT", "80")
+		assert.Nil(t, LoadConfig(fsys, "invalid.json", &cfg, WithEnv(map[string]string{"database.port": "TEST_DB_PORT"})))
+		assert.Equal(t, 80, cfg.Database.Port)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		t.Setenv("TEST_DEBUG", "yes")
+
+		var cfg config
+		env := WithEnv(map[string]string{"debug": "TEST_DEBUG"})
+		assert.NotNil(t, LoadConfig(fsys, "config.json", &cfg, env, WithConfigOptions(Options{StrictStandards: true})))
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		var cfg config
+		assert.True(t, errors.Is(LoadConfig(fsys, "nope.json", &cfg), fs.ErrNotExist))
+	})
+}
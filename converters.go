@@ -0,0 +1,188 @@
+package gojson
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fieldConverter returns the conversion selected by the tag option name, given in lowercase, or nil if
+// there is none. A conversion decodes the raw value b, of JSON type t, into the field p, in place of
+// the usual decoding. Conversions aren't applied to null, which is decoded as usual.
+func fieldConverter(name string) func(u *unmarshaler, b []byte, t string, p reflect.Value) error {
+	switch name {
+	case "unixsec":
+		return unixTime(time.Second)
+	case "unixms":
+		return unixTime(time.Millisecond)
+	case "commasplit":
+		return (*unmarshaler).commaSplit
+	case "bytesize":
+		return (*unmarshaler).byteSize
+	}
+
+	return nil
+}
+
+var typeTime = reflect.TypeOf(time.Time{})
+
+// unixTime returns the converter for a time.Time field holding a count of units since the Unix epoch,
+// such as 1700000000 seconds. A fraction gives a time within the unit. Outside of strict standards,
+// the count may also be given as a string. The time is in UTC.
+func unixTime(unit time.Duration) func(u *unmarshaler, b []byte, t string, p reflect.Value) error {
+	return func(u *unmarshaler, b []byte, t string, p reflect.Value) error {
+		if p.Type() != typeTime {
+			return fmt.Errorf("requires a time.Time field, found '%v'", p.Type())
+		}
+
+		n, err := u.number(b, t)
+		if err != nil {
+			return err
+		}
+
+		perSecond := int64(time.Second / unit)
+		if i, err := strconv.ParseInt(n, 10, 64); err == nil {
+			p.Set(reflect.ValueOf(time.Unix(i/perSecond, i%perSecond*int64(unit)).UTC()))
+			return nil
+		}
+
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil || math.IsInf(f, 0) {
+			return fmt.Errorf("'%s' is not a time", n)
+		}
+
+		sec, frac := math.Modf(f / float64(perSecond))
+		p.Set(reflect.ValueOf(time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC()))
+		return nil
+	}
+}
+
+// commaSplit decodes a string of comma separated values, such as "a, b,c", into a slice field, with
+// each value trimmed of spaces, and empty values dropped. The values are decoded as the elements of an
+// array would be, as strings for a slice of strings, and as JSON (e.g. numbers) otherwise. A JSON
+// array is decoded as usual.
+func (u *unmarshaler) commaSplit(b []byte, t string, p reflect.Value) error {
+	if p.Kind() != reflect.Slice {
+		return fmt.Errorf("requires a slice field, found '%v'", p.Type())
+	}
+
+	switch t {
+	case JSONArray:
+		return u.unmarshalValue(b, t, p, tagOptions{})
+	case JSONString:
+	default:
+		return fmt.Errorf("expected a string, found JSON type '%s'", t)
+	}
+
+	s, err := convertString(b, t, false, u.InvalidUTF8)
+	if err != nil {
+		return err
+	}
+
+	out := reflect.MakeSlice(p.Type(), 0, strings.Count(s, ",")+1)
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+
+		raw := []byte(v)
+		if p.Type().Elem().Kind() == reflect.String {
+			raw = AppendString(nil, v)
+		}
+
+		rt := GetJSONTypeStrict(raw, 0)
+		if rt == JSONInvalid {
+			return fmt.Errorf("'%s' is not a valid %v", v, p.Type().Elem())
+		}
+
+		e := reflect.New(p.Type().Elem()).Elem()
+		if err := u.unmarshalValue(raw, rt, e, tagOptions{}); err != nil {
+			return fieldError(err, strconv.Itoa(out.Len()))
+		}
+		out = reflect.Append(out, e)
+	}
+
+	p.Set(out)
+	return nil
+}
+
+// byteUnits are the multipliers of the units accepted by byteSize, keyed in lowercase.
+var byteUnits = map[string]float64{
+	"": 1, "b": 1,
+	"kb": 1e3, "mb": 1e6, "gb": 1e9, "tb": 1e12, "pb": 1e15,
+	"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40, "pib": 1 << 50,
+}
+
+// byteSize decodes a size in bytes into a numeric field. The size is a number of bytes, or a string
+// holding a number followed by a unit, such as "512", "10 MB" or "1.5GiB". Units are case-insensitive:
+// B, KB, MB, GB, TB and PB are powers of 1000, and KiB, MiB, GiB, TiB and PiB powers of 1024.
+func (u *unmarshaler) byteSize(b []byte, t string, p reflect.Value) error {
+	var s string
+	switch t {
+	case JSONInt, JSONFloat:
+		s = string(b)
+	case JSONString:
+		var err error
+		if s, err = convertString(b, t, false, u.InvalidUTF8); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("expected a size, found JSON type '%s'", t)
+	}
+
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != 'e' && r != 'E' && r != '+' && r != '-'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	n, err := strconv.ParseFloat(s[:i], 64)
+	unit, ok := byteUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+	if err != nil || !ok || n < 0 {
+		return fmt.Errorf("'%s' is not a size", s)
+	}
+	n *= unit
+
+	switch p.Kind() {
+	case reflect.Float32, reflect.Float64:
+		p.SetFloat(n)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n != math.Trunc(n) || n >= math.MaxInt64 || p.OverflowInt(int64(n)) {
+			return fmt.Errorf("'%s' can not be held by '%v'", s, p.Type())
+		}
+		p.SetInt(int64(n))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n != math.Trunc(n) || n >= math.MaxUint64 || p.OverflowUint(uint64(n)) {
+			return fmt.Errorf("'%s' can not be held by '%v'", s, p.Type())
+		}
+		p.SetUint(uint64(n))
+		return nil
+	}
+
+	return fmt.Errorf("requires a numeric field, found '%v'", p.Type())
+}
+
+// number returns the number b, of JSON type t. Outside of strict standards, it may be held in a string.
+func (u *unmarshaler) number(b []byte, t string) (string, error) {
+	switch {
+	case t == JSONInt || t == JSONFloat:
+		return string(b), nil
+	case t == JSONString && !u.StrictStandards:
+		s, err := convertString(b, t, false, u.InvalidUTF8)
+		if err != nil {
+			return "", err
+		}
+		if s = strings.TrimSpace(s); IsJSONNumber([]byte(s)) {
+			return s, nil
+		}
+	}
+
+	return "", fmt.Errorf("expected a number, found JSON type '%s'", t)
+}
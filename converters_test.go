@@ -0,0 +1,85 @@
+package gojson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldConverters(t *testing.T) {
+	type Event struct {
+		At       time.Time  `json:"at,unixsec"`
+		AtMS     *time.Time `json:"at_ms,unixms"`
+		Tags     []string   `json:"tags,commaSplit"`
+		IDs      []int      `json:"ids" gojson:",commasplit"`
+		Size     int64      `json:"size,bytesize"`
+		Quota    uint32     `json:"quota,bytesize"`
+		Ratio    float64    `json:"ratio,bytesize"`
+		Bytesize string     `json:"bytesize"`
+	}
+
+	t.Run("Decode", func(t *testing.T) {
+		var e Event
+		data := []byte(`{"at": 1700000000, "at_ms": 1700000000123, "tags": " a, b,,c ", "ids": "1, 2,3", "size": "10 MB", "quota": "1.5KiB", "ratio": 12, "bytesize": "name"}`)
+		assert.Nil(t, Unmarshal(data, &e))
+
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), e.At)
+		assert.Equal(t, time.Unix(1700000000, 123e6).UTC(), *e.AtMS)
+		assert.Equal(t, []string{"a", "b", "c"}, e.Tags)
+		assert.Equal(t, []int{1, 2, 3}, e.IDs)
+		assert.Equal(t, int64(10e6), e.Size)
+		assert.Equal(t, uint32(1536), e.Quota)
+		assert.Equal(t, 12.0, e.Ratio)
+		assert.Equal(t, "name", e.Bytesize)
+	})
+
+	t.Run("Alternatives", func(t *testing.T) {
+		var e Event
+		data := []byte(`{"at": "1700000000.5", "at_ms": -1500, "tags": ["x", "y"], "ids": "", "size": 512, "quota": "2 gb", "ratio": null}`)
+		assert.Nil(t, Unmarshal(data, &e))
+
+		assert.Equal(t, time.Unix(1700000000, 5e8).UTC(), e.At)
+		assert.Equal(t, time.Unix(-1, -5e8).UTC(), *e.AtMS)
+		assert.Equal(t, []string{"x", "y"}, e.Tags)
+		assert.Equal(t, []int{}, e.IDs)
+		assert.Equal(t, int64(512), e.Size)
+		assert.Equal(t, uint32(2e9), e.Quota)
+	})
+
+	testCases := []struct {
+		Name string
+		Data string
+		Err  string
+	}{
+		{"Time", `{"at": "soon"}`, `key 'at' for struct 'Event' has invalid unixsec value "soon": expected a number, found JSON type 'string'`},
+		{"Time Type", `{"at": true}`, `key 'at' for struct 'Event' has invalid unixsec value true: expected a number, found JSON type 'bool'`},
+		{"List Element", `{"ids": "1,x"}`, `key 'ids' for struct 'Event' has invalid commasplit value "1,x": 'x' is not a valid int`},
+		{"List Type", `{"tags": 7}`, `key 'tags' for struct 'Event' has invalid commasplit value 7: expected a string, found JSON type 'int'`},
+		{"Size Unit", `{"size": "10 parsecs"}`, `key 'size' for struct 'Event' has invalid bytesize value "10 parsecs": '10 parsecs' is not a size`},
+		{"Size Fraction", `{"size": "1.5B"}`, `key 'size' for struct 'Event' has invalid bytesize value "1.5B": '1.5B' can not be held by 'int64'`},
+		{"Size Overflow", `{"quota": "5GB"}`, `key 'quota' for struct 'Event' has invalid bytesize value "5GB": '5GB' can not be held by 'uint32'`},
+		{"Size Negative", `{"size": -1}`, `key 'size' for struct 'Event' has invalid bytesize value -1: '-1' is not a size`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var e Event
+			assert.EqualError(t, Unmarshal([]byte(tc.Data), &e), tc.Err)
+		})
+	}
+
+	t.Run("Strict", func(t *testing.T) {
+		var e Event
+		assert.Nil(t, UnmarshalStrict([]byte(`{"at": 1700000000, "ids": "1,2", "size": "1KB"}`), &e))
+		assert.Equal(t, []int{1, 2}, e.IDs)
+		assert.NotNil(t, UnmarshalStrict([]byte(`{"at": "1700000000"}`), &e))
+	})
+
+	t.Run("Field Type", func(t *testing.T) {
+		var v struct {
+			At string `json:"at,unixsec"`
+		}
+		assert.EqualError(t, Unmarshal([]byte(`{"at": 1}`), &v), "key 'at' for struct '' has invalid unixsec value 1: requires a time.Time field, found 'string'")
+	})
+}
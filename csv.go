@@ -0,0 +1,81 @@
+package gojson
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// ToCSV writes the top-level array of objects as CSV, with one row per element, and one column
+// per key path. The first row holds the key paths as a header. Key paths may be nested, e.g.
+// "price.amount", and a top-level object is written as a single row.
+//
+// Strings are written unquoted, and null values and missing keys are written as empty cells.
+// Nested objects and arrays are written as compact JSON.
+//
+// Example, exporting the items array:
+//
+//	err := r.Get("items").ToCSV([]string{"sku", "price.amount", "tags"}, os.Stdout)
+func (jr *JSONReader) ToCSV(keyPaths []string, w io.Writer) error {
+	if jr.Empty {
+		return ErrEmpty
+	}
+
+	root := jr.getChildByKey("")
+
+	var rows []parsed
+	switch jr.Type {
+	case JSONArray:
+		for _, k := range root.keys {
+			rows = append(rows, root.children[k])
+		}
+	case JSONObject:
+		rows = append(rows, *root)
+	default:
+		return fmt.Errorf("ToCSV requires an array of objects, found %s", jr.Type)
+	}
+
+	out := csv.NewWriter(w)
+	if err := out.Write(keyPaths); err != nil {
+		return err
+	}
+
+	record := make([]string, len(keyPaths))
+	for _, row := range rows {
+		r := JSONReader{rawData: row.bytes, parsed: row.children, dups: row.dups, Type: row.dtype, Keys: row.keys}
+
+		for i, path := range keyPaths {
+			record[i] = ""
+
+			// Scalar elements have no keys to select.
+			if row.dtype != JSONObject && row.dtype != JSONArray {
+				continue
+			}
+
+			if p := r.getChildByKey(path); p != nil {
+				record[i] = csvCell(*p)
+			}
+		}
+
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// csvCell formats a node for a CSV cell.
+func csvCell(p parsed) string {
+	switch p.dtype {
+	case JSONNull:
+		return ""
+	case JSONString:
+		return toString(p.bytes, p.dtype, false)
+	case JSONObject, JSONArray:
+		return string(compact(p))
+	}
+
+	return string(p.bytes)
+}
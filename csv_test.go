@@ -0,0 +1,69 @@
+package gojson
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToCSV(t *testing.T) {
+	r, err := NewJSONReader([]byte(`[
+		{"sku": "a", "price": {"amount": 1.50, "currency": "USD"}, "tags": ["x", "y"], "note": "say \"hi\", twice"},
+		{"sku": "b", "price": null, "tags": []},
+		{"price": {"amount": 3}, "note": null},
+		17
+	]`))
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	err = r.ToCSV([]string{"sku", "price.amount", "tags", "note"}, &buf)
+	assert.Nil(t, err)
+
+	expected := "sku,price.amount,tags,note\n" +
+		"a,1.50,\"[\"\"x\"\",\"\"y\"\"]\",\"say \"\"hi\"\", twice\"\n" +
+		"b,,[],\n" +
+		",3,,\n" +
+		",,,\n"
+	assert.Equal(t, expected, buf.String())
+
+	t.Run("Object Root", func(t *testing.T) {
+		r, _ := NewJSONReader([]byte(`{"id": 1, "name": "n"}`))
+
+		var buf bytes.Buffer
+		assert.Nil(t, r.ToCSV([]string{"name", "id"}, &buf))
+		assert.Equal(t, "name,id\nn,1\n", buf.String())
+	})
+
+	t.Run("Large Blob Items", func(t *testing.T) {
+		r, _ := NewJSONReader([]byte(largeJSONTestBlob))
+
+		var buf bytes.Buffer
+		assert.Nil(t, r.Get("items").ToCSV([]string{"id", "data.assets.0.title", "metadata.schema"}, &buf))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Equal(t, len(r.GetCollection("items"))+1, len(lines))
+		assert.Equal(t, "id 0,title 0,schema 0", lines[1])
+	})
+
+	t.Run("Scalar Root", func(t *testing.T) {
+		r, _ := NewJSONReader([]byte(`"abc"`))
+		assert.EqualError(t, r.ToCSV([]string{"a"}, &bytes.Buffer{}), "ToCSV requires an array of objects, found string")
+	})
+
+	t.Run("Empty Reader", func(t *testing.T) {
+		assert.Equal(t, ErrEmpty, (&JSONReader{Empty: true}).ToCSV([]string{"a"}, &bytes.Buffer{}))
+	})
+
+	t.Run("Write Error", func(t *testing.T) {
+		assert.EqualError(t, r.ToCSV([]string{"sku"}, failingWriter{}), "write failed")
+	})
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
@@ -0,0 +1,595 @@
+package gojson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Document is an editable JSON document, such as a user's config file, which tolerates comments and
+// preserves them across edits. Line (//) and block (/* */) comments may appear wherever whitespace
+// may. Set and Delete modify the document in place, leaving the comments, key order and formatting
+// of everything they don't touch exactly as they were, so that the document can be written back with
+// Bytes.
+//
+// Example:
+//
+//	doc, err := gojson.ParseDocument(data)
+//	err = doc.Set("server.port", 8080)
+//	err = doc.Delete("server.debug")
+//	err = os.WriteFile("config.json", doc.Bytes(), 0644)
+type Document struct {
+	data []byte
+	root *docNode
+
+	// edits holds the splices made by Set and Delete since the document was last parsed in full. Only
+	// the values they add are parsed, and the offsets of the other nodes are brought up to date as
+	// the nodes are visited, so that small edits to large documents are cheap.
+	edits []docEdit
+}
+
+// docNode is a value within a Document, located by its span in the document.
+type docNode struct {
+	dtype string
+	start int
+	end   int
+
+	// members holds the members of an object, or the elements of an array, in document order.
+	members []docMember
+
+	// version is the number of the document's edits reflected in the offsets of the node and its
+	// members.
+	version int
+}
+
+// docMember is a member of an object, or an element of an array, for which key is empty.
+type docMember struct {
+	key string
+
+	// start is the position of the key, or of the value for an array element.
+	start int
+	value *docNode
+
+	// comma is the position of the comma following the member, or -1 if there is none.
+	comma int
+}
+
+// docEdit records that the bytes between start and end were replaced with size bytes.
+type docEdit struct {
+	start int
+	end   int
+	size  int
+}
+
+// maxDocEdits is the number of edits retained before the document is parsed afresh.
+const maxDocEdits = 256
+
+// pos returns the position p, of the start of a value or key or of a comma, after the edit.
+func (e docEdit) pos(p int) int {
+	if p >= e.end {
+		return p + e.size - (e.end - e.start)
+	}
+	return p
+}
+
+// endPos returns the position p, following the end of a value, after the edit. A value ending where
+// bytes are inserted is unchanged.
+func (e docEdit) endPos(p int) int {
+	if p > e.end {
+		return p + e.size - (e.end - e.start)
+	}
+	return p
+}
+
+// ParseDocument parses a JSON document which may contain comments, for editing. A copy is made of
+// the data. A document nested deeper than DefaultMaxDepth returns a DepthExceededError.
+func ParseDocument(data []byte) (*Document, error) {
+	if len(data) == 0 {
+		return nil, ErrEmpty
+	}
+
+	d := &Document{}
+	if err := d.reset(append([]byte{}, data...)); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Bytes returns the document, including any edits. The returned slice must not be modified.
+func (d *Document) Bytes() []byte {
+	return d.data
+}
+
+// Reader parses the document, with its comments removed, into a JSONReader. Comments are replaced with
+// whitespace, so that positions recorded with WithPositions match the document.
+func (d *Document) Reader(opts ...ReaderOption) (*JSONReader, error) {
+	return NewJSONReader(stripComments(d.data), opts...)
+}
+
+// stripComments returns a copy of data with its comments replaced by whitespace. Newlines are kept, so
+// that lines and positions are unchanged.
+func stripComments(data []byte) []byte {
+	stripped := make([]byte, len(data))
+	copy(stripped, data)
+
+	for pos := 0; pos < len(stripped); {
+		switch {
+		case stripped[pos] == '"':
+			pos = stringEnd(stripped, pos+1) + 1
+		case stripped[pos] == '/':
+			end, _ := skipComment(stripped, pos)
+			for ; pos < end; pos++ {
+				if stripped[pos] != '\n' {
+					stripped[pos] = ' '
+				}
+			}
+		default:
+			pos++
+		}
+	}
+
+	return stripped
+}
+
+// Set sets the value at the given key path to the JSON encoding of value, replacing any existing
+// value. Missing objects along the path are created. New members are added at the end of their
+// object, on a line of their own if the member before them is.
+func (d *Document) Set(path string, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("key '%s' could not be encoded: %w", path, err)
+	}
+
+	keys := pathToKeys(path)
+
+	// m is the member holding n, or nil for the root.
+	var m *docMember
+	n := d.visit(d.root)
+	for i, k := range keys {
+		next := n.member(k)
+		if next == nil {
+			if n.dtype != JSONObject {
+				return keyNotFound(path)
+			}
+
+			// Wrap the value in an object for each of the remaining keys.
+			for j := len(keys) - 1; j > i; j-- {
+				b = append(append([]byte(`{`+quoteKey(keys[j])+`: `), b...), '}')
+			}
+
+			if err := d.addMember(n, keys[i], b); err != nil {
+				return err
+			}
+			return d.settle()
+		}
+
+		m, n = next, d.visit(next.value)
+	}
+
+	start := n.start
+	d.splice(n.start, n.end, b)
+
+	p := docParser{data: d.data, version: len(d.edits)}
+	v, _, err := p.value(start)
+	if err != nil {
+		return err
+	}
+
+	if m == nil {
+		d.root = v
+	} else {
+		m.value = v
+	}
+
+	return d.settle()
+}
+
+// Delete removes the value at the given key path, along with its key, the comma separating it from
+// its neighbours, and any comment following it on the same line.
+func (d *Document) Delete(path string) error {
+	keys := pathToKeys(path)
+	if len(keys) == 0 {
+		return fmt.Errorf("the root of a document cannot be deleted")
+	}
+
+	n := d.visit(d.root)
+	for _, k := range keys[:len(keys)-1] {
+		m := n.member(k)
+		if m == nil {
+			return keyNotFound(path)
+		}
+		n = d.visit(m.value)
+	}
+
+	i := n.index(keys[len(keys)-1])
+	if i < 0 {
+		return keyNotFound(path)
+	}
+	m := n.members[i]
+
+	// A member alone on its line takes the whole line, including any comment which follows it.
+	// Otherwise, only the member and the spaces separating it from its neighbour are removed.
+	start, end := m.start, d.visit(m.value).end
+	lineStart, alone := startOfLine(d.data, start)
+
+	// Remove the member's own comma, or failing that, the comma before it.
+	if m.comma >= 0 {
+		end = m.comma + 1
+		for !alone && end < len(d.data) && (d.data[end] == ' ' || d.data[end] == '\t') {
+			end++
+		}
+	} else if i > 0 {
+		prev := n.members[i-1].comma
+		if !alone && len(bytes.Trim(d.data[prev+1:start], " \t")) == 0 {
+			start = prev
+		} else {
+			d.splice(prev, prev+1, nil)
+			start, end, lineStart = start-1, end-1, lineStart-1
+		}
+		n.members[i-1].comma = -1
+	}
+
+	if alone {
+		if lineEnd, ok := restOfLine(d.data, end); ok {
+			start, end = lineStart, lineEnd
+		}
+	}
+
+	d.splice(start, end, nil)
+	n.members = append(n.members[:i], n.members[i+1:]...)
+
+	return d.settle()
+}
+
+// addMember adds a new member to the end of the object n.
+func (d *Document) addMember(n *docNode, key string, value []byte) error {
+	member := append([]byte(quoteKey(key)+": "), value...)
+	if len(n.members) == 0 {
+		d.splice(n.start+1, n.start+1, member)
+		return d.parseMember(n, n.start+1)
+	}
+
+	// The last member is never followed by a comma, so one is added after its value. The new member
+	// follows on the same line, unless the last member is alone on its line, in which case the new
+	// member is given a line of its own with the same indentation.
+	last := &n.members[len(n.members)-1]
+	end := d.visit(last.value).end
+	at, text, pos := end, append([]byte{' '}, member...), end+1
+
+	if lineStart, ok := startOfLine(d.data, last.start); ok {
+		if lineEnd, ok := restOfLine(d.data, at); ok && lineEnd > 0 && d.data[lineEnd-1] == '\n' {
+			indent := d.data[lineStart:last.start]
+			at, pos = lineEnd, lineEnd+len(indent)
+			text = append(append(append([]byte{}, indent...), member...), '\n')
+		}
+	}
+
+	d.splice(at, at, text)
+	d.splice(end, end, []byte{','})
+
+	// The comma precedes the new member.
+	d.visit(n)
+	last.comma = end
+	return d.parseMember(n, pos+1)
+}
+
+// parseMember parses the member of the object n at pos, which has been added to the document, and
+// appends it to n's members.
+func (d *Document) parseMember(n *docNode, pos int) error {
+	d.visit(n)
+
+	p := docParser{data: d.data, version: len(d.edits)}
+	m, _, err := p.member(pos, true)
+	if err != nil {
+		return err
+	}
+
+	n.members = append(n.members, m)
+	return nil
+}
+
+// splice replaces the bytes of the document between start and end with b, and records the edit.
+func (d *Document) splice(start, end int, b []byte) {
+	d.data = splice(d.data, start, end, b)
+	d.edits = append(d.edits, docEdit{start: start, end: end, size: len(b)})
+}
+
+// visit brings the offsets of n and its members up to date with the document's edits, and returns n.
+func (d *Document) visit(n *docNode) *docNode {
+	for _, e := range d.edits[n.version:] {
+		n.start, n.end = e.pos(n.start), e.endPos(n.end)
+		for i := range n.members {
+			n.members[i].start = e.pos(n.members[i].start)
+			if n.members[i].comma >= 0 {
+				n.members[i].comma = e.pos(n.members[i].comma)
+			}
+		}
+	}
+
+	n.version = len(d.edits)
+	return n
+}
+
+// settle parses the document afresh once maxDocEdits edits have accumulated, so that visiting a node
+// never has more than that number to apply.
+func (d *Document) settle() error {
+	if len(d.edits) < maxDocEdits {
+		return nil
+	}
+
+	return d.reset(d.data)
+}
+
+// splice returns a copy of data with the bytes between start and end replaced by b.
+func splice(data []byte, start, end int, b []byte) []byte {
+	out := make([]byte, 0, len(data)-(end-start)+len(b))
+	out = append(out, data[:start]...)
+	out = append(out, b...)
+	return append(out, data[end:]...)
+}
+
+func (d *Document) reset(data []byte) error {
+	p := docParser{data: data}
+
+	pos, err := p.skip(0)
+	if err != nil {
+		return err
+	}
+
+	root, pos, err := p.value(pos)
+	if err != nil {
+		return err
+	}
+
+	if pos, err = p.skip(pos); err != nil {
+		return err
+	}
+	if pos < len(data) {
+		return fmt.Errorf("unexpected '%c' at position '%d' in segment '%s'", data[pos], pos, truncate(data[pos:], 50))
+	}
+
+	d.data, d.root, d.edits = data, root, nil
+	return nil
+}
+
+// member returns the member of an object or array with the given key or index, or nil. As with
+// NewJSONReader, the last of any duplicate keys is used.
+func (n *docNode) member(key string) *docMember {
+	if i := n.index(key); i >= 0 {
+		return &n.members[i]
+	}
+
+	return nil
+}
+
+func (n *docNode) index(key string) int {
+	switch n.dtype {
+	case JSONObject:
+		for i := len(n.members) - 1; i >= 0; i-- {
+			if n.members[i].key == key {
+				return i
+			}
+		}
+	case JSONArray:
+		if isDecimalNumber([]byte(key)) {
+			i := toInt([]byte(key), JSONInt, false, TruncateNumbers)
+			if i < len(n.members) {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// quoteKey returns the key as a JSON string.
+func quoteKey(key string) string {
+	b, _ := json.Marshal(key)
+	return string(b)
+}
+
+// startOfLine returns the position of the start of the line containing pos, if only spaces and
+// tabs precede pos on the line.
+func startOfLine(data []byte, pos int) (int, bool) {
+	for pos > 0 && (data[pos-1] == ' ' || data[pos-1] == '\t') {
+		pos--
+	}
+
+	return pos, pos == 0 || data[pos-1] == '\n'
+}
+
+// restOfLine returns the position following the end of the line containing pos, if the rest of the
+// line holds only whitespace and comments.
+func restOfLine(data []byte, pos int) (int, bool) {
+	for pos < len(data) {
+		switch data[pos] {
+		case ' ', '\t', '\r':
+			pos++
+		case '\n':
+			return pos + 1, true
+		case '/':
+			end, err := skipComment(data, pos)
+			if err != nil || (bytes.IndexByte(data[pos:end], '\n') >= 0 && data[end-1] != '\n') {
+				return 0, false
+			}
+			pos = end
+			if data[end-1] == '\n' {
+				return end, true
+			}
+		default:
+			return 0, false
+		}
+	}
+
+	return pos, true
+}
+
+// skipComment returns the position following the comment at pos. A line comment includes its
+// newline.
+func skipComment(data []byte, pos int) (int, error) {
+	if pos+1 < len(data) {
+		switch data[pos+1] {
+		case '/':
+			if end := bytes.IndexByte(data[pos:], '\n'); end >= 0 {
+				return pos + end + 1, nil
+			}
+			return len(data), nil
+		case '*':
+			if end := bytes.Index(data[pos+2:], []byte("*/")); end >= 0 {
+				return pos + 2 + end + 2, nil
+			}
+			return 0, fmt.Errorf("unterminated comment at position '%d'", pos)
+		}
+	}
+
+	return 0, fmt.Errorf("unexpected '/' at position '%d' in segment '%s'", pos, truncate(data[pos:], 50))
+}
+
+// docParser parses a Document, recording the span of each value.
+type docParser struct {
+	data []byte
+
+	// version is the version given to the nodes parsed.
+	version int
+
+	// depth is the number of containers being parsed.
+	depth int
+}
+
+// skip returns the position of the next byte which is neither whitespace nor part of a comment.
+func (p *docParser) skip(pos int) (int, error) {
+	for pos < len(p.data) {
+		switch {
+		case isWhitespace(p.data[pos]):
+			pos++
+		case p.data[pos] == '/':
+			end, err := skipComment(p.data, pos)
+			if err != nil {
+				return 0, err
+			}
+			pos = end
+		default:
+			return pos, nil
+		}
+	}
+
+	return pos, nil
+}
+
+// value parses the value at pos, returning it and the position following it.
+func (p *docParser) value(pos int) (*docNode, int, error) {
+	if pos >= len(p.data) {
+		return nil, 0, ErrMalformedJSON
+	}
+
+	switch p.data[pos] {
+	case '{', '[':
+		return p.container(pos)
+	case '"':
+		end := stringEnd(p.data, pos+1)
+		if end < 0 {
+			return nil, 0, fmt.Errorf("unterminated string at position '%d'", pos)
+		}
+		return &docNode{dtype: JSONString, start: pos, end: end + 1, version: p.version}, end + 1, nil
+	}
+
+	end := pos
+	for end < len(p.data) && !isWhitespace(p.data[end]) && !isTermByte(p.data[end]) && p.data[end] != '/' {
+		end++
+	}
+
+	b := p.data[pos:end]
+	var t string
+	switch {
+	case IsJSONNumber(b):
+		t = extractNumberType(b)
+	case IsJSONTrue(b), IsJSONFalse(b):
+		t = JSONBool
+	case IsJSONNull(b):
+		t = JSONNull
+	default:
+		return nil, 0, fmt.Errorf("unexpected value at position '%d' in segment '%s'", pos, truncate(p.data[pos:], 50))
+	}
+
+	return &docNode{dtype: t, start: pos, end: end, version: p.version}, end, nil
+}
+
+// container parses the object or array at pos.
+func (p *docParser) container(pos int) (*docNode, int, error) {
+	if p.depth++; p.depth > DefaultMaxDepth {
+		return nil, 0, &DepthExceededError{MaxDepth: DefaultMaxDepth, Offset: pos}
+	}
+	defer func() { p.depth-- }()
+
+	n := &docNode{dtype: JSONArray, start: pos, version: p.version}
+	close := byte(']')
+	if p.data[pos] == '{' {
+		n.dtype, close = JSONObject, '}'
+	}
+
+	next, err := p.skip(pos + 1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for len(n.members) > 0 || next >= len(p.data) || p.data[next] != close {
+		m, end, err := p.member(next, n.dtype == JSONObject)
+		if err != nil {
+			return nil, 0, err
+		}
+		if next, err = p.skip(end); err != nil {
+			return nil, 0, err
+		}
+
+		if next >= len(p.data) || p.data[next] != ',' {
+			n.members = append(n.members, m)
+			break
+		}
+
+		m.comma = next
+		n.members = append(n.members, m)
+		if next, err = p.skip(next + 1); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if next >= len(p.data) || p.data[next] != close {
+		return nil, 0, fmt.Errorf("expected ',' or '%c' at position '%d' in segment '%s'", close, next, truncate(p.data[next:], 50))
+	}
+
+	n.end = next + 1
+	return n, next + 1, nil
+}
+
+// member parses the member of an object, or the element of an array, at pos, returning it and the
+// position following its value.
+func (p *docParser) member(pos int, object bool) (docMember, int, error) {
+	m := docMember{start: pos, comma: -1}
+
+	var err error
+	if object {
+		if pos >= len(p.data) || p.data[pos] != '"' {
+			return m, 0, fmt.Errorf("expected key at position '%d' in segment '%s'", pos, truncate(p.data[pos:], 50))
+		}
+
+		end := stringEnd(p.data, pos+1)
+		if end < 0 {
+			return m, 0, fmt.Errorf("unterminated string at position '%d'", pos)
+		}
+		m.key = string(p.data[pos+1 : end])
+
+		if pos, err = p.skip(end + 1); err != nil {
+			return m, 0, err
+		}
+		if pos >= len(p.data) || p.data[pos] != ':' {
+			return m, 0, fmt.Errorf("expected ':' at position '%d' in segment '%s'", pos, truncate(p.data[pos:], 50))
+		}
+		if pos, err = p.skip(pos + 1); err != nil {
+			return m, 0, err
+		}
+	}
+
+	m.value, pos, err = p.value(pos)
+	return m, pos, err
+}
@@ -0,0 +1,242 @@
+package gojson
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfig = `// Service configuration.
+{
+	"name": "api", // The service name.
+	/* Network settings. */
+	"server": {
+		"host": "localhost",
+		"port": 80 // Must be at least 1024 in production.
+	},
+	"tags": ["a", "b"]
+}
+`
+
+func TestDocument(t *testing.T) {
+	t.Run("Round Trip", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(testConfig))
+		assert.Nil(t, err)
+		assert.Equal(t, testConfig, string(doc.Bytes()))
+	})
+
+	t.Run("Set Existing", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(testConfig))
+		assert.Nil(t, err)
+
+		assert.Nil(t, doc.Set("server.port", 8080))
+		assert.Nil(t, doc.Set("tags.1", map[string]int{"c": 1}))
+		assert.Equal(t, `// Service configuration.
+{
+	"name": "api", // The service name.
+	/* Network settings. */
+	"server": {
+		"host": "localhost",
+		"port": 8080 // Must be at least 1024 in production.
+	},
+	"tags": ["a", {"c":1}]
+}
+`, string(doc.Bytes()))
+	})
+
+	t.Run("Set New", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(testConfig))
+		assert.Nil(t, err)
+
+		assert.Nil(t, doc.Set("server.tls", true))
+		assert.Nil(t, doc.Set("limits.rate.burst", 10))
+		assert.Nil(t, doc.Set("limits.rate.per", "1s"))
+		assert.Equal(t, `// Service configuration.
+{
+	"name": "api", // The service name.
+	/* Network settings. */
+	"server": {
+		"host": "localhost",
+		"port": 80, // Must be at least 1024 in production.
+		"tls": true
+	},
+	"tags": ["a", "b"],
+	"limits": {"rate": {"burst": 10, "per": "1s"}}
+}
+`, string(doc.Bytes()))
+	})
+
+	t.Run("Set Empty Object", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(`{}`))
+		assert.Nil(t, err)
+
+		assert.Nil(t, doc.Set("a", 1))
+		assert.Equal(t, `{"a": 1}`, string(doc.Bytes()))
+	})
+
+	t.Run("Set Root", func(t *testing.T) {
+		doc, err := ParseDocument([]byte("// Comment\n{}\n"))
+		assert.Nil(t, err)
+
+		assert.Nil(t, doc.Set("", []int{1}))
+		assert.Equal(t, "// Comment\n[1]\n", string(doc.Bytes()))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(testConfig))
+		assert.Nil(t, err)
+
+		assert.Nil(t, doc.Delete("name"))
+		assert.Nil(t, doc.Delete("server.port"))
+		assert.Nil(t, doc.Delete("tags.0"))
+		assert.Equal(t, `// Service configuration.
+{
+	/* Network settings. */
+	"server": {
+		"host": "localhost"
+	},
+	"tags": ["b"]
+}
+`, string(doc.Bytes()))
+
+		assert.Nil(t, doc.Delete("tags"))
+		assert.Nil(t, doc.Delete("server.host"))
+		assert.Equal(t, `// Service configuration.
+{
+	/* Network settings. */
+	"server": {
+	}
+}
+`, string(doc.Bytes()))
+	})
+
+	t.Run("Delete Inline", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(`{"a": 1, "b": 2, "c": [3] /* c */}`))
+		assert.Nil(t, err)
+
+		assert.Nil(t, doc.Delete("b"))
+		assert.Equal(t, `{"a": 1, "c": [3] /* c */}`, string(doc.Bytes()))
+
+		assert.Nil(t, doc.Delete("c.0"))
+		assert.Nil(t, doc.Delete("c"))
+		assert.Equal(t, `{"a": 1 /* c */}`, string(doc.Bytes()))
+
+		assert.Nil(t, doc.Delete("a"))
+		assert.Equal(t, `{ /* c */}`, string(doc.Bytes()))
+	})
+
+	t.Run("Incremental", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(testConfig))
+		assert.Nil(t, err)
+
+		edits := []func() error{
+			func() error { return doc.Set("server.port", 8080) },
+			func() error { return doc.Set("server.tls", map[string]bool{"enabled": true}) },
+			func() error { return doc.Delete("name") },
+			func() error { return doc.Set("tags.0", []int{1, 2}) },
+			func() error { return doc.Set("limits.rate", 10) },
+			func() error { return doc.Delete("server.host") },
+			func() error { return doc.Set("server.tls.enabled", false) },
+			func() error { return doc.Delete("tags.1") },
+			func() error { return doc.Set("tags.0.1", "two") },
+			func() error { return doc.Set("", map[string]int{"a": 1}) },
+			func() error { return doc.Set("b", 2) },
+		}
+
+		for i, edit := range edits {
+			assert.Nil(t, edit(), i)
+
+			// The offsets of every node match those of a fresh parse.
+			fresh, err := ParseDocument(doc.Bytes())
+			if assert.Nil(t, err, i) {
+				assertSameNodes(t, doc, doc.root, fresh.root)
+			}
+		}
+		assert.Equal(t, `// Service configuration.
+{"a":1, "b": 2}
+`, string(doc.Bytes()))
+	})
+
+	t.Run("Settle", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(`{"n": 0, "list": [1, 2, 3]}`))
+		assert.Nil(t, err)
+
+		for i := 0; i < maxDocEdits+10; i++ {
+			assert.Nil(t, doc.Set("n", i*1000))
+		}
+		assert.Less(t, len(doc.edits), maxDocEdits)
+
+		fresh, err := ParseDocument(doc.Bytes())
+		assert.Nil(t, err)
+		assertSameNodes(t, doc, doc.root, fresh.root)
+	})
+
+	t.Run("Reader", func(t *testing.T) {
+		doc, err := ParseDocument([]byte(testConfig))
+		assert.Nil(t, err)
+
+		jr, err := doc.Reader(WithPositions())
+		assert.Nil(t, err)
+		assert.Equal(t, 80, jr.GetInt("server.port"))
+		assert.Equal(t, []string{"a", "b"}, jr.GetStringSlice("tags"))
+
+		pos, ok := jr.Position("server.port")
+		assert.True(t, ok)
+		assert.Equal(t, "7:11", pos.String())
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		testCases := []struct {
+			Name     string
+			Input    string
+			Expected string
+		}{
+			{"Empty", ``, "empty input value"},
+			{"Unterminated Comment", `{} /* `, "unterminated comment at position '3'"},
+			{"Trailing Comma", `[1, ]`, "unexpected value at position '4' in segment ']'"},
+			{"Missing Colon", `{"a" 1}`, "expected ':' at position '5' in segment '1}'"},
+			{"Missing Close", `{"a": 1`, "expected ',' or '}' at position '7' in segment ''"},
+			{"Trailing Data", `{} {}`, "unexpected '{' at position '3' in segment '{}'"},
+			{"Single Slash", `{"a": 1 / 2}`, "unexpected '/' at position '8' in segment '/ 2}'"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.Name, func(t *testing.T) {
+				_, err := ParseDocument([]byte(tc.Input))
+				assert.EqualError(t, err, tc.Expected)
+			})
+		}
+
+		doc, err := ParseDocument([]byte(testConfig))
+		assert.Nil(t, err)
+		assert.EqualError(t, doc.Set("name.first", "x"), "key 'name.first' not found")
+		assert.EqualError(t, doc.Set("tags.5", "x"), "key 'tags.5' not found")
+		assert.EqualError(t, doc.Delete("server.missing"), "key 'server.missing' not found")
+		assert.EqualError(t, doc.Delete("missing.key"), "key 'missing.key' not found")
+		assert.EqualError(t, doc.Delete(""), "the root of a document cannot be deleted")
+		assert.EqualError(t, doc.Set("name", func() {}), "key 'name' could not be encoded: json: unsupported type: func()")
+		assert.Equal(t, testConfig, string(doc.Bytes()))
+
+		deep := strings.Repeat("[", DefaultMaxDepth+1) + strings.Repeat("]", DefaultMaxDepth+1)
+		_, err = ParseDocument([]byte(deep))
+		assert.Equal(t, &DepthExceededError{MaxDepth: DefaultMaxDepth, Offset: DefaultMaxDepth}, err)
+
+		_, err = ParseDocument([]byte(deep[1 : len(deep)-1]))
+		assert.Nil(t, err)
+	})
+}
+
+// assertSameNodes asserts that the node n of doc, once visited, matches the freshly parsed node f.
+func assertSameNodes(t *testing.T, doc *Document, n, f *docNode) {
+	doc.visit(n)
+	if !assert.Equal(t, []int{f.start, f.end}, []int{n.start, n.end}, f.dtype) || !assert.Len(t, n.members, len(f.members)) {
+		return
+	}
+
+	for i, m := range n.members {
+		assert.Equal(t, []int{f.members[i].start, f.members[i].comma}, []int{m.start, m.comma}, m.key)
+		assert.Equal(t, f.members[i].key, m.key)
+		assertSameNodes(t, doc, m.value, f.members[i].value)
+	}
+}
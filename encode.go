@@ -0,0 +1,390 @@
+package gojson
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// AppendMarshal appends the JSON encoding of v to dst and returns the extended buffer, in the manner
+// of strconv.AppendInt, so that hot paths can reuse a buffer rather than allocating for each value.
+// On error, the returned buffer is nil and dst is left as it was.
+//
+// Strings, numbers, booleans, maps with string keys, slices, arrays, pointers, RawMessage,
+// OrderedMap, Pairs, Builder, and types implementing json.Marshaler or encoding.TextMarshaler are encoded
+// directly. Map keys are sorted, as with encoding/json. Structs, and anything else, are encoded by
+// encoding/json. Unlike encoding/json, <, > and & are not escaped; see EncodeOptions.EscapeHTML.
+//
+// Example:
+//
+//	buf := make([]byte, 0, 4096)
+//	for _, event := range events {
+//		buf, err = gojson.AppendMarshal(buf[:0], event.Attributes)
+//		...
+//	}
+func AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	return encoder{}.appendValue(dst, v)
+}
+
+// EncodeOptions configures the behavior of AppendMarshalWithOptions.
+type EncodeOptions struct {
+	// NonFiniteNumbers encodes NaN and the infinities as the bare literals NaN, Infinity and
+	// -Infinity, which JavaScript and many other producers accept, rather than giving an error. The
+	// output is then not standard JSON, and is read back with Options.NonFiniteNumbers.
+	NonFiniteNumbers bool
+
+	// EscapeHTML escapes <, > and & within strings as \u003c, \u003e and \u0026, as encoding/json
+	// does by default, so that the output can be embedded in HTML. Without it they are written as
+	// they are, including within values encoded by encoding/json and the output of MarshalJSON
+	// methods, so that strings are forwarded unchanged.
+	EscapeHTML bool
+}
+
+// AppendMarshalWithOptions is AppendMarshal, configured by opts.
+func AppendMarshalWithOptions(dst []byte, v interface{}, opts EncodeOptions) ([]byte, error) {
+	return encoder{opts}.appendValue(dst, v)
+}
+
+// encoder holds the EncodeOptions of a call to AppendMarshalWithOptions.
+type encoder struct {
+	EncodeOptions
+}
+
+// AppendString appends s as a quoted JSON string. Invalid UTF-8 is replaced with U+FFFD, and U+2028
+// and U+2029 are escaped, since they end lines in JavaScript.
+func AppendString(dst []byte, s string) []byte {
+	return appendString(dst, s, false)
+}
+
+// appendString is AppendString, escaping <, > and & as well if html is set.
+func appendString(dst []byte, s string, html bool) []byte {
+	const hex = "0123456789abcdef"
+
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && !(html && (c == '<' || c == '>' || c == '&')) {
+				i++
+				continue
+			}
+
+			dst = append(dst, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(append(dst, s[start:i]...), "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(append(dst, s[start:i]...), '\\', 'u', '2', '0', '2', hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+
+		i += size
+	}
+
+	return append(append(dst, s[start:]...), '"')
+}
+
+// appendString appends s as a quoted JSON string, escaping HTML if the options ask for it.
+func (e encoder) appendString(dst []byte, s string) []byte {
+	return appendString(dst, s, e.EscapeHTML)
+}
+
+// AppendInt appends the JSON number i.
+func AppendInt(dst []byte, i int64) []byte {
+	return strconv.AppendInt(dst, i, 10)
+}
+
+// AppendUint appends the JSON number i.
+func AppendUint(dst []byte, i uint64) []byte {
+	return strconv.AppendUint(dst, i, 10)
+}
+
+// AppendFloat appends the JSON number f, which was a float of the given bitSize (32 or 64). As with
+// encoding/json, an exponent is only used for very large or very small numbers. NaN and the
+// infinities can't be represented in JSON, and give an error; see EncodeOptions.NonFiniteNumbers.
+func AppendFloat(dst []byte, f float64, bitSize int) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("unsupported float value: %s", strconv.FormatFloat(f, 'g', -1, bitSize))
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bitSize == 64 && (abs < 1e-6 || abs >= 1e21) || bitSize == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+
+	dst = strconv.AppendFloat(dst, f, format, -1, bitSize)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		if n := len(dst); n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+
+	return dst, nil
+}
+
+// appendFloat appends the number f, writing NaN and the infinities as literals if the options allow it.
+func (e encoder) appendFloat(dst []byte, f float64, bitSize int) ([]byte, error) {
+	if e.NonFiniteNumbers {
+		switch {
+		case math.IsNaN(f):
+			return append(dst, "NaN"...), nil
+		case math.IsInf(f, 1):
+			return append(dst, "Infinity"...), nil
+		case math.IsInf(f, -1):
+			return append(dst, "-Infinity"...), nil
+		}
+	}
+
+	return AppendFloat(dst, f, bitSize)
+}
+
+// AppendBool appends the JSON literal true or false.
+func AppendBool(dst []byte, b bool) []byte {
+	return strconv.AppendBool(dst, b)
+}
+
+// AppendNull appends the JSON literal null.
+func AppendNull(dst []byte) []byte {
+	return append(dst, "null"...)
+}
+
+// appendValue appends the JSON encoding of v to dst.
+func (e encoder) appendValue(dst []byte, v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return AppendNull(dst), nil
+	case bool:
+		return AppendBool(dst, v), nil
+	case string:
+		return e.appendString(dst, v), nil
+	case int:
+		return AppendInt(dst, int64(v)), nil
+	case int64:
+		return AppendInt(dst, v), nil
+	case uint64:
+		return AppendUint(dst, v), nil
+	case float64:
+		return e.appendFloat(dst, v, 64)
+	case RawMessage:
+		return e.appendRaw(dst, v, "raw message")
+	case *Builder:
+		if v == nil {
+			return AppendNull(dst), nil
+		}
+		return v.appendTo(dst, e)
+	case *OrderedMap:
+		if v == nil {
+			return AppendNull(dst), nil
+		}
+		return e.appendValue(dst, *v)
+	case OrderedMap:
+		var err error
+		dst = append(dst, '{')
+		for i, k := range v.Keys {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = append(e.appendString(dst, k), ':')
+			if dst, err = e.appendValue(dst, v.Values[k]); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, '}'), nil
+	case Pairs:
+		if v == nil {
+			return AppendNull(dst), nil
+		}
+
+		var err error
+		dst = append(dst, '{')
+		for i, kv := range v {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = append(e.appendString(dst, kv.Key), ':')
+			if dst, err = e.appendValue(dst, kv.Value); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, '}'), nil
+	case *[]interface{}:
+		return e.appendValue(dst, *v)
+	case []interface{}:
+		var err error
+		dst = append(dst, '[')
+		for i, elem := range v {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = e.appendValue(dst, elem); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, ']'), nil
+	case map[string]interface{}:
+		return e.appendMap(dst, reflect.ValueOf(v))
+	case json.Marshaler:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return AppendNull(dst), nil
+		}
+		b, err := v.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return e.appendRaw(dst, b, fmt.Sprintf("MarshalJSON for type %T returned", v))
+	case encoding.TextMarshaler:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return AppendNull(dst), nil
+		}
+		b, err := v.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return e.appendString(dst, string(b)), nil
+	}
+
+	return e.appendReflect(dst, reflect.ValueOf(v))
+}
+
+// appendReflect encodes the kinds of value not covered by appendValue's type switch, such as named
+// types and typed slices and maps.
+func (e encoder) appendReflect(dst []byte, rv reflect.Value) ([]byte, error) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		return AppendBool(dst, rv.Bool()), nil
+	case reflect.String:
+		return e.appendString(dst, rv.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return AppendInt(dst, rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return AppendUint(dst, rv.Uint()), nil
+	case reflect.Float32:
+		return e.appendFloat(dst, rv.Float(), 32)
+	case reflect.Float64:
+		return e.appendFloat(dst, rv.Float(), 64)
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return AppendNull(dst), nil
+		}
+		return e.appendValue(dst, rv.Elem().Interface())
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			return e.appendMap(dst, rv)
+		}
+	case reflect.Slice:
+		if rv.IsNil() {
+			return AppendNull(dst), nil
+		}
+
+		// []byte is encoded as base64 by encoding/json.
+		if rv.Type().Elem().Kind() != reflect.Uint8 {
+			return e.appendArray(dst, rv)
+		}
+	case reflect.Array:
+		return e.appendArray(dst, rv)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(e.EscapeHTML)
+	if err := enc.Encode(rv.Interface()); err != nil {
+		return nil, err
+	}
+	return append(dst, bytes.TrimSuffix(buf.Bytes(), []byte{'\n'})...), nil
+}
+
+// appendMap encodes a map with string keys as an object, with its keys sorted.
+func (e encoder) appendMap(dst []byte, rv reflect.Value) ([]byte, error) {
+	if rv.IsNil() {
+		return AppendNull(dst), nil
+	}
+
+	keys := make([]string, 0, rv.Len())
+	values := make(map[string]reflect.Value, rv.Len())
+	for it := rv.MapRange(); it.Next(); {
+		k := it.Key().String()
+		keys = append(keys, k)
+		values[k] = it.Value()
+	}
+	sort.Strings(keys)
+
+	var err error
+	dst = append(dst, '{')
+	for i, k := range keys {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = append(e.appendString(dst, k), ':')
+		if dst, err = e.appendValue(dst, values[k].Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+// appendArray encodes a slice or array.
+func (e encoder) appendArray(dst []byte, rv reflect.Value) ([]byte, error) {
+	var err error
+	dst = append(dst, '[')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		if dst, err = e.appendValue(dst, rv.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return append(dst, ']'), nil
+}
+
+// appendRaw appends an encoded JSON value, after checking that it is valid, escaping HTML if the
+// options ask for it. what describes the value, for errors.
+func (e encoder) appendRaw(dst []byte, raw []byte, what string) ([]byte, error) {
+	raw = trim(raw)
+	if !IsJSON(raw) {
+		return nil, fmt.Errorf("%s '%s' is not valid json", what, truncate(raw, 50))
+	}
+
+	if e.EscapeHTML && bytes.ContainsAny(raw, "<>&") {
+		var buf bytes.Buffer
+		json.HTMLEscape(&buf, raw)
+		return append(dst, buf.Bytes()...), nil
+	}
+
+	return append(dst, raw...), nil
+}
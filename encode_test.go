@@ -0,0 +1,173 @@
+package gojson
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type textValue struct{ s string }
+
+func (t textValue) MarshalText() ([]byte, error) {
+	return []byte("text:" + t.s), nil
+}
+
+type badMarshaler struct{}
+
+func (badMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(`{"a"`), nil
+}
+
+func TestAppendMarshal(t *testing.T) {
+	type level string
+	type inner struct {
+		ID   int    `json:"id"`
+		Name string `json:"name,omitempty"`
+	}
+
+	one := 1
+	var nilPtr *int
+	var nilMap map[string]int
+
+	testCases := []struct {
+		name string
+		in   interface{}
+		out  string
+	}{
+		{"Null", nil, `null`},
+		{"Bool", true, `true`},
+		{"String", "a\"b\\c\né", `"a\"b\\c\né"`},
+		{"Int", -42, `-42`},
+		{"Int8", int8(-8), `-8`},
+		{"Uint16", uint16(16), `16`},
+		{"Float", 1.25, `1.25`},
+		{"Whole Float", 100.0, `100`},
+		{"Float32", float32(0.1), `0.1`},
+		{"Small Float", 0.000000123, `1.23e-7`},
+		{"Named String", level("debug"), `"debug"`},
+		{"Duration", 2 * time.Second, `2000000000`},
+		{"Pointer", &one, `1`},
+		{"Nil Pointer", nilPtr, `null`},
+		{"Slice", []string{"a", "b"}, `["a","b"]`},
+		{"Nil Slice", []int(nil), `null`},
+		{"Bytes", []byte("hi"), `"aGk="`},
+		{"Array", [3]uint8{1, 2, 3}, `[1,2,3]`},
+		{"Map", map[string]int{"b": 2, "a": 1, "c": 3}, `{"a":1,"b":2,"c":3}`},
+		{"Nil Map", nilMap, `null`},
+		{"Interface Map", map[string]interface{}{"z": []interface{}{1, "x", nil}, "a": map[string]bool{"t": true}}, `{"a":{"t":true},"z":[1,"x",null]}`},
+		{"Int Keys", map[int]string{2: "b", 1: "a"}, `{"1":"a","2":"b"}`},
+		{"Struct", inner{ID: 7}, `{"id":7}`},
+		{"Struct Slice", []*inner{{ID: 1, Name: "x"}, nil}, `[{"id":1,"name":"x"},null]`},
+		{"Text Marshaler", []textValue{{"a"}}, `["text:a"]`},
+		{"IP", net.ParseIP("10.0.0.1"), `"10.0.0.1"`},
+		{"Time", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), `"2024-01-02T03:04:05Z"`},
+		{"Raw Message", RawMessage(` [1, 2] `), `[1, 2]`},
+		{"Null Type", Null[int]{}, `null`},
+		{"Builder", Obj().Set("a", 1), `{"a":1}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := AppendMarshal(nil, tc.in)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.out, string(out))
+
+			expected, err := json.Marshal(tc.in)
+			assert.Nil(t, err)
+			assert.JSONEq(t, string(expected), string(out))
+		})
+	}
+
+	t.Run("Reuses Buffer", func(t *testing.T) {
+		buf := make([]byte, 0, 64)
+		buf = append(buf, "prefix:"...)
+
+		out, err := AppendMarshal(buf, []int{1, 2})
+		assert.Nil(t, err)
+		assert.Equal(t, `prefix:[1,2]`, string(out))
+		assert.Equal(t, &buf[:1][0], &out[0])
+
+		allocs := testing.AllocsPerRun(100, func() {
+			out, _ = AppendMarshal(out[:0], "a string value")
+		})
+		assert.Equal(t, 0.0, allocs)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := AppendMarshal(nil, map[string]float64{"a": math.Inf(1)})
+		assert.EqualError(t, err, "unsupported float value: +Inf")
+
+		_, err = AppendMarshal(nil, []interface{}{badMarshaler{}})
+		assert.EqualError(t, err, `MarshalJSON for type gojson.badMarshaler returned '{"a"' is not valid json`)
+
+		_, err = AppendMarshal(nil, make(chan int))
+		var unsupported *json.UnsupportedTypeError
+		assert.True(t, errors.As(err, &unsupported))
+	})
+}
+
+func TestAppenders(t *testing.T) {
+	out := AppendString([]byte("["), "tab\there \x1f \xff \u2028")
+	out = append(out, ',')
+	out = AppendInt(out, math.MinInt64)
+	out = append(out, ',')
+	out = AppendUint(out, math.MaxUint64)
+	out = append(out, ',')
+	out, err := AppendFloat(out, 1e21, 64)
+	assert.Nil(t, err)
+	out = append(out, ',')
+	out = AppendBool(out, false)
+	out = append(out, ',')
+	out = append(AppendNull(out), ']')
+
+	assert.Equal(t, `["tab\there \u001f `+"\ufffd"+` \u2028",-9223372036854775808,18446744073709551615,1e+21,false,null]`, string(out))
+
+	_, err = AppendFloat(nil, math.NaN(), 64)
+	assert.EqualError(t, err, "unsupported float value: NaN")
+}
+
+func TestAppendMarshalNonFinite(t *testing.T) {
+	v := map[string]interface{}{"nan": math.NaN(), "inf": math.Inf(1), "list": []float32{float32(math.Inf(-1)), 1.5}}
+
+	_, err := AppendMarshal(nil, v)
+	assert.NotNil(t, err)
+
+	out, err := AppendMarshalWithOptions(nil, v, EncodeOptions{NonFiniteNumbers: true})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"inf":Infinity,"list":[-Infinity,1.5],"nan":NaN}`, string(out))
+
+	var back map[string]interface{}
+	assert.Nil(t, UnmarshalWithOptions(out, &back, Options{NonFiniteNumbers: true}))
+	assert.True(t, math.IsNaN(back["nan"].(float64)))
+	assert.Equal(t, math.Inf(1), back["inf"])
+}
+
+func TestAppendMarshalEscapeHTML(t *testing.T) {
+	type Page struct {
+		Body string `json:"body"`
+	}
+
+	v := map[string]interface{}{
+		"<k>":  "a<b>&c",
+		"page": Page{Body: "<p>"},
+		"raw":  RawMessage(`"<i>"`),
+		"list": []interface{}{"&"},
+	}
+
+	b, err := AppendMarshal(nil, v)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"<k>":"a<b>&c","list":["&"],"page":{"body":"<p>"},"raw":"<i>"}`, string(b))
+
+	b, err = AppendMarshalWithOptions(nil, v, EncodeOptions{EscapeHTML: true})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"\u003ck\u003e":"a\u003cb\u003e\u0026c","list":["\u0026"],"page":{"body":"\u003cp\u003e"},"raw":"\u003ci\u003e"}`, string(b))
+
+	expected, err := json.Marshal(v)
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected), string(b))
+}
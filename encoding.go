@@ -0,0 +1,97 @@
+package gojson
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is the character encoding of a document. Documents are transcoded to UTF-8 before they are
+// parsed.
+type Encoding int
+
+const (
+	// AutoEncoding detects UTF-16 documents by their byte order mark, or by the zero bytes which
+	// precede or follow their first character, as RFC 4627 describes. A UTF-8 byte order mark is
+	// skipped. Any other document is taken to be UTF-8.
+	AutoEncoding Encoding = iota
+
+	// Latin1Encoding takes documents without a byte order mark to be ISO-8859-1 (Latin-1), in which
+	// every byte is a character, rather than UTF-8.
+	Latin1Encoding
+)
+
+// WithEncoding sets the encoding of the document given to NewJSONReader.
+func WithEncoding(enc Encoding) ReaderOption {
+	return func(jr *JSONReader) {
+		jr.encoding = enc
+	}
+}
+
+// toUTF8 returns the document b transcoded from the encoding enc to UTF-8. A UTF-8 document is
+// returned as it is, without its byte order mark.
+func toUTF8(b []byte, enc Encoding) ([]byte, error) {
+	if len(b) < 2 {
+		return b, nil
+	}
+
+	switch {
+	case len(b) >= 3 && b[0] == 0xEF && b[1] == 0xBB && b[2] == 0xBF:
+		return b[3:], nil
+	case b[0] == 0xFE && b[1] == 0xFF:
+		return utf16ToUTF8(b[2:], binary.BigEndian)
+	case b[0] == 0xFF && b[1] == 0xFE:
+		return utf16ToUTF8(b[2:], binary.LittleEndian)
+	case b[0] == 0 && b[1] != 0:
+		return utf16ToUTF8(b, binary.BigEndian)
+	case b[0] != 0 && b[1] == 0:
+		return utf16ToUTF8(b, binary.LittleEndian)
+	case enc == Latin1Encoding:
+		return latin1ToUTF8(b), nil
+	}
+
+	return b, nil
+}
+
+// utf16ToUTF8 transcodes the UTF-16 document b. Unpaired surrogates become U+FFFD.
+func utf16ToUTF8(b []byte, order binary.ByteOrder) ([]byte, error) {
+	if len(b)%2 != 0 {
+		return nil, fmt.Errorf("UTF-16 document has an odd length of %d bytes", len(b))
+	}
+
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = order.Uint16(b[i*2:])
+	}
+
+	out := make([]byte, 0, len(units))
+	for _, r := range utf16.Decode(units) {
+		out = utf8.AppendRune(out, r)
+	}
+
+	return out, nil
+}
+
+// latin1ToUTF8 transcodes the ISO-8859-1 document b, in which each byte is the code point of its
+// character.
+func latin1ToUTF8(b []byte) []byte {
+	ascii := true
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+
+	if ascii {
+		return b
+	}
+
+	out := make([]byte, 0, len(b)+len(b)/4)
+	for _, c := range b {
+		out = utf8.AppendRune(out, rune(c))
+	}
+
+	return out
+}
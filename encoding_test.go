@@ -0,0 +1,85 @@
+package gojson
+
+import (
+	"encoding/binary"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// encodeUTF16 encodes s as UTF-16 in the given byte order, preceded by a byte order mark if bom is set.
+func encodeUTF16(s string, order binary.AppendByteOrder, bom bool) []byte {
+	var out []byte
+	if bom {
+		out = order.AppendUint16(out, 0xFEFF)
+	}
+	for _, u := range utf16.Encode([]rune(s)) {
+		out = order.AppendUint16(out, u)
+	}
+	return out
+}
+
+func TestEncoding(t *testing.T) {
+	const doc = "{\"name\": \"caf\u00e9 \U0001F600\", \"n\": [1, 2]}"
+
+	testCases := []struct {
+		name string
+		data []byte
+		enc  Encoding
+	}{
+		{name: "UTF-8", data: []byte(doc)},
+		{name: "UTF-8 BOM", data: append([]byte{0xEF, 0xBB, 0xBF}, doc...)},
+		{name: "UTF-16BE BOM", data: encodeUTF16(doc, binary.BigEndian, true)},
+		{name: "UTF-16LE BOM", data: encodeUTF16(doc, binary.LittleEndian, true)},
+		{name: "UTF-16BE", data: encodeUTF16(doc, binary.BigEndian, false)},
+		{name: "UTF-16LE", data: encodeUTF16(doc, binary.LittleEndian, false)},
+		{name: "UTF-16LE BOM Latin-1", data: encodeUTF16(doc, binary.LittleEndian, true), enc: Latin1Encoding},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var v struct {
+				Name string `json:"name"`
+				N    []int  `json:"n"`
+			}
+			assert.Nil(t, UnmarshalWithOptions(tc.data, &v, Options{Encoding: tc.enc}))
+			assert.Equal(t, "caf\u00e9 \U0001F600", v.Name)
+			assert.Equal(t, []int{1, 2}, v.N)
+
+			jr, err := NewJSONReader(tc.data, WithEncoding(tc.enc))
+			assert.Nil(t, err)
+			assert.Equal(t, "caf\u00e9 \U0001F600", jr.GetString("name"))
+			assert.Equal(t, []int{1, 2}, jr.GetIntSlice("n"))
+		})
+	}
+
+	t.Run("Latin-1", func(t *testing.T) {
+		data := []byte("{\"name\": \"caf\xe9 \xbd\"}")
+
+		var v struct {
+			Name string `json:"name"`
+		}
+		assert.Nil(t, UnmarshalWithOptions(data, &v, Options{Encoding: Latin1Encoding}))
+		assert.Equal(t, "caf\u00e9 \u00bd", v.Name)
+
+		jr, err := NewJSONReader(data, WithEncoding(Latin1Encoding))
+		assert.Nil(t, err)
+		assert.Equal(t, "caf\u00e9 \u00bd", jr.GetString("name"))
+
+		// Without the option, the bytes are invalid UTF-8.
+		assert.Nil(t, Unmarshal(data, &v))
+		assert.Equal(t, "caf\ufffd \ufffd", v.Name)
+	})
+
+	t.Run("Odd Length", func(t *testing.T) {
+		data := append(encodeUTF16(`{"a": 1}`, binary.LittleEndian, true), 0)
+
+		var v map[string]int
+		assert.EqualError(t, Unmarshal(data, &v), "UTF-16 document has an odd length of 17 bytes")
+
+		jr, err := NewJSONReader(data)
+		assert.True(t, jr.Empty)
+		assert.NotNil(t, err)
+	})
+}
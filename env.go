@@ -0,0 +1,140 @@
+package gojson
+
+import (
+	"bytes"
+	"os"
+)
+
+// EnvExpansion configures the expansion of environment variables within string values, selected by
+// Options.ExpandEnv. A string value may refer to a variable as ${NAME} or $NAME, where NAME is made of
+// letters, digits and underscores, and doesn't begin with a digit. $$ is a literal $. A reference
+// which is malformed, or to a variable which isn't allowed, is left as it is, and a variable which
+// isn't set expands to an empty string. Object keys are never expanded.
+//
+// Since the document is expanded before it is decoded, a reference may also supply a number or bool
+// held in a string, such as "port": "${PORT}" for an int field.
+type EnvExpansion struct {
+	// Allow lists the variables which may be expanded. When nil, every variable may be.
+	Allow []string
+
+	// Lookup returns the value of a variable, and whether it is set. os.LookupEnv is used when nil.
+	Lookup func(name string) (string, bool)
+}
+
+// expand returns the document b with the references within its string values expanded. b is returned
+// as it is if there are none.
+func (e *EnvExpansion) expand(b []byte) []byte {
+	var out []byte
+	last := 0
+
+	for i := 0; i < len(b); i++ {
+		if b[i] != '"' {
+			continue
+		}
+
+		end := skipString(b, i)
+
+		// Strings followed by a colon are keys.
+		j := skipWhitespace(b, end)
+		if j < len(b) && b[j] == ':' || bytes.IndexByte(b[i:end], '$') < 0 || end == len(b) {
+			i = end - 1
+			continue
+		}
+
+		out = e.expandString(append(out, b[last:i+1]...), b[i+1:end-1])
+		last, i = end-1, end-1
+	}
+
+	if out == nil {
+		return b
+	}
+
+	return append(out, b[last:]...)
+}
+
+// expandString appends the contents of a string, s, to dst with its references expanded. The values of
+// variables are escaped for use within the string.
+func (e *EnvExpansion) expandString(dst, s []byte) []byte {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i == len(s)-1 {
+			dst = append(dst, s[i])
+			continue
+		}
+
+		var name []byte
+		end := i + 1
+		switch c := s[i+1]; {
+		case c == '$':
+			dst = append(dst, '$')
+			i++
+			continue
+		case c == '{':
+			if n := bytes.IndexByte(s[i+2:], '}'); n >= 0 {
+				name, end = s[i+2:i+2+n], i+3+n
+			}
+		case isEnvNameStart(c):
+			for end < len(s) && isEnvNameChar(s[end]) {
+				end++
+			}
+			name = s[i+1 : end]
+		}
+
+		if !validEnvName(name) || !e.allowed(string(name)) {
+			dst = append(dst, s[i])
+			continue
+		}
+
+		dst = append(dst, EscapeString(e.lookup(string(name)))...)
+		i = end - 1
+	}
+
+	return dst
+}
+
+// allowed returns whether the variable may be expanded.
+func (e *EnvExpansion) allowed(name string) bool {
+	if e.Allow == nil {
+		return true
+	}
+
+	for _, a := range e.Allow {
+		if a == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookup returns the value of the variable, or an empty string if it isn't set.
+func (e *EnvExpansion) lookup(name string) string {
+	lookup := e.Lookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	v, _ := lookup(name)
+	return v
+}
+
+func validEnvName(name []byte) bool {
+	if len(name) == 0 || !isEnvNameStart(name[0]) {
+		return false
+	}
+
+	for _, c := range name {
+		if !isEnvNameChar(c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isEnvNameStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isEnvNameChar(c byte) bool {
+	return isEnvNameStart(c) || c >= '0' && c <= '9'
+}
@@ -0,0 +1,57 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandEnv(t *testing.T) {
+	vars := map[string]string{"HOST": "db.internal", "PORT": "5432", "QUOTE": `say "hi"`, "EMPTY": ""}
+	e := &EnvExpansion{Lookup: func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}}
+
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{`{"a": 1}`, `{"a": 1}`},
+		{`{"h": "${HOST}:$PORT"}`, `{"h": "db.internal:5432"}`},
+		{`{"q": "$QUOTE"}`, `{"q": "say \"hi\""}`},
+		{`{"m": "$MISSING|${EMPTY}|"}`, `{"m": "||"}`},
+		{`{"d": "$$HOST costs $$5"}`, `{"d": "$HOST costs $5"}`},
+		{`{"b": "$ ${ ${1X} ${A-B} $9 end$"}`, `{"b": "$ ${ ${1X} ${A-B} $9 end$"}`},
+		{`{"$HOST": "$HOST", "k" : ["$PORT"]}`, `{"$HOST": "db.internal", "k" : ["5432"]}`},
+		{`{"e": "\"$HOST\\"}`, `{"e": "\"db.internal\\"}`},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, string(e.expand([]byte(tc.in))), tc.in)
+	}
+
+	e.Allow = []string{"PORT"}
+	assert.Equal(t, `["$HOST", "5432"]`, string(e.expand([]byte(`["$HOST", "$PORT"]`))))
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v struct {
+			Host string `json:"host"`
+			Port int    `json:"port"`
+		}
+
+		err := UnmarshalWithOptions([]byte(`{"host": "$HOST", "port": "${PORT}"}`), &v, Options{ExpandEnv: &EnvExpansion{Lookup: e.Lookup}})
+		assert.Nil(t, err)
+		assert.Equal(t, "db.internal", v.Host)
+		assert.Equal(t, 5432, v.Port)
+	})
+
+	t.Run("Environment", func(t *testing.T) {
+		t.Setenv("GOJSON_TEST_HOST", "example.com")
+
+		var v map[string]string
+		err := UnmarshalWithOptions([]byte(`{"host": "$GOJSON_TEST_HOST"}`), &v, Options{ExpandEnv: &EnvExpansion{}})
+		assert.Nil(t, err)
+		assert.Equal(t, "example.com", v["host"])
+	})
+}
@@ -1,6 +1,11 @@
 package gojson
 
-import "regexp"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
 
 var (
 	ErrMissingRequiredFields = `%wrequired fields must not be empty [%s]`
@@ -8,10 +13,67 @@ var (
 	gojsonRequiredKeys = regexp.MustCompile(`(?:nonempty|required) key[s]? '([^']+)'`)
 )
 
+// RequiredFieldError is returned by Unmarshal when a key marked required is missing from the object
+// decoded into a struct.
+type RequiredFieldError struct {
+	// Field is the key path of the struct from the root, e.g. "items.3". The root is represented by "".
+	Field string
+
+	// Struct is the name of the struct type.
+	Struct string
+
+	// Keys lists the missing keys. When the value held no members at all, or wasn't an object, every
+	// required key of the struct is listed.
+	Keys []string
+
+	// empty is set when the value held no members, or wasn't an object.
+	empty bool
+}
+
+func (e *RequiredFieldError) Error() string {
+	if e.empty {
+		return fmt.Sprintf("missing required keys '%s' for struct '%s'", strings.Join(e.Keys, ","), e.Struct)
+	}
+
+	return fmt.Sprintf("required key '%s' for struct '%s' was not found", strings.Join(e.Keys, ","), e.Struct)
+}
+
+// NonEmptyFieldError is returned by Unmarshal when the value of a key marked nonempty is the zero value
+// of its JSON type, such as "" or 0.
+type NonEmptyFieldError struct {
+	// Field is the key path of the value from the root, e.g. "items.3.name".
+	Field string
+
+	// Key is the key of the value within its object.
+	Key string
+
+	// Struct is the name of the struct type.
+	Struct string
+
+	// Type is the JSON type of the value.
+	Type string
+}
+
+func (e *NonEmptyFieldError) Error() string {
+	return fmt.Sprintf("nonempty key '%s' for struct '%s' has %s zero value", e.Key, e.Struct, e.Type)
+}
+
+// ParseRequiredKeys returns the keys named by a RequiredFieldError or NonEmptyFieldError, or found in
+// the message of any other error, joined by commas. It returns "" if there are none.
 func ParseRequiredKeys(err error) string {
 	if err == nil {
 		return ""
 	}
+
+	var re *RequiredFieldError
+	var ne *NonEmptyFieldError
+	switch {
+	case errors.As(err, &re):
+		return strings.Join(re.Keys, ",")
+	case errors.As(err, &ne):
+		return ne.Key
+	}
+
 	matches := gojsonRequiredKeys.FindAllStringSubmatch(err.Error(), 1)
 	if len(matches) < 1 {
 		return ""
@@ -19,3 +81,28 @@ func ParseRequiredKeys(err error) string {
 
 	return matches[0][1]
 }
+
+// kindError is an error with its own message which matches one of the sentinel errors, such as
+// ErrKeyNotFound, with errors.Is.
+type kindError struct {
+	msg  string
+	kind error
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// keyNotFound returns the error for a key path which does not exist.
+func keyNotFound(path string) error {
+	return &kindError{msg: fmt.Sprintf("key '%s' not found", path), kind: ErrKeyNotFound}
+}
+
+// malformedf returns an error describing malformed JSON, which matches ErrMalformedJSON.
+func malformedf(format string, args ...interface{}) error {
+	return &kindError{msg: fmt.Sprintf(format, args...), kind: ErrMalformedJSON}
+}
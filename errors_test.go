@@ -0,0 +1,93 @@
+package gojson
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldErrors(t *testing.T) {
+	type Item struct {
+		ID   int    `json:"id,required"`
+		Name string `json:"name,nonempty"`
+	}
+	type Order struct {
+		Items []Item `json:"items"`
+	}
+
+	t.Run("Required", func(t *testing.T) {
+		var v Order
+		err := Unmarshal([]byte(`{"items": [{"id": 1, "name": "a"}, {"name": "b"}]}`), &v)
+		assert.Equal(t, &RequiredFieldError{Field: "items.1", Struct: "Item", Keys: []string{"id"}}, err)
+		assert.EqualError(t, err, "required key 'id' for struct 'Item' was not found")
+
+		err = Unmarshal([]byte(`{"items": [{}]}`), &v)
+		assert.EqualError(t, err, "missing required keys 'id,name' for struct 'Item'")
+
+		var re *RequiredFieldError
+		assert.True(t, errors.As(err, &re))
+		assert.Equal(t, "items.0", re.Field)
+		assert.Equal(t, []string{"id", "name"}, re.Keys)
+	})
+
+	t.Run("NonEmpty", func(t *testing.T) {
+		var v Order
+		err := Unmarshal([]byte(`{"items": [{"id": 1, "name": ""}]}`), &v)
+		assert.Equal(t, &NonEmptyFieldError{Field: "items.0.name", Key: "name", Struct: "Item", Type: JSONString}, err)
+		assert.EqualError(t, err, "nonempty key 'name' for struct 'Item' has string zero value")
+	})
+
+	t.Run("ParseRequiredKeys", func(t *testing.T) {
+		assert.Equal(t, "", ParseRequiredKeys(nil))
+		assert.Equal(t, "a,b", ParseRequiredKeys(&RequiredFieldError{Keys: []string{"a", "b"}}))
+		assert.Equal(t, "name", ParseRequiredKeys(fmt.Errorf("decoding: %w", &NonEmptyFieldError{Key: "name"})))
+		assert.Equal(t, "id", ParseRequiredKeys(errors.New("required key 'id' for struct 'User' was not found")))
+		assert.Equal(t, "", ParseRequiredKeys(errors.New("something else")))
+	})
+}
+
+func TestSentinelErrors(t *testing.T) {
+	data := []byte(`{"a": {"b": [1, 2]}, "s": "x"}`)
+
+	t.Run("ErrKeyNotFound", func(t *testing.T) {
+		_, _, err := Extract(data, "a.c")
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+		assert.EqualError(t, err, "key 'a.c' not found")
+
+		_, _, err = Extract(data, "a.b.5")
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+
+		_, err = ExtractString(data, "missing")
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+
+		_, _, err = Extract([]byte(`"x"`), "a")
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+
+		var s string
+		assert.True(t, errors.Is(DecodeFirst(data, "missing", &s), ErrKeyNotFound))
+
+		reader, err := NewJSONReader(data)
+		assert.Nil(t, err)
+		_, err = reader.GetStringE("missing")
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+		assert.True(t, errors.Is(err, ErrNoSuchKey))
+
+		doc, err := ParseDocument(data)
+		assert.Nil(t, err)
+		assert.True(t, errors.Is(doc.Delete("a.missing"), ErrKeyNotFound))
+
+		err = Arr(1).Set("2.a", 2).Err()
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+	})
+
+	t.Run("ErrMalformedJSON", func(t *testing.T) {
+		_, _, err := Extract([]byte(`{"a": "b`), "a")
+		assert.True(t, errors.Is(err, ErrMalformedJSON))
+		assert.False(t, errors.Is(err, ErrKeyNotFound))
+
+		_, _, err = Extract([]byte(`{"a": tru}`), "a")
+		assert.True(t, errors.Is(err, ErrMalformedJSON))
+	})
+}
@@ -0,0 +1,225 @@
+package gojson
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// RawValue is a raw JSON value, and its JSON type.
+type RawValue struct {
+	Value []byte
+	Type  string
+}
+
+// errAllFound stops the scan once every requested path has been found.
+var errAllFound = errors.New("all paths found")
+
+// ExtractMany performs an Extract for each of the given key paths, scanning the document only once.
+// Paths which don't exist are absent from the returned map, so that callers may check for them as
+// they would for any map key. An error is returned only if the document is malformed.
+//
+// As with Extract, a copy is made of each extracted value.
+//
+// Example:
+//
+//	values, err := gojson.ExtractMany(data, "id", "user.name", "items.0.sku")
+//	if v, ok := values["user.name"]; ok {
+//		name := string(v.Value)
+//	}
+func ExtractMany(search []byte, paths ...string) (map[string]RawValue, error) {
+	if len(search) == 0 {
+		return nil, ErrEmpty
+	}
+
+	m := manyExtractor{root: &pathNode{}, found: make(map[string]RawValue, len(paths))}
+	for _, p := range paths {
+		m.add(p)
+	}
+
+	if m.remaining == 0 {
+		return m.found, nil
+	}
+
+	_, err := m.value(search, 0, m.root)
+	if err != nil && err != errAllFound {
+		return nil, err
+	}
+
+	return m.found, nil
+}
+
+// pathNode is a node in the tree of requested key paths.
+type pathNode struct {
+	children map[string]*pathNode
+
+	// indexes holds the children addressed by array index.
+	indexes map[int]*pathNode
+
+	// paths holds the requested paths ending at this node.
+	paths []string
+}
+
+type manyExtractor struct {
+	root  *pathNode
+	found map[string]RawValue
+
+	// remaining counts the nodes which end a requested path, and have yet to be found.
+	remaining int
+}
+
+func (m *manyExtractor) add(path string) {
+	n := m.root
+	for _, k := range pathToKeys(path) {
+		c, ok := n.children[k]
+		if !ok {
+			c = &pathNode{}
+			if n.children == nil {
+				n.children = make(map[string]*pathNode)
+			}
+			n.children[k] = c
+
+			if isDecimalNumber([]byte(k)) {
+				if n.indexes == nil {
+					n.indexes = make(map[int]*pathNode)
+				}
+				i, _ := strconv.Atoi(k)
+				n.indexes[i] = c
+			}
+		}
+		n = c
+	}
+
+	if len(n.paths) == 0 {
+		m.remaining++
+	}
+	n.paths = append(n.paths, path)
+}
+
+// value scans the value at start, recording it if it ends a requested path, and descending into it if
+// any requested paths lead further. The position following the value is returned.
+func (m *manyExtractor) value(search []byte, start int, n *pathNode) (int, error) {
+	start = ltrim(search, start)
+	if start < 0 || start >= len(search) {
+		return 0, ErrMalformedJSON
+	}
+
+	var b []byte
+	var t string
+	var end int
+	var err error
+
+	switch {
+	case len(n.children) > 0 && search[start] == '{':
+		end, err = m.object(search, start, n)
+		t = JSONObject
+	case len(n.children) > 0 && search[start] == '[':
+		end, err = m.array(search, start, n)
+		t = JSONArray
+	default:
+		b, t, end, err = extractValue(search, start)
+	}
+
+	if err != nil || len(n.paths) == 0 {
+		return end, err
+	}
+
+	if b == nil {
+		b = search[start:end]
+	}
+
+	v := RawValue{Value: make([]byte, len(b)), Type: t}
+	copy(v.Value, b)
+	for _, p := range n.paths {
+		m.found[p] = v
+	}
+
+	m.remaining--
+	if m.remaining == 0 {
+		return end, errAllFound
+	}
+
+	return end, nil
+}
+
+// object scans the object at start, descending into the members named by the children of n.
+func (m *manyExtractor) object(search []byte, start int, n *pathNode) (int, error) {
+	pos := ltrim(search, start+1)
+	if pos >= 0 && pos < len(search) && search[pos] == '}' {
+		return pos + 1, nil
+	}
+
+	// As with Extract, the first of any duplicate keys is used.
+	var seen map[*pathNode]bool
+
+	for {
+		key, vStart, err := extractKey(search, pos)
+		if err != nil {
+			return 0, err
+		}
+
+		c := n.children[string(key)]
+		if c != nil && !seen[c] {
+			if seen == nil {
+				seen = make(map[*pathNode]bool)
+			}
+			seen[c] = true
+
+			pos, err = m.value(search, vStart, c)
+		} else {
+			_, _, pos, err = extractValue(search, vStart)
+		}
+		if err != nil {
+			return pos, err
+		}
+
+		var closed bool
+		if pos, closed, err = nextMember(search, pos, '}'); closed || err != nil {
+			return pos, err
+		}
+	}
+}
+
+// array scans the array at start, descending into the elements indexed by the children of n.
+func (m *manyExtractor) array(search []byte, start int, n *pathNode) (int, error) {
+	pos := ltrim(search, start+1)
+	if pos >= 0 && pos < len(search) && search[pos] == ']' {
+		return pos + 1, nil
+	}
+
+	for i := 0; ; i++ {
+		var err error
+
+		if c := n.indexes[i]; c != nil {
+			pos, err = m.value(search, pos, c)
+		} else {
+			_, _, pos, err = extractValue(search, pos)
+		}
+		if err != nil {
+			return pos, err
+		}
+
+		var closed bool
+		if pos, closed, err = nextMember(search, pos, ']'); closed || err != nil {
+			return pos, err
+		}
+	}
+}
+
+// nextMember moves past the separator following a member of a container, returning the position of
+// the next member, or the position following the container if closed is true.
+func nextMember(search []byte, pos int, close byte) (next int, closed bool, err error) {
+	pos = ltrim(search, pos)
+	if pos < 0 || pos >= len(search) {
+		return 0, false, ErrMalformedJSON
+	}
+
+	switch search[pos] {
+	case ',':
+		return pos + 1, false, nil
+	case close:
+		return pos + 1, true, nil
+	}
+
+	return 0, false, fmt.Errorf("expected ',' or '%c' at position '%d' in segment '%s'", close, pos, truncate(search, 50))
+}
@@ -0,0 +1,76 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractMany(t *testing.T) {
+	data := []byte(`{"a": "str", "b": {"c": [1, {"d": true}, null], "e": {}}, "f": [], "a\.b": 2, "dup": 1, "dup": 2, "g": [[0, 1], [2, 3]]}`)
+
+	t.Run("Values", func(t *testing.T) {
+		v, err := ExtractMany(data, "a", "b.c.1.d", "b.e", "missing", "b.c.7", "a.x", "")
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]RawValue{
+			"a":       {Value: []byte(`"str"`), Type: JSONString},
+			"b.c.1.d": {Value: []byte(`true`), Type: JSONBool},
+			"b.e":     {Value: []byte(`{}`), Type: JSONObject},
+			"":        {Value: data, Type: JSONObject},
+		}, v)
+	})
+
+	t.Run("Nested Paths", func(t *testing.T) {
+		v, err := ExtractMany(data, "b", "b.c", "b.c.0", "g.1.0")
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]RawValue{
+			"b":     {Value: []byte(`{"c": [1, {"d": true}, null], "e": {}}`), Type: JSONObject},
+			"b.c":   {Value: []byte(`[1, {"d": true}, null]`), Type: JSONArray},
+			"b.c.0": {Value: []byte(`1`), Type: JSONInt},
+			"g.1.0": {Value: []byte(`2`), Type: JSONInt},
+		}, v)
+	})
+
+	// Every path must give the same result as Extract.
+	for _, path := range []string{"", "a", "b", "b.c", "b.c.0", "b.c.1", "b.c.1.d", "b.c.2", "b.e", "f", "f.0", `a\.b`, "dup", "g.0", "g.1.1", ".a", "nope"} {
+		t.Run("Extract Parity "+path, func(t *testing.T) {
+			v, err := ExtractMany(data, path)
+			assert.Nil(t, err)
+
+			b, typ, err := Extract(data, path)
+			if err != nil {
+				assert.NotContains(t, v, path)
+				return
+			}
+			assert.Equal(t, RawValue{Value: b, Type: typ}, v[path])
+		})
+	}
+
+	t.Run("Copies", func(t *testing.T) {
+		d := []byte(`{"a": [1]}`)
+		v, err := ExtractMany(d, "a")
+		assert.Nil(t, err)
+		v["a"].Value[1] = '2'
+		assert.Equal(t, `{"a": [1]}`, string(d))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := ExtractMany(nil, "a")
+		assert.Equal(t, ErrEmpty, err)
+
+		_, err = ExtractMany([]byte(`{"a": "x" "b": 2}`), "b")
+		assert.Equal(t, "expected ',' or '}' at position '10' in segment '{\"a\": \"x\" \"b\": 2}'", err.Error())
+
+		_, err = ExtractMany([]byte(`{"a": [1, 2`), "a.2")
+		assert.Equal(t, ErrMalformedJSON, err)
+
+		// The scan stops once every path is found.
+		v, err := ExtractMany([]byte(`{"a": 1, "b": "x" "c": 3}`), "a")
+		assert.Nil(t, err)
+		assert.Equal(t, RawValue{Value: []byte(`1`), Type: JSONInt}, v["a"])
+
+		v, err = ExtractMany(data)
+		assert.Nil(t, err)
+		assert.Empty(t, v)
+	})
+}
@@ -2,11 +2,9 @@ package gojson
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
-	"unsafe"
 )
 
 // Extract a specific key from a given JSON string. Returns value, type, and error.
@@ -63,7 +61,7 @@ func Extract(search []byte, path string) ([]byte, string, error) {
 	switch t := GetJSONType(search, 0); t {
 	case JSONString, JSONFloat, JSONInt, JSONBool, JSONNull:
 		if path != "" {
-			return nil, "", fmt.Errorf("key path provided '%s' is invalid for JSON type '%s'", path, t)
+			return nil, "", keyNotFound(path)
 		}
 
 		b, t, _, err := extractValue(search, 0)
@@ -83,18 +81,30 @@ func Extract(search []byte, path string) ([]byte, string, error) {
 		return retVal, t, err
 	}
 
-	return nil, "", fmt.Errorf("requested key path '%s' doesn't exist or json is malformed", path)
+	return nil, "", malformedf("requested key path '%s' doesn't exist or json is malformed", path)
 }
 
 // ExtractReader performs an Extract on the given JSON path. The resulting value
 // is returned in the form of a JSONReader primed with the value returned from Extract.
-func ExtractReader(search []byte, path string) (*JSONReader, error) {
+//
+// Extract locates the value by scanning, without building a parse tree, so there is no parse to share
+// with the reader: only the extracted value is parsed, once, and the copy made by Extract becomes the
+// reader's own. To query several subtrees of one document, create a single reader and use Get, whose
+// readers share the parse of the whole document.
+func ExtractReader(search []byte, path string) (reader *JSONReader, err error) {
+	defer PanicRecovery(&err)
+
 	b, _, err := Extract(search, path)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewJSONReader(b)
+	reader = &JSONReader{rawData: b}
+	if err := checkLimits(nil, b, reader.limits()); err != nil {
+		return &JSONReader{Empty: true}, err
+	}
+
+	return reader.load()
 }
 
 // ExtractString performs an Extract on the given JSON path. The resulting value
@@ -114,7 +124,7 @@ func ExtractInt(search []byte, path string) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	return toInt(b, t, false), nil
+	return toInt(b, t, false, TruncateNumbers), nil
 }
 
 // ExtractFloat performs an Extract on the given JSON path. The resulting value
@@ -138,6 +148,80 @@ func ExtractBool(search []byte, path string) (bool, error) {
 	return toBool(b, t, false), nil
 }
 
+// ExtractStringSlice performs an Extract on the given JSON path. The resulting value
+// is returned in the form of a string slice, using the same rules as JSONReader.GetStringSlice:
+// each member of an array or object is converted to a string, and a scalar value becomes
+// a single element slice.
+func ExtractStringSlice(search []byte, path string) ([]string, error) {
+	out := make([]string, 0)
+	err := extractSlice(search, path, func(b []byte, t string) {
+		out = append(out, toString(b, t, false))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtractIntSlice performs an Extract on the given JSON path. The resulting value
+// is returned in the form of an int slice, using the same rules as JSONReader.GetIntSlice.
+func ExtractIntSlice(search []byte, path string) ([]int, error) {
+	out := make([]int, 0)
+	err := extractSlice(search, path, func(b []byte, t string) {
+		out = append(out, toInt(b, t, false, TruncateNumbers))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtractFloatSlice performs an Extract on the given JSON path. The resulting value
+// is returned in the form of a float64 slice, using the same rules as JSONReader.GetFloatSlice.
+func ExtractFloatSlice(search []byte, path string) ([]float64, error) {
+	out := make([]float64, 0)
+	err := extractSlice(search, path, func(b []byte, t string) {
+		out = append(out, toFloat(b, t, false))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtractBoolSlice performs an Extract on the given JSON path. The resulting value
+// is returned in the form of a bool slice, using the same rules as JSONReader.GetBoolSlice.
+func ExtractBoolSlice(search []byte, path string) ([]bool, error) {
+	out := make([]bool, 0)
+	err := extractSlice(search, path, func(b []byte, t string) {
+		out = append(out, toBool(b, t, false))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// extractSlice performs an Extract on the given JSON path, and passes each member of the
+// resulting array or object to fn. A scalar value is passed as-is, and null is passed as a
+// single null value, matching the JSONReader slice accessors.
+func extractSlice(search []byte, path string, fn func(b []byte, t string)) error {
+	b, t, err := Extract(search, path)
+	if err != nil {
+		return err
+	}
+
+	if t != JSONArray && t != JSONObject {
+		fn(b, t)
+		return nil
+	}
+
+	return EachElement(b, t, func(v []byte, vt string) error {
+		fn(v, vt)
+		return nil
+	})
+}
+
 // ExtractInterface performs an Extract on the given JSON path. The resulting value
 // is returned in the form defined below.
 // The returned type is the JSON type. These map as follows:
@@ -156,7 +240,12 @@ func ExtractInterface(search []byte, path string) (interface{}, string, error) {
 		return interface{}(nil), "", err
 	}
 
-	return toIface(b, t, false), t, nil
+	v, err := convertIface(b, t, false)
+	if err != nil {
+		return interface{}(nil), "", err
+	}
+
+	return v, t, nil
 }
 
 func extractValue(search []byte, start int) ([]byte, string, int, error) {
@@ -178,7 +267,7 @@ func extractValue(search []byte, start int) ([]byte, string, int, error) {
 	case len(search[start:]) >= 5 && IsJSONFalse(search[start:start+5]):
 		return extractConstant(search, start)
 	default:
-		return nil, "", 0, fmt.Errorf("invalid character '%s' at position '%d' in segment '%s'", string(search[start]), start, search)
+		return nil, "", 0, malformedf("invalid character '%s' at position '%d' in segment '%s'", string(search[start]), start, search)
 	}
 }
 
@@ -245,10 +334,10 @@ func extractKeyPath(search []byte, path string) ([]byte, string, int, error) {
 			for start <= len(search)-1 {
 				key, pos, err := extractKey(search, start)
 				if err != nil {
-					return nil, "", 0, fmt.Errorf("key '%s' not found", path)
+					return nil, "", 0, keyNotFound(path)
 				}
 
-				if k == *(*string)(unsafe.Pointer(&key)) {
+				if k == bytesToString(key) {
 					start = pos
 					found = true
 					break
@@ -261,6 +350,9 @@ func extractKeyPath(search []byte, path string) ([]byte, string, int, error) {
 				}
 
 				start = findTerminator(search, pos)
+				if start < 0 {
+					return nil, "", 0, keyNotFound(path)
+				}
 			}
 		case JSONArray:
 			// Non-numeric keys are invalid
@@ -280,7 +372,15 @@ func extractKeyPath(search []byte, path string) ([]byte, string, int, error) {
 					return nil, "", 0, err
 				}
 
+				// The array ends before the index is reached.
 				start = findTerminator(search, pos)
+				if start < 0 || search[start-1] == ']' {
+					return nil, "", 0, keyNotFound(path)
+				}
+			}
+
+			if start = ltrim(search, start); start >= len(search) || search[start] == ']' {
+				return nil, "", 0, keyNotFound(path)
 			}
 
 			found = true
@@ -291,7 +391,7 @@ func extractKeyPath(search []byte, path string) ([]byte, string, int, error) {
 		return extractValue(search, start)
 	}
 
-	return nil, "", 0, fmt.Errorf("key '%s' not found", path)
+	return nil, "", 0, keyNotFound(path)
 }
 
 // Extract a key from a JSONObject.
@@ -299,7 +399,7 @@ func extractKey(search []byte, start int) ([]byte, int, error) {
 	// Find the key
 	k, _, pos, err := extractString(search, start)
 	if err != nil {
-		return nil, 0, fmt.Errorf("expected object key at position %d in segment '%s'", start, truncate(search, 50))
+		return nil, 0, malformedf("expected object key at position %d in segment '%s'", start, truncate(search, 50))
 	}
 
 	// Advance past the key
@@ -313,7 +413,7 @@ func extractKey(search []byte, start int) ([]byte, int, error) {
 		case isWhitespace(search[start]):
 			start++
 		default:
-			return nil, 0, fmt.Errorf("invalid character '%s' as position %d (expecting ':' following object key)", string(search[start]), start)
+			return nil, 0, malformedf("invalid character '%s' as position %d (expecting ':' following object key)", string(search[start]), start)
 		}
 	}
 
@@ -333,30 +433,19 @@ func extractString(search []byte, start int) ([]byte, string, int, error) {
 	start = ltrim(search, start)
 
 	if start >= len(search) {
-		return nil, "", 0, fmt.Errorf("expected string not found")
+		return nil, "", 0, malformedf("expected string not found")
 	}
 
 	if search[start] != '"' {
-		return nil, "", 0, fmt.Errorf(`invalid character '%s' as position %d (expecting '"' for open string)`, string(search[start]), start)
+		return nil, "", 0, malformedf(`invalid character '%s' as position %d (expecting '"' for open string)`, string(search[start]), start)
 	}
 
-	start++
-	end := start
-	for end <= len(search)-1 {
-		b := search[end]
-		if b == '\\' {
-			end += 2
-			continue
-		}
-
-		if b == '"' {
-			return search[start-1 : end+1], JSONString, end + 1, nil
-		}
-
-		end++
+	end := stringEnd(search, start+1)
+	if end < 0 {
+		return nil, "", 0, malformedf("expected string not found")
 	}
 
-	return nil, "", 0, fmt.Errorf("expected string not found")
+	return search[start : end+1], JSONString, end + 1, nil
 }
 
 // Extract a number from a starting position.
@@ -383,7 +472,7 @@ func extractNumber(search []byte, start int) ([]byte, string, int, error) {
 		return trim(search[start:end]), extractNumberType(search[start:end]), end, nil
 	}
 
-	return nil, "", 0, fmt.Errorf("expected number not found")
+	return nil, "", 0, malformedf("expected number not found")
 }
 
 func extractNumberType(number []byte) string {
@@ -414,7 +503,7 @@ func extractConstant(search []byte, start int) ([]byte, string, int, error) {
 		}
 	}
 
-	return nil, "", 0, fmt.Errorf("expected constant not found")
+	return nil, "", 0, malformedf("expected constant not found")
 }
 
 // Extract an object from a starting position.
@@ -471,7 +560,7 @@ func extractComplexItem(search []byte, start int, open, close byte, dtype string
 		}
 	}
 
-	return nil, "", 0, fmt.Errorf("expected %s not found in segment '%s'", dtype, truncate(search, 50))
+	return nil, "", 0, malformedf("expected %s not found in segment '%s'", dtype, truncate(search, 50))
 }
 
 // Extract a key/value pair from an object.
@@ -485,13 +574,13 @@ func extractKeyValue(search []byte, start int) ([]byte, string, string, int, err
 
 	v, t, start, err := extractValue(search, start)
 	if err != nil {
-		return nil, "", "", 0, errors.New(err.Error() + " (expected object value)")
+		return nil, "", "", 0, malformedf("%s (expected object value)", err)
 	}
 
 	var termErr error
 	finalPos := findTerminator(search, start)
 	if finalPos < 0 {
-		termErr = fmt.Errorf("expected object value terminator ('}', ']' or ',') at position '%d' in segment '%s'", start, truncate(search, 50))
+		termErr = malformedf("expected object value terminator ('}', ']' or ',') at position '%d' in segment '%s'", start, truncate(search, 50))
 	}
 
 	return v, manualUnescapeString(key), t, finalPos, termErr
@@ -501,6 +590,11 @@ func extractKeyValue(search []byte, start int) ([]byte, string, string, int, err
 // Value, Key, Type, EndPosition, Error
 // Start needs to be pointing at the opening quote (") (or whitespace) of the key in order to succeed.
 func extractObjectMember(search []byte, start int) ([]byte, string, string, int, error) {
+	return extractInternedMember(search, start, nil)
+}
+
+// extractInternedMember is extractObjectMember, taking the key from the given keyInterner.
+func extractInternedMember(search []byte, start int, keys *keyInterner) ([]byte, string, string, int, error) {
 	key, start, err := extractKey(search, start)
 	if err != nil {
 		return nil, "", "", 0, err
@@ -508,10 +602,10 @@ func extractObjectMember(search []byte, start int) ([]byte, string, string, int,
 
 	v, t, start, err := extractValue(search, start)
 	if err != nil {
-		return nil, "", "", 0, errors.New(err.Error() + " (expected object value)")
+		return nil, "", "", 0, malformedf("%s (expected object value)", err)
 	}
 
-	return v, manualUnescapeString(key), t, start, err
+	return v, keys.key(key), t, start, err
 }
 
 // Extract the next available value from an array.
@@ -520,22 +614,22 @@ func extractObjectMember(search []byte, start int) ([]byte, string, string, int,
 func extractArrayValue(search []byte, start int) ([]byte, string, int, error) {
 	v, t, start, err := extractValue(search, start)
 	if err != nil {
-		return nil, "", 0, errors.New(err.Error() + " (expected array value)")
+		return nil, "", 0, malformedf("%s (expected array value)", err)
 	}
 
 	var termErr error
 	finalPos := findTerminator(search, start)
 	if finalPos < 0 {
-		termErr = fmt.Errorf("expected array value terminator ('}', ']' or ',') at position '%d' in segment '%s'", start, truncate(search, 50))
+		termErr = malformedf("expected array value terminator ('}', ']' or ',') at position '%d' in segment '%s'", start, truncate(search, 50))
 	}
 
 	return v, t, finalPos, termErr
 }
 
 // countMembers assumes a full object or slice, complete with opening and closing brackets.
-func countMembers(b []byte, t string) int {
+func countMembers(b []byte, t string) (int, error) {
 	if IsEmptyArray(b) || IsEmptyObject(b) {
-		return 0
+		return 0, nil
 	}
 
 	switch t {
@@ -544,48 +638,48 @@ func countMembers(b []byte, t string) int {
 	case JSONArray:
 		return countSliceMembers(b)
 	case JSONNull:
-		return -1
+		return -1, nil
 	default:
-		return 1
+		return 1, nil
 	}
 }
 
-func countObjectMembers(b []byte) int {
+func countObjectMembers(b []byte) (int, error) {
 	start := 1
 	length := 0
 	for start < len(b) {
 		_, _, _, pos, err := extractObjectMember(b, start)
 		if err != nil {
-			panic(err)
+			return 0, err
 		}
 
 		start = findTerminator(b, pos)
 		if pos >= len(b) || start < 0 {
-			panic(fmt.Errorf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, truncate(b, 50)))
+			return 0, malformedf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, truncate(b, 50))
 		}
 
 		length++
 	}
 
-	return length
+	return length, nil
 }
 
-func countSliceMembers(b []byte) int {
+func countSliceMembers(b []byte) (int, error) {
 	start := 1
 	length := 0
 	for start < len(b) {
 		_, _, pos, err := extractValue(b, start)
 		if err != nil {
-			panic(err)
+			return 0, err
 		}
 
 		start = findTerminator(b, pos)
 		if pos >= len(b) || start < 0 {
-			panic(fmt.Errorf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, truncate(b, 50)))
+			return 0, malformedf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, truncate(b, 50))
 		}
 
 		length++
 	}
 
-	return length
+	return length, nil
 }
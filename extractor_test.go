@@ -1,7 +1,9 @@
 package gojson
 
 import (
+	"bytes"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -111,6 +113,32 @@ func TestExtractReader(t *testing.T) {
 			assert.Equal(t, tc.expected, r.GetString(tc.key))
 		})
 	}
+
+	t.Run("Nested", func(t *testing.T) {
+		search := []byte(`{"skip": [1, {"x": 2}], "h": {"1": "ob", "3": [false, true]}}`)
+		r, err := ExtractReader(search, "h")
+		assert.Nil(t, err)
+		assert.Equal(t, JSONObject, r.Type)
+		assert.Equal(t, []string{"1", "3"}, r.Keys)
+		assert.Equal(t, []bool{false, true}, r.GetBoolSlice("3"))
+
+		s, err := ExtractReader(search, "h.1")
+		assert.Nil(t, err)
+		assert.Equal(t, JSONString, s.Type)
+		assert.False(t, s.Empty)
+
+		// The readers don't share the search space.
+		copy(search[bytes.Index(search, []byte(`"ob"`)):], `"XX"`)
+		assert.Equal(t, "ob", r.GetString("1"))
+		assert.Equal(t, "ob", s.GetString(""))
+	})
+
+	t.Run("Depth Limit", func(t *testing.T) {
+		deep := strings.Repeat("[", DefaultMaxDepth+1) + strings.Repeat("]", DefaultMaxDepth+1)
+		r, err := ExtractReader([]byte(`{"a": `+deep+`}`), "a")
+		assert.Equal(t, &DepthExceededError{MaxDepth: DefaultMaxDepth, Offset: DefaultMaxDepth}, err)
+		assert.True(t, r.Empty)
+	})
 }
 
 func TestExtractString(t *testing.T) {
@@ -264,6 +292,63 @@ func TestExtractBool(t *testing.T) {
 	}
 }
 
+func TestExtractSlices(t *testing.T) {
+	t.Run("Extract Failure", func(t *testing.T) {
+		data := []byte(`{"a": [1, 2]}`)
+		s, err := ExtractIntSlice(data, "b")
+		assert.Nil(t, s)
+		assert.Equal(t, "key 'b' not found", err.Error())
+	})
+
+	data := []byte(`{"a": "This is string", "b": 123, "c": 19.23, "d": true, "e": null, "f": ["1", 2.5, true, null, "st"], "g": {"1": "7", "2": 0}, "h": [], "i": [[1, 2], {"x": 3}]}`)
+
+	t.Run("Values", func(t *testing.T) {
+		s, err := ExtractStringSlice(data, "f")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"1", "2.5", "true", "", "st"}, s)
+
+		i, err := ExtractIntSlice(data, "f")
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2, 1, 0, 0}, i)
+
+		f, err := ExtractFloatSlice(data, "g")
+		assert.Nil(t, err)
+		assert.Equal(t, []float64{7, 0}, f)
+
+		b, err := ExtractBoolSlice(data, "f")
+		assert.Nil(t, err)
+		assert.Equal(t, []bool{true, true, true, false, false}, b)
+
+		s, err = ExtractStringSlice(data, "h")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{}, s)
+	})
+
+	// The extractors must agree with the JSONReader slice accessors.
+	reader, err := NewJSONReader(data)
+	assert.Nil(t, err)
+
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "f.0"} {
+		t.Run("Reader Key "+key, func(t *testing.T) {
+			s, err := ExtractStringSlice(data, key)
+			assert.Nil(t, err)
+			assert.Equal(t, reader.GetStringSlice(key), s)
+
+			i, err := ExtractIntSlice(data, key)
+			assert.Nil(t, err)
+			assert.Equal(t, reader.GetIntSlice(key), i)
+
+			f, err := ExtractFloatSlice(data, key)
+			assert.Nil(t, err)
+			assert.Equal(t, reader.GetFloatSlice(key), f)
+
+			b, err := ExtractBoolSlice(data, key)
+			assert.Nil(t, err)
+			assert.Equal(t, reader.GetBoolSlice(key), b)
+		})
+	}
+}
+
 func TestExtractInterface(t *testing.T) {
 	t.Run("Extract Failure", func(t *testing.T) {
 		data := []byte(`This is not json`)
@@ -342,7 +427,7 @@ func TestExtract(t *testing.T) {
 		v, dt, err := Extract([]byte(`"p_0":{"pfId":"p_0","creationDate":1423681272350,"lastUpdated":1423681272350,"default_portfolio":false,"positions":[]}`), "some_key")
 		assert.Nil(t, v)
 		assert.Equal(t, "", dt)
-		assert.Equal(t, "key path provided 'some_key' is invalid for JSON type 'string'", err.Error())
+		assert.Equal(t, "key 'some_key' not found", err.Error())
 	})
 }
 
@@ -0,0 +1,186 @@
+package gojson
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// The most common shapes of API responses are decoded without reflection. Each fast path mirrors
+// the conversions of the reflection based path exactly, and is only taken for the unnamed types
+// below, which can't implement json.Unmarshaler, when no decoder is registered for any type it
+// contains.
+var (
+	typeStrings        = reflect.TypeOf([]string(nil))
+	typeInts           = reflect.TypeOf([]int(nil))
+	typeMapIface       = reflect.TypeOf(map[string]interface{}(nil))
+	typeMapStrings     = reflect.TypeOf(map[string][]string(nil))
+	typeMapInts        = reflect.TypeOf(map[string][]int(nil))
+	typeSliceMapIface  = reflect.TypeOf([]map[string]interface{}(nil))
+	typeString         = reflect.TypeOf("")
+	typeInt            = reflect.TypeOf(0)
+	typeIface          = reflect.TypeOf((*interface{})(nil)).Elem()
+	fastPathComponents = []reflect.Type{typeString, typeInt, typeIface, typeStrings, typeInts, typeMapIface}
+)
+
+// decodeFast decodes the most common container types without reflection. ok is false if p is not
+// one of them, and must be decoded by reflection.
+func (u *unmarshaler) decodeFast(b []byte, t string, p reflect.Value, opts tagOptions) (ok bool, err error) {
+	// Interface members with a discriminator are populated with registered types.
+	if opts.Discriminator != "" || !p.CanAddr() {
+		return false, nil
+	}
+
+	switch p.Type() {
+	case typeStrings, typeInts, typeMapIface, typeMapStrings, typeMapInts, typeSliceMapIface:
+	default:
+		return false, nil
+	}
+
+	if decoders.count.Load() > 0 {
+		if _, registered := lookupDecoder(p.Type()); registered {
+			return false, nil
+		}
+		for _, c := range fastPathComponents {
+			if _, registered := lookupDecoder(c); registered {
+				return false, nil
+			}
+		}
+	}
+
+	switch v := p.Addr().Interface().(type) {
+	case *[]string:
+		return true, decodeFastSlice(u, b, t, v, u.fastString)
+	case *[]int:
+		return true, decodeFastSlice(u, b, t, v, u.fastInt)
+	case *map[string]interface{}:
+		return true, decodeFastMap(u, b, t, v, u.fastIface)
+	case *map[string][]string:
+		return true, decodeFastMap(u, b, t, v, func(b []byte, t string) (s []string, err error) {
+			return s, decodeFastSlice(u, b, t, &s, u.fastString)
+		})
+	case *map[string][]int:
+		return true, decodeFastMap(u, b, t, v, func(b []byte, t string) (s []int, err error) {
+			return s, decodeFastSlice(u, b, t, &s, u.fastInt)
+		})
+	case *[]map[string]interface{}:
+		return true, decodeFastSlice(u, b, t, v, func(b []byte, t string) (m map[string]interface{}, err error) {
+			return m, decodeFastMap(u, b, t, &m, u.fastIface)
+		})
+	}
+
+	return false, nil
+}
+
+// decodeFastSlice mirrors unmarshalSlice.
+func decodeFastSlice[T any](u *unmarshaler, b []byte, t string, p *[]T, fn func([]byte, string) (T, error)) error {
+	if t == JSONNull {
+		return nil
+	}
+
+	if u.StrictStandards && t != JSONArray {
+		return u.strictError(b, JSONArray, t)
+	}
+
+	length, err := countMembers(b, t)
+	if err != nil || length < 1 {
+		return err
+	}
+
+	slice := make([]T, 0, length)
+	err = EachElement(b, t, func(v []byte, vt string) error {
+		e, err := fn(v, vt)
+		if err != nil {
+			return fieldError(err, strconv.Itoa(len(slice)))
+		}
+		slice = append(slice, e)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	*p = slice
+	return nil
+}
+
+// decodeFastMap mirrors unmarshalMap.
+func decodeFastMap[T any](u *unmarshaler, b []byte, t string, p *map[string]T, fn func([]byte, string) (T, error)) error {
+	if t == JSONNull {
+		return nil
+	}
+
+	if u.StrictStandards && t != JSONObject {
+		return u.strictError(b, JSONObject, t)
+	}
+
+	switch {
+	case t == JSONObject && IsEmptyObject(b):
+		return nil
+	case t == JSONArray && IsEmptyArray(b):
+		return nil
+	}
+
+	m := make(map[string]T)
+
+	var err error
+	switch t {
+	case JSONObject:
+		err = eachMember(b, t, u.interner, func(k string, v []byte, vt string) error {
+			e, err := fn(v, vt)
+			if err != nil {
+				return fieldError(err, k)
+			}
+			m[k] = e
+			return nil
+		})
+	default:
+		// Array elements are keyed by their index, and a scalar is keyed by 0.
+		i := 0
+		err = EachElement(b, t, func(v []byte, vt string) error {
+			e, err := fn(v, vt)
+			if err != nil {
+				return fieldError(err, strconv.Itoa(i))
+			}
+			m[u.interner.index(i)] = e
+			i++
+			return nil
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	*p = m
+	return nil
+}
+
+// fastString mirrors setValue for a string.
+func (u *unmarshaler) fastString(b []byte, t string) (string, error) {
+	if u.StrictStandards && t != JSONString {
+		return "", u.strictError(b, JSONString, t)
+	}
+
+	return convertString(b, t, u.StrictStandards, u.InvalidUTF8)
+}
+
+// fastInt mirrors setValue for an int.
+func (u *unmarshaler) fastInt(b []byte, t string) (int, error) {
+	if u.StrictStandards && t != JSONInt {
+		return 0, u.strictError(b, JSONInt, t)
+	}
+
+	i, _, ok, err := u.intValue(b, t, strconv.IntSize, false)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, &UnmarshalTypeError{Value: string(truncate(b, 50)), Type: typeInt, Offset: u.offset(b)}
+	}
+
+	return int(i), nil
+}
+
+// fastIface mirrors unmarshalInterface without a discriminator.
+func (u *unmarshaler) fastIface(b []byte, t string) (interface{}, error) {
+	return convertInternedIface(b, t, u.ifaceOptions())
+}
@@ -0,0 +1,179 @@
+package gojson
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The named types below are decoded by reflection, for comparison with the fast paths.
+type (
+	reflectStrings       []string
+	reflectInts          []int
+	reflectMap           map[string]interface{}
+	reflectMapStrings    map[string]reflectStrings
+	reflectMapInts       map[string]reflectInts
+	reflectSliceMapIface []reflectMap
+)
+
+func TestDecodeFast(t *testing.T) {
+	inputs := []string{
+		`{"a": ["x", "y"], "b": [], "c": null, "d": "z", "e": [1, true, null, 2.5, {"f": "g"}], "a": ["dup"]}`,
+		`{"a": [1, 2, "3", 4.7, true], "b": {"c": 5}, "d": 6, "e": [null]}`,
+		`[{"a": 1, "b": [1, {"c": null}]}, {}, null, {"d": "e\"f"}, [7, 8], 9]`,
+		`["a", "bé", 1, null, false, {"c": "d"}, ["e"]]`,
+		`[[1, 2], [], null, ["3"], 4]`,
+		`{}`,
+		`[]`,
+		`null`,
+		`"scalar"`,
+		`17`,
+		`{"a": [1, 2}`,
+		`[{"a": }]`,
+		`{"a": 1`,
+		`{"a": [1, 2]`,
+		`{"a": ["x"] "b": ["y"]}`,
+		`[{"a": 1}`,
+		`[{"a": 1]`,
+	}
+
+	testCases := []struct {
+		label   string
+		fast    func() interface{}
+		reflect func() interface{}
+	}{
+		{"[]string", func() interface{} { return &[]string{"old"} }, func() interface{} { return &reflectStrings{"old"} }},
+		{"[]int", func() interface{} { return &[]int{9} }, func() interface{} { return &reflectInts{9} }},
+		{"map[string]interface{}", func() interface{} { return &map[string]interface{}{"old": 1} }, func() interface{} { return &reflectMap{"old": 1} }},
+		{"map[string][]string", func() interface{} { return &map[string][]string{} }, func() interface{} { return &reflectMapStrings{} }},
+		{"map[string][]int", func() interface{} { return new(map[string][]int) }, func() interface{} { return new(reflectMapInts) }},
+		{"[]map[string]interface{}", func() interface{} { return new([]map[string]interface{}) }, func() interface{} { return new(reflectSliceMapIface) }},
+	}
+
+	for _, tc := range testCases {
+		for _, strict := range []bool{false, true} {
+			for _, input := range inputs {
+				fast, slow := tc.fast(), tc.reflect()
+
+				opts := DefaultOptions
+				opts.StrictStandards = strict
+				fastErr := UnmarshalWithOptions([]byte(input), fast, opts)
+				slowErr := UnmarshalWithOptions([]byte(input), slow, opts)
+
+				label := tc.label + " " + input
+				if strict {
+					label = "strict " + label
+				}
+
+				if slowErr != nil {
+					if assert.NotNil(t, fastErr, label) {
+						// Errors raised by reflection carry the location of the panic.
+						assert.True(t, strings.HasPrefix(slowErr.Error(), fastErr.Error()), "%s: %s != %s", label, fastErr, slowErr)
+					}
+					continue
+				}
+
+				assert.Nil(t, fastErr, label)
+				assert.Equal(t, toUnnamed(reflect.ValueOf(slow).Elem()), toUnnamed(reflect.ValueOf(fast).Elem()), label)
+			}
+		}
+	}
+}
+
+func TestDecodeFastTruncated(t *testing.T) {
+	testCases := []struct {
+		label string
+		v     interface{}
+		JSON  string
+	}{
+		{"map[string]interface{}", &map[string]interface{}{}, `{"a": 1`},
+		{"map[string]interface{}", &map[string]interface{}{}, `{"a": "x" "b": "y"}`},
+		{"map[string][]string", &map[string][]string{}, `{"a": ["x", "y"]`},
+		{"map[string][]string", &map[string][]string{}, `{"a": ["x"] "b": ["y"]}`},
+		{"map[string][]int", &map[string][]int{}, `{"a": [1, 2]`},
+		{"map[string][]int", &map[string][]int{}, `{"a": [1] "b": [2]}`},
+		{"[]map[string]interface{}", &[]map[string]interface{}{}, `[{"a": 1`},
+		{"[]map[string]interface{}", &[]map[string]interface{}{}, `[{"a": "x" "b": "y"}]`},
+		{"[]map[string]interface{}", &[]map[string]interface{}{}, `[{"a": "x"}, {"b": ["y"]]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.label+" "+tc.JSON, func(t *testing.T) {
+			assert.NotNil(t, Unmarshal([]byte(tc.JSON), tc.v))
+		})
+	}
+}
+
+func TestDecodeFastSkipped(t *testing.T) {
+	t.Run("Registered Decoder", func(t *testing.T) {
+		RegisterDecoder(reflect.TypeOf(""), func(b []byte, t string) (interface{}, error) {
+			return strings.ToUpper(string(b)), nil
+		})
+		defer RegisterDecoder(reflect.TypeOf(""), nil)
+
+		var m map[string][]string
+		assert.Nil(t, Unmarshal([]byte(`{"a": ["x"]}`), &m))
+		assert.Equal(t, map[string][]string{"a": {`"X"`}}, m)
+	})
+
+	t.Run("Discriminator", func(t *testing.T) {
+		type Shape interface{}
+		type Circle struct {
+			R int `json:"r"`
+		}
+
+		registry := NewTypeRegistry()
+		assert.Nil(t, registry.Register((*Shape)(nil), "circle", Circle{}))
+
+		var m struct {
+			Shapes map[string]Shape `json:"shapes,discriminator=kind"`
+		}
+		opts := DefaultOptions
+		opts.Registry = registry
+		assert.Nil(t, UnmarshalWithOptions([]byte(`{"shapes": {"a": {"kind": "circle", "r": 2}}}`), &m, opts))
+		assert.Equal(t, Circle{R: 2}, m.Shapes["a"])
+	})
+}
+
+// toUnnamed converts the named reflection types into their unnamed equivalents, for comparison.
+func toUnnamed(v reflect.Value) interface{} {
+	switch v.Interface().(type) {
+	case reflectStrings:
+		return []string(v.Interface().(reflectStrings))
+	case reflectInts:
+		return []int(v.Interface().(reflectInts))
+	case reflectMap:
+		return map[string]interface{}(v.Interface().(reflectMap))
+	case reflectMapStrings:
+		if v.IsNil() {
+			return map[string][]string(nil)
+		}
+		out := map[string][]string{}
+		for k, s := range v.Interface().(reflectMapStrings) {
+			out[k] = s
+		}
+		return out
+	case reflectMapInts:
+		if v.IsNil() {
+			return map[string][]int(nil)
+		}
+		out := map[string][]int{}
+		for k, s := range v.Interface().(reflectMapInts) {
+			out[k] = s
+		}
+		return out
+	case reflectSliceMapIface:
+		if v.IsNil() {
+			return []map[string]interface{}(nil)
+		}
+		out := []map[string]interface{}{}
+		for _, m := range v.Interface().(reflectSliceMapIface) {
+			out = append(out, m)
+		}
+		return out
+	}
+
+	return v.Interface()
+}
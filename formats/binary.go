@@ -0,0 +1,177 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/btm6084/gojson"
+)
+
+// ErrTruncated is returned when a binary document ends part way through a value.
+var ErrTruncated = errors.New("unexpected end of binary document")
+
+// binaryToJSON converts a binary document holding exactly one value to JSON, using the given function
+// to decode the value.
+func binaryToJSON(b []byte, name string, decode func(r *binaryReader) (interface{}, error)) ([]byte, error) {
+	if len(b) == 0 {
+		return nil, ErrEmpty
+	}
+
+	r := binaryReader{data: b, name: name}
+
+	v, err := decode(&r)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.pos != len(r.data) {
+		return nil, r.errorf("unexpected data after value")
+	}
+
+	var buf bytes.Buffer
+	encode(&buf, v)
+	return buf.Bytes(), nil
+}
+
+// binaryReader tracks the position, and the nesting depth, within a binary document.
+type binaryReader struct {
+	data  []byte
+	pos   int
+	depth int
+	name  string
+}
+
+func (r *binaryReader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s: offset %d: %s", r.name, r.pos, fmt.Sprintf(format, args...))
+}
+
+// next returns the next n bytes.
+func (r *binaryReader) next(n uint64) ([]byte, error) {
+	if n > uint64(len(r.data)-r.pos) {
+		return nil, ErrTruncated
+	}
+
+	b := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+func (r *binaryReader) byte() (byte, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// uint reads a big endian unsigned integer of the given size in bytes.
+func (r *binaryReader) uint(size int) (uint64, error) {
+	b, err := r.next(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// float reads a big endian float of the given size in bytes. Infinity and NaN are rejected.
+func (r *binaryReader) float(size int) (interface{}, error) {
+	n, err := r.uint(size)
+	if err != nil {
+		return nil, err
+	}
+
+	var f float64
+	switch size {
+	case 2:
+		f = halfFloat(uint16(n))
+	case 4:
+		f = float64(math.Float32frombits(uint32(n)))
+	default:
+		f = math.Float64frombits(n)
+	}
+
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, r.errorf("float '%v' can not be represented in JSON", f)
+	}
+
+	if size == 8 {
+		return f, nil
+	}
+	return float32(f), nil
+}
+
+// enter increases the nesting depth on entering an array or map, enforcing gojson.DefaultMaxDepth.
+func (r *binaryReader) enter() error {
+	r.depth++
+	if r.depth > gojson.DefaultMaxDepth {
+		return &gojson.DepthExceededError{MaxDepth: gojson.DefaultMaxDepth, Offset: r.pos}
+	}
+	return nil
+}
+
+func (r *binaryReader) leave() {
+	r.depth--
+}
+
+// capacity limits the capacity allocated for a container of the declared length, as each member takes at
+// least one byte. This stops a small document from declaring an enormous length.
+func (r *binaryReader) capacity(n uint64) int {
+	if rest := uint64(len(r.data) - r.pos); n > rest {
+		return int(rest)
+	}
+	return int(n)
+}
+
+// mapKey converts a decoded map key to an object key. Strings and integers are accepted.
+func (r *binaryReader) mapKey(k interface{}) (string, error) {
+	switch k := k.(type) {
+	case string:
+		return k, nil
+	case int64, uint64:
+		return fmt.Sprint(k), nil
+	}
+	return "", r.errorf("map keys must be strings or integers, found %T", k)
+}
+
+// encodeBytes converts binary data to a string, base64 encoded as in encoding/json.
+func encodeBytes(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// halfFloat converts an IEEE 754 half precision float.
+func halfFloat(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
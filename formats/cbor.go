@@ -0,0 +1,229 @@
+package formats
+
+import (
+	"math"
+
+	"github.com/btm6084/gojson"
+)
+
+// cborBreak marks the end of an indefinite length item.
+const cborBreak = 0xff
+
+// CBORToJSON converts a CBOR document to JSON. Byte strings become base64 encoded strings, as in
+// encoding/json, and undefined becomes null. Tags are dropped in favor of their content, so that a
+// date/time string (tag 0) becomes a string and an epoch time (tag 1) becomes a number. Map keys must
+// be strings or integers.
+func CBORToJSON(b []byte) ([]byte, error) {
+	return binaryToJSON(b, "cbor", decodeCBOR)
+}
+
+// NewReaderFromCBOR creates a JSONReader from a CBOR document.
+func NewReaderFromCBOR(b []byte, opts ...gojson.ReaderOption) (*gojson.JSONReader, error) {
+	j, err := CBORToJSON(b)
+	if err != nil {
+		return &gojson.JSONReader{Empty: true}, err
+	}
+
+	return gojson.NewJSONReader(j, opts...)
+}
+
+func decodeCBOR(r *binaryReader) (interface{}, error) {
+	c, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	major, info := c>>5, c&0x1f
+
+	// Floats and simple values use the argument as the value itself, rather than as a length.
+	if major == 7 {
+		switch info {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22, 23:
+			return nil, nil
+		case 25:
+			return r.float(2)
+		case 26:
+			return r.float(4)
+		case 27:
+			return r.float(8)
+		}
+
+		r.pos--
+		return nil, r.errorf("unsupported simple value %d", info)
+	}
+
+	if info == 31 {
+		return decodeCBORIndefinite(r, major)
+	}
+
+	n, err := cborArgument(r, info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0:
+		return n, nil
+	case 1:
+		if n > math.MaxInt64 {
+			return nil, r.errorf("negative integer out of range")
+		}
+		return -1 - int64(n), nil
+	case 2:
+		b, err := r.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return encodeBytes(b), nil
+	case 3:
+		b, err := r.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case 4:
+		return decodeCBORArray(r, n, false)
+	case 5:
+		return decodeCBORMap(r, n, false)
+	}
+
+	// Tags annotate the item which follows.
+	return decodeCBOR(r)
+}
+
+// cborArgument reads the argument of an item, given the additional information from its initial byte.
+func cborArgument(r *binaryReader, info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info <= 27:
+		return r.uint(1 << (info - 24))
+	}
+
+	return 0, r.errorf("invalid additional information %d", info)
+}
+
+// decodeCBORIndefinite decodes an indefinite length string, array, or map.
+func decodeCBORIndefinite(r *binaryReader, major byte) (interface{}, error) {
+	switch major {
+	case 2, 3:
+		// Indefinite strings are a series of definite strings of the same major type.
+		var b []byte
+		for {
+			c, err := r.byte()
+			if err != nil {
+				return nil, err
+			}
+
+			if c == cborBreak {
+				break
+			}
+
+			if c>>5 != major || c&0x1f == 31 {
+				return nil, r.errorf("invalid chunk in indefinite length string")
+			}
+
+			n, err := cborArgument(r, c&0x1f)
+			if err != nil {
+				return nil, err
+			}
+
+			chunk, err := r.next(n)
+			if err != nil {
+				return nil, err
+			}
+			b = append(b, chunk...)
+		}
+
+		if major == 2 {
+			return encodeBytes(b), nil
+		}
+		return string(b), nil
+	case 4:
+		return decodeCBORArray(r, 0, true)
+	case 5:
+		return decodeCBORMap(r, 0, true)
+	}
+
+	return nil, r.errorf("invalid indefinite length for major type %d", major)
+}
+
+// atBreak consumes a break marker, if it is next.
+func atBreak(r *binaryReader) (bool, error) {
+	if r.pos >= len(r.data) {
+		return false, ErrTruncated
+	}
+
+	if r.data[r.pos] == cborBreak {
+		r.pos++
+		return true, nil
+	}
+	return false, nil
+}
+
+// decodeCBORArray decodes an array of n items, or up to a break marker if indefinite is true.
+func decodeCBORArray(r *binaryReader, n uint64, indefinite bool) (interface{}, error) {
+	if err := r.enter(); err != nil {
+		return nil, err
+	}
+	defer r.leave()
+
+	out := make([]interface{}, 0, r.capacity(n))
+	for i := uint64(0); indefinite || i < n; i++ {
+		if indefinite {
+			if done, err := atBreak(r); done || err != nil {
+				return out, err
+			}
+		}
+
+		v, err := decodeCBOR(r)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+// decodeCBORMap decodes a map of n pairs, or up to a break marker if indefinite is true.
+func decodeCBORMap(r *binaryReader, n uint64, indefinite bool) (interface{}, error) {
+	if err := r.enter(); err != nil {
+		return nil, err
+	}
+	defer r.leave()
+
+	out := newObject()
+	for i := uint64(0); indefinite || i < n; i++ {
+		if indefinite {
+			if done, err := atBreak(r); done || err != nil {
+				return out, err
+			}
+		}
+
+		k, err := decodeCBOR(r)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := r.mapKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := decodeCBOR(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if !out.set(key, v) {
+			out.values[key] = v
+		}
+	}
+
+	return out, nil
+}
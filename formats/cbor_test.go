@@ -0,0 +1,74 @@
+package formats
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btm6084/gojson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCBORToJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		doc      []byte
+		expected string
+		err      string
+	}{
+		{name: "Small Uint", doc: []byte{0x17}, expected: `23`},
+		{name: "Uints", doc: []byte{0x84, 0x18, 0x18, 0x19, 0x01, 0x00, 0x1a, 0x00, 0x01, 0x00, 0x00, 0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, expected: `[24,256,65536,18446744073709551615]`},
+		{name: "Negative", doc: []byte{0x82, 0x20, 0x38, 0x63}, expected: `[-1,-100]`},
+		{name: "Simple Values", doc: []byte{0x84, 0xf4, 0xf5, 0xf6, 0xf7}, expected: `[false,true,null,null]`},
+		{name: "Floats", doc: []byte{0x83, 0xf9, 0x3e, 0x00, 0xfa, 0x3d, 0xcc, 0xcc, 0xcd, 0xfb, 0x3f, 0xf1, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a}, expected: `[1.5,0.1,1.1]`},
+		{name: "Half Float Subnormal", doc: []byte{0xf9, 0x00, 0x01}, expected: `5.9604645e-08`},
+		{name: "Text", doc: []byte{0x62, 'h', '\n'}, expected: `"h\n"`},
+		{name: "Bytes", doc: []byte{0x43, 'a', 'b', 'c'}, expected: `"YWJj"`},
+		{name: "Map", doc: []byte{0xa2, 0x61, 'z', 0x01, 0x61, 'a', 0x81, 0xa0}, expected: `{"z":1,"a":[{}]}`},
+		{name: "Integer Keys", doc: []byte{0xa1, 0x20, 0x01}, expected: `{"-1":1}`},
+		{name: "Tagged Date", doc: []byte{0xc0, 0x74, '2', '0', '1', '3', '-', '0', '3', '-', '2', '1', 'T', '2', '0', ':', '0', '4', ':', '0', '0', 'Z'}, expected: `"2013-03-21T20:04:00Z"`},
+		{name: "Tagged Epoch", doc: []byte{0xc1, 0x1a, 0x51, 0x4b, 0x67, 0xb0}, expected: `1363896240`},
+		{name: "Indefinite Array", doc: []byte{0x9f, 0x01, 0x9f, 0xff, 0xff}, expected: `[1,[]]`},
+		{name: "Indefinite Map", doc: []byte{0xbf, 0x61, 'a', 0x01, 0xff}, expected: `{"a":1}`},
+		{name: "Indefinite Text", doc: []byte{0x7f, 0x62, 'a', 'b', 0x61, 'c', 0xff}, expected: `"abc"`},
+		{name: "Empty", doc: nil, err: "empty document provided"},
+		{name: "Truncated", doc: []byte{0x82, 0x01}, err: "unexpected end of binary document"},
+		{name: "Unterminated Indefinite", doc: []byte{0x9f, 0x01}, err: "unexpected end of binary document"},
+		{name: "Oversized Length", doc: []byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, err: "unexpected end of binary document"},
+		{name: "Trailing Data", doc: []byte{0x01, 0x02}, err: "cbor: offset 1: unexpected data after value"},
+		{name: "Invalid Chunk", doc: []byte{0x7f, 0x41, 'a', 0xff}, err: "cbor: offset 2: invalid chunk in indefinite length string"},
+		{name: "Negative Overflow", doc: []byte{0x3b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, err: "cbor: offset 9: negative integer out of range"},
+		{name: "Infinity", doc: []byte{0xf9, 0x7c, 0x00}, err: "cbor: offset 3: float '+Inf' can not be represented in JSON"},
+		{name: "Simple Value", doc: []byte{0xe0}, err: "cbor: offset 0: unsupported simple value 0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := CBORToJSON(tc.doc)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, string(actual))
+		})
+	}
+}
+
+func TestCBORMaxDepth(t *testing.T) {
+	deep := bytes.Repeat([]byte{0x81}, gojson.DefaultMaxDepth+1)
+	deep = append(deep, 0x01)
+
+	_, err := CBORToJSON(deep)
+	assert.IsType(t, &gojson.DepthExceededError{}, err)
+}
+
+func TestNewReaderFromCBOR(t *testing.T) {
+	// {"id": 7, "tags": ["a"]}
+	doc := []byte{0xa2, 0x62, 'i', 'd', 0x07, 0x64, 't', 'a', 'g', 's', 0x81, 0x61, 'a'}
+
+	reader, err := NewReaderFromCBOR(doc)
+	assert.Nil(t, err)
+	assert.Equal(t, 7, reader.GetInt("id"))
+	assert.Equal(t, []string{"a"}, reader.GetStringSlice("tags"))
+}
@@ -0,0 +1,206 @@
+// Package formats allows documents written in YAML, TOML, MessagePack, or CBOR to be read with the same
+// tools as JSON. Documents are converted to JSON, so that the JSONReader accessors and Unmarshal behave
+// identically regardless of the source format.
+package formats
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/btm6084/gojson"
+)
+
+// Format identifies the encoding of a document.
+type Format int
+
+const (
+	// Unknown is returned by Detect for empty documents.
+	Unknown Format = iota
+	JSON
+	YAML
+	TOML
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case JSON:
+		return "json"
+	case YAML:
+		return "yaml"
+	case TOML:
+		return "toml"
+	}
+
+	return "unknown"
+}
+
+// ErrEmpty is returned when no document is provided.
+var ErrEmpty = errors.New("empty document provided")
+
+// TOML is only detected by lines starting at column 0, as an indented line may be within a YAML block
+// scalar.
+var (
+	// A line holding a [table] or [[array.of.tables]] header.
+	tomlHeader = regexp.MustCompile(`(?m)^\[\[?[ \t]*[A-Za-z0-9_\-"'.]+[ \t"'\]]*\]\]?[ \t]*(#.*)?$`)
+
+	// A line holding a key = value assignment.
+	tomlAssignment = regexp.MustCompile(`(?m)^[A-Za-z0-9_\-"'.]+[ \t]*=`)
+)
+
+// Detect reports the format of a document. Valid JSON is always reported as JSON, and a document with
+// TOML table headers or key = value assignments at the start of a line is reported as TOML. Anything
+// else is assumed to be YAML.
+func Detect(b []byte) Format {
+	b = bytes.TrimSpace(b)
+
+	switch {
+	case len(b) == 0:
+		return Unknown
+	case gojson.IsJSON(b):
+		return JSON
+	case tomlHeader.Match(b) || tomlAssignment.Match(b):
+		return TOML
+	}
+
+	return YAML
+}
+
+// ToJSON converts a JSON, YAML, or TOML document to JSON. JSON documents are returned unchanged.
+// Object keys retain the order in which they appear in the document.
+func ToJSON(b []byte) ([]byte, error) {
+	switch Detect(b) {
+	case JSON:
+		return b, nil
+	case YAML:
+		return YAMLToJSON(b)
+	case TOML:
+		return TOMLToJSON(b)
+	}
+
+	return nil, ErrEmpty
+}
+
+// NewReader creates a JSONReader from a JSON, YAML, or TOML document.
+func NewReader(b []byte, opts ...gojson.ReaderOption) (*gojson.JSONReader, error) {
+	j, err := ToJSON(b)
+	if err != nil {
+		return &gojson.JSONReader{Empty: true}, err
+	}
+
+	return gojson.NewJSONReader(j, opts...)
+}
+
+// UnmarshalAny extracts a JSON, YAML, or TOML document into the given container, as gojson.Unmarshal.
+func UnmarshalAny(b []byte, v interface{}) error {
+	j, err := ToJSON(b)
+	if err != nil {
+		return err
+	}
+
+	return gojson.Unmarshal(j, v)
+}
+
+// object is an object whose keys are kept in document order.
+type object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newObject() *object {
+	return &object{values: make(map[string]interface{})}
+}
+
+// set stores a value, returning false if the key already exists.
+func (o *object) set(key string, value interface{}) bool {
+	if _, exists := o.values[key]; exists {
+		return false
+	}
+
+	o.keys = append(o.keys, key)
+	o.values[key] = value
+	return true
+}
+
+// encode writes a value produced by one of the format decoders as JSON. Supported values are
+// nil, bool, int64, uint64, float32, float64, string, []interface{}, and *object.
+func encode(buf *bytes.Buffer, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		if v {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case int64:
+		buf.WriteString(strconv.FormatInt(v, 10))
+	case uint64:
+		buf.WriteString(strconv.FormatUint(v, 10))
+	case float32:
+		encodeFloat(buf, float64(v), 32)
+	case float64:
+		encodeFloat(buf, v, 64)
+	case string:
+		encodeString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			encode(buf, e)
+		}
+		buf.WriteByte(']')
+	case *object:
+		buf.WriteByte('{')
+		for i, k := range v.keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			encodeString(buf, k)
+			buf.WriteByte(':')
+			encode(buf, v.values[k])
+		}
+		buf.WriteByte('}')
+	}
+}
+
+// encodeFloat writes a finite float with the given precision in bits. Whole numbers retain a decimal
+// point, so that they remain floats.
+func encodeFloat(buf *bytes.Buffer, f float64, bits int) {
+	s := strconv.FormatFloat(f, 'g', -1, bits)
+	buf.WriteString(s)
+
+	if !strings.ContainsAny(s, ".e") {
+		buf.WriteString(".0")
+	}
+}
+
+// encodeString writes s as a quoted JSON string.
+func encodeString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r == '\n':
+			buf.WriteString(`\n`)
+		case r == '\r':
+			buf.WriteString(`\r`)
+		case r == '\t':
+			buf.WriteString(`\t`)
+		case r < 0x20:
+			fmt.Fprintf(buf, `\u%04x`, r)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
@@ -0,0 +1,95 @@
+package formats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		name     string
+		doc      string
+		expected Format
+	}{
+		{name: "Empty", doc: "  \n", expected: Unknown},
+		{name: "JSON Object", doc: `{"a": 1}`, expected: JSON},
+		{name: "JSON Array", doc: `[1, 2]`, expected: JSON},
+		{name: "JSON Scalar", doc: `12`, expected: JSON},
+		{name: "TOML Table", doc: "[server]\nport = 80", expected: TOML},
+		{name: "TOML Array Of Tables", doc: "[[servers]]\nport = 80", expected: TOML},
+		{name: "TOML Assignment", doc: "# config\ntitle = \"example\"", expected: TOML},
+		{name: "TOML Dotted Key", doc: "server.port = 80", expected: TOML},
+		{name: "YAML Mapping", doc: "server:\n  port: 80", expected: YAML},
+		{name: "YAML Sequence", doc: "- a\n- b", expected: YAML},
+		{name: "YAML Flow", doc: "[a, b]", expected: YAML},
+		{name: "YAML Equals In Value", doc: "query: a = b", expected: YAML},
+		{name: "YAML Block Scalar Assignment", doc: "script: |\n  x = 1\n", expected: YAML},
+		{name: "YAML Block Scalar Header", doc: "script: |\n  [x]\n  y = 2\n", expected: YAML},
+		{name: "TOML Indented Keys", doc: "[server]\n  port = 80", expected: TOML},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Detect([]byte(tc.doc)))
+		})
+	}
+}
+
+type config struct {
+	Title  string `json:"title"`
+	Server struct {
+		Host    string   `json:"host"`
+		Port    int      `json:"port"`
+		Enabled bool     `json:"enabled"`
+		Ratio   float64  `json:"ratio"`
+		Tags    []string `json:"tags"`
+	} `json:"server"`
+}
+
+func TestUnmarshalAny(t *testing.T) {
+	docs := map[string]string{
+		"json": `{"title": "example", "server": {"host": "localhost", "port": 8080, "enabled": true, "ratio": 0.5, "tags": ["a", "b"]}}`,
+		"yaml": `
+title: example
+server:
+  host: localhost
+  port: 8080
+  enabled: true
+  ratio: 0.5
+  tags: [a, b]
+`,
+		"toml": `
+title = "example"
+
+[server]
+host = "localhost"
+port = 8080
+enabled = true
+ratio = 0.5
+tags = ["a", "b"]
+`,
+	}
+
+	for name, doc := range docs {
+		t.Run(name, func(t *testing.T) {
+			var c config
+			assert.Nil(t, UnmarshalAny([]byte(doc), &c))
+			assert.Equal(t, "example", c.Title)
+			assert.Equal(t, "localhost", c.Server.Host)
+			assert.Equal(t, 8080, c.Server.Port)
+			assert.True(t, c.Server.Enabled)
+			assert.Equal(t, 0.5, c.Server.Ratio)
+			assert.Equal(t, []string{"a", "b"}, c.Server.Tags)
+
+			reader, err := NewReader([]byte(doc))
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"title", "server"}, reader.Keys)
+			assert.Equal(t, 8080, reader.GetInt("server.port"))
+			assert.Equal(t, "b", reader.GetString("server.tags.1"))
+		})
+	}
+
+	var c config
+	assert.Equal(t, ErrEmpty, UnmarshalAny(nil, &c))
+}
@@ -0,0 +1,217 @@
+package formats
+
+import (
+	"time"
+
+	"github.com/btm6084/gojson"
+)
+
+// MsgpackToJSON converts a MessagePack document to JSON. Binary values become base64 encoded strings,
+// as in encoding/json, and timestamps (extension type -1) become RFC 3339 strings. Map keys must be
+// strings or integers. Other extension types are rejected.
+func MsgpackToJSON(b []byte) ([]byte, error) {
+	return binaryToJSON(b, "msgpack", decodeMsgpack)
+}
+
+// NewReaderFromMsgpack creates a JSONReader from a MessagePack document.
+func NewReaderFromMsgpack(b []byte, opts ...gojson.ReaderOption) (*gojson.JSONReader, error) {
+	j, err := MsgpackToJSON(b)
+	if err != nil {
+		return &gojson.JSONReader{Empty: true}, err
+	}
+
+	return gojson.NewJSONReader(j, opts...)
+}
+
+func decodeMsgpack(r *binaryReader) (interface{}, error) {
+	c, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c >= 0x80 && c <= 0x8f:
+		return decodeMsgpackMap(r, uint64(c&0x0f))
+	case c >= 0x90 && c <= 0x9f:
+		return decodeMsgpackArray(r, uint64(c&0x0f))
+	case c >= 0xa0 && c <= 0xbf:
+		return decodeMsgpackString(r, uint64(c&0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := r.uint(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := r.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return encodeBytes(b), nil
+	case 0xc7, 0xc8, 0xc9:
+		n, err := r.uint(1 << (c - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return decodeMsgpackExt(r, n)
+	case 0xca:
+		return r.float(4)
+	case 0xcb:
+		return r.float(8)
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := r.uint(1 << (c - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		if c == 0xcf {
+			return n, nil
+		}
+		return int64(n), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+
+		n, err := r.uint(size)
+		if err != nil {
+			return nil, err
+		}
+
+		// Sign extend from the encoded size.
+		shift := 64 - 8*size
+		return int64(n<<shift) >> shift, nil
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return decodeMsgpackExt(r, 1<<(c-0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := r.uint(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return decodeMsgpackString(r, n)
+	case 0xdc, 0xdd:
+		n, err := r.uint(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return decodeMsgpackArray(r, n)
+	case 0xde, 0xdf:
+		n, err := r.uint(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return decodeMsgpackMap(r, n)
+	}
+
+	r.pos--
+	return nil, r.errorf("invalid type byte 0x%02x", c)
+}
+
+func decodeMsgpackString(r *binaryReader, n uint64) (interface{}, error) {
+	b, err := r.next(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func decodeMsgpackArray(r *binaryReader, n uint64) (interface{}, error) {
+	if err := r.enter(); err != nil {
+		return nil, err
+	}
+	defer r.leave()
+
+	out := make([]interface{}, 0, r.capacity(n))
+	for i := uint64(0); i < n; i++ {
+		v, err := decodeMsgpack(r)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+func decodeMsgpackMap(r *binaryReader, n uint64) (interface{}, error) {
+	if err := r.enter(); err != nil {
+		return nil, err
+	}
+	defer r.leave()
+
+	out := newObject()
+	for i := uint64(0); i < n; i++ {
+		k, err := decodeMsgpack(r)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := r.mapKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := decodeMsgpack(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if !out.set(key, v) {
+			out.values[key] = v
+		}
+	}
+
+	return out, nil
+}
+
+// decodeMsgpackExt decodes an extension value with n bytes of data. Only timestamps are supported.
+func decodeMsgpackExt(r *binaryReader, n uint64) (interface{}, error) {
+	t, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := r.next(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if int8(t) != -1 {
+		return nil, r.errorf("unsupported extension type %d", int8(t))
+	}
+
+	var sec int64
+	var nsec uint64
+
+	switch n {
+	case 4:
+		sec = int64(beUint(b))
+	case 8:
+		v := beUint(b)
+		nsec, sec = v>>34, int64(v&(1<<34-1))
+	case 12:
+		nsec, sec = beUint(b[:4]), int64(beUint(b[4:]))
+	default:
+		return nil, r.errorf("invalid timestamp length %d", n)
+	}
+
+	return time.Unix(sec, int64(nsec)).UTC().Format(time.RFC3339Nano), nil
+}
+
+// beUint reads a big endian unsigned integer of up to 8 bytes.
+func beUint(b []byte) uint64 {
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n
+}
@@ -0,0 +1,66 @@
+package formats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsgpackToJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		doc      []byte
+		expected string
+		err      string
+	}{
+		{name: "Fixint", doc: []byte{0x07}, expected: `7`},
+		{name: "Negative Fixint", doc: []byte{0xff}, expected: `-1`},
+		{name: "Nil And Bools", doc: []byte{0x93, 0xc0, 0xc2, 0xc3}, expected: `[null,false,true]`},
+		{name: "Unsigned", doc: []byte{0x94, 0xcc, 0xff, 0xcd, 0x01, 0x00, 0xce, 0x00, 0x01, 0x00, 0x00, 0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, expected: `[255,256,65536,18446744073709551615]`},
+		{name: "Signed", doc: []byte{0x94, 0xd0, 0x80, 0xd1, 0xff, 0x00, 0xd2, 0xff, 0xff, 0xff, 0xfe, 0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0}, expected: `[-128,-256,-2,-9223372036854775808]`},
+		{name: "Floats", doc: []byte{0x92, 0xca, 0x3d, 0xcc, 0xcc, 0xcd, 0xcb, 0x40, 0x09, 0x21, 0xfb, 0x54, 0x44, 0x2d, 0x18}, expected: `[0.1,3.141592653589793]`},
+		{name: "Whole Float", doc: []byte{0xcb, 0x40, 0, 0, 0, 0, 0, 0, 0}, expected: `2.0`},
+		{name: "Strings", doc: []byte{0x92, 0xa3, 'a', '"', 'c', 0xd9, 0x02, 'h', 'i'}, expected: `["a\"c","hi"]`},
+		{name: "Binary", doc: []byte{0xc4, 0x03, 'a', 'b', 'c'}, expected: `"YWJj"`},
+		{name: "Map", doc: []byte{0x82, 0xa1, 'z', 0x01, 0xa1, 'a', 0x91, 0x80}, expected: `{"z":1,"a":[{}]}`},
+		{name: "Integer Keys", doc: []byte{0x81, 0x05, 0xa1, 'x'}, expected: `{"5":"x"}`},
+		{name: "Map16", doc: []byte{0xde, 0x00, 0x01, 0xa1, 'k', 0xc0}, expected: `{"k":null}`},
+		{name: "Timestamp32", doc: []byte{0xd6, 0xff, 0x5c, 0x3d, 0xf3, 0x80}, expected: `"2019-01-15T14:51:44Z"`},
+		{name: "Timestamp64", doc: []byte{0xd7, 0xff, 0x00, 0x00, 0x00, 0x04, 0x5c, 0x3d, 0xf3, 0x80}, expected: `"2019-01-15T14:51:44.000000001Z"`},
+		{name: "Empty", doc: []byte{}, err: "empty document provided"},
+		{name: "Truncated", doc: []byte{0x92, 0x01}, err: "unexpected end of binary document"},
+		{name: "Oversized Length", doc: []byte{0xdd, 0xff, 0xff, 0xff, 0xff}, err: "unexpected end of binary document"},
+		{name: "Trailing Data", doc: []byte{0x01, 0x02}, err: "msgpack: offset 1: unexpected data after value"},
+		{name: "Invalid Type", doc: []byte{0xc1}, err: "msgpack: offset 0: invalid type byte 0xc1"},
+		{name: "Unsupported Extension", doc: []byte{0xd4, 0x05, 0x00}, err: "msgpack: offset 3: unsupported extension type 5"},
+		{name: "Map Key", doc: []byte{0x81, 0x90, 0x01}, err: "msgpack: offset 2: map keys must be strings or integers, found []interface {}"},
+		{name: "NaN", doc: []byte{0xcb, 0x7f, 0xf8, 0, 0, 0, 0, 0, 0x01}, err: "msgpack: offset 9: float 'NaN' can not be represented in JSON"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := MsgpackToJSON(tc.doc)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, string(actual))
+		})
+	}
+}
+
+func TestNewReaderFromMsgpack(t *testing.T) {
+	// {"items": [{"id": 1, "name": "a"}]}
+	doc := []byte{0x81, 0xa5, 'i', 't', 'e', 'm', 's', 0x91, 0x82, 0xa2, 'i', 'd', 0x01, 0xa4, 'n', 'a', 'm', 'e', 0xa1, 'a'}
+
+	reader, err := NewReaderFromMsgpack(doc)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, reader.GetInt("items.0.id"))
+	assert.Equal(t, "a", reader.GetString("items.0.name"))
+
+	reader, err = NewReaderFromMsgpack([]byte{0x91})
+	assert.NotNil(t, err)
+	assert.True(t, reader.Empty)
+}
@@ -0,0 +1,635 @@
+package formats
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TOMLToJSON converts a TOML document to JSON. Dates and times become strings, as JSON has no
+// equivalent type. Infinity and NaN can not be represented in JSON, and are rejected.
+func TOMLToJSON(b []byte) ([]byte, error) {
+	p := tomlParser{data: b, line: 1, root: newObject(), defined: make(map[*object]bool)}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encode(&buf, p.root)
+	return buf.Bytes(), nil
+}
+
+var tomlDatetime = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}(:\d{2}(\.\d+)?)?([Zz]|[+-]\d{2}:\d{2})?)?|\d{2}:\d{2}(:\d{2}(\.\d+)?)?)$`)
+
+type tomlParser struct {
+	data []byte
+	pos  int
+	line int
+
+	root    *object
+	current *object
+
+	// defined holds the tables which have been declared with a [table] header.
+	defined map[*object]bool
+
+	// arrays records the keys, per table, declared as [[array.of.tables]]. Unlike arrays assigned as
+	// values, these may be extended and descended into by later headers.
+	arrays map[*object]map[string]bool
+}
+
+func (p *tomlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("toml: line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *tomlParser) peek() byte {
+	if p.pos < len(p.data) {
+		return p.data[p.pos]
+	}
+	return 0
+}
+
+func (p *tomlParser) hasPrefix(s string) bool {
+	return bytes.HasPrefix(p.data[p.pos:], []byte(s))
+}
+
+// skipSpace skips spaces and tabs.
+func (p *tomlParser) skipSpace() {
+	for p.pos < len(p.data) && (p.data[p.pos] == ' ' || p.data[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipComment skips a comment, if present, up to the end of the line.
+func (p *tomlParser) skipComment() {
+	if p.peek() != '#' {
+		return
+	}
+	for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+		p.pos++
+	}
+}
+
+// skipBlank skips whitespace, newlines, and comments.
+func (p *tomlParser) skipBlank() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r':
+			p.pos++
+		case '\n':
+			p.pos++
+			p.line++
+		case '#':
+			p.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+// endLine consumes the remainder of a line, which may only hold a comment.
+func (p *tomlParser) endLine() error {
+	p.skipSpace()
+	p.skipComment()
+
+	switch {
+	case p.pos >= len(p.data):
+		return nil
+	case p.hasPrefix("\r\n"):
+		p.pos += 2
+	case p.peek() == '\n':
+		p.pos++
+	default:
+		return p.errorf("unexpected '%c' after value", p.peek())
+	}
+
+	p.line++
+	return nil
+}
+
+func (p *tomlParser) parse() error {
+	p.current = p.root
+
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.data) {
+			return nil
+		}
+
+		var err error
+		switch {
+		case p.hasPrefix("[["):
+			err = p.parseArrayTable()
+		case p.peek() == '[':
+			err = p.parseTable()
+		default:
+			err = p.parseKeyValue(p.current)
+		}
+
+		if err == nil {
+			err = p.endLine()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// parseTable parses a [table] header, and makes it the current table.
+func (p *tomlParser) parseTable() error {
+	p.pos++
+
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	if p.peek() != ']' {
+		return p.errorf("expected ']' to close table header")
+	}
+	p.pos++
+
+	t, err := p.descend(p.root, keys)
+	if err != nil {
+		return err
+	}
+
+	if p.defined[t] {
+		return p.errorf("table '%s' is defined more than once", strings.Join(keys, "."))
+	}
+	p.defined[t] = true
+
+	p.current = t
+	return nil
+}
+
+// parseArrayTable parses an [[array.of.tables]] header, appends a new table to the array, and makes it
+// the current table.
+func (p *tomlParser) parseArrayTable() error {
+	p.pos += 2
+
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	if !p.hasPrefix("]]") {
+		return p.errorf("expected ']]' to close array of tables header")
+	}
+	p.pos += 2
+
+	parent, err := p.descend(p.root, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	last := keys[len(keys)-1]
+	t := newObject()
+
+	switch existing := parent.values[last].(type) {
+	case nil:
+		if p.arrays == nil {
+			p.arrays = make(map[*object]map[string]bool)
+		}
+		if p.arrays[parent] == nil {
+			p.arrays[parent] = make(map[string]bool)
+		}
+		p.arrays[parent][last] = true
+		parent.set(last, []interface{}{t})
+	case []interface{}:
+		if !p.arrays[parent][last] {
+			return p.errorf("key '%s' is not an array of tables", strings.Join(keys, "."))
+		}
+		parent.values[last] = append(existing, t)
+	default:
+		return p.errorf("key '%s' is not an array of tables", strings.Join(keys, "."))
+	}
+
+	p.current = t
+	return nil
+}
+
+// descend follows a dotted key from the given table, creating tables as needed. When a key refers to an
+// array of tables, the last table in the array is followed.
+func (p *tomlParser) descend(t *object, keys []string) (*object, error) {
+	for i, k := range keys {
+		switch v := t.values[k].(type) {
+		case nil:
+			child := newObject()
+			t.set(k, child)
+			t = child
+		case *object:
+			t = v
+		case []interface{}:
+			last, ok := v[len(v)-1].(*object)
+			if !ok || !p.arrays[t][k] {
+				return nil, p.errorf("key '%s' is not a table", strings.Join(keys[:i+1], "."))
+			}
+			t = last
+		default:
+			return nil, p.errorf("key '%s' is not a table", strings.Join(keys[:i+1], "."))
+		}
+	}
+
+	return t, nil
+}
+
+// parseKeyValue parses a key = value pair into the given table.
+func (p *tomlParser) parseKeyValue(t *object) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	if p.peek() != '=' {
+		return p.errorf("expected '=' after key '%s'", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipSpace()
+
+	v, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	parent, err := p.descend(t, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	if !parent.set(keys[len(keys)-1], v) {
+		return p.errorf("key '%s' is defined more than once", strings.Join(keys, "."))
+	}
+
+	return nil
+}
+
+// parseKey parses a dotted key, made up of bare and quoted keys, and any surrounding whitespace.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+
+	for {
+		p.skipSpace()
+
+		var k string
+		var err error
+
+		switch p.peek() {
+		case '"':
+			k, err = p.parseBasicString()
+		case '\'':
+			k, err = p.parseLiteralString()
+		default:
+			start := p.pos
+			for p.pos < len(p.data) && isBareKeyChar(p.data[p.pos]) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("expected key, found '%c'", p.peek())
+			}
+			k = string(p.data[start:p.pos])
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+
+		p.skipSpace()
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_' || c == '-'
+}
+
+// parseValue parses any TOML value.
+func (p *tomlParser) parseValue() (interface{}, error) {
+	switch {
+	case p.hasPrefix(`"""`):
+		return p.parseMultilineBasicString()
+	case p.hasPrefix(`'''`):
+		return p.parseMultilineLiteralString()
+	case p.peek() == '"':
+		return p.parseBasicString()
+	case p.peek() == '\'':
+		return p.parseLiteralString()
+	case p.peek() == '[':
+		return p.parseArray()
+	case p.peek() == '{':
+		return p.parseInlineTable()
+	case p.hasPrefix("true"):
+		p.pos += 4
+		return true, nil
+	case p.hasPrefix("false"):
+		p.pos += 5
+		return false, nil
+	}
+
+	return p.parseScalar()
+}
+
+// parseScalar parses numbers, dates, and times.
+func (p *tomlParser) parseScalar() (interface{}, error) {
+	start := p.pos
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+
+		// Allow a space between the date and time of a datetime.
+		if c == ' ' && p.pos-start == 10 && p.pos+1 < len(p.data) && p.data[p.pos+1] >= '0' && p.data[p.pos+1] <= '9' {
+			p.pos++
+			continue
+		}
+
+		if c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ',' || c == ']' || c == '}' || c == '#' {
+			break
+		}
+		p.pos++
+	}
+
+	s := string(p.data[start:p.pos])
+
+	switch {
+	case s == "":
+		return nil, p.errorf("expected value")
+	case tomlDatetime.MatchString(s):
+		return s, nil
+	case strings.HasSuffix(s, "inf") || strings.HasSuffix(s, "nan"):
+		return nil, p.errorf("float '%s' can not be represented in JSON", s)
+	}
+
+	n := strings.ReplaceAll(s, "_", "")
+
+	if len(n) > 2 && n[0] == '0' {
+		base := 0
+		switch n[1] {
+		case 'x':
+			base = 16
+		case 'o':
+			base = 8
+		case 'b':
+			base = 2
+		}
+
+		if base > 0 {
+			i, err := strconv.ParseInt(n[2:], base, 64)
+			if err != nil {
+				return nil, p.errorf("invalid integer '%s'", s)
+			}
+			return i, nil
+		}
+	}
+
+	if strings.ContainsAny(n, ".eE") {
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return nil, p.errorf("invalid float '%s'", s)
+		}
+		return f, nil
+	}
+
+	i, err := strconv.ParseInt(n, 10, 64)
+	if err != nil {
+		return nil, p.errorf("invalid value '%s'", s)
+	}
+	return i, nil
+}
+
+// parseArray parses an array, which may span multiple lines and hold comments.
+func (p *tomlParser) parseArray() (interface{}, error) {
+	p.pos++
+	out := []interface{}{}
+
+	for {
+		p.skipBlank()
+		if p.peek() == ']' {
+			p.pos++
+			return out, nil
+		}
+
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+
+		p.skipBlank()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+// parseInlineTable parses an inline table, e.g. { x = 1, y = 2 }.
+func (p *tomlParser) parseInlineTable() (interface{}, error) {
+	p.pos++
+	out := newObject()
+
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		return out, nil
+	}
+
+	for {
+		if err := p.parseKeyValue(out); err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return out, nil
+		default:
+			return nil, p.errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+// parseLiteralString parses a single-quoted string, which has no escapes.
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++
+	end := bytes.IndexAny(p.data[p.pos:], "'\n")
+	if end < 0 || p.data[p.pos+end] != '\'' {
+		return "", p.errorf("unterminated string")
+	}
+
+	s := string(p.data[p.pos : p.pos+end])
+	p.pos += end + 1
+	return s, nil
+}
+
+// parseMultilineLiteralString parses a string delimited by triple single quotes, which has no escapes.
+func (p *tomlParser) parseMultilineLiteralString() (string, error) {
+	p.pos += 3
+	end := bytes.Index(p.data[p.pos:], []byte(`'''`))
+	if end < 0 {
+		return "", p.errorf("unterminated string")
+	}
+
+	// Up to two quotes may directly precede the closing delimiter.
+	for i := 0; i < 2 && p.pos+end+3 < len(p.data) && p.data[p.pos+end+3] == '\''; i++ {
+		end++
+	}
+
+	s := string(p.data[p.pos : p.pos+end])
+	p.pos += end + 3
+	p.line += strings.Count(s, "\n")
+
+	return trimFirstNewline(s), nil
+}
+
+// parseBasicString parses a double-quoted string.
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++
+
+	var sb strings.Builder
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+
+		switch c {
+		case '"':
+			p.pos++
+			return sb.String(), nil
+		case '\n':
+			return "", p.errorf("unterminated string")
+		case '\\':
+			if err := p.parseEscape(&sb); err != nil {
+				return "", err
+			}
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+// parseMultilineBasicString parses a string delimited by """.
+func (p *tomlParser) parseMultilineBasicString() (string, error) {
+	p.pos += 3
+
+	// A newline directly following the opening delimiter is trimmed.
+	if p.hasPrefix("\r\n") {
+		p.pos += 2
+		p.line++
+	} else if p.peek() == '\n' {
+		p.pos++
+		p.line++
+	}
+
+	var sb strings.Builder
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+
+		switch {
+		case p.hasPrefix(`"""`) && !p.hasPrefix(`""""`):
+			p.pos += 3
+			return sb.String(), nil
+		case c == '\\' && p.isLineEndingBackslash():
+			// A line ending backslash trims all whitespace up to the next non-whitespace character.
+			p.pos++
+			for p.pos < len(p.data) && strings.IndexByte(" \t\r\n", p.data[p.pos]) >= 0 {
+				if p.data[p.pos] == '\n' {
+					p.line++
+				}
+				p.pos++
+			}
+		case c == '\\':
+			if err := p.parseEscape(&sb); err != nil {
+				return "", err
+			}
+		default:
+			if c == '\n' {
+				p.line++
+			}
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+// isLineEndingBackslash reports whether the backslash at the current position is followed only by
+// whitespace up to the end of the line.
+func (p *tomlParser) isLineEndingBackslash() bool {
+	for i := p.pos + 1; i < len(p.data); i++ {
+		switch p.data[i] {
+		case ' ', '\t', '\r':
+		case '\n':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// parseEscape parses the escape sequence at the current position.
+func (p *tomlParser) parseEscape(sb *strings.Builder) error {
+	if p.pos+1 >= len(p.data) {
+		return p.errorf("unterminated string")
+	}
+
+	c := p.data[p.pos+1]
+	p.pos += 2
+
+	switch c {
+	case 'b':
+		sb.WriteByte('\b')
+	case 't':
+		sb.WriteByte('\t')
+	case 'n':
+		sb.WriteByte('\n')
+	case 'f':
+		sb.WriteByte('\f')
+	case 'r':
+		sb.WriteByte('\r')
+	case 'e':
+		sb.WriteByte(0x1b)
+	case '"', '\\':
+		sb.WriteByte(c)
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.data) {
+			return p.errorf("invalid unicode escape")
+		}
+
+		r, err := strconv.ParseUint(string(p.data[p.pos:p.pos+size]), 16, 32)
+		if err != nil || !utf8.ValidRune(rune(r)) {
+			return p.errorf("invalid unicode escape '\\%c%s'", c, p.data[p.pos:p.pos+size])
+		}
+
+		sb.WriteRune(rune(r))
+		p.pos += size
+	default:
+		return p.errorf("invalid escape '\\%c'", c)
+	}
+
+	return nil
+}
+
+func trimFirstNewline(s string) string {
+	if strings.HasPrefix(s, "\r\n") {
+		return s[2:]
+	}
+	return strings.TrimPrefix(s, "\n")
+}
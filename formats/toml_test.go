@@ -0,0 +1,49 @@
+package formats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOMLToJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		doc      string
+		expected string
+		err      string
+	}{
+		{name: "Empty", doc: "# nothing here\n", expected: `{}`},
+		{name: "Scalars", doc: "s = \"a\\tb\\u00e9\"\nl = 'C:\\path'\ni = -1_000\nh = 0xff\no = 0o17\nb = 0b101\nf = 6.5e-1\nw = 3.0\nt = true\nu = false", expected: `{"s":"a\tbé","l":"C:\\path","i":-1000,"h":255,"o":15,"b":5,"f":0.65,"w":3.0,"t":true,"u":false}`},
+		{name: "Dates", doc: "a = 1979-05-27T07:32:00Z\nb = 1979-05-27 07:32:00.999-07:00\nc = 1979-05-27\nd = 07:32:00", expected: `{"a":"1979-05-27T07:32:00Z","b":"1979-05-27 07:32:00.999-07:00","c":"1979-05-27","d":"07:32:00"}`},
+		{name: "Comments", doc: "a = 1 # one\n# full line\nb = \"#2\" # two", expected: `{"a":1,"b":"#2"}`},
+		{name: "Quoted And Dotted Keys", doc: "\"a.b\" = 1\nc . 'd' = 2\nc.e = 3", expected: `{"a.b":1,"c":{"d":2,"e":3}}`},
+		{name: "Tables", doc: "top = 1\n[a.b]\nx = 1\n[a]\ny = 2\n[c]", expected: `{"top":1,"a":{"b":{"x":1},"y":2},"c":{}}`},
+		{name: "Array Of Tables", doc: "[[p]]\nn = 1\n[p.q]\nz = 0\n[[p]]\nn = 2\n[[p.r]]\nm = 3", expected: `{"p":[{"n":1,"q":{"z":0}},{"n":2,"r":[{"m":3}]}]}`},
+		{name: "Arrays", doc: "a = [\n  1, # first\n  2,\n]\nb = [[1, 2], [\"x\"], []]", expected: `{"a":[1,2],"b":[[1,2],["x"],[]]}`},
+		{name: "Inline Table", doc: "p = { x = 1, y.z = \"2\", e = {} }", expected: `{"p":{"x":1,"y":{"z":"2"},"e":{}}}`},
+		{name: "Multiline Basic", doc: "s = \"\"\"\nline \"one\"\nline \\\n    two\"\"\"", expected: `{"s":"line \"one\"\nline two"}`},
+		{name: "Multiline Literal", doc: "s = '''\nraw \\n text'''", expected: `{"s":"raw \\n text"}`},
+		{name: "Duplicate Key", doc: "a = 1\na = 2", err: "toml: line 2: key 'a' is defined more than once"},
+		{name: "Duplicate Table", doc: "[a]\n[a]", err: "toml: line 2: table 'a' is defined more than once"},
+		{name: "Not A Table", doc: "a = 1\n[a.b]", err: "toml: line 2: key 'a' is not a table"},
+		{name: "Static Array", doc: "a = []\n[[a]]", err: "toml: line 2: key 'a' is not an array of tables"},
+		{name: "Infinity", doc: "f = -inf", err: "toml: line 1: float '-inf' can not be represented in JSON"},
+		{name: "Trailing Garbage", doc: "a = 1 2", err: "toml: line 1: unexpected '2' after value"},
+		{name: "Unterminated", doc: "a = \"abc\nb = 1", err: "toml: line 1: unterminated string"},
+		{name: "Invalid Escape", doc: `a = "\q"`, err: `toml: line 1: invalid escape '\q'`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := TOMLToJSON([]byte(tc.doc))
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, string(actual))
+		})
+	}
+}
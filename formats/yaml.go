@@ -0,0 +1,202 @@
+package formats
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxAliasExpansion is the number of nodes which aliases may expand to in a document, so that a small
+// document of nested aliases (a "billion laughs") can't expand to an enormous one.
+const maxAliasExpansion = 100000
+
+// YAMLToJSON converts a YAML document to JSON. Only the first document of a multi-document stream is
+// converted. Timestamps and binary values become strings, and merge keys (<<) are expanded. An alias
+// within its own anchor, or aliases which expand to more than 100,000 nodes, are an error.
+func YAMLToJSON(b []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if len(doc.Content) > 0 {
+		var err error
+		y := yamlDecoder{expanding: map[*yaml.Node]bool{}}
+		if v, err = y.fromYAML(doc.Content[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	encode(&buf, v)
+	return buf.Bytes(), nil
+}
+
+// yamlDecoder converts YAML nodes, expanding aliases.
+type yamlDecoder struct {
+	// expanding holds the anchored nodes being converted, to find aliases within their own anchor.
+	expanding map[*yaml.Node]bool
+
+	// aliased counts the nodes converted through aliases, and aliases is the depth of aliases being
+	// expanded.
+	aliased int
+	aliases int
+}
+
+// alias returns the node an alias refers to, checking that it isn't within its own anchor and that the
+// expansion limit hasn't been reached.
+func (y *yamlDecoder) alias(n *yaml.Node) (*yaml.Node, error) {
+	if y.expanding[n.Alias] {
+		return nil, fmt.Errorf("line %d: alias '*%s' refers to itself", n.Line, n.Value)
+	}
+
+	return n.Alias, nil
+}
+
+// fromYAML converts a YAML node to a value supported by encode.
+func (y *yamlDecoder) fromYAML(n *yaml.Node) (interface{}, error) {
+	if y.aliases > 0 {
+		if y.aliased++; y.aliased > maxAliasExpansion {
+			return nil, fmt.Errorf("line %d: aliases expand to more than %d nodes", n.Line, maxAliasExpansion)
+		}
+	}
+
+	if n.Anchor != "" {
+		y.expanding[n] = true
+		defer delete(y.expanding, n)
+	}
+
+	switch n.Kind {
+	case yaml.AliasNode:
+		target, err := y.alias(n)
+		if err != nil {
+			return nil, err
+		}
+
+		y.aliases++
+		defer func() { y.aliases-- }()
+		return y.fromYAML(target)
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return y.fromYAML(n.Content[0])
+	case yaml.SequenceNode:
+		out := make([]interface{}, 0, len(n.Content))
+		for _, c := range n.Content {
+			v, err := y.fromYAML(c)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	case yaml.MappingNode:
+		out := newObject()
+		if err := y.mergeYAML(out, n, false); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+
+	switch n.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var v bool
+		err := n.Decode(&v)
+		return v, err
+	case "!!int":
+		var v int64
+		if err := n.Decode(&v); err != nil {
+			return nil, fmt.Errorf("line %d: integer '%s' out of range", n.Line, n.Value)
+		}
+		return v, nil
+	case "!!float":
+		var v float64
+		if err := n.Decode(&v); err != nil {
+			return nil, err
+		}
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, fmt.Errorf("line %d: float '%s' can not be represented in JSON", n.Line, n.Value)
+		}
+		return v, nil
+	}
+
+	return n.Value, nil
+}
+
+// mergeYAML adds the members of a mapping node to out. Explicit keys replace merged keys, but merged keys
+// never replace explicit keys. merged indicates that n is the target of a merge key.
+func (y *yamlDecoder) mergeYAML(out *object, n *yaml.Node, merged bool) error {
+	for n.Kind == yaml.AliasNode {
+		target, err := y.alias(n)
+		if err != nil {
+			return err
+		}
+
+		y.aliases++
+		defer func() { y.aliases-- }()
+		n = target
+	}
+
+	if merged && n.Anchor != "" {
+		y.expanding[n] = true
+		defer delete(y.expanding, n)
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+	case yaml.SequenceNode:
+		if !merged {
+			return fmt.Errorf("line %d: merge value must be a mapping or a sequence of mappings", n.Line)
+		}
+
+		// Earlier mappings in the sequence take precedence.
+		for _, c := range n.Content {
+			if err := y.mergeYAML(out, c, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("line %d: merge value must be a mapping or a sequence of mappings", n.Line)
+	}
+
+	var merges []*yaml.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+
+		if k.Kind == yaml.ScalarNode && k.ShortTag() == "!!merge" {
+			merges = append(merges, v)
+			continue
+		}
+
+		if k.Kind != yaml.ScalarNode {
+			return fmt.Errorf("line %d: mapping keys must be scalars", k.Line)
+		}
+
+		value, err := y.fromYAML(v)
+		if err != nil {
+			return err
+		}
+
+		if _, exists := out.values[k.Value]; !exists {
+			out.keys = append(out.keys, k.Value)
+		} else if merged {
+			continue
+		}
+		out.values[k.Value] = value
+	}
+
+	for _, m := range merges {
+		if err := y.mergeYAML(out, m, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
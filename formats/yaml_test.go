@@ -0,0 +1,62 @@
+package formats
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		doc      string
+		expected string
+		err      string
+	}{
+		{name: "Empty", doc: ``, expected: `null`},
+		{name: "Scalars", doc: "s: text\ni: 0x1F\nf: 2.0\nb: yes\nt: true\nn: ~", expected: `{"s":"text","i":31,"f":2.0,"b":"yes","t":true,"n":null}`},
+		{name: "Key Order", doc: "z: 1\na: 2\nm: 3", expected: `{"z":1,"a":2,"m":3}`},
+		{name: "Quoted Numbers", doc: `v: "123"`, expected: `{"v":"123"}`},
+		{name: "Nested", doc: "a:\n  - b: 1\n    c: [1, 2]\n  - {d: e}", expected: `{"a":[{"b":1,"c":[1,2]},{"d":"e"}]}`},
+		{name: "Timestamp", doc: "at: 2001-12-14t21:59:43.10-05:00", expected: `{"at":"2001-12-14t21:59:43.10-05:00"}`},
+		{name: "Multiline", doc: "text: |\n  line \"1\"\n  line 2\n", expected: `{"text":"line \"1\"\nline 2\n"}`},
+		{name: "Alias", doc: "a: &x [1, 2]\nb: *x", expected: `{"a":[1,2],"b":[1,2]}`},
+		{name: "Merge", doc: "base: &b {x: 1, y: 2}\nover:\n  <<: *b\n  y: 3", expected: `{"base":{"x":1,"y":2},"over":{"y":3,"x":1}}`},
+		{name: "Merge Sequence", doc: "a: &a {x: 1}\nb: &b {x: 2, y: 2}\nc:\n  <<: [*a, *b]", expected: `{"a":{"x":1},"b":{"x":2,"y":2},"c":{"x":1,"y":2}}`},
+		{name: "Recursive Alias", doc: "a: &a [*a]", err: "line 1: alias '*a' refers to itself"},
+		{name: "Recursive Nested Alias", doc: "a: &a\n  b: [1, {c: *a}]", err: "line 2: alias '*a' refers to itself"},
+		{name: "Recursive Merge", doc: "a: &a\n  x: 1\n  <<: *a", err: "line 3: alias '*a' refers to itself"},
+		{name: "Infinity", doc: "f: .inf", err: "line 1: float '.inf' can not be represented in JSON"},
+		{name: "Complex Key", doc: "? [a]\n: 1", err: "line 1: mapping keys must be scalars"},
+		{name: "Invalid", doc: "a: [1, 2", err: "yaml: line 1: did not find expected ',' or ']'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := YAMLToJSON([]byte(tc.doc))
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, string(actual))
+		})
+	}
+}
+
+func TestYAMLAliasExpansion(t *testing.T) {
+	doc := "a: &a [x, x, x, x, x, x, x, x, x]\n"
+	for i, prev := 'b', 'a'; i <= 'i'; i, prev = i+1, i {
+		doc += fmt.Sprintf("%c: &%c [*%c, *%c, *%c, *%c, *%c, *%c, *%c, *%c, *%c]\n", i, i, prev, prev, prev, prev, prev, prev, prev, prev, prev)
+	}
+
+	_, err := YAMLToJSON([]byte(doc))
+	assert.EqualError(t, err, fmt.Sprintf("line 1: aliases expand to more than %d nodes", maxAliasExpansion))
+
+	// Aliases within the limit are expanded.
+	actual, err := YAMLToJSON([]byte("a: &a [1, 2]\nb: &b [*a, *a]\nc: [*b, *b]"))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":[1,2],"b":[[1,2],[1,2]],"c":[[[1,2],[1,2]],[[1,2],[1,2]]]}`, string(actual))
+}
@@ -0,0 +1,114 @@
+package gojson
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"unicode/utf8"
+)
+
+// fuzzSeeds are added to the corpus in testdata/fuzz, which holds the inputs found by past fuzzing runs.
+var fuzzSeeds = [][]byte{
+	tdEmptyString, tdString, tdInt, tdBool, tdNull, tdFloat, tdStringSlice, tdBoolSlice, tdIntSlice,
+	tdFloatSlice, tdObject, tdObjects, tdComplex, readerTestData,
+	[]byte(`{"a":{"b":[1,{"c":"é\n\"x\""}]},"d":-1.5e+10}`),
+	[]byte(`["😀", 1E2, -0, 0.5e-3, {}]`),
+	[]byte(`{"a":1,"a":2}`),
+	[]byte(`{"a" 1}`),
+	[]byte(`[1,]`),
+	[]byte(`"\x"`),
+	[]byte(` [ [ [ ] ] ] `),
+	[]byte(`99999999999999999999999`),
+}
+
+func FuzzNewJSONReader(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if _, err := ParseStrictRFC8259(data); utf8.Valid(data) {
+			var depth *DepthExceededError
+			if valid := json.Valid(data); (err == nil) != valid && !errors.As(err, &depth) {
+				t.Fatalf("ParseStrictRFC8259(%q) returned %v, encoding/json validity is %v", data, err, valid)
+			}
+		}
+
+		jr, err := NewJSONReader(data)
+		if err != nil || jr.Empty {
+			return
+		}
+
+		jr.ToInterface()
+		jr.RawBytes("")
+		for _, k := range jr.Keys {
+			jr.GetString(k)
+			jr.GetInt(k)
+			jr.GetFloat(k)
+			jr.GetBool(k)
+			jr.GetInterfaceSlice(k)
+			jr.GetMapStringInterface(k)
+			jr.Get(k).ToInterface()
+		}
+	})
+}
+
+func FuzzExtract(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed, "")
+		f.Add(seed, "0")
+	}
+	f.Add(readerTestData, "objects.1.k")
+	f.Add(readerTestData, "complex.5.empty_string")
+	f.Add(readerTestData, "string_slice.*")
+	f.Add(readerTestData, "objects..e")
+
+	f.Fuzz(func(t *testing.T, data []byte, path string) {
+		Extract(data, path)
+		ExtractInterface(data, path)
+		ExtractString(data, path)
+		ExtractInt(data, path)
+		ExtractReader(data, path)
+
+		if it, err := NewRawIterator(data); err == nil {
+			for err == nil {
+				_, _, _, _, err = it.Next()
+			}
+		}
+	})
+}
+
+func FuzzUnmarshal(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	type fuzzStruct struct {
+		String  string                 `json:"string"`
+		Int     int                    `json:"int"`
+		Uint8   uint8                  `json:"uint8"`
+		Float   float64                `json:"float"`
+		Bool    *bool                  `json:"bool"`
+		Slice   []string               `json:"string_slice"`
+		Array   [2]int                 `json:"int_slice"`
+		Object  map[string]string      `json:"object"`
+		Objects []map[string]string    `json:"objects"`
+		Complex []interface{}          `json:"complex"`
+		Any     map[string]interface{} `json:"any"`
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var s fuzzStruct
+		Unmarshal(data, &s)
+		UnmarshalStrict(data, &s)
+
+		var m map[string][]int
+		Unmarshal(data, &m)
+
+		var v interface{}
+		err := Unmarshal(data, &v)
+		if err != nil && utf8.Valid(data) && json.Valid(data) && checkDepth(data, DefaultMaxDepth) == nil {
+			t.Fatalf("Unmarshal(%q) returned %v for valid JSON", data, err)
+		}
+	})
+}
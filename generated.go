@@ -0,0 +1,185 @@
+package gojson
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// GoJSONUnmarshaler is the interface implemented by types that can decode themselves without
+// reflection, such as those with methods generated by gojson-gen. Unmarshal prefers it to
+// json.Unmarshaler, and calls it for the root container and for any struct nested within it.
+type GoJSONUnmarshaler interface {
+	UnmarshalGoJSON([]byte) error
+}
+
+// The functions below are the runtime support for code generated by gojson-gen. They apply the same
+// conversions as Unmarshal does without StrictStandards, and are exported for use by generated code.
+
+// EachMember calls fn with the key, value and type of each member of the JSON object b of type t, in
+// document order. Values of any other type are skipped, as Unmarshal does for a struct container. A
+// member not followed by ',' or '}' is an error, as it is when Unmarshal fills a map.
+func EachMember(b []byte, t string, fn func(key string, value []byte, dtype string) error) error {
+	return eachMember(b, t, nil, fn)
+}
+
+// eachMember is EachMember, taking the keys from the given keyInterner.
+func eachMember(b []byte, t string, keys *keyInterner, fn func(key string, value []byte, dtype string) error) error {
+	switch t {
+	case JSONInvalid:
+		return ErrMalformedJSON
+	case JSONObject:
+	default:
+		return nil
+	}
+
+	if IsEmptyObject(b) {
+		return nil
+	}
+
+	for start := 1; start < len(b); {
+		v, k, vt, pos, err := extractInternedMember(b, start, keys)
+		if err != nil {
+			return err
+		}
+
+		start = findTerminator(b, pos)
+		if pos >= len(b) || start < 0 {
+			return fmt.Errorf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, truncate(b, 50))
+		}
+
+		if err := fn(k, v, vt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EachElement calls fn with the value and type of each element of the JSON value b of type t, as
+// Unmarshal does when filling a slice: the elements of an array, the member values of an object, or
+// a lone scalar. Null has no elements.
+func EachElement(b []byte, t string, fn func(value []byte, dtype string) error) error {
+	switch t {
+	case JSONInvalid:
+		return ErrMalformedJSON
+	case JSONNull:
+		return nil
+	case JSONObject, JSONArray:
+	default:
+		return fn(b, t)
+	}
+
+	if n, err := countMembers(b, t); err != nil || n < 1 {
+		return err
+	}
+
+	for start := 1; start < len(b); {
+		var v []byte
+		var vt string
+		var pos int
+		var err error
+
+		if t == JSONObject {
+			v, _, vt, pos, err = extractObjectMember(b, start)
+		} else {
+			v, vt, pos, err = extractValue(b, start)
+		}
+		if err != nil {
+			return err
+		}
+
+		start = findTerminator(b, pos)
+		if pos >= len(b) || start < 0 {
+			return fmt.Errorf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, truncate(b, 50))
+		}
+
+		if err := fn(v, vt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecodeString converts a JSON value to a string.
+func DecodeString(b []byte, t string) string {
+	return toString(b, t, false)
+}
+
+// DecodeInt converts a JSON value to an integer.
+func DecodeInt(b []byte, t string) int64 {
+	return int64(toInt(b, t, false, TruncateNumbers))
+}
+
+// DecodeUint converts a JSON value to an unsigned integer.
+func DecodeUint(b []byte, t string) uint64 {
+	return uint64(toInt(b, t, false, TruncateNumbers))
+}
+
+// Integer is the set of integer types decoded by DecodeIntE.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// DecodeIntE converts a JSON value to an integer of type T, as Unmarshal does for a field of that type.
+// A value outside the range of T is reported as an UnmarshalTypeError, whose Field is left for the
+// caller to set with FieldError. Its Offset is -1, as the document holding the value isn't known.
+func DecodeIntE[T Integer](b []byte, t string) (T, error) {
+	var u unmarshaler
+	typ := reflect.TypeOf(T(0))
+	unsigned := typ.Kind() >= reflect.Uint && typ.Kind() <= reflect.Uint64
+
+	i, ui, ok, err := u.intValue(b, t, typ.Bits(), unsigned)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, &UnmarshalTypeError{Value: string(truncate(b, 50)), Type: typ, Offset: -1}
+	}
+
+	if unsigned {
+		return T(ui), nil
+	}
+	return T(i), nil
+}
+
+// DecodeFloat32E converts a JSON value to a float32, reporting a value outside its range as an
+// UnmarshalTypeError, as DecodeIntE does.
+func DecodeFloat32E(b []byte, t string) (float32, error) {
+	f := toFloat(b, t, false)
+	if a := math.Abs(f); a > math.MaxFloat32 && !math.IsInf(a, 1) {
+		return 0, &UnmarshalTypeError{Value: string(truncate(b, 50)), Type: reflect.TypeOf(float32(0)), Offset: -1}
+	}
+
+	return float32(f), nil
+}
+
+// FieldError prefixes the key path of err, if it is one of the errors returned by Unmarshal, with key,
+// as Unmarshal does for the member of a container holding the value. A nil err is returned as is.
+func FieldError(err error, key string) error {
+	return fieldError(err, key)
+}
+
+// DecodeFloat converts a JSON value to a float.
+func DecodeFloat(b []byte, t string) float64 {
+	return toFloat(b, t, false)
+}
+
+// DecodeBool converts a JSON value to a bool.
+func DecodeBool(b []byte, t string) bool {
+	return toBool(b, t, false)
+}
+
+// DecodeInterface converts a JSON value to an interface{}, as Unmarshal does for an interface{} field:
+// objects become map[string]interface{}, and arrays []interface{}. A malformed object or array is
+// reported as an error.
+func DecodeInterface(b []byte, t string) (interface{}, error) {
+	return convertIface(b, t, false)
+}
+
+// IsZeroJSON returns true if the JSON value is the zero value of its type, as checked by the
+// nonempty tag option.
+func IsZeroJSON(b []byte, t string) bool {
+	return isZeroValue(b, t)
+}
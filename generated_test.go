@@ -0,0 +1,132 @@
+package gojson
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testGenerated struct {
+	Calls int
+	Data  string
+}
+
+func (g *testGenerated) UnmarshalGoJSON(b []byte) error {
+	g.Calls++
+	g.Data = string(b)
+	return nil
+}
+
+func TestGoJSONUnmarshaler(t *testing.T) {
+	var g testGenerated
+	assert.Nil(t, Unmarshal([]byte(`{"a": 1}`), &g))
+	assert.Equal(t, 1, g.Calls)
+	assert.Equal(t, `{"a": 1}`, g.Data)
+
+	var nested struct {
+		G testGenerated `json:"g"`
+	}
+	assert.Nil(t, Unmarshal([]byte(`{"g": [1, 2]}`), &nested))
+	assert.Equal(t, 1, nested.G.Calls)
+	assert.Equal(t, `[1, 2]`, nested.G.Data)
+}
+
+func TestEachMember(t *testing.T) {
+	var keys, values, types []string
+	collect := func(k string, v []byte, vt string) error {
+		keys, values, types = append(keys, k), append(values, string(v)), append(types, vt)
+		return nil
+	}
+
+	b := []byte(`{"a": 1, "b\"c": "x", "d": [1, {"e": null}]}`)
+	assert.Nil(t, EachMember(b, GetJSONType(b, 0), collect))
+	assert.Equal(t, []string{"a", `b"c`, "d"}, keys)
+	assert.Equal(t, []string{"1", `"x"`, `[1, {"e": null}]`}, values)
+	assert.Equal(t, []string{JSONInt, JSONString, JSONArray}, types)
+
+	keys = nil
+	assert.Nil(t, EachMember([]byte(`{}`), JSONObject, collect))
+	assert.Nil(t, EachMember([]byte(`[1]`), JSONArray, collect))
+	assert.Nil(t, keys)
+
+	assert.Equal(t, ErrMalformedJSON, EachMember([]byte(`{`), JSONInvalid, collect))
+
+	for input, pos := range map[string]int{`{"a": 1`: 7, `{"a": [1, 2]`: 12, `{"a": "x" "b": 2}`: 9} {
+		err := EachMember([]byte(input), JSONObject, collect)
+		assert.EqualError(t, err, fmt.Sprintf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, input), input)
+	}
+
+	stop := errors.New("stop")
+	assert.Equal(t, stop, EachMember(b, JSONObject, func(string, []byte, string) error { return stop }))
+}
+
+func TestEachElement(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		JSON     string
+		Expected []string
+	}{
+		{"Array", `[1, "a", [2]]`, []string{"1", `"a"`, "[2]"}},
+		{"Object", `{"a": 1, "b": true}`, []string{"1", "true"}},
+		{"Scalar", `"solo"`, []string{`"solo"`}},
+		{"Null", `null`, nil},
+		{"EmptyArray", `[]`, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var values []string
+			err := EachElement([]byte(tc.JSON), GetJSONType([]byte(tc.JSON), 0), func(v []byte, vt string) error {
+				values = append(values, string(v))
+				return nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.Expected, values)
+		})
+	}
+}
+
+func TestDecodeInterface(t *testing.T) {
+	v, err := DecodeInterface([]byte(`{"a": [1, 2.5, "b", true, null], "c": {}}`), JSONObject)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": []interface{}{1, 2.5, "b", true, nil}, "c": map[string]interface{}{}}, v)
+
+	v, err = DecodeInterface([]byte(`"solo"`), JSONString)
+	assert.Nil(t, err)
+	assert.Equal(t, "solo", v)
+
+	// Malformed containers are reported, rather than panicking.
+	_, err = DecodeInterface([]byte(`{"a": 1,}`), JSONObject)
+	assert.EqualError(t, err, "expected object key at position 8 in segment '{\"a\": 1,}'")
+
+	_, err = DecodeInterface([]byte(`[1 2]`), JSONArray)
+	assert.NotNil(t, err)
+}
+
+func TestDecodeRangeChecked(t *testing.T) {
+	i, err := DecodeIntE[int8]([]byte(`"-128"`), JSONString)
+	assert.Nil(t, err)
+	assert.Equal(t, int8(-128), i)
+
+	u, err := DecodeIntE[uint16]([]byte(`6.5e4`), JSONFloat)
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(65000), u)
+
+	_, err = DecodeIntE[uint8]([]byte(`300`), JSONInt)
+	assert.Equal(t, &UnmarshalTypeError{Value: "300", Type: reflect.TypeOf(uint8(0)), Offset: -1}, err)
+
+	_, err = DecodeIntE[uint]([]byte(`-1`), JSONInt)
+	assert.EqualError(t, FieldError(err, "n"), "key 'n' with value '-1' is out of range for type 'uint'")
+
+	f, err := DecodeFloat32E([]byte(`1.5`), JSONFloat)
+	assert.Nil(t, err)
+	assert.Equal(t, float32(1.5), f)
+
+	_, err = DecodeFloat32E([]byte(`-1e39`), JSONFloat)
+	assert.EqualError(t, FieldError(FieldError(err, "f"), "items"), "key 'items.f' with value '-1e39' is out of range for type 'float32'")
+
+	assert.Nil(t, FieldError(nil, "n"))
+}
@@ -0,0 +1,154 @@
+package gojson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// DefaultMaxBodySize is the largest body read by DecodeRequest and DecodeResponse unless configured
+// otherwise with WithMaxBodySize.
+var DefaultMaxBodySize int64 = 1 << 20
+
+// ErrContentType is returned by DecodeRequest and DecodeResponse when the body is not declared as JSON.
+// Servers will usually respond with 415 Unsupported Media Type.
+var ErrContentType = errors.New("content type is not json")
+
+// HTTPOption configures DecodeRequest and DecodeResponse.
+type HTTPOption func(*httpOptions)
+
+type httpOptions struct {
+	options     Options
+	maxBodySize int64
+	anyType     bool
+}
+
+// WithOptions decodes the body with the given Options, rather than DefaultOptions.
+func WithOptions(opts Options) HTTPOption {
+	return func(o *httpOptions) {
+		o.options = opts
+	}
+}
+
+// WithMaxBodySize sets the largest body which will be read. A negative value disables the limit.
+func WithMaxBodySize(n int64) HTTPOption {
+	return func(o *httpOptions) {
+		o.maxBodySize = n
+	}
+}
+
+// WithAnyContentType accepts a body regardless of its Content-Type header.
+func WithAnyContentType() HTTPOption {
+	return func(o *httpOptions) {
+		o.anyType = true
+	}
+}
+
+// DecodeRequest decodes the JSON body of r into v, and closes the body. The Content-Type header must be
+// application/json, or another JSON media type such as application/problem+json, or ErrContentType is
+// returned. A body larger than DefaultMaxBodySize is rejected with a *LimitError without being read in
+// full. The decode is abandoned if the request's context is canceled. A body with a charset of
+// ISO-8859-1 is transcoded to UTF-8, as is UTF-16.
+//
+// Example:
+//
+//	var payload CreateUser
+//	if err := gojson.DecodeRequest(r, &payload); err != nil {
+//		http.Error(w, err.Error(), http.StatusBadRequest)
+//		return
+//	}
+func DecodeRequest(r *http.Request, v interface{}, opts ...HTTPOption) error {
+	return decodeBody(r.Context(), r.Header, r.Body, v, opts)
+}
+
+// DecodeResponse decodes the JSON body of resp into v, and closes the body, applying the same checks as
+// DecodeRequest. The status code is not checked.
+func DecodeResponse(resp *http.Response, v interface{}, opts ...HTTPOption) error {
+	ctx := context.Background()
+	if resp.Request != nil {
+		ctx = resp.Request.Context()
+	}
+
+	return decodeBody(ctx, resp.Header, resp.Body, v, opts)
+}
+
+// WriteJSON encodes v with AppendMarshal, and writes it to w with the given status code and a JSON
+// Content-Type. Nothing is written if v can not be encoded.
+func WriteJSON(w http.ResponseWriter, code int, v interface{}) error {
+	b, err := AppendMarshal(nil, v)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	_, err = w.Write(b)
+	return err
+}
+
+func decodeBody(ctx context.Context, header http.Header, body io.ReadCloser, v interface{}, opts []HTTPOption) error {
+	if body == nil || body == http.NoBody {
+		return ErrEmpty
+	}
+	defer body.Close()
+
+	o := httpOptions{options: DefaultOptions, maxBodySize: DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if !o.anyType && !isJSONContentType(header.Get("Content-Type")) {
+		return fmt.Errorf("%w: '%s'", ErrContentType, header.Get("Content-Type"))
+	}
+
+	reader := io.Reader(body)
+	if o.maxBodySize >= 0 {
+		reader = io.LimitReader(body, o.maxBodySize+1)
+	}
+
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	if o.maxBodySize >= 0 && int64(len(b)) > o.maxBodySize {
+		return &LimitError{Limit: "body size", Max: int(o.maxBodySize), Offset: int(o.maxBodySize)}
+	}
+
+	u := unmarshaler{Options: o.options, ctx: ctx}
+	if u.Encoding == AutoEncoding && isLatin1ContentType(header.Get("Content-Type")) {
+		u.Encoding = Latin1Encoding
+	}
+
+	return u.unmarshal(b, v)
+}
+
+// isJSONContentType reports whether the media type is application/json, or a structured syntax type
+// such as application/problem+json.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || (strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json"))
+}
+
+// isLatin1ContentType reports whether the charset of the content type is ISO-8859-1.
+func isLatin1ContentType(contentType string) bool {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(params["charset"]) {
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1", "l1":
+		return true
+	}
+
+	return false
+}
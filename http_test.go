@@ -0,0 +1,155 @@
+package gojson
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestDecodeRequest(t *testing.T) {
+	type payload struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	newRequest := func(contentType, body string) (*http.Request, *closeRecorder) {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		rc := &closeRecorder{Reader: strings.NewReader(body)}
+		r.Body = rc
+		if contentType != "" {
+			r.Header.Set("Content-Type", contentType)
+		}
+		return r, rc
+	}
+
+	t.Run("Decodes", func(t *testing.T) {
+		r, body := newRequest("application/json; charset=utf-8", `{"name": "a", "count": 2}`)
+
+		var v payload
+		assert.Nil(t, DecodeRequest(r, &v))
+		assert.Equal(t, payload{"a", 2}, v)
+		assert.True(t, body.closed)
+	})
+
+	t.Run("Structured Syntax Suffix", func(t *testing.T) {
+		r, _ := newRequest("application/merge-patch+json", `{"name": "b"}`)
+
+		var v payload
+		assert.Nil(t, DecodeRequest(r, &v))
+		assert.Equal(t, "b", v.Name)
+	})
+
+	t.Run("Content Type", func(t *testing.T) {
+		for _, contentType := range []string{"", "text/plain", "application/jsonx", "not a media type;"} {
+			r, body := newRequest(contentType, `{}`)
+
+			var v payload
+			err := DecodeRequest(r, &v)
+			assert.True(t, errors.Is(err, ErrContentType), contentType)
+			assert.True(t, body.closed)
+		}
+
+		r, _ := newRequest("text/plain", `{"count": 3}`)
+		var v payload
+		assert.Nil(t, DecodeRequest(r, &v, WithAnyContentType()))
+		assert.Equal(t, 3, v.Count)
+	})
+
+	t.Run("Body Size", func(t *testing.T) {
+		r, body := newRequest("application/json", `{"name": "abcdefghij"}`)
+
+		var v payload
+		err := DecodeRequest(r, &v, WithMaxBodySize(10))
+		assert.Equal(t, &LimitError{Limit: "body size", Max: 10, Offset: 10}, err)
+		assert.True(t, body.closed)
+
+		r, _ = newRequest("application/json", `{"name": "abcdefghij"}`)
+		assert.Nil(t, DecodeRequest(r, &v, WithMaxBodySize(-1)))
+		assert.Equal(t, "abcdefghij", v.Name)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		r, _ := newRequest("application/json", `{"count": "2"}`)
+
+		var v payload
+		err := DecodeRequest(r, &v, WithOptions(Options{StrictStandards: true}))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Latin-1", func(t *testing.T) {
+		r, _ := newRequest("application/json; charset=ISO-8859-1", "{\"name\": \"caf\xe9\"}")
+
+		var v payload
+		assert.Nil(t, DecodeRequest(r, &v))
+		assert.Equal(t, "café", v.Name)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		r, _ := newRequest("application/json", `{"count": 2}`)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var v payload
+		assert.Equal(t, context.Canceled, DecodeRequest(r.WithContext(ctx), &v))
+	})
+
+	t.Run("No Body", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		var v payload
+		assert.Equal(t, ErrEmpty, DecodeRequest(r, &v))
+	})
+}
+
+func TestDecodeResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/text" {
+			w.Write([]byte(`{"a": 1}`))
+			return
+		}
+
+		WriteJSON(w, http.StatusCreated, map[string]int{"a": 1})
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+
+	var v map[string]int
+	assert.Nil(t, DecodeResponse(resp, &v))
+	assert.Equal(t, map[string]int{"a": 1}, v)
+
+	resp, err = http.Get(server.URL + "/text")
+	assert.Nil(t, err)
+	assert.True(t, errors.Is(DecodeResponse(resp, &v), ErrContentType))
+}
+
+func TestWriteJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	assert.Nil(t, WriteJSON(w, http.StatusOK, []string{"a", "b"}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `["a","b"]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	assert.NotNil(t, WriteJSON(w, http.StatusOK, func() {}))
+	assert.Equal(t, "", w.Header().Get("Content-Type"))
+	assert.Equal(t, 0, w.Body.Len())
+}
@@ -0,0 +1,70 @@
+package gojson
+
+import "strconv"
+
+// maxInternedKeys bounds the number of distinct keys, and of array indexes, held by a keyInterner, so
+// that documents keyed by unique IDs don't grow it without limit. Keys beyond it are not interned.
+const maxInternedKeys = 1 << 12
+
+// keyInterner returns one string for each distinct object key or array index, so that the maps decoded
+// from arrays of similar objects share their keys rather than each holding a copy. A nil keyInterner
+// doesn't intern, and returns a new string for every key.
+type keyInterner struct {
+	keys    map[string]string
+	indexes []string
+
+	// views holds the raw keys returned by view.
+	views map[string]string
+}
+
+func newKeyInterner() *keyInterner {
+	return &keyInterner{keys: make(map[string]string), views: make(map[string]string)}
+}
+
+// key returns the unescaped form of the raw key, as manualUnescapeString does.
+func (in *keyInterner) key(raw []byte) string {
+	if in == nil {
+		return manualUnescapeString(raw)
+	}
+
+	// Looking up string(raw) doesn't allocate.
+	if s, ok := in.keys[string(raw)]; ok {
+		return s
+	}
+
+	s := manualUnescapeString(raw)
+	if len(in.keys) < maxInternedKeys {
+		in.keys[string(raw)] = s
+	}
+	return s
+}
+
+// view returns the raw key b as a string, as bytesToString does. Under the purego build tag, where the
+// string is a copy rather than a view of the document, the copies are interned.
+func (in *keyInterner) view(b []byte) string {
+	if zeroCopyViews || in == nil {
+		return bytesToString(b)
+	}
+
+	if s, ok := in.views[string(b)]; ok {
+		return s
+	}
+
+	s := string(b)
+	if len(in.views) < maxInternedKeys {
+		in.views[s] = s
+	}
+	return s
+}
+
+// index returns the key of an array index.
+func (in *keyInterner) index(i int) string {
+	if in == nil || i >= maxInternedKeys {
+		return strconv.Itoa(i)
+	}
+
+	for len(in.indexes) <= i {
+		in.indexes = append(in.indexes, strconv.Itoa(len(in.indexes)))
+	}
+	return in.indexes[i]
+}
@@ -0,0 +1,95 @@
+package gojson
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stringData returns a pointer to the bytes of s, to tell whether two strings share their data.
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func TestInternKeys(t *testing.T) {
+	raw := []byte(`[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}, {"id": 3, "name": "c"}]`)
+
+	t.Run("Maps", func(t *testing.T) {
+		var plain, interned []map[string]interface{}
+		assert.Nil(t, Unmarshal(raw, &plain))
+		assert.Nil(t, UnmarshalWithOptions(raw, &interned, Options{InternKeys: true}))
+		assert.Equal(t, plain, interned)
+
+		keys := make(map[string]uintptr)
+		for _, m := range interned {
+			for k := range m {
+				if p, ok := keys[k]; ok {
+					assert.Equal(t, p, stringData(k), k)
+				}
+				keys[k] = stringData(k)
+			}
+		}
+		assert.Len(t, keys, 2)
+	})
+
+	t.Run("Interface", func(t *testing.T) {
+		var plain, interned interface{}
+		assert.Nil(t, Unmarshal(raw, &plain))
+		assert.Nil(t, UnmarshalWithOptions(raw, &interned, Options{InternKeys: true}))
+		assert.Equal(t, plain, interned)
+	})
+
+	t.Run("Array Keys", func(t *testing.T) {
+		var plain, interned map[string][]map[string]string
+		in := []byte(`{"a": [{"0": "x"}], "b": [{"0": "y"}]}`)
+		assert.Nil(t, Unmarshal(in, &plain))
+		assert.Nil(t, UnmarshalWithOptions(in, &interned, Options{InternKeys: true}))
+		assert.Equal(t, plain, interned)
+
+		var m map[string]string
+		assert.Nil(t, UnmarshalWithOptions([]byte(`["a", "b"]`), &m, Options{InternKeys: true}))
+		assert.Equal(t, map[string]string{"0": "a", "1": "b"}, m)
+	})
+
+	t.Run("Reader", func(t *testing.T) {
+		in := []byte(`[[1, 2], [3, 4]]`)
+		jr, err := NewJSONReader(in, WithInternKeys())
+		assert.Nil(t, err)
+		assert.Equal(t, 4, jr.GetInt("1.1"))
+		assert.Equal(t, []string{"0", "1"}, jr.Keys)
+
+		a, b := jr.Get("0"), jr.Get("1")
+		assert.Equal(t, stringData(a.Keys[1]), stringData(b.Keys[1]))
+	})
+
+	t.Run("Reader Object Keys", func(t *testing.T) {
+		jr, err := NewJSONReader(raw, WithInternKeys())
+		assert.Nil(t, err)
+		assert.Equal(t, "b", jr.GetString("1.name"))
+
+		a, b := jr.Get("0"), jr.Get("2")
+		assert.Equal(t, []string{"id", "name"}, b.Keys)
+		if zeroCopyViews {
+			// Keys are views of the document, at their own positions.
+			assert.NotEqual(t, stringData(a.Keys[1]), stringData(b.Keys[1]))
+		} else {
+			assert.Equal(t, stringData(a.Keys[1]), stringData(b.Keys[1]))
+		}
+	})
+
+	t.Run("Bounded", func(t *testing.T) {
+		in := newKeyInterner()
+		for i := 0; i < maxInternedKeys+10; i++ {
+			in.key([]byte(in.index(i)))
+		}
+		assert.Len(t, in.keys, maxInternedKeys)
+		assert.Len(t, in.indexes, maxInternedKeys)
+		assert.Equal(t, "5000", in.index(5000))
+
+		var none *keyInterner
+		assert.Equal(t, "a\nb", none.key([]byte(`a\nb`)))
+		assert.Equal(t, "7", none.index(7))
+	})
+}
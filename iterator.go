@@ -3,6 +3,7 @@ package gojson
 import (
 	"errors"
 	"fmt"
+	"strconv"
 )
 
 var (
@@ -51,7 +52,7 @@ func NewIterator(raw []byte) (*Iterator, error) {
 		close = '}'
 	}
 
-	if raw[len(raw)-1] != close && raw[len(raw)-1] != close {
+	if raw[len(raw)-1] != close {
 		return nil, ErrRequiresObject
 	}
 
@@ -140,3 +141,109 @@ func (i *Iterator) Reset() {
 	i.lastStart = i.start
 	i.end = false
 }
+
+// RawIterator reads the members of a JSON object, or the elements of a JSON array, one at a time and
+// without decoding them, for libraries building their own decoders on the byte scanner. Unlike
+// Iterator, the container is not validated up front: a malformed member is reported by Next when it
+// is reached.
+//
+// Example:
+//
+//	it, err := gojson.NewRawIterator(data)
+//	for err == nil {
+//		var key string
+//		var value []byte
+//		var dtype string
+//		if key, value, dtype, _, err = it.Next(); err == nil {
+//			fmt.Printf("%s: %s (%s)\n", key, value, dtype)
+//		}
+//	}
+//	if err != gojson.ErrEndOfInput {
+//		return err
+//	}
+type RawIterator struct {
+	data   []byte
+	pos    int
+	index  int
+	close  byte
+	object bool
+	done   bool
+}
+
+// NewRawIterator returns a RawIterator over the JSON object or array raw, which may be surrounded by
+// whitespace. ErrRequiresObject is returned for any other value.
+func NewRawIterator(raw []byte) (*RawIterator, error) {
+	start := ltrim(raw, 0)
+	if start >= len(raw) || (raw[start] != '{' && raw[start] != '[') {
+		return nil, ErrRequiresObject
+	}
+
+	it := &RawIterator{data: raw, pos: start + 1, close: ']', object: raw[start] == '{'}
+	if it.object {
+		it.close = '}'
+	}
+
+	if next := ltrim(raw, it.pos); next < len(raw) && raw[next] == it.close {
+		it.done = true
+	}
+
+	return it, nil
+}
+
+// Next returns the next member of the container: its key, which for array elements is the index, its
+// raw value (strings keep their quotes), its JSON type, and the byte offset of the value within the
+// input given to NewRawIterator. ErrEndOfInput is returned once every member has been read.
+func (it *RawIterator) Next() (key string, value []byte, dtype string, offset int, err error) {
+	if it.done {
+		return "", nil, "", 0, ErrEndOfInput
+	}
+
+	key, offset, err = it.key()
+	if err == nil {
+		value, dtype, offset, err = it.value(offset)
+	}
+	if err != nil {
+		it.done = true
+		return "", nil, "", 0, err
+	}
+
+	it.index++
+	return key, value, dtype, offset, nil
+}
+
+// key reads the key of the next member, returning the position following it.
+func (it *RawIterator) key() (string, int, error) {
+	if !it.object {
+		return strconv.Itoa(it.index), it.pos, nil
+	}
+
+	k, pos, err := extractKey(it.data, it.pos)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return manualUnescapeString(k), pos, nil
+}
+
+// value reads the value beginning at or after start, and the terminator following it, returning the
+// value's offset.
+func (it *RawIterator) value(start int) ([]byte, string, int, error) {
+	offset := ltrim(it.data, start)
+
+	v, t, end, err := extractValue(it.data, offset)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	next := ltrim(it.data, end)
+	switch {
+	case next < len(it.data) && it.data[next] == ',':
+		it.pos = next + 1
+	case next < len(it.data) && it.data[next] == it.close:
+		it.done = true
+	default:
+		return nil, "", 0, fmt.Errorf("expected value terminator ('%c' or ',') at position '%d' in segment '%s'", it.close, next, truncate(it.data, 50))
+	}
+
+	return v, t, offset, nil
+}
@@ -286,3 +286,75 @@ func TestIterator(t *testing.T) {
 		assert.Equal(t, ``, typ)
 	})
 }
+
+func TestRawIterator(t *testing.T) {
+	type member struct {
+		key    string
+		value  string
+		dtype  string
+		offset int
+	}
+
+	collect := func(raw string) ([]member, error) {
+		it, err := NewRawIterator([]byte(raw))
+		if err != nil {
+			return nil, err
+		}
+
+		var members []member
+		for {
+			k, v, dt, off, err := it.Next()
+			if err != nil {
+				return members, err
+			}
+			members = append(members, member{k, string(v), dt, off})
+		}
+	}
+
+	t.Run("Object", func(t *testing.T) {
+		members, err := collect(` {"a": 1, "b\n": "x", "c": {"d": [1, 2]}, "e": null} `)
+		assert.Equal(t, ErrEndOfInput, err)
+		assert.Equal(t, []member{
+			{"a", "1", JSONInt, 7},
+			{"b\n", `"x"`, JSONString, 17},
+			{"c", `{"d": [1, 2]}`, JSONObject, 27},
+			{"e", "null", JSONNull, 47},
+		}, members)
+	})
+
+	t.Run("Array", func(t *testing.T) {
+		members, err := collect(`[1.5, [], "y", true]`)
+		assert.Equal(t, ErrEndOfInput, err)
+		assert.Equal(t, []member{
+			{"0", "1.5", JSONFloat, 1},
+			{"1", "[]", JSONArray, 6},
+			{"2", `"y"`, JSONString, 10},
+			{"3", "true", JSONBool, 15},
+		}, members)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		members, err := collect(`{ }`)
+		assert.Equal(t, ErrEndOfInput, err)
+		assert.Empty(t, members)
+	})
+
+	t.Run("Scalar", func(t *testing.T) {
+		_, err := collect(`"a"`)
+		assert.Equal(t, ErrRequiresObject, err)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		members, err := collect(`[1, "a" "b"]`)
+		assert.Equal(t, `expected value terminator (']' or ',') at position '8' in segment '[1, "a" "b"]'`, err.Error())
+		assert.Len(t, members, 1)
+
+		members, err = collect(`{"a": 1, "b"}`)
+		assert.NotNil(t, err)
+		assert.Len(t, members, 1)
+
+		members, err = collect(`[1, 2`)
+		assert.NotNil(t, err)
+		assert.Len(t, members, 1)
+	})
+}
@@ -0,0 +1,80 @@
+package gojson
+
+import (
+	"strings"
+)
+
+// JoinPair is a matched pair of records produced by Join.
+type JoinPair struct {
+	// Key is the value the records were matched on.
+	Key string
+
+	// Left and Right are the matched records from the left and right documents.
+	Left  *JSONReader
+	Right *JSONReader
+}
+
+// Join matches records from two documents foreign-key style, returning every pair of records
+// whose values at the given key paths are equal. A "*" segment in a key path matches each child
+// of an object or array, and the record is the node matched by the last "*" in the path.
+//
+// For example, joining items.*.asset_id against assets.*.id pairs each element of items with the
+// element(s) of assets sharing its id. Pairs are returned in left document order, then right
+// document order. Values are compared as strings, so "17" matches 17. Null, object, and array
+// values never match.
+func Join(left *JSONReader, leftPath string, right *JSONReader, rightPath string) []JoinPair {
+	if left == nil || right == nil || left.Empty || right.Empty {
+		return nil
+	}
+
+	type record struct {
+		key  string
+		path string
+	}
+
+	records := func(jr *JSONReader, pattern string) []record {
+		// The number of path segments, up to and including the last wildcard, which make up a record.
+		depth := 0
+		for i, segment := range strings.Split(pattern, ".") {
+			if segment == "*" {
+				depth = i + 1
+			}
+		}
+
+		var out []record
+		for _, path := range jr.expandPath(pattern) {
+			p := jr.getChildByKey(path)
+			if p == nil {
+				continue
+			}
+
+			switch p.dtype {
+			case JSONNull, JSONObject, JSONArray:
+				continue
+			}
+
+			r := record{key: toString(p.bytes, p.dtype, false)}
+			if depth > 0 {
+				r.path = strings.Join(strings.SplitN(path, ".", depth+1)[:depth], ".")
+			}
+
+			out = append(out, r)
+		}
+
+		return out
+	}
+
+	index := make(map[string][]string)
+	for _, r := range records(right, rightPath) {
+		index[r.key] = append(index[r.key], r.path)
+	}
+
+	var pairs []JoinPair
+	for _, l := range records(left, leftPath) {
+		for _, path := range index[l.key] {
+			pairs = append(pairs, JoinPair{Key: l.key, Left: left.Get(l.path), Right: right.Get(path)})
+		}
+	}
+
+	return pairs
+}
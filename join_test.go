@@ -0,0 +1,65 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoin(t *testing.T) {
+	orders, err := NewJSONReader([]byte(`{"items": [{"sku": "a", "asset_id": 17}, {"sku": "b", "asset_id": "22"}, {"sku": "c", "asset_id": 99}, {"sku": "d", "asset_id": null}, {"sku": "e", "asset_id": 17}]}`))
+	assert.Nil(t, err)
+
+	catalog, err := NewJSONReader([]byte(`{"assets": [{"id": "17", "url": "x.png"}, {"id": 22, "url": "y.png"}, {"id": null}]}`))
+	assert.Nil(t, err)
+
+	t.Run("Arrays", func(t *testing.T) {
+		pairs := Join(orders, "items.*.asset_id", catalog, "assets.*.id")
+		assert.Len(t, pairs, 3)
+
+		var got []string
+		for _, p := range pairs {
+			got = append(got, p.Key+":"+p.Left.GetString("sku")+":"+p.Right.GetString("url"))
+		}
+		assert.Equal(t, []string{"17:a:x.png", "22:b:y.png", "17:e:x.png"}, got)
+	})
+
+	t.Run("Objects", func(t *testing.T) {
+		users, err := NewJSONReader([]byte(`{"u1": {"team": "red"}, "u2": {"team": "blue"}, "u3": {"team": "red"}}`))
+		assert.Nil(t, err)
+
+		teams, err := NewJSONReader([]byte(`[{"name": "red", "lead": "Al"}]`))
+		assert.Nil(t, err)
+
+		pairs := Join(users, "*.team", teams, "*.name")
+		assert.Len(t, pairs, 2)
+		assert.Equal(t, "Al", pairs[1].Right.GetString("lead"))
+		assert.Equal(t, `{"team": "red"}`, string(pairs[1].Left.ToByteSlice()))
+	})
+
+	t.Run("No Wildcard Joins Whole Documents", func(t *testing.T) {
+		a, _ := NewJSONReader([]byte(`{"id": 1, "a": true}`))
+		b, _ := NewJSONReader([]byte(`{"ref": {"id": 1}}`))
+
+		pairs := Join(a, "id", b, "ref.id")
+		assert.Len(t, pairs, 1)
+		assert.True(t, pairs[0].Left.GetBool("a"))
+		assert.Equal(t, 1, pairs[0].Right.GetInt("ref.id"))
+	})
+
+	t.Run("No Matches", func(t *testing.T) {
+		assert.Nil(t, Join(orders, "items.*.missing", catalog, "assets.*.id"))
+		assert.Nil(t, Join(orders, "items.*.asset_id", &JSONReader{Empty: true}, "assets.*.id"))
+	})
+}
+
+func TestExpandPath(t *testing.T) {
+	r, err := NewJSONReader([]byte(`{"a": [{"b": 1}, {"c": 2}, {"b": 3}], "d": {"e": {"b": 4}}}`))
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"a.0.b", "a.2.b"}, r.expandPath("a.*.b"))
+	assert.Equal(t, []string{"a.0.b", "a.2.b", "d.e.b"}, r.expandPath("*.*.b"))
+	assert.Equal(t, []string{"a", "d"}, r.expandPath("*"))
+	assert.Equal(t, []string{"d.e"}, r.expandPath("d.e"))
+	assert.Nil(t, r.expandPath("x.*"))
+}
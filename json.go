@@ -38,7 +38,22 @@ const (
 //     or JSONString
 //     or JSONObject
 //     or JSONArray
-func IsJSON(b []byte) bool {
+//
+// Documents nested deeper than DefaultMaxDepth are reported as invalid. With WithRFC8259, only documents
+// which are valid under RFC 8259 pass.
+func IsJSON(b []byte, opts ...ValidateOption) bool {
+	if checkDepth(b, DefaultMaxDepth) != nil {
+		return false
+	}
+
+	if rfc8259(opts) {
+		return validateRFC8259(b) == nil
+	}
+
+	return isJSON(b)
+}
+
+func isJSON(b []byte) bool {
 	b = trim(b)
 	if len(b) <= 0 {
 		return false
@@ -49,8 +64,13 @@ func IsJSON(b []byte) bool {
 		IsJSONObject(b) || IsJSONArray(b)
 }
 
-// IsJSONNull returns true if the byte array is a JSON null value.
-func IsJSONNull(b []byte) bool {
+// IsJSONNull returns true if the byte array is a JSON null value. Unless WithRFC8259 is given, the
+// comparison ignores case.
+func IsJSONNull(b []byte, opts ...ValidateOption) bool {
+	if rfc8259(opts) {
+		return isRFC8259(b, func(s *rfcScanner) error { return s.literal("null") })
+	}
+
 	b = trim(b)
 	if len(b) != 4 {
 		return false
@@ -62,8 +82,13 @@ func IsJSONNull(b []byte) bool {
 		(b[3] == 'l' || b[3] == 'L')
 }
 
-// IsJSONTrue returns true if the byte array is a JSON true value.
-func IsJSONTrue(b []byte) bool {
+// IsJSONTrue returns true if the byte array is a JSON true value. Unless WithRFC8259 is given, the
+// comparison ignores case.
+func IsJSONTrue(b []byte, opts ...ValidateOption) bool {
+	if rfc8259(opts) {
+		return isRFC8259(b, func(s *rfcScanner) error { return s.literal("true") })
+	}
+
 	b = trim(b)
 	if len(b) != 4 {
 		return false
@@ -75,8 +100,13 @@ func IsJSONTrue(b []byte) bool {
 		(b[3] == 'e' || b[3] == 'E')
 }
 
-// IsJSONFalse returns true if the byte array is a JSON false value.
-func IsJSONFalse(b []byte) bool {
+// IsJSONFalse returns true if the byte array is a JSON false value. Unless WithRFC8259 is given, the
+// comparison ignores case.
+func IsJSONFalse(b []byte, opts ...ValidateOption) bool {
+	if rfc8259(opts) {
+		return isRFC8259(b, func(s *rfcScanner) error { return s.literal("false") })
+	}
+
 	b = trim(b)
 	if len(b) != 5 {
 		return false
@@ -108,7 +138,11 @@ func IsJSONFalse(b []byte) bool {
 //       or Digits Digit
 // Digit = 0 through 9
 // OneToNine = 1 through 9
-func IsJSONNumber(b []byte) bool {
+func IsJSONNumber(b []byte, opts ...ValidateOption) bool {
+	if rfc8259(opts) {
+		return isRFC8259(b, (*rfcScanner).number)
+	}
+
 	b = trim(b)
 	if len(b) == 0 {
 		return false
@@ -248,7 +282,11 @@ func isOneToNine(b byte) bool {
 //
 // JSONString = ""
 //           or " StringCharacters "
-func IsJSONString(b []byte) bool {
+func IsJSONString(b []byte, opts ...ValidateOption) bool {
+	if rfc8259(opts) {
+		return isRFC8259(b, rfcOpening('"', (*rfcScanner).string))
+	}
+
 	b = trim(b)
 	if len(b) == 0 {
 		return false
@@ -360,7 +398,11 @@ func isTermByte(b byte) bool {
 //
 // JSONObject = { }
 //           or { Members }
-func IsJSONObject(b []byte) bool {
+func IsJSONObject(b []byte, opts ...ValidateOption) bool {
+	if rfc8259(opts) {
+		return isRFC8259(b, rfcOpening('{', (*rfcScanner).object))
+	}
+
 	b = trim(b)
 	if len(b) == 0 {
 		return false
@@ -395,7 +437,7 @@ func isMembers(b []byte) bool {
 		}
 
 		v, _, pos, err := extractValue(b, pos)
-		if err != nil || !IsJSON(v) {
+		if err != nil || !isJSON(v) {
 			return false
 		}
 
@@ -426,7 +468,11 @@ func isMembers(b []byte) bool {
 //
 // JSONArray = [ ]
 //          or [ ArrayElements ]
-func IsJSONArray(b []byte) bool {
+func IsJSONArray(b []byte, opts ...ValidateOption) bool {
+	if rfc8259(opts) {
+		return isRFC8259(b, rfcOpening('[', (*rfcScanner).array))
+	}
+
 	b = trim(b)
 	if len(b) == 0 {
 		return false
@@ -456,7 +502,7 @@ func isArrayElements(b []byte) bool {
 	requiresValue := false
 	for start < len(b) {
 		v, _, pos, err := extractValue(b, start)
-		if err != nil || !IsJSON(v) {
+		if err != nil || !isJSON(v) {
 			return false
 		}
 
@@ -488,16 +534,7 @@ func ltrim(search []byte, start int) int {
 		return start
 	}
 
-	for start < len(search) {
-		switch {
-		case isWhitespace(search[start]):
-			start++
-		default:
-			return start
-		}
-	}
-
-	return start
+	return skipWhitespace(search, start)
 }
 
 // Remove all leading and trailing whitespace.
@@ -573,7 +610,14 @@ func trimString(b []byte) []byte {
 //
 // If you must validate that you have valid JSON, call IsJSON with your byte string prior
 // to calling GetJSONType. Or call GetJSONTypeStrict.
-func GetJSONType(search []byte, start int) string {
+//
+// With WithRFC8259, the value beginning at start is validated in full, and JSONInvalid is returned
+// unless it is valid under RFC 8259. Any data following the value is not examined.
+func GetJSONType(search []byte, start int, opts ...ValidateOption) string {
+	if rfc8259(opts) && !isRFC8259Value(search, start) {
+		return JSONInvalid
+	}
+
 	current := ltrim(search, start)
 
 	switch {
@@ -616,14 +660,15 @@ func GetJSONType(search []byte, start int) string {
 // JSONInvalid ("") denotes a JSON error.
 //
 // GetJSONTypeStrict WILL perform JSON Validation, and return JSONInvalid if that validation fails.
-// This is slower than GetJSONType due to the extra validation involved.
-func GetJSONTypeStrict(search []byte, start int) string {
+// This is slower than GetJSONType due to the extra validation involved. With WithRFC8259, the
+// validation follows RFC 8259.
+func GetJSONTypeStrict(search []byte, start int, opts ...ValidateOption) string {
 	current := ltrim(search, start)
 
 	switch {
 	case current < 0 || len(search) < 1 || len(search) <= current:
 		return JSONInvalid
-	case !IsJSON(search[current:]):
+	case !IsJSON(search[current:], opts...):
 		return JSONInvalid
 	default:
 		return GetJSONType(search, current)
@@ -806,6 +806,68 @@ func TestGetJSONTypeStrict(t *testing.T) {
 	}
 }
 
+func TestGetJSONTypeRFC8259(t *testing.T) {
+	testCases := []struct {
+		label    string
+		input    string
+		start    int
+		expected string
+	}{
+		{label: `Bool T`, input: `True`, expected: JSONInvalid},
+		{label: `Null N`, input: `Null`, expected: JSONInvalid},
+		{label: `Bool t`, input: `true`, expected: JSONBool},
+		{label: `String Invalid`, input: `"No closing quote`, expected: JSONInvalid},
+		{label: `Object InValid`, input: `{"a""b"}`, expected: JSONInvalid},
+		{label: `Array InValid`, input: `["a""b"]`, expected: JSONInvalid},
+		{label: `Nested Invalid`, input: `{"a": [1, tRue]}`, expected: JSONInvalid},
+		{label: `Object Valid`, input: `{"a": [1, true]}`, expected: JSONObject},
+		{label: `Value Followed By Data`, input: `["a", {"b": 1.5}, 2]`, start: 6, expected: JSONObject},
+		{label: `Float`, input: `[1, -21.0e3]`, start: 3, expected: JSONFloat},
+		{label: `Invalid Number`, input: `01`, expected: JSONInvalid},
+		{label: `Out Of Range`, input: `[]`, start: 2, expected: JSONInvalid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.label, func(t *testing.T) {
+			assert.Equal(t, tc.expected, GetJSONType([]byte(tc.input), tc.start, WithRFC8259()))
+		})
+	}
+
+	assert.Equal(t, JSONInvalid, GetJSONTypeStrict([]byte(`["a"]`+"\f"), 0, WithRFC8259()))
+	assert.Equal(t, JSONArray, GetJSONTypeStrict([]byte(`["a"]`+"\f"), 0))
+}
+
+func TestIsJSONRFC8259(t *testing.T) {
+	testCases := []struct {
+		label    string
+		fn       func([]byte, ...ValidateOption) bool
+		input    string
+		lenient  bool
+		expected bool
+	}{
+		{label: "Null", fn: IsJSONNull, input: ` null `, lenient: true, expected: true},
+		{label: "Null Mixed Case", fn: IsJSONNull, input: `NuLl`, lenient: true},
+		{label: "True Mixed Case", fn: IsJSONTrue, input: `TrUe`, lenient: true},
+		{label: "False Mixed Case", fn: IsJSONFalse, input: `FaLsE`, lenient: true},
+		{label: "Number", fn: IsJSONNumber, input: `-1.5E+3`, lenient: true, expected: true},
+		{label: "Number Form Feed", fn: IsJSONNumber, input: "\f1", lenient: true},
+		{label: "String Uppercase Escape", fn: IsJSONString, input: `"\B"`, lenient: true},
+		{label: "String Invalid UTF-8", fn: IsJSONString, input: "\"\xff\"", lenient: true},
+		{label: "Object Mixed Case", fn: IsJSONObject, input: `{"a": NULL}`, lenient: true},
+		{label: "Object", fn: IsJSONObject, input: `{"a": null}`, lenient: true, expected: true},
+		{label: "Array Mixed Case", fn: IsJSONArray, input: `[False]`, lenient: true},
+		{label: "Array Not Object", fn: IsJSONArray, input: `{}`},
+		{label: "JSON Mixed Case", fn: IsJSON, input: `[1, TRUE]`, lenient: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.label, func(t *testing.T) {
+			assert.Equal(t, tc.lenient, tc.fn([]byte(tc.input)))
+			assert.Equal(t, tc.expected, tc.fn([]byte(tc.input), WithRFC8259()))
+		})
+	}
+}
+
 func TestFindTerminator(t *testing.T) {
 	t.Run("Start LessThan 0", func(t *testing.T) {
 		assert.Equal(t, -1, findTerminator([]byte(`[ "a" , "b"]`), -1))
@@ -1,13 +1,22 @@
 package gojson
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
-	"unsafe"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
 // JSONReader Provides utility functions for manipulating json structures.
+//
+// A JSONReader is never modified by reading it, so one reader, and the readers returned by its Get and
+// GetCollection, may be read from any number of goroutines at once. The exported settings, such as
+// StrictStandards, are best given as ReaderOptions, such as WithStrict, when the reader is created.
+// They must not be changed while the reader is in use; use Clone to read with other settings.
 type JSONReader struct {
 	// Keys holds the list of top-level keys
 	Keys []string
@@ -15,46 +24,318 @@ type JSONReader struct {
 	// rawData is the initial byte string provided to NewJSONReader.
 	rawData []byte
 
+	// doc is the whole document, before trimming, shared with the readers returned by Get. OffsetOf
+	// gives offsets within it.
+	doc []byte
+
 	// Type is the JSONType of the top-level data.
 	Type string
 
 	// parsed is the set of child nodes populated by the parser.
 	parsed map[string]parsed
 
-	// Empty is true if parsing failed or no data was supplied.
+	// Empty is true if parsing failed or no data was supplied, or if the reader was returned by Get
+	// for a key which doesn't exist. Readers holding null, or an empty object or array, are not Empty.
 	Empty bool
 
 	// StrictStandards directs the extraction functions to be strict with type
-	// casting and extractions where applicable.
+	// casting and extractions where applicable. Prefer setting it with WithStrict, so that the reader
+	// isn't changed after it is created; the field remains for compatibility.
 	StrictStandards bool
+
+	// NumberConversion determines how the integer functions convert numbers with a fractional part.
+	// Prefer setting it with WithNumberConversion.
+	NumberConversion NumberConversion
+
+	// InvalidUTF8 determines how the string functions handle invalid UTF-8 and unpaired surrogates.
+	// Prefer setting it with WithInvalidUTF8.
+	InvalidUTF8 InvalidUTF8
+
+	// observer, if set, is notified of keys and values as they are parsed.
+	observer *ParseObserver
+
+	// maxDepth is the nesting depth limit set by WithMaxDepth, and the other limits are set by
+	// WithMaxStringLength, WithMaxTokenSize and WithMaxDocumentSize.
+	maxDepth        int
+	maxStringLength int
+	maxTokenSize    int
+	maxDocumentSize int
+
+	// positions, if set by WithPositions, records the position of each value during parsing.
+	positions *positionTracker
+
+	// position is the position of the top-level data, if positions were recorded.
+	position *Position
+
+	// dups holds the earlier values of any duplicated top-level keys, for GetAll.
+	dups map[string][]parsed
+
+	// zeroCopyStrings, set by WithZeroCopyStrings, returns strings without escapes as views of rawData.
+	zeroCopyStrings bool
+
+	// rawStrings, set by WithRawStrings, returns strings with their escape sequences intact.
+	rawStrings bool
+
+	// interner, set by WithInternKeys, interns the keys of array elements during parsing.
+	interner *keyInterner
+
+	// encoding, set by WithEncoding, is the encoding of the document given to NewJSONReader.
+	encoding Encoding
+
+	// relaxedQuotes, set by WithRelaxedQuotes, accepts single-quoted strings and unquoted keys.
+	relaxedQuotes bool
+
+	// trailingData, set by WithTrailingData, ignores the data after the top-level value.
+	trailingData bool
+
+	// noCopy, set by WithNoCopy, parses the document given to NewJSONReader in place.
+	noCopy bool
+
+	// errs holds the values rejected under StrictStandards, shared with the readers returned by Get and
+	// GetCollection. path is the key path of the reader's root from the reader which created errs. It
+	// is created along with the reader, so that readers used concurrently needn't create it.
+	errs *conversionLog
+	path string
+}
+
+// ReaderOption configures a JSONReader created by NewJSONReader.
+type ReaderOption func(*JSONReader)
+
+// ParseObserver receives callbacks from the parser as a document is read by NewJSONReader,
+// allowing metrics such as key cardinality or type histograms to be collected without
+// a second pass over the document. Either callback may be nil.
+//
+// Callbacks are made in document order. If parsing fails, the callbacks made before the
+// failure are not retracted.
+type ParseObserver struct {
+	// OnKey is called for every object key, exactly as it appears in the document (escapes intact).
+	OnKey func(key string)
+
+	// OnValueType is called for every value, including the root, with its JSON type.
+	// Container values are reported after their children.
+	OnValueType func(dtype string)
+}
+
+// WithObserver registers a ParseObserver to be notified during parsing.
+func WithObserver(o ParseObserver) ReaderOption {
+	return func(jr *JSONReader) {
+		jr.observer = &o
+	}
+}
+
+// WithMaxDepth sets the maximum nesting depth accepted by NewJSONReader. Zero selects DefaultMaxDepth,
+// and a negative value disables the limit.
+func WithMaxDepth(n int) ReaderOption {
+	return func(jr *JSONReader) {
+		jr.maxDepth = n
+	}
+}
+
+// WithMaxStringLength sets the maximum encoded length of any string, including keys, accepted by
+// NewJSONReader. Zero means no limit.
+func WithMaxStringLength(n int) ReaderOption {
+	return func(jr *JSONReader) {
+		jr.maxStringLength = n
+	}
+}
+
+// WithMaxTokenSize sets the maximum encoded length of any string, number or literal accepted by
+// NewJSONReader, as Options.MaxTokenSize does for Unmarshal. Zero means no limit.
+func WithMaxTokenSize(n int) ReaderOption {
+	return func(jr *JSONReader) {
+		jr.maxTokenSize = n
+	}
+}
+
+// WithMaxDocumentSize sets the maximum length of the document accepted by NewJSONReader. Larger
+// documents are rejected before they are copied or scanned. Zero means no limit.
+func WithMaxDocumentSize(n int) ReaderOption {
+	return func(jr *JSONReader) {
+		jr.maxDocumentSize = n
+	}
+}
+
+// WithZeroCopyStrings returns strings which contain no escape sequences as views of the reader's copy
+// of the document, rather than copying them. This makes string extraction allocation free, but any
+// string retained keeps the whole document in memory, so it suits readers which are discarded along
+// with the strings read from them. Readers returned by Get and GetCollection share the setting. It
+// has no effect when built with the purego tag, which doesn't use unsafe.
+func WithZeroCopyStrings() ReaderOption {
+	return func(jr *JSONReader) {
+		jr.zeroCopyStrings = true
+	}
+}
+
+// WithRawStrings returns the contents of strings exactly as they appear in the document, without
+// decoding their escape sequences, so that a value such as "a\u003cb\"" is read as a\u003cb\" rather
+// than a<b". This suits values forwarded into other JSON documents, which must be byte-identical.
+// Invalid UTF-8 is left as it is. Readers returned by Get and GetCollection share the setting.
+func WithRawStrings() ReaderOption {
+	return func(jr *JSONReader) {
+		jr.rawStrings = true
+	}
+}
+
+// WithInternKeys shares one string between every array element with the same index, rather than
+// allocating the key of each element afresh, which cuts the memory held by documents with many arrays.
+// Object keys are views of the reader's copy of the document, and need no interning, except under the
+// purego build tag, where they are copies and are interned too. Use Options.InternKeys to intern the
+// keys of maps decoded by Unmarshal.
+func WithInternKeys() ReaderOption {
+	return func(jr *JSONReader) {
+		jr.interner = newKeyInterner()
+	}
+}
+
+// WithStrict sets StrictStandards, applying the type association of UnmarshalStrict to the accessors.
+func WithStrict() ReaderOption {
+	return func(jr *JSONReader) {
+		jr.StrictStandards = true
+	}
+}
+
+// WithNumberConversion sets NumberConversion, which determines how the integer functions convert
+// numbers with a fractional part.
+func WithNumberConversion(nc NumberConversion) ReaderOption {
+	return func(jr *JSONReader) {
+		jr.NumberConversion = nc
+	}
+}
+
+// WithInvalidUTF8 sets InvalidUTF8, which determines how the string functions handle invalid UTF-8
+// and unpaired surrogates.
+func WithInvalidUTF8(policy InvalidUTF8) ReaderOption {
+	return func(jr *JSONReader) {
+		jr.InvalidUTF8 = policy
+	}
+}
+
+// WithNoCopy parses the document given to NewJSONReader in place, rather than copying it first, which
+// saves an allocation the size of the document. The reader refers to the document for as long as it
+// is used, so the document must not be modified afterwards.
+func WithNoCopy() ReaderOption {
+	return func(jr *JSONReader) {
+		jr.noCopy = true
+	}
+}
+
+// limits returns the limits set by the reader options, with the depth limit resolved.
+func (jr *JSONReader) limits() Options {
+	return Options{
+		MaxDepth:        resolveMaxDepth(jr.maxDepth),
+		MaxStringLength: jr.maxStringLength,
+		MaxTokenSize:    jr.maxTokenSize,
+		MaxDocumentSize: jr.maxDocumentSize,
+	}
 }
 
 // NewJSONReader creates a new JSONReader object, which parses the rawData input and provides
 // access to various accessor functions useful for working with JSONData.
 //
 // Behavior is undefined when a JSONReader is created via means other than NewJSONReader.
-func NewJSONReader(rawData []byte) (reader *JSONReader, err error) {
+func NewJSONReader(rawData []byte, opts ...ReaderOption) (reader *JSONReader, err error) {
 	defer PanicRecovery(&err)
 
 	if len(rawData) == 0 {
 		return &JSONReader{Empty: true}, fmt.Errorf("No JSON Provided")
 	}
 
+	reader = &JSONReader{}
+	for _, opt := range opts {
+		opt(reader)
+	}
+
+	if rawData, err = toUTF8(rawData, reader.encoding); err != nil {
+		return &JSONReader{Empty: true}, err
+	}
+
+	if reader.relaxedQuotes {
+		if rawData, err = relaxQuotes(rawData); err != nil {
+			return &JSONReader{Empty: true}, err
+		}
+	}
+
+	if err := checkLimits(nil, rawData, reader.limits()); err != nil {
+		return &JSONReader{Empty: true}, err
+	}
+
+	if rawData, err = checkTrailing(rawData, reader.trailingData); err != nil {
+		return &JSONReader{Empty: true}, err
+	}
+
 	// We make a copy of rawData so that the backing array is completely incapsulated
 	// by the reader, so that the user can't change the backing array later.
-	reader = &JSONReader{}
-	reader.rawData = make([]byte, len(rawData))
-	copy(reader.rawData, rawData)
+	if reader.noCopy {
+		reader.rawData = rawData
+	} else {
+		reader.rawData = make([]byte, len(rawData))
+		copy(reader.rawData, rawData)
+	}
 
-	reader.parse()
+	return reader.load()
+}
+
+// load parses rawData, which the reader must own, and returns the reader.
+func (jr *JSONReader) load() (*JSONReader, error) {
+	jr.errs = new(conversionLog)
+
+	// Input which isn't JSON at all gives an Empty reader, rather than an error.
+	if err := jr.parse(); err != nil && err != ErrMalformedJSON {
+		jr.Empty = true
+		return jr, err
+	}
 
-	if len(reader.parsed) == 0 {
-		reader.Empty = true
-		reader.rawData = nil
-		return reader, err
+	// Empty objects and arrays have no children, but are not Empty.
+	if len(jr.parsed) == 0 && jr.Type != JSONObject && jr.Type != JSONArray {
+		jr.Empty = true
+		jr.rawData = nil
 	}
 
-	return reader, err
+	return jr, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that encoding/json can populate a JSONReader. Unmarshal
+// populates a JSONReader itself, applying its options.
+func (jr *JSONReader) UnmarshalJSON(b []byte) (err error) {
+	defer PanicRecovery(&err)
+
+	u := unmarshaler{Options: DefaultOptions}
+	return u.unmarshalReader(trim(b), GetJSONType(b, 0), jr)
+}
+
+// unmarshalReader replaces jr with a reader of b, as NewJSONReader would create, which converts values
+// as the unmarshaler does. The limits were checked when the whole document was, and aren't again.
+func (u *unmarshaler) unmarshalReader(b []byte, t string, jr *JSONReader) error {
+	if t == JSONInvalid {
+		return ErrMalformedJSON
+	}
+
+	*jr = JSONReader{
+		StrictStandards:  u.StrictStandards,
+		NumberConversion: u.NumberConversion,
+		InvalidUTF8:      u.InvalidUTF8,
+		maxDepth:         u.MaxDepth,
+		maxStringLength:  u.MaxStringLength,
+		maxTokenSize:     u.MaxTokenSize,
+		maxDocumentSize:  u.MaxDocumentSize,
+		interner:         u.interner,
+		errs:             new(conversionLog),
+	}
+
+	jr.rawData = make([]byte, len(b))
+	copy(jr.rawData, b)
+
+	if err := jr.parse(); err != nil {
+		jr.Empty = true
+		return err
+	}
+
+	if len(jr.parsed) == 0 && jr.Type != JSONObject && jr.Type != JSONArray {
+		jr.Empty = true
+		jr.rawData = nil
+	}
+
+	return nil
 }
 
 // KeyExists returns true if a given key exists in the parsed json.
@@ -74,6 +355,72 @@ func (jr *JSONReader) KeyExists(key string) bool {
 	return true
 }
 
+// IsNull returns true if the given key exists and holds an explicit null. Together with KeyExists,
+// this distinguishes a missing key from a null one, which the Get* functions report alike as the
+// zero value. Use empty string ("") to represent the root.
+//
+// Example, applying a PATCH document:
+//
+//	switch {
+//	case !r.KeyExists("email"):
+//		// leave the email unchanged
+//	case r.IsNull("email"):
+//		// clear the email
+//	default:
+//		// set the email to r.GetString("email")
+//	}
+func (jr *JSONReader) IsNull(key string) bool {
+	return jr.TypeOf(key) == JSONNull
+}
+
+// TypeOf returns the JSON type of the value at the given key, or JSONInvalid ("") if the key
+// doesn't exist. Use empty string ("") to represent the root.
+func (jr *JSONReader) TypeOf(key string) string {
+	if jr.Empty {
+		return JSONInvalid
+	}
+
+	p := jr.getChildByKey(key)
+	if p == nil {
+		return JSONInvalid
+	}
+
+	return p.dtype
+}
+
+// RawBytes returns a copy of the JSON encoding of the value at the given key, or nil if the key
+// doesn't exist. Unlike GetByteSlice, strings retain their surrounding quotes, so the result is
+// always valid JSON. Use empty string ("") to represent the root.
+func (jr *JSONReader) RawBytes(key string) []byte {
+	if jr.Empty {
+		return nil
+	}
+
+	p := jr.getChildByKey(key)
+	if p == nil {
+		return nil
+	}
+
+	return rawBytes(*p)
+}
+
+// rawBytes returns a copy of the JSON encoding of the node.
+func rawBytes(p parsed) []byte {
+	b := trim(p.bytes)
+	if p.dtype != JSONString {
+		return append([]byte{}, b...)
+	}
+
+	if len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
+		b = b[1 : len(b)-1]
+	}
+
+	out := make([]byte, 0, len(b)+2)
+	out = append(out, '"')
+	out = append(out, b...)
+	return append(out, '"')
+}
+
 /**
  * Nesting Functions
  */
@@ -82,32 +429,184 @@ func (jr *JSONReader) KeyExists(key string) bool {
 func (jr *JSONReader) Get(key string) *JSONReader {
 	p := jr.getChildByKey(key)
 	if p == nil {
-		return &JSONReader{Empty: true}
+		r := &JSONReader{Empty: true}
+		jr.inherit(r, key)
+		return r
+	}
+
+	r := readerFor(p)
+	jr.inherit(r, key)
+	return r
+}
+
+// Clone returns a copy of the reader, sharing its parsed data, whose settings can be changed without
+// affecting the original, for example to read with StrictStandards in one goroutine but not another.
+// The copy records its own errors, with key paths given from its root.
+func (jr *JSONReader) Clone() *JSONReader {
+	c := *jr
+	c.Keys = append([]string(nil), jr.Keys...)
+	c.errs, c.path = new(conversionLog), ""
+	return &c
+}
+
+// Count returns the number of elements of the array at key, or of members of the object at key,
+// without creating a reader for each. Scalars and missing keys give 0. Use empty string ("") to
+// represent the root.
+func (jr *JSONReader) Count(key string) int {
+	p, ok := jr.child(key)
+	if !ok {
+		return 0
+	}
+
+	switch p.dtype {
+	case JSONArray:
+		return len(p.keys)
+	case JSONObject:
+		return len(p.children)
+	}
+
+	return 0
+}
+
+// Slice returns a JSONReader holding at most limit elements of the array at key, starting from the
+// element at offset, for windowing huge arrays without a reader for every element. The elements are
+// re-indexed from 0. A negative limit takes every element after offset, and an offset past the end
+// gives an empty array. If the key doesn't exist or isn't an array, the JSONReader is Empty.
+//
+// Example, paging through an array:
+//
+//	for offset := 0; offset < r.Count("items"); offset += 100 {
+//		page := r.Slice("items", offset, 100)
+//		...
+//	}
+func (jr *JSONReader) Slice(key string, offset, limit int) *JSONReader {
+	p, ok := jr.child(key)
+	if !ok || p.dtype != JSONArray {
+		r := &JSONReader{Empty: true}
+		jr.inherit(r, key)
+		return r
+	}
+
+	start, end := offset, len(p.keys)
+	switch {
+	case start < 0:
+		start = 0
+	case start > end:
+		start = end
+	}
+	if limit >= 0 && limit < end-start {
+		end = start + limit
+	}
+
+	r := &JSONReader{
+		rawData: []byte{'['},
+		parsed:  make(map[string]parsed, end-start),
+		Type:    JSONArray,
+		Keys:    make([]string, 0, end-start),
+	}
+
+	for i := start; i < end; i++ {
+		c := p.children[p.keys[i]]
+		c.key = strconv.Itoa(i - start)
+
+		if i > start {
+			r.rawData = append(r.rawData, ',')
+		}
+		r.rawData = append(r.rawData, rawBytes(c)...)
+		r.parsed[c.key] = c
+		r.Keys = append(r.Keys, c.key)
+	}
+	r.rawData = append(r.rawData, ']')
+
+	jr.inherit(r, key)
+	return r
+}
+
+// GetAll returns a JSONReader for every occurrence of the last key in the path, in document order,
+// for documents in which duplicate keys are meaningful. The other functions only see the last
+// occurrence of a duplicated key, as do the earlier keys of the path. Nil is returned if the key
+// doesn't exist.
+//
+// Example:
+//
+//	reader, _ := gojson.NewJSONReader([]byte(`{"to": "a@example.com", "to": "b@example.com"}`))
+//	for _, to := range reader.GetAll("to") {
+//		fmt.Println(to.ToString()) // a@example.com, then b@example.com
+//	}
+func (jr *JSONReader) GetAll(key string) []JSONReader {
+	parent, last := "", key
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		parent, last = key[:i], key[i+1:]
+	}
+
+	p := jr.getChildByKey(parent)
+	if p == nil {
+		return nil
+	}
+
+	c, ok := p.children[last]
+	if !ok || key == "" {
+		return nil
+	}
+
+	occurrences := make([]parsed, 0, len(p.dups[last])+1)
+	occurrences = append(append(occurrences, p.dups[last]...), c)
+
+	all := make([]JSONReader, len(occurrences))
+	for i := range occurrences {
+		r := readerFor(&occurrences[i])
+		jr.inherit(r, key)
+		all[i] = *r
 	}
 
-	var r JSONReader
+	return all
+}
+
+// member returns a JSONReader holding the child node v, found at k within the value at key. Unlike Get,
+// it doesn't look the child up by path, which can't address keys which are empty or contain dots.
+func (jr *JSONReader) member(key, k string, v *parsed) *JSONReader {
+	r := readerFor(v)
+	jr.inherit(r, joinPath(key, k))
+	return r
+}
 
+// readerFor returns a JSONReader with the given node as its root.
+func readerFor(p *parsed) *JSONReader {
 	switch p.dtype {
 	case JSONArray, JSONObject:
-		r = JSONReader{rawData: p.bytes, parsed: p.children, Type: p.dtype, Keys: p.keys}
+		return &JSONReader{rawData: p.bytes, parsed: p.children, dups: p.dups, Type: p.dtype, Keys: p.keys, position: p.pos}
 	default:
-		r = JSONReader{rawData: p.bytes, parsed: map[string]parsed{"0": *p}, Type: p.dtype, Keys: []string{"0"}}
+		return &JSONReader{rawData: p.bytes, parsed: map[string]parsed{"0": *p}, Type: p.dtype, Keys: []string{"0"}, position: p.pos}
 	}
-
-	return &r
 }
 
 // GetCollection extracts a nested JSONArray and returns a slice of JSONReader, with one JSONReader for each
 // element in the JSONArray.
+//
+// A "*" segment in the key matches every child of an object or array, and one JSONReader is returned for
+// each value matched by the whole key, in document order. GetCollection("items.*.data.assets.0") returns
+// the first asset of each item, skipping the items which have none.
 func (jr *JSONReader) GetCollection(key string) []JSONReader {
+	if hasWildcard(key) {
+		var slice []JSONReader
+		for _, path := range jr.expandPath(key) {
+			p, _ := jr.child(path)
+			r := readerFor(&p)
+			jr.inherit(r, path)
+			slice = append(slice, *r)
+		}
+		return slice
+	}
+
 	p := jr.getChildByKey(key)
-	if p == nil {
+	if p == nil || !jr.strictContainer(key, p.bytes, p.dtype, JSONArray, "[]JSONReader") {
 		return []JSONReader(nil)
 	}
 
 	if len(p.keys) == 0 {
 		slice := make([]JSONReader, 1)
-		slice[0] = JSONReader{rawData: p.bytes, parsed: map[string]parsed{"0": *p}, Type: p.dtype, Keys: []string{"0"}}
+		slice[0] = JSONReader{rawData: p.bytes, parsed: map[string]parsed{"0": *p}, Type: p.dtype, Keys: []string{"0"}, position: p.pos}
+		jr.inherit(&slice[0], key)
 		return slice
 	}
 
@@ -117,16 +616,64 @@ func (jr *JSONReader) GetCollection(key string) []JSONReader {
 		v := p.children[k]
 		switch v.dtype {
 		case JSONArray, JSONObject:
-			slice[count] = JSONReader{rawData: v.bytes, parsed: v.children, Type: v.dtype, Keys: v.keys}
+			slice[count] = JSONReader{rawData: v.bytes, parsed: v.children, dups: v.dups, Type: v.dtype, Keys: v.keys, position: v.pos}
 		default:
-			slice[count] = JSONReader{rawData: v.bytes, parsed: map[string]parsed{"0": v}, Type: v.dtype, Keys: []string{"0"}}
+			slice[count] = JSONReader{rawData: v.bytes, parsed: map[string]parsed{"0": v}, Type: v.dtype, Keys: []string{"0"}, position: v.pos}
 		}
+		jr.inherit(&slice[count], joinPath(key, k))
 		count++
 	}
 
 	return slice
 }
 
+// Pluck returns the string at the key path field within each element of the array at key, in the
+// manner of GetString. An element without the field gives an empty string, so the result has one
+// entry per element. As with GetCollection, key may have "*" segments.
+//
+// Example:
+//
+//	titles := r.Pluck("items", "data.title")
+func (jr *JSONReader) Pluck(key, field string) []string {
+	return pluck(jr, key, field, (*JSONReader).GetString)
+}
+
+// PluckInt returns the int at the key path field within each element of the array at key, as Pluck.
+func (jr *JSONReader) PluckInt(key, field string) []int {
+	return pluck(jr, key, field, (*JSONReader).GetInt)
+}
+
+// PluckFloat returns the float at the key path field within each element of the array at key, as Pluck.
+func (jr *JSONReader) PluckFloat(key, field string) []float64 {
+	return pluck(jr, key, field, (*JSONReader).GetFloat)
+}
+
+// PluckBool returns the bool at the key path field within each element of the array at key, as Pluck.
+func (jr *JSONReader) PluckBool(key, field string) []bool {
+	return pluck(jr, key, field, (*JSONReader).GetBool)
+}
+
+// PluckInterface returns the value at the key path field within each element of the array at key, as
+// GetInterface returns it. An element without the field gives nil.
+func (jr *JSONReader) PluckInterface(key, field string) []interface{} {
+	return pluck(jr, key, field, (*JSONReader).GetInterface)
+}
+
+// pluck applies get to the field of each element of the collection at key.
+func pluck[T any](jr *JSONReader, key, field string, get func(*JSONReader, string) T) []T {
+	c := jr.GetCollection(key)
+	if c == nil {
+		return nil
+	}
+
+	out := make([]T, len(c))
+	for i := range c {
+		out[i] = get(&c[i], field)
+	}
+
+	return out
+}
+
 /**
  * String Functions
  */
@@ -134,86 +681,181 @@ func (jr *JSONReader) GetCollection(key string) []JSONReader {
 // GetString retrieves a given key as a string, if it exists.
 func (jr *JSONReader) GetString(key string) string {
 	b, t, _ := jr.getDataByKey(key)
-	if b == nil {
+	if b == nil || !jr.strictValue("", key, b, t, JSONString, "string") {
 		return ""
 	}
-	return toString(b, t, jr.StrictStandards)
+	return jr.stringOf("", key, b, t)
 }
 
 // ToString returns the top-level JSON as a string.
 func (jr *JSONReader) ToString() string {
-	return toString(jr.rawData, jr.Type, jr.StrictStandards)
+	if !jr.strictValue("", "", jr.rawData, jr.Type, JSONString, "string") {
+		return ""
+	}
+	return jr.stringOf("", "", jr.rawData, jr.Type)
 }
 
 // GetStringSlice retrieves a given key as a string slice, if it exists.
 func (jr *JSONReader) GetStringSlice(key string) []string {
+	s, ok := appendSlice(jr, key, []string{}, JSONString, "[]string", "string", func(parent, key string, b []byte, t string) string {
+		return jr.stringOf(parent, key, b, t)
+	})
+	if !ok {
+		return nil
+	}
+	return s
+}
+
+// GetStringSliceInto appends the elements of a given key to dst, as GetStringSlice would return them,
+// and returns the extended slice. dst is returned unchanged if the key doesn't exist. Reusing dst
+// across calls avoids allocating a new slice each time.
+func (jr *JSONReader) GetStringSliceInto(key string, dst []string) []string {
+	dst, _ = appendSlice(jr, key, dst, JSONString, "[]string", "string", func(parent, key string, b []byte, t string) string {
+		return jr.stringOf(parent, key, b, t)
+	})
+	return dst
+}
+
+// ToStringSlice returns all top-level data as a string slice.
+func (jr *JSONReader) ToStringSlice() []string {
+	return jr.GetStringSlice("")
+}
+
+// GetMapStringString retrieves a given key as a map of string onto string, if it exists.
+func (jr *JSONReader) GetMapStringString(key string) map[string]string {
 	p := jr.getChildByKey(key)
 	if p == nil {
 		return nil
 	}
 
-	iface := make([]string, 0)
+	iface := make(map[string]string)
+	if !jr.strictContainer(key, p.bytes, p.dtype, JSONObject, "map[string]string") {
+		return iface
+	}
 
 	switch p.dtype {
 	case JSONInt, JSONFloat, JSONBool, JSONString:
-		iface = append(iface, toString(p.bytes, p.dtype, jr.StrictStandards))
+		iface["0"] = jr.stringOf("", key, p.bytes, p.dtype)
 	case JSONArray, JSONObject:
 		for _, k := range p.keys {
-			iface = append(iface, toString(p.children[k].bytes, p.children[k].dtype, jr.StrictStandards))
+			var v string
+			if c := p.children[k]; jr.strictValue(key, k, c.bytes, c.dtype, JSONString, "string") {
+				v = jr.stringOf(key, k, c.bytes, c.dtype)
+			}
+			iface[k] = v
 		}
-	default:
-		iface = append(iface, "")
 	}
 
 	return iface
 }
 
-// ToStringSlice returns all top-level data as a string slice.
-func (jr *JSONReader) ToStringSlice() []string {
-	return jr.GetStringSlice("")
-}
-
 // ToMapStringString returns all top-level data as map of string onto string.
 func (jr *JSONReader) ToMapStringString() map[string]string {
-	p := jr.getChildByKey("")
-	iface := make(map[string]string)
+	return jr.GetMapStringString("")
+}
 
-	switch p.dtype {
-	case JSONInt, JSONFloat, JSONBool, JSONString:
-		iface["0"] = toString(p.bytes, p.dtype, jr.StrictStandards)
-	case JSONArray, JSONObject:
-		for _, k := range p.keys {
-			iface[k] = toString(p.children[k].bytes, p.children[k].dtype, jr.StrictStandards)
+// The to* conversion functions panic with the errors of their convert* counterparts. They serve the
+// JSONReader accessors, which have no error return, and are never used where an error can be returned
+// instead. Values which the options of a reader can reject, such as lossy numbers under
+// RejectLossyNumbers or invalid UTF-8 under RejectInvalidUTF8, are converted by intOf and stringOf,
+// which record the error rather than panicking.
+
+func toString(b []byte, t string, strict bool) string {
+	s, err := convertString(b, t, strict, ReplaceInvalidUTF8)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// stringOf converts a value of the reader at the key path given as parent and key to a string, as
+// toString does following jr.InvalidUTF8. A string which can't be converted, such as one holding
+// invalid UTF-8 under RejectInvalidUTF8, is recorded as a ConversionError, reported by Err, and read
+// as "".
+func (jr *JSONReader) stringOf(parent, key string, b []byte, t string) string {
+	s, err := jr.stringValue(b, t)
+	if err != nil {
+		jr.reject(joinPath(parent, key), b, t, "string")
+		return ""
+	}
+	return s
+}
+
+// stringValue is stringOf, returning any error, taking the zero copy fast path for strings without
+// escapes if WithZeroCopyStrings was given. rawData is a private copy which is never modified, so the
+// string can safely point into it.
+func (jr *JSONReader) stringValue(b []byte, t string) (string, error) {
+	if jr.rawStrings && t == JSONString {
+		return jr.rawString(b), nil
+	}
+
+	// Leading whitespace is trimmed by manualUnescapeString, so such strings take the usual path.
+	if !jr.zeroCopyStrings || t != JSONString || len(b) == 0 || isWhitespace(b[0]) || bytes.IndexByte(b, '\\') >= 0 ||
+		(jr.InvalidUTF8 != KeepInvalidUTF8 && !utf8.Valid(b)) {
+		return convertString(b, t, jr.StrictStandards, jr.InvalidUTF8)
+	}
+
+	// The root of a reader holding a string keeps its quotes.
+	if b[0] == '"' {
+		if len(b) < 2 || b[len(b)-1] != '"' || jr.StrictStandards {
+			return convertString(b, t, jr.StrictStandards, jr.InvalidUTF8)
 		}
+		b = b[1 : len(b)-1]
 	}
 
-	return iface
+	return bytesToString(b), nil
 }
 
-func toString(b []byte, t string, strict bool) string {
+// rawString returns the contents of the string b, with its escape sequences intact, for WithRawStrings.
+func (jr *JSONReader) rawString(b []byte) string {
+	// The root of a reader holding a string keeps its quotes.
+	if len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
+		b = b[1 : len(b)-1]
+	}
+
+	if jr.zeroCopyStrings {
+		return bytesToString(b)
+	}
+
+	return string(b)
+}
+
+// Cast the given byte array to string based on its JSON type. Invalid UTF-8 is handled according to
+// policy.
+func convertString(b []byte, t string, strict bool, policy InvalidUTF8) (string, error) {
 	if len(b) == 0 {
-		return ""
+		return "", nil
 	}
 
-	if strict {
+	// The values held by a JSONReader were validated as they were parsed, and have their quotes removed,
+	// so only quoted strings are checked here.
+	if strict && b[0] == '"' {
 		if !IsJSONString(b) {
-			panic(fmt.Errorf("invalid escape sequence in segment '%s'", truncate(b, 50)))
+			return "", fmt.Errorf("invalid escape sequence in segment '%s'", truncate(b, 50))
 		}
 	}
 
 	if t == JSONNull {
-		return ""
+		return "", nil
 	}
 
-	return manualUnescapeString(b)
+	return unescapeString(b, policy)
 }
 
 // manualUnescapeString unquotes a quoted string, and replaces any escaped quotes with plain quotes.
+// Invalid UTF-8 is handled as ReplaceInvalidUTF8 directs.
 func manualUnescapeString(raw []byte) string {
+	s, _ := unescapeString(raw, ReplaceInvalidUTF8)
+	return s
+}
+
+// unescapeString is manualUnescapeString, handling invalid UTF-8 and unpaired surrogates according to
+// policy. An error is only returned for RejectInvalidUTF8.
+func unescapeString(raw []byte, policy InvalidUTF8) (string, error) {
 	start := 0
 
 	if len(raw) < 2 {
-		return string(raw)
+		return validUTF8(raw, raw, policy)
 	}
 
 	// find the first non-whitespace character
@@ -236,7 +878,14 @@ func manualUnescapeString(raw []byte) string {
 
 	raw = raw[start:]
 
-	out := make([]byte, len(raw))
+	// The string is unescaped into a pooled buffer, and copied out at its final length.
+	buf := getUnescapeBuffer(len(raw))
+	defer putUnescapeBuffer(buf)
+	out := (*buf)[:len(raw)]
+
+	if end, ok := unescapeSlashes(out, raw, quotedString); ok {
+		return validUTF8(out[:end], raw, policy)
+	}
 
 	end := 0
 	for i := 0; i < len(raw); i++ {
@@ -266,7 +915,7 @@ func manualUnescapeString(raw []byte) string {
 			}
 
 			piece := raw[i+2 : i+6]
-			r, err := strconv.ParseInt(*(*string)(unsafe.Pointer(&piece)), 16, 32)
+			r, err := strconv.ParseInt(bytesToString(piece), 16, 32)
 			if err != nil {
 				out[end] = b
 				end++
@@ -277,48 +926,155 @@ func manualUnescapeString(raw []byte) string {
 
 			// https://unicodebook.readthedocs.io/unicode_encodings.html#utf-16-surrogate-pairs
 			// Unicode Surrogate Pair hex {D800-DBFF},{DC00-DFFF} dec {55296-56319},{56320-57343}
-			if i+11 < len(raw) && (r >= 55296 && r <= 56319) {
+			// The low surrogate must be the very next escape, or the high surrogate is unpaired.
+			if i+11 < len(raw) && (r >= 55296 && r <= 56319) && raw[i+6] == '\\' && raw[i+7] == 'u' {
 				piece := raw[i+8 : i+12]
-				r2, _ := strconv.ParseInt(*(*string)(unsafe.Pointer(&piece)), 16, 32)
+				r2, _ := strconv.ParseInt(bytesToString(piece), 16, 32)
 				if r2 >= 56320 && r2 <= 57343 {
 					length = 12
 					r = ((r - 0xD800) * 0x400) + (r2 - 0xDC00) + 0x10000
 				}
 			}
 
-			for _, bn := range []byte(string(rune(r))) {
-				out[end] = bn
-				end++
-			}
-			i += length - 1 // -1 to account for the incoming i++ following the continue
-			continue
+			switch {
+			case !utf16.IsSurrogate(rune(r)):
+				end += utf8.EncodeRune(out[end:], rune(r))
+			case policy == KeepInvalidUTF8:
+				// The three byte form of the surrogate, which utf8.EncodeRune refuses to write.
+				out[end] = byte(0xE0 | r>>12)
+				out[end+1] = byte(0x80 | (r>>6)&0x3F)
+				out[end+2] = byte(0x80 | r&0x3F)
+				end += 3
+			case policy == RejectInvalidUTF8:
+				return "", fmt.Errorf("unpaired surrogate '%s' in segment '%s'", raw[i:i+6], truncate(raw, 50))
+			default:
+				end += utf8.EncodeRune(out[end:], utf8.RuneError)
+			}
+
+			i += length - 1 // -1 to account for the incoming i++ following the continue
+			continue
+		}
+
+		switch c {
+		case '"':
+			out[end] = '"'
+		case 'n':
+			out[end] = '\n'
+		case 't':
+			out[end] = '\t'
+		case '\\':
+			out[end] = '\\'
+		case '/':
+			out[end] = '/'
+		case 'r':
+			out[end] = '\r'
+		case 'b':
+			out[end] = '\b'
+		case 'f':
+			out[end] = '\f'
+		default:
+			// An unknown escape has always decoded to a zero byte. It must be written, as the pooled
+			// buffer still holds the bytes of an earlier string.
+			out[end] = 0
+		}
+
+		end++
+		i++
+	}
+
+	return validUTF8(out[:end], raw, policy)
+}
+
+// validUTF8 returns the unescaped string s, from the raw string raw, with any invalid UTF-8 handled
+// according to policy. Unpaired surrogates have already been handled while unescaping.
+func validUTF8(s, raw []byte, policy InvalidUTF8) (string, error) {
+	if policy == KeepInvalidUTF8 || utf8.Valid(s) {
+		return string(s), nil
+	}
+
+	if policy == RejectInvalidUTF8 {
+		return "", fmt.Errorf("invalid UTF-8 in segment '%s'", truncate(raw, 50))
+	}
+
+	// Each invalid byte is replaced, as encoding/json does, rather than each run of them.
+	var b strings.Builder
+	b.Grow(len(s) + 8)
+	for len(s) > 0 {
+		r, size := utf8.DecodeRune(s)
+		if r == utf8.RuneError && size == 1 {
+			b.WriteRune(utf8.RuneError)
+		} else {
+			b.Write(s[:size])
+		}
+		s = s[size:]
+	}
+
+	return b.String(), nil
+}
+
+// unescapeBuffers holds the scratch buffers used by manualUnescapeString. Buffers larger than
+// maxPooledBuffer are not returned to the pool, so that one huge string doesn't pin its buffer.
+var unescapeBuffers = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+const maxPooledBuffer = 64 << 10
+
+func getUnescapeBuffer(n int) *[]byte {
+	buf := unescapeBuffers.Get().(*[]byte)
+	if cap(*buf) < n {
+		*buf = make([]byte, n)
+	}
+	return buf
+}
+
+func putUnescapeBuffer(buf *[]byte) {
+	if cap(*buf) <= maxPooledBuffer {
+		unescapeBuffers.Put(buf)
+	}
+}
+
+// unescapeSlashes is a fast path for manualUnescapeString, covering the common case (e.g. URLs)
+// where the only escape sequences are \/ and \". Rather than walking the string byte-by-byte,
+// each escape is located with IndexByte and the spans between them are copied in bulk into out,
+// which must be at least len(raw) long.
+//
+// A quoted string must end in its closing quote. Since the parser never produces a string
+// containing an unescaped quote, there is no need to search for the end of the string. ok is
+// false if any other escape sequence is found, in which case the caller must fall back to the
+// general path.
+func unescapeSlashes(out, raw []byte, quoted bool) (int, bool) {
+	if quoted {
+		if len(raw) == 0 || raw[len(raw)-1] != '"' {
+			return 0, false
+		}
+		raw = raw[:len(raw)-1]
+	}
+
+	end := 0
+	for {
+		i := bytes.IndexByte(raw, '\\')
+		if i < 0 {
+			return end + copy(out[end:], raw), true
+		}
+
+		// A trailing backslash means the closing quote was escaped.
+		if i == len(raw)-1 {
+			return 0, false
 		}
 
-		switch c {
-		case '"':
-			out[end] = '"'
-		case 'n':
-			out[end] = '\n'
-		case 't':
-			out[end] = '\t'
-		case '\\':
-			out[end] = '\\'
-		case '/':
-			out[end] = '/'
-		case 'r':
-			out[end] = '\r'
-		case 'b':
-			out[end] = '\b'
-		case 'f':
-			out[end] = '\f'
+		if c := raw[i+1]; c != '/' && c != '"' {
+			return 0, false
 		}
 
+		end += copy(out[end:], raw[:i])
+		out[end] = raw[i+1]
 		end++
-		i++
+		raw = raw[i+2:]
 	}
-
-	final := out[:end]
-	return *(*string)(unsafe.Pointer(&final))
 }
 
 /**
@@ -328,7 +1084,7 @@ func manualUnescapeString(raw []byte) string {
 // GetBool retrieves a given key as boolean, if it exists.
 func (jr *JSONReader) GetBool(key string) bool {
 	b, t, _ := jr.getDataByKey(key)
-	if b == nil {
+	if b == nil || !jr.strictValue("", key, b, t, JSONBool, "bool") {
 		return false
 	}
 	return toBool(b, t, jr.StrictStandards)
@@ -336,30 +1092,31 @@ func (jr *JSONReader) GetBool(key string) bool {
 
 // ToBool returns the top-level JSON into an integer.
 func (jr *JSONReader) ToBool() bool {
+	if !jr.strictValue("", "", jr.rawData, jr.Type, JSONBool, "bool") {
+		return false
+	}
 	return toBool(jr.rawData, jr.Type, jr.StrictStandards)
 }
 
 // GetBoolSlice retrieves a given key as a bool slice, if it exists.
 func (jr *JSONReader) GetBoolSlice(key string) []bool {
-	p := jr.getChildByKey(key)
-	if p == nil {
+	s, ok := appendSlice(jr, key, []bool{}, JSONBool, "[]bool", "bool", func(_, _ string, b []byte, t string) bool {
+		return toBool(b, t, jr.StrictStandards)
+	})
+	if !ok {
 		return nil
 	}
+	return s
+}
 
-	iface := make([]bool, 0)
-
-	switch p.dtype {
-	case JSONInt, JSONFloat, JSONBool, JSONString:
-		iface = append(iface, toBool(p.bytes, p.dtype, jr.StrictStandards))
-	case JSONArray, JSONObject:
-		for _, k := range p.keys {
-			iface = append(iface, toBool(p.children[k].bytes, p.children[k].dtype, jr.StrictStandards))
-		}
-	default:
-		iface = append(iface, false)
-	}
-
-	return iface
+// GetBoolSliceInto appends the elements of a given key to dst, as GetBoolSlice would return them,
+// and returns the extended slice. dst is returned unchanged if the key doesn't exist. Reusing dst
+// across calls avoids allocating a new slice each time.
+func (jr *JSONReader) GetBoolSliceInto(key string, dst []bool) []bool {
+	dst, _ = appendSlice(jr, key, dst, JSONBool, "[]bool", "bool", func(_, _ string, b []byte, t string) bool {
+		return toBool(b, t, jr.StrictStandards)
+	})
+	return dst
 }
 
 // ToBoolSlice returns all top-level data as a bool slice.
@@ -367,51 +1124,75 @@ func (jr *JSONReader) ToBoolSlice() []bool {
 	return jr.GetBoolSlice("")
 }
 
-// ToMapStringBool returns all top-level data as map of string onto bool.
-func (jr *JSONReader) ToMapStringBool() map[string]bool {
-	p := jr.getChildByKey("")
+// GetMapStringBool retrieves a given key as a map of string onto bool, if it exists.
+func (jr *JSONReader) GetMapStringBool(key string) map[string]bool {
+	p := jr.getChildByKey(key)
+	if p == nil {
+		return nil
+	}
+
 	iface := make(map[string]bool)
+	if !jr.strictContainer(key, p.bytes, p.dtype, JSONObject, "map[string]bool") {
+		return iface
+	}
 
 	switch p.dtype {
 	case JSONInt, JSONFloat, JSONBool, JSONString:
 		iface["0"] = toBool(p.bytes, p.dtype, jr.StrictStandards)
 	case JSONArray, JSONObject:
 		for _, k := range p.keys {
-			iface[k] = toBool(p.children[k].bytes, p.children[k].dtype, jr.StrictStandards)
+			var v bool
+			if c := p.children[k]; jr.strictValue(key, k, c.bytes, c.dtype, JSONBool, "bool") {
+				v = toBool(c.bytes, c.dtype, jr.StrictStandards)
+			}
+			iface[k] = v
 		}
 	}
 
 	return iface
 }
 
-// Cast the given byte array to bool based on its JSON type.
+// ToMapStringBool returns all top-level data as map of string onto bool.
+func (jr *JSONReader) ToMapStringBool() map[string]bool {
+	return jr.GetMapStringBool("")
+}
+
 func toBool(b []byte, t string, strict bool) bool {
+	v, err := convertBool(b, t, strict)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Cast the given byte array to bool based on its JSON type.
+func convertBool(b []byte, t string, strict bool) (bool, error) {
 	switch t {
 	case JSONBool:
-		return IsJSONTrue(b)
+		return IsJSONTrue(b), nil
 	case JSONInt:
-		return !(len(b) == 1 && b[0] == '0')
+		return !(len(b) == 1 && b[0] == '0'), nil
 	case JSONString:
 		if len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
 			b = b[1 : len(b)-1]
 		}
 
-		b, err := strconv.ParseBool(*(*string)(unsafe.Pointer(&b)))
+		b, err := strconv.ParseBool(bytesToString(b))
 		if err != nil {
-			return false
+			return false, nil
 		}
-		return b
+		return b, nil
 	case JSONFloat:
-		i, err := strconv.ParseFloat(*(*string)(unsafe.Pointer(&b)), 64)
+		i, err := strconv.ParseFloat(bytesToString(b), 64)
 		if err != nil {
 			if strict {
-				panic(err)
+				return false, err
 			}
-			return false
+			return false, nil
 		}
-		return i != 0
+		return i != 0, nil
 	default:
-		return false
+		return false, nil
 	}
 }
 
@@ -422,97 +1203,135 @@ func toBool(b []byte, t string, strict bool) bool {
 // GetInt retrieves a given key as an int, if it exists.
 func (jr *JSONReader) GetInt(key string) int {
 	b, t, _ := jr.getDataByKey(key)
-	if b == nil {
+	if b == nil || !jr.strictValue("", key, b, t, JSONInt, "int") {
 		return 0
 	}
-	return toInt(b, t, jr.StrictStandards)
+	return jr.intOf("", key, b, t)
 }
 
 // ToInt returns the top-level JSON into an integer.
 func (jr *JSONReader) ToInt() int {
-	return toInt(jr.rawData, jr.Type, jr.StrictStandards)
+	if !jr.strictValue("", "", jr.rawData, jr.Type, JSONInt, "int") {
+		return 0
+	}
+	return jr.intOf("", "", jr.rawData, jr.Type)
 }
 
 // GetIntSlice retrieves a given key as a int slice, if it exists.
 func (jr *JSONReader) GetIntSlice(key string) []int {
+	s, ok := appendSlice(jr, key, []int{}, JSONInt, "[]int", "int", func(parent, key string, b []byte, t string) int {
+		return jr.intOf(parent, key, b, t)
+	})
+	if !ok {
+		return nil
+	}
+	return s
+}
+
+// GetIntSliceInto appends the elements of a given key to dst, as GetIntSlice would return them,
+// and returns the extended slice. dst is returned unchanged if the key doesn't exist. Reusing dst
+// across calls avoids allocating a new slice each time.
+func (jr *JSONReader) GetIntSliceInto(key string, dst []int) []int {
+	dst, _ = appendSlice(jr, key, dst, JSONInt, "[]int", "int", func(parent, key string, b []byte, t string) int {
+		return jr.intOf(parent, key, b, t)
+	})
+	return dst
+}
+
+// ToIntSlice returns all top-level data as a int slice.
+func (jr *JSONReader) ToIntSlice() []int {
+	return jr.GetIntSlice("")
+}
+
+// GetMapStringInt retrieves a given key as a map of string onto int, if it exists.
+func (jr *JSONReader) GetMapStringInt(key string) map[string]int {
 	p := jr.getChildByKey(key)
 	if p == nil {
 		return nil
 	}
 
-	iface := make([]int, 0)
+	iface := make(map[string]int)
+	if !jr.strictContainer(key, p.bytes, p.dtype, JSONObject, "map[string]int") {
+		return iface
+	}
 
 	switch p.dtype {
 	case JSONInt, JSONFloat, JSONBool, JSONString:
-		iface = append(iface, toInt(p.bytes, p.dtype, jr.StrictStandards))
+		iface["0"] = jr.intOf("", key, p.bytes, p.dtype)
 	case JSONArray, JSONObject:
 		for _, k := range p.keys {
-			iface = append(iface, toInt(p.children[k].bytes, p.children[k].dtype, jr.StrictStandards))
+			var v int
+			if c := p.children[k]; jr.strictValue(key, k, c.bytes, c.dtype, JSONInt, "int") {
+				v = jr.intOf(key, k, c.bytes, c.dtype)
+			}
+			iface[k] = v
 		}
-	default:
-		iface = append(iface, 0)
 	}
 
 	return iface
 }
 
-// ToIntSlice returns all top-level data as a int slice.
-func (jr *JSONReader) ToIntSlice() []int {
-	return jr.GetIntSlice("")
-}
-
 // ToMapStringInt returns all top-level data as map of string onto int.
 func (jr *JSONReader) ToMapStringInt() map[string]int {
-	p := jr.getChildByKey("")
-	iface := make(map[string]int)
+	return jr.GetMapStringInt("")
+}
 
-	switch p.dtype {
-	case JSONInt, JSONFloat, JSONBool, JSONString:
-		iface["0"] = toInt(p.bytes, p.dtype, jr.StrictStandards)
-	case JSONArray, JSONObject:
-		for _, k := range p.keys {
-			iface[k] = toInt(p.children[k].bytes, p.children[k].dtype, jr.StrictStandards)
-		}
+// intOf converts a value of the reader at the key path given as parent and key to an int, following
+// jr.NumberConversion. A value which can't be converted, such as a lossy number under
+// RejectLossyNumbers, is recorded as a ConversionError, reported by Err, and read as 0.
+func (jr *JSONReader) intOf(parent, key string, b []byte, t string) int {
+	i, err := convertInt(b, t, jr.StrictStandards, jr.NumberConversion)
+	if err != nil {
+		jr.reject(joinPath(parent, key), b, t, "int")
+		return 0
 	}
+	return i
+}
 
-	return iface
+func toInt(b []byte, t string, strict bool, conv NumberConversion) int {
+	i, err := convertInt(b, t, strict, conv)
+	if err != nil {
+		panic(err)
+	}
+	return i
 }
 
-// Cast the given byte array to int based on its JSON type.
-func toInt(b []byte, t string, strict bool) int {
+// Cast the given byte array to int based on its JSON type. Numbers with a fractional part are
+// converted according to conv.
+func convertInt(b []byte, t string, strict bool, conv NumberConversion) (int, error) {
 	switch t {
 	case JSONNull, JSONObject, JSONArray:
-		return 0
+		return 0, nil
 	case JSONBool:
 		if IsJSONTrue(b) {
-			return 1
+			return 1, nil
 		}
-		return 0
+		return 0, nil
 	case JSONString:
 		b = trimString(b)
 		t = GetJSONType(b, 0)
 		if t != JSONString {
-			return toInt(b, t, strict)
+			return convertInt(b, t, strict, conv)
 		}
 	case JSONFloat:
-		i, err := strconv.ParseFloat(*(*string)(unsafe.Pointer(&b)), 64)
+		i, err := strconv.ParseFloat(bytesToString(b), 64)
 		if err != nil {
 			if strict {
-				panic(err)
+				return 0, err
 			}
-			return 0
+			return 0, nil
 		}
-		return int(i)
+		return conv.toInt(i, b)
 	}
 
-	i, err := strconv.ParseInt(*(*string)(unsafe.Pointer(&b)), 10, 64)
+	i, err := strconv.ParseInt(bytesToString(b), 10, 64)
 	if err != nil {
 		if strict {
-			panic(err)
+			return 0, err
 		}
-		return 0
+		return 0, nil
 	}
-	return int(i)
+	return int(i), nil
 }
 
 /**
@@ -522,7 +1341,7 @@ func toInt(b []byte, t string, strict bool) int {
 // GetFloat retrieves a given key as float64, if it exists.
 func (jr *JSONReader) GetFloat(key string) float64 {
 	b, t, _ := jr.getDataByKey(key)
-	if b == nil {
+	if b == nil || !jr.strictValue("", key, b, t, JSONFloat, "float64") {
 		return 0
 	}
 	return toFloat(b, t, jr.StrictStandards)
@@ -530,30 +1349,31 @@ func (jr *JSONReader) GetFloat(key string) float64 {
 
 // ToFloat returns the top-level JSON into a float64.
 func (jr *JSONReader) ToFloat() float64 {
+	if !jr.strictValue("", "", jr.rawData, jr.Type, JSONFloat, "float64") {
+		return 0
+	}
 	return toFloat(jr.rawData, jr.Type, jr.StrictStandards)
 }
 
 // GetFloatSlice retrieves a given key as a float64 slice, if it exists.
 func (jr *JSONReader) GetFloatSlice(key string) []float64 {
-	p := jr.getChildByKey(key)
-	if p == nil {
+	s, ok := appendSlice(jr, key, []float64{}, JSONFloat, "[]float64", "float64", func(_, _ string, b []byte, t string) float64 {
+		return toFloat(b, t, jr.StrictStandards)
+	})
+	if !ok {
 		return nil
 	}
+	return s
+}
 
-	iface := make([]float64, 0)
-
-	switch p.dtype {
-	case JSONInt, JSONFloat, JSONBool, JSONString:
-		iface = append(iface, toFloat(p.bytes, p.dtype, jr.StrictStandards))
-	case JSONArray, JSONObject:
-		for _, k := range p.keys {
-			iface = append(iface, toFloat(p.children[k].bytes, p.children[k].dtype, jr.StrictStandards))
-		}
-	default:
-		iface = append(iface, 0)
-	}
-
-	return iface
+// GetFloatSliceInto appends the elements of a given key to dst, as GetFloatSlice would return them,
+// and returns the extended slice. dst is returned unchanged if the key doesn't exist. Reusing dst
+// across calls avoids allocating a new slice each time.
+func (jr *JSONReader) GetFloatSliceInto(key string, dst []float64) []float64 {
+	dst, _ = appendSlice(jr, key, dst, JSONFloat, "[]float64", "float64", func(_, _ string, b []byte, t string) float64 {
+		return toFloat(b, t, jr.StrictStandards)
+	})
+	return dst
 }
 
 // ToFloatSlice returns all top-level data as a float64 slice.
@@ -561,47 +1381,130 @@ func (jr *JSONReader) ToFloatSlice() []float64 {
 	return jr.GetFloatSlice("")
 }
 
-// ToMapStringFloat returns all top-level data as map of string onto float64.
-func (jr *JSONReader) ToMapStringFloat() map[string]float64 {
-	p := jr.getChildByKey("")
+// GetMapStringFloat retrieves a given key as a map of string onto float64, if it exists.
+func (jr *JSONReader) GetMapStringFloat(key string) map[string]float64 {
+	p := jr.getChildByKey(key)
+	if p == nil {
+		return nil
+	}
+
 	iface := make(map[string]float64)
+	if !jr.strictContainer(key, p.bytes, p.dtype, JSONObject, "map[string]float64") {
+		return iface
+	}
 
 	switch p.dtype {
 	case JSONInt, JSONFloat, JSONBool, JSONString:
 		iface["0"] = toFloat(p.bytes, p.dtype, jr.StrictStandards)
 	case JSONArray, JSONObject:
 		for _, k := range p.keys {
-			iface[k] = toFloat(p.children[k].bytes, p.children[k].dtype, jr.StrictStandards)
+			var v float64
+			if c := p.children[k]; jr.strictValue(key, k, c.bytes, c.dtype, JSONFloat, "float64") {
+				v = toFloat(c.bytes, c.dtype, jr.StrictStandards)
+			}
+			iface[k] = v
 		}
 	}
 
 	return iface
 }
 
-// Cast the given byte array to float64 based on its JSON type.
+// ToMapStringFloat returns all top-level data as map of string onto float64.
+func (jr *JSONReader) ToMapStringFloat() map[string]float64 {
+	return jr.GetMapStringFloat("")
+}
+
 func toFloat(b []byte, t string, strict bool) float64 {
+	f, err := convertFloat(b, t, strict)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Cast the given byte array to float64 based on its JSON type.
+func convertFloat(b []byte, t string, strict bool) (float64, error) {
 	switch t {
 	case JSONNull, JSONObject, JSONArray:
-		return 0.0
+		return 0.0, nil
 	case JSONBool:
 		if IsJSONTrue(b) {
-			return 1.0
+			return 1.0, nil
 		}
-		return 0.0
+		return 0.0, nil
 	default:
 		if t == JSONString {
 			b = trimString(b)
 		}
 
-		i, err := strconv.ParseFloat(*(*string)(unsafe.Pointer(&b)), 64)
+		i, err := strconv.ParseFloat(bytesToString(b), 64)
 		if err != nil {
 			if strict {
-				panic(err)
+				return 0.0, err
 			}
-			return 0.0
+			return 0.0, nil
 		}
-		return i
+		return i, nil
+	}
+}
+
+/**
+ * Number Functions
+ */
+
+// GetNumber retrieves a given key as the literal text of a number, if it exists, without converting
+// it to a float64 or int. This preserves 64-bit IDs and high precision decimals such as
+// 6.754210771357157538e18 for forwarding downstream. A string holding a valid number gives the
+// number, unless StrictStandards is set. Any other value gives "".
+func (jr *JSONReader) GetNumber(key string) json.Number {
+	b, t, _ := jr.getDataByKey(key)
+	if b == nil {
+		return ""
+	}
+	return jr.numberOf(key, b, t, "json.Number")
+}
+
+// ToNumber returns the top-level JSON as the literal text of a number, as GetNumber does.
+func (jr *JSONReader) ToNumber() json.Number {
+	if jr.Empty {
+		return ""
+	}
+	return jr.numberOf("", jr.rawData, jr.Type, "json.Number")
+}
+
+// numberOf converts the value at key to a json.Number, recording a ConversionError for the Go type
+// target under StrictStandards for anything other than a number.
+func (jr *JSONReader) numberOf(key string, b []byte, t, target string) json.Number {
+	if t == JSONInt || t == JSONFloat {
+		return json.Number(trim(b))
+	}
+
+	if jr.StrictStandards {
+		jr.reject(key, b, t, target)
+		return ""
+	}
+
+	n, _ := quotedNumber(b, t)
+	return n
+}
+
+// quotedNumber returns the number held by the string b, of JSON type t, and whether it holds one.
+func quotedNumber(b []byte, t string) (json.Number, bool) {
+	if t != JSONString {
+		return "", false
+	}
+
+	// The root of a reader holding a string keeps its quotes.
+	inner := b
+	if q := trim(b); len(q) >= 2 && q[0] == '"' {
+		inner = trimString(q)
 	}
+
+	if nt := GetJSONType(inner, 0); (nt == JSONInt || nt == JSONFloat) && len(trim(inner)) == len(inner) && IsJSON(inner) {
+		return json.Number(inner), true
+	}
+
+	return "", false
 }
 
 /**
@@ -629,7 +1532,7 @@ func (jr *JSONReader) ToByteSlice() []byte {
 // GetByteSlices retrieves a given key as a slice of byte slices, if it exists.
 func (jr *JSONReader) GetByteSlices(key string) [][]byte {
 	p := jr.getChildByKey(key)
-	if p == nil {
+	if p == nil || !jr.strictContainer(key, p.bytes, p.dtype, JSONArray, "[][]byte") {
 		return nil
 	}
 
@@ -651,6 +1554,10 @@ func (jr *JSONReader) GetByteSlices(key string) [][]byte {
 
 // ToByteSlices returns all top-level data as a slice of byte slices.
 func (jr *JSONReader) ToByteSlices() [][]byte {
+	if !jr.strictContainer("", jr.rawData, jr.Type, JSONArray, "[][]byte") {
+		return nil
+	}
+
 	iface := make([][]byte, 0)
 
 	switch jr.Type {
@@ -672,29 +1579,42 @@ func (jr *JSONReader) ToByteSlices() [][]byte {
 	return iface
 }
 
-// ToMapStringBytes returns all top-level data as map of string onto []byte.
-func (jr *JSONReader) ToMapStringBytes() map[string][]byte {
+// GetMapStringBytes retrieves a given key as a map of string onto []byte, if it exists.
+func (jr *JSONReader) GetMapStringBytes(key string) map[string][]byte {
+	p := jr.getChildByKey(key)
+	if p == nil {
+		return nil
+	}
+
 	iface := make(map[string][]byte, 0)
+	if !jr.strictContainer(key, p.bytes, p.dtype, JSONObject, "map[string][]byte") {
+		return iface
+	}
 
-	switch jr.Type {
+	switch p.dtype {
 	case JSONArray, JSONObject:
-		for _, k := range jr.Keys {
-			iface[k] = jr.parsed[k].bytes
+		for _, k := range p.keys {
+			iface[k] = p.children[k].bytes
 		}
 	case JSONString:
-		b := jr.rawData[:]
+		b := p.bytes[:]
 		if len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
 			b = b[1 : len(b)-1]
 		}
 
 		iface["0"] = b
 	default:
-		iface["0"] = jr.rawData
+		iface["0"] = p.bytes
 	}
 
 	return iface
 }
 
+// ToMapStringBytes returns all top-level data as map of string onto []byte.
+func (jr *JSONReader) ToMapStringBytes() map[string][]byte {
+	return jr.GetMapStringBytes("")
+}
+
 /**
  * Empty Interface Functions
  */
@@ -712,7 +1632,7 @@ func (jr *JSONReader) ToInterface() interface{} {
 // GetInterfaceSlice returns the given key as an interface{} slice.
 func (jr *JSONReader) GetInterfaceSlice(key string) []interface{} {
 	b, t, _ := jr.getDataByKey(key)
-	if b == nil {
+	if b == nil || !jr.strictContainer(key, b, t, JSONArray, "[]interface{}") {
 		return nil
 	}
 
@@ -735,6 +1655,10 @@ func (jr *JSONReader) GetInterfaceSlice(key string) []interface{} {
 
 // ToInterfaceSlice returns all top-level data as an interface{} slice.
 func (jr *JSONReader) ToInterfaceSlice() []interface{} {
+	if !jr.strictContainer("", jr.rawData, jr.Type, JSONArray, "[]interface{}") {
+		return nil
+	}
+
 	var slice []interface{}
 	switch jr.Type {
 	case JSONArray:
@@ -755,7 +1679,7 @@ func (jr *JSONReader) ToInterfaceSlice() []interface{} {
 // GetMapStringInterface retrieves a given key as a map of string onto interface{}, if said key exists.
 func (jr *JSONReader) GetMapStringInterface(key string) map[string]interface{} {
 	b, t, _ := jr.getDataByKey(key)
-	if b == nil {
+	if b == nil || !jr.strictContainer(key, b, t, JSONObject, "map[string]interface{}") {
 		return nil
 	}
 
@@ -780,6 +1704,10 @@ func (jr *JSONReader) GetMapStringInterface(key string) map[string]interface{} {
 
 // ToMapStringInterface retrieves a given key as a map of string onto interface{}, if said key exists.
 func (jr *JSONReader) ToMapStringInterface() map[string]interface{} {
+	if !jr.strictContainer("", jr.rawData, jr.Type, JSONObject, "map[string]interface{}") {
+		return nil
+	}
+
 	var slice map[string]interface{}
 	switch jr.Type {
 	case JSONArray:
@@ -808,13 +1736,13 @@ func (jr *JSONReader) getIface(key string) interface{} {
 
 	switch p.dtype {
 	case JSONInt:
-		return toInt(p.bytes, p.dtype, jr.StrictStandards)
+		return jr.intOf("", key, p.bytes, p.dtype)
 	case JSONFloat:
 		return toFloat(p.bytes, p.dtype, jr.StrictStandards)
 	case JSONBool:
 		return toBool(p.bytes, p.dtype, jr.StrictStandards)
 	case JSONString:
-		return toString(p.bytes, p.dtype, jr.StrictStandards)
+		return jr.stringOf("", key, p.bytes, p.dtype)
 	case JSONObject:
 		o, _ := jr.getObject(key)
 		return o
@@ -844,17 +1772,17 @@ func (jr *JSONReader) getObject(key string) (map[string]interface{}, []string) {
 
 		switch v.dtype {
 		case JSONInt:
-			iface[k] = toInt(v.bytes, v.dtype, jr.StrictStandards)
+			iface[k] = jr.intOf(key, k, v.bytes, v.dtype)
 		case JSONFloat:
 			iface[k] = toFloat(v.bytes, v.dtype, jr.StrictStandards)
 		case JSONBool:
 			iface[k] = toBool(v.bytes, v.dtype, jr.StrictStandards)
 		case JSONString:
-			iface[k] = toString(v.bytes, v.dtype, jr.StrictStandards)
+			iface[k] = jr.stringOf(key, k, v.bytes, v.dtype)
 		case JSONObject:
-			iface[k], _ = jr.Get(key).getObject(k)
+			iface[k], _ = jr.member(key, k, &v).getObject("")
 		case JSONArray:
-			iface[k] = jr.Get(key).getSlice(k)
+			iface[k] = jr.member(key, k, &v).getSlice("")
 		default:
 			iface[k] = nil
 		}
@@ -881,18 +1809,18 @@ func (jr *JSONReader) getSlice(key string) []interface{} {
 
 		switch v.dtype {
 		case JSONInt:
-			iface = append(iface, toInt(v.bytes, v.dtype, jr.StrictStandards))
+			iface = append(iface, jr.intOf(key, k, v.bytes, v.dtype))
 		case JSONFloat:
 			iface = append(iface, toFloat(v.bytes, v.dtype, jr.StrictStandards))
 		case JSONBool:
 			iface = append(iface, toBool(v.bytes, v.dtype, jr.StrictStandards))
 		case JSONString:
-			iface = append(iface, toString(v.bytes, v.dtype, jr.StrictStandards))
+			iface = append(iface, jr.stringOf(key, k, v.bytes, v.dtype))
 		case JSONObject:
-			o, _ := jr.Get(key).getObject(k)
+			o, _ := jr.member(key, k, &v).getObject("")
 			iface = append(iface, o)
 		case JSONArray:
-			iface = append(iface, jr.Get(key).getSlice(k))
+			iface = append(iface, jr.member(key, k, &v).getSlice(""))
 		default:
 			iface = append(iface, nil)
 		}
@@ -938,9 +1866,18 @@ func (jr *JSONReader) getDataByKey(key string) ([]byte, string, []string) {
 
 // Return the child node at the associated key. Use empty string ("") to represent the root.
 func (jr *JSONReader) getChildByKey(key string) *parsed {
+	p, ok := jr.child(key)
+	if !ok {
+		return nil
+	}
+	return &p
+}
 
+// child returns the child node at the associated key by value, which unlike getChildByKey doesn't
+// allocate.
+func (jr *JSONReader) child(key string) (parsed, bool) {
 	if key == "" {
-		return &parsed{bytes: jr.rawData, dtype: jr.Type, children: jr.parsed, keys: jr.Keys}
+		return parsed{bytes: jr.rawData, dtype: jr.Type, children: jr.parsed, dups: jr.dups, keys: jr.Keys, pos: jr.position}, true
 	}
 
 	var p parsed
@@ -951,13 +1888,13 @@ func (jr *JSONReader) getChildByKey(key string) *parsed {
 	for b := range key {
 		if b == len(key)-1 {
 			if p, isset = search[key[a:b+1]]; !isset {
-				return nil
+				return p, false
 			}
 		}
 
 		if key[b] == '.' {
 			if p, isset = search[key[a:b]]; !isset {
-				return nil
+				return p, false
 			}
 
 			search = p.children
@@ -965,36 +1902,112 @@ func (jr *JSONReader) getChildByKey(key string) *parsed {
 		}
 	}
 
-	return &p
+	return p, true
+}
+
+// appendSlice appends the elements of a given key to dst, converted by conv, and reports whether the
+// key exists and may be read as a slice. dst is grown at most once. A scalar gives a single element,
+// as with the Get*Slice functions.
+func appendSlice[T any](jr *JSONReader, key string, dst []T, want, sliceTarget, target string, conv func(parent, key string, b []byte, t string) T) ([]T, bool) {
+	p, ok := jr.child(key)
+	if !ok || !jr.strictContainer(key, p.bytes, p.dtype, JSONArray, sliceTarget) {
+		return dst, false
+	}
+
+	var zero T
+	switch p.dtype {
+	case JSONInt, JSONFloat, JSONBool, JSONString:
+		dst = append(dst, conv("", key, p.bytes, p.dtype))
+	case JSONArray, JSONObject:
+		if n := len(dst) + len(p.keys); n > cap(dst) {
+			grown := make([]T, len(dst), n)
+			copy(grown, dst)
+			dst = grown
+		}
+
+		for _, k := range p.keys {
+			v := zero
+			if c := p.children[k]; jr.strictValue(key, k, c.bytes, c.dtype, want, target) {
+				v = conv(key, k, c.bytes, c.dtype)
+			}
+			dst = append(dst, v)
+		}
+	default:
+		dst = append(dst, zero)
+	}
+
+	return dst, true
 }
 
-// Turn a byte string into the given interface type. Objects and Arrays are expensive.
 func toIface(b []byte, t string, strict bool) interface{} {
+	v, err := convertIface(b, t, strict)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Turn a byte string into the given interface type. Objects and Arrays are expensive.
+func convertIface(b []byte, t string, strict bool) (interface{}, error) {
+	return convertInternedIface(b, t, ifaceOptions{strict: strict})
+}
+
+// ifaceOptions are the settings of convertInternedIface. keys, if set, supplies the keys of objects,
+// and nonFinite, if set, the values of non-finite literals.
+type ifaceOptions struct {
+	strict     bool
+	policy     InvalidUTF8
+	bigNumbers bool
+	keys       *keyInterner
+	nonFinite  *nonFiniteNumbers
+
+	// pairs decodes objects as Pairs.
+	pairs bool
+}
+
+// convertInternedIface is convertIface, applying the Options of an unmarshal given in opts.
+func convertInternedIface(b []byte, t string, opts ifaceOptions) (interface{}, error) {
+	strict, keys := opts.strict, opts.keys
+
+	if f, ok := opts.nonFinite.lookup(b); ok {
+		return f, nil
+	}
+
+	if opts.bigNumbers && (t == JSONInt || t == JSONFloat) {
+		if n, ok := bigNumber(b, t); ok {
+			return n, nil
+		}
+	}
+
 	switch t {
 	case JSONInt:
-		return toInt(b, t, strict)
+		return convertInt(b, t, strict, TruncateNumbers)
 	case JSONFloat:
-		return toFloat(b, t, strict)
+		return convertFloat(b, t, strict)
 	case JSONBool:
-		return toBool(b, t, strict)
+		return convertBool(b, t, strict)
 	case JSONString:
-		return toString(b, t, strict)
+		return convertString(b, t, strict, opts.policy)
 	case JSONObject:
+		if opts.pairs {
+			return convertPairs(b, opts)
+		}
+
 		iface := make(map[string]interface{})
 		if IsEmptyObject(b) {
-			return iface
+			return iface, nil
 		}
 
 		expectsValue := true
 		start := 1
 		for start < len(b) {
-			v, k, t, pos, err := extractObjectMember(b, start)
+			v, k, t, pos, err := extractInternedMember(b, start, keys)
 			start = findTerminator(b, pos)
 			if err != nil {
-				panic(err)
+				return nil, err
 			}
 			if pos >= len(b) || start < 0 {
-				panic(fmt.Errorf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, truncate(b, 50)))
+				return nil, fmt.Errorf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, truncate(b, 50))
 			}
 
 			expectsValue = false
@@ -1002,18 +2015,20 @@ func toIface(b []byte, t string, strict bool) interface{} {
 				expectsValue = true
 			}
 
-			iface[k] = toIface(v, t, strict)
+			if iface[k], err = convertInternedIface(v, t, opts); err != nil {
+				return nil, err
+			}
 		}
 
 		if expectsValue {
-			panic(fmt.Errorf("expected array terminator '}' at position '%d' in segment '%s'", start-1, truncate(b, 50)))
+			return nil, fmt.Errorf("expected array terminator '}' at position '%d' in segment '%s'", start-1, truncate(b, 50))
 		}
 
-		return iface
+		return iface, nil
 	case JSONArray:
 		iface := make([]interface{}, 0)
 		if IsEmptyArray(b) {
-			return iface
+			return iface, nil
 		}
 
 		expectsValue := true
@@ -1022,10 +2037,10 @@ func toIface(b []byte, t string, strict bool) interface{} {
 			v, t, pos, err := extractValue(b, start)
 			start = findTerminator(b, pos)
 			if err != nil {
-				panic(err)
+				return nil, err
 			}
-			if pos >= len(b) {
-				panic(fmt.Errorf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, truncate(b, 50)))
+			if pos >= len(b) || start < 0 {
+				return nil, fmt.Errorf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, truncate(b, 50))
 			}
 
 			expectsValue = false
@@ -1033,16 +2048,20 @@ func toIface(b []byte, t string, strict bool) interface{} {
 				expectsValue = true
 			}
 
-			iface = append(iface, toIface(v, t, strict))
+			e, err := convertInternedIface(v, t, opts)
+			if err != nil {
+				return nil, err
+			}
+			iface = append(iface, e)
 		}
 
 		if expectsValue {
-			panic(fmt.Errorf("expected array terminator ']' at position '%d' in segment '%s'", start-1, truncate(b, 50)))
+			return nil, fmt.Errorf("expected array terminator ']' at position '%d' in segment '%s'", start-1, truncate(b, 50))
 		}
 
-		return iface
+		return iface, nil
 	default:
-		return nil
+		return nil, nil
 	}
 }
 
@@ -1056,3 +2075,36 @@ func stringInArray(needle string, haystack []string) bool {
 
 	return false
 }
+
+// hasWildcard returns whether the key path has a "*" segment.
+func hasWildcard(key string) bool {
+	return key == "*" || strings.HasPrefix(key, "*.") || strings.HasSuffix(key, ".*") || strings.Contains(key, ".*.")
+}
+
+// expandPath returns the concrete key paths matching the given key path, in document order.
+// A "*" segment matches every child of an object or array. Paths which do not exist are omitted.
+func (jr *JSONReader) expandPath(pattern string) []string {
+	var out []string
+
+	var walk func(p parsed, prefix string, segments []string)
+	walk = func(p parsed, prefix string, segments []string) {
+		if len(segments) == 0 {
+			out = append(out, prefix)
+			return
+		}
+
+		if segments[0] == "*" {
+			for _, k := range uniqueString(p.keys, true) {
+				walk(p.children[k], joinPath(prefix, k), segments[1:])
+			}
+			return
+		}
+
+		if c, ok := p.children[segments[0]]; ok {
+			walk(c, joinPath(prefix, segments[0]), segments[1:])
+		}
+	}
+
+	walk(*jr.getChildByKey(""), "", strings.Split(pattern, "."))
+	return out
+}
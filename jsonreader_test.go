@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -66,6 +67,107 @@ func TestKeyExists(t *testing.T) {
 	})
 }
 
+func TestIsNull(t *testing.T) {
+	r, err := NewJSONReader([]byte(`{"a": null, "b": "", "c": {}, "d": [null], "e": {"f": null}}`))
+	assert.Nil(t, err)
+
+	assert.True(t, r.IsNull("a"))
+	assert.True(t, r.IsNull("d.0"))
+	assert.True(t, r.IsNull("e.f"))
+	assert.False(t, r.IsNull("b"))
+	assert.False(t, r.IsNull("c"))
+	assert.False(t, r.IsNull("missing"))
+	assert.False(t, r.IsNull(""))
+
+	// Missing, null, and present values are distinguishable through Get.
+	assert.True(t, r.Get("missing").Empty)
+	assert.False(t, r.Get("a").Empty)
+	assert.True(t, r.Get("a").IsNull(""))
+	assert.False(t, r.Get("c").Empty)
+	assert.False(t, r.Get("c").IsNull(""))
+
+	n, err := NewJSONReader([]byte(`null`))
+	assert.Nil(t, err)
+	assert.False(t, n.Empty)
+	assert.True(t, n.IsNull(""))
+}
+
+func TestEmptyContainers(t *testing.T) {
+	for _, s := range []string{`{}`, ` [ ] `} {
+		r, err := NewJSONReader([]byte(s))
+		assert.Nil(t, err)
+		assert.False(t, r.Empty)
+		assert.Equal(t, 0, len(r.Keys))
+		assert.Equal(t, strings.TrimSpace(s), string(r.RawBytes("")))
+	}
+
+	r, err := NewJSONReader([]byte(`{}`))
+	assert.Nil(t, err)
+	assert.Equal(t, JSONObject, r.TypeOf(""))
+	assert.Equal(t, map[string]interface{}{}, r.ToMapStringInterface())
+}
+
+func TestTypeOfAndRawBytes(t *testing.T) {
+	r, err := NewJSONReader(readerTestData)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		key   string
+		dtype string
+		raw   string
+	}{
+		{key: "empty_string", dtype: JSONString, raw: `""`},
+		{key: "string", dtype: JSONString, raw: `"some string"`},
+		{key: "int", dtype: JSONInt, raw: `17`},
+		{key: "bool", dtype: JSONBool, raw: `true`},
+		{key: "null", dtype: JSONNull, raw: `null`},
+		{key: "float", dtype: JSONFloat, raw: `22.83`},
+		{key: "object", dtype: JSONObject, raw: `{ "a": "b", "c": "d" }`},
+		{key: "int_slice", dtype: JSONArray, raw: `[ -1, 0, 1, 2, 3, 4 ]`},
+		{key: "complex.5.c", dtype: JSONString, raw: `"d"`},
+		{key: "complex.6.0", dtype: JSONString, raw: `"s"`},
+		{key: "missing", dtype: JSONInvalid, raw: ""},
+		{key: "complex.5.c.d", dtype: JSONInvalid, raw: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			assert.Equal(t, tc.dtype, r.TypeOf(tc.key))
+
+			if tc.raw == "" {
+				assert.Nil(t, r.RawBytes(tc.key))
+				return
+			}
+			assert.Equal(t, tc.raw, string(r.RawBytes(tc.key)))
+		})
+	}
+
+	t.Run("Root", func(t *testing.T) {
+		assert.Equal(t, JSONObject, r.TypeOf(""))
+		assert.Equal(t, readerTestData, r.RawBytes(""))
+
+		s, err := NewJSONReader([]byte(` "a\"b" `))
+		assert.Nil(t, err)
+		assert.Equal(t, JSONString, s.TypeOf(""))
+		assert.Equal(t, `"a\"b"`, string(s.RawBytes("")))
+
+		// A string reader from Get holds the string without its quotes.
+		assert.Equal(t, `"some string"`, string(r.Get("string").RawBytes("")))
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		b := r.RawBytes("object")
+		b[0] = 'x'
+		assert.Equal(t, `{ "a": "b", "c": "d" }`, string(r.RawBytes("object")))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		e := &JSONReader{Empty: true}
+		assert.Equal(t, JSONInvalid, e.TypeOf(""))
+		assert.Nil(t, e.RawBytes(""))
+	})
+}
+
 func TestGet(t *testing.T) {
 	t.Run("Missing Key", func(t *testing.T) {
 		r, err := NewJSONReader(readerTestData)
@@ -115,6 +217,40 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestGetAll(t *testing.T) {
+	data := []byte(`{
+		"to": "a@example.com",
+		"cc": "c@example.com",
+		"to": "b@example.com",
+		"headers": {"x": 1, "x": [2], "x": {"y": 3}},
+		"list": [{"k": 1, "k": 2}]
+	}`)
+
+	r, err := NewJSONReader(data)
+	assert.Nil(t, err)
+
+	var to []string
+	for _, v := range r.GetAll("to") {
+		to = append(to, v.ToString())
+	}
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, to)
+	assert.Equal(t, "b@example.com", r.GetString("to"))
+
+	headers := r.GetAll("headers.x")
+	assert.Len(t, headers, 3)
+	assert.Equal(t, 1, headers[0].ToInt())
+	assert.Equal(t, []int{2}, headers[1].ToIntSlice())
+	assert.Equal(t, 3, headers[2].GetInt("y"))
+
+	assert.Len(t, r.GetAll("cc"), 1)
+	assert.Len(t, r.GetAll("list.0.k"), 2)
+	assert.Len(t, r.Get("list").GetAll("0.k"), 2)
+	assert.Len(t, r.Get("headers").GetAll("x"), 3)
+	assert.Nil(t, r.GetAll("missing"))
+	assert.Nil(t, r.GetAll("to.missing"))
+	assert.Nil(t, r.GetAll(""))
+}
+
 func TestGetCollection(t *testing.T) {
 	t.Run("Missing Key", func(t *testing.T) {
 		r, err := NewJSONReader(readerTestData)
@@ -145,6 +281,109 @@ func TestGetCollection(t *testing.T) {
 		assert.Equal(t, 2.2, c[4].GetFloat(``))
 		assert.Equal(t, `d`, c[5].Get(`c`).GetString(``))
 	})
+
+	t.Run("Wildcard", func(t *testing.T) {
+		r, err := NewJSONReader([]byte(`{"items": [{"id": 1, "data": {"assets": [{"url": "a"}, {"url": "b"}]}}, {"id": 2, "data": {"assets": []}}, {"id": 3, "data": {"assets": [{"url": "c"}]}}]}`))
+		assert.Nil(t, err)
+
+		c := r.GetCollection("items.*.data.assets.0")
+		assert.Len(t, c, 2)
+		assert.Equal(t, "a", c[0].GetString("url"))
+		assert.Equal(t, "c", c[1].GetString("url"))
+
+		ids := r.GetCollection("items.*.id")
+		assert.Len(t, ids, 3)
+		assert.Equal(t, 3, ids[2].ToInt())
+
+		urls := r.GetCollection("items.*.data.assets.*.url")
+		assert.Len(t, urls, 3)
+		assert.Equal(t, "b", urls[1].ToString())
+
+		objects, _ := NewJSONReader(readerTestData)
+		assert.Equal(t, []string{"f", "j", "n"}, []string{objects.GetCollection("objects.*.e")[0].ToString(), objects.GetCollection("objects.*.i")[0].ToString(), objects.GetCollection("objects.*.m")[0].ToString()})
+		assert.Len(t, objects.GetCollection("object.*"), 2)
+		assert.Equal(t, []JSONReader(nil), r.GetCollection("items.*.missing"))
+	})
+
+	t.Run("Wildcard Strict", func(t *testing.T) {
+		r, err := NewJSONReader([]byte(`{"items": [{"n": "1"}, {"n": 2}]}`))
+		assert.Nil(t, err)
+		r.StrictStandards = true
+
+		c := r.GetCollection("items.*.n")
+		assert.Equal(t, 0, c[0].ToInt())
+		assert.Equal(t, 2, c[1].ToInt())
+		assert.EqualError(t, r.Err(), "key 'items.0.n' with string value '1' can not be converted to int")
+	})
+}
+
+func TestCountAndSlice(t *testing.T) {
+	r, err := NewJSONReader([]byte(`{"items": [{"id": 0}, "one", 2, [3], {"id": 4}], "object": {"a": 1, "b": 2}, "empty": [], "n": 5}`))
+	assert.Nil(t, err)
+
+	assert.Equal(t, 5, r.Count("items"))
+	assert.Equal(t, 2, r.Count("object"))
+	assert.Equal(t, 0, r.Count("empty"))
+	assert.Equal(t, 0, r.Count("n"))
+	assert.Equal(t, 0, r.Count("missing"))
+	assert.Equal(t, 4, r.Count(""))
+	assert.Equal(t, 1, r.Count("items.3"))
+
+	testCases := []struct {
+		name          string
+		offset, limit int
+		expected      string
+	}{
+		{"Window", 1, 2, `["one",2]`},
+		{"Start", 0, 1, `[{"id": 0}]`},
+		{"Past Limit", 3, 10, `[[3],{"id": 4}]`},
+		{"No Limit", 2, -1, `[2,[3],{"id": 4}]`},
+		{"Negative Offset", -5, 1, `[{"id": 0}]`},
+		{"Past End", 7, 2, `[]`},
+		{"Zero Limit", 1, 0, `[]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := r.Slice("items", tc.offset, tc.limit)
+			assert.False(t, s.Empty)
+			assert.Equal(t, JSONArray, s.Type)
+			assert.Equal(t, tc.expected, string(s.RawBytes("")))
+			assert.True(t, IsJSON(s.RawBytes("")))
+			assert.Equal(t, s.Count(""), len(s.Keys))
+		})
+	}
+
+	s := r.Slice("items", 3, 2)
+	assert.Equal(t, []string{"0", "1"}, s.Keys)
+	assert.Equal(t, 3, s.GetInt("0.0"))
+	assert.Equal(t, 4, s.GetInt("1.id"))
+	assert.Equal(t, []interface{}{[]interface{}{3}, map[string]interface{}{"id": 4}}, s.ToInterface())
+	assert.Len(t, s.GetCollection(""), 2)
+
+	assert.True(t, r.Slice("object", 0, 1).Empty)
+	assert.True(t, r.Slice("missing", 0, 1).Empty)
+}
+
+func TestPluck(t *testing.T) {
+	r, err := NewJSONReader([]byte(`{"items": [{"id": 1, "score": 1.5, "ok": true, "data": {"title": "a"}}, {"id": "2", "data": {}}, {"id": 3, "score": 2, "ok": false, "data": {"title": "c"}}], "groups": [{"items": [{"id": 4}]}, {"items": [{"id": 5}, {"id": 6}]}]}`))
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"a", "", "c"}, r.Pluck("items", "data.title"))
+	assert.Equal(t, []int{1, 2, 3}, r.PluckInt("items", "id"))
+	assert.Equal(t, []float64{1.5, 0, 2}, r.PluckFloat("items", "score"))
+	assert.Equal(t, []bool{true, false, false}, r.PluckBool("items", "ok"))
+	assert.Equal(t, []interface{}{map[string]interface{}{"title": "a"}, map[string]interface{}{}, map[string]interface{}{"title": "c"}}, r.PluckInterface("items", "data"))
+	assert.Equal(t, []int{4, 5, 6}, r.PluckInt("groups.*.items.*", "id"))
+	assert.Equal(t, []string(nil), r.Pluck("missing", "id"))
+
+	t.Run("Strict", func(t *testing.T) {
+		r.StrictStandards = true
+		defer func() { r.StrictStandards = false }()
+
+		assert.Equal(t, []int{1, 0, 3}, r.PluckInt("items", "id"))
+		assert.EqualError(t, r.Err(), "key 'items.1.id' with string value '2' can not be converted to int")
+	})
 }
 
 func TestGetString(t *testing.T) {
@@ -345,6 +584,38 @@ func TestToStringSlice(t *testing.T) {
 	}
 }
 
+func TestGetMapStringString(t *testing.T) {
+	t.Run("Missing Key", func(t *testing.T) {
+		r, err := NewJSONReader(readerTestData)
+		assert.Nil(t, err)
+
+		v := r.GetMapStringString("Invalid Key")
+		assert.Equal(t, map[string]string(nil), v)
+	})
+
+	testCases := []struct {
+		key string
+		exp map[string]string
+	}{
+		{key: "object", exp: map[string]string{"a": "b", "c": "d"}},
+		{key: "objects.1", exp: map[string]string{"i": "j", "k": "l"}},
+		{key: "complex.5", exp: map[string]string{"c": "d", "empty_string": ""}},
+		{key: "int_slice", exp: map[string]string{"0": "-1", "1": "0", "2": "1", "3": "2", "4": "3", "5": "4"}},
+		{key: "string", exp: map[string]string{"0": "some string"}},
+		{key: "null", exp: map[string]string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			r, err := NewJSONReader(readerTestData)
+			assert.Nil(t, err)
+
+			v := r.GetMapStringString(tc.key)
+			assert.Equal(t, tc.exp, v)
+		})
+	}
+}
+
 func TestToMapStringString(t *testing.T) {
 	testCases := []struct {
 		label string
@@ -530,6 +801,37 @@ func TestToBoolSlice(t *testing.T) {
 	}
 }
 
+func TestGetMapStringBool(t *testing.T) {
+	t.Run("Missing Key", func(t *testing.T) {
+		r, err := NewJSONReader(readerTestData)
+		assert.Nil(t, err)
+
+		v := r.GetMapStringBool("Invalid Key")
+		assert.Equal(t, map[string]bool(nil), v)
+	})
+
+	testCases := []struct {
+		key string
+		exp map[string]bool
+	}{
+		{key: "object", exp: map[string]bool{"a": false, "c": false}},
+		{key: "bool_slice", exp: map[string]bool{"0": true, "1": false, "2": true, "3": false}},
+		{key: "complex.5", exp: map[string]bool{"c": false, "empty_string": false}},
+		{key: "bool", exp: map[string]bool{"0": true}},
+		{key: "null", exp: map[string]bool{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			r, err := NewJSONReader(readerTestData)
+			assert.Nil(t, err)
+
+			v := r.GetMapStringBool(tc.key)
+			assert.Equal(t, tc.exp, v)
+		})
+	}
+}
+
 func TestToMapStringBool(t *testing.T) {
 	testCases := []struct {
 		label string
@@ -715,6 +1017,36 @@ func TestToIntSlice(t *testing.T) {
 	}
 }
 
+func TestGetMapStringInt(t *testing.T) {
+	t.Run("Missing Key", func(t *testing.T) {
+		r, err := NewJSONReader(readerTestData)
+		assert.Nil(t, err)
+
+		v := r.GetMapStringInt("Invalid Key")
+		assert.Equal(t, map[string]int(nil), v)
+	})
+
+	testCases := []struct {
+		key string
+		exp map[string]int
+	}{
+		{key: "int_slice", exp: map[string]int{"0": -1, "1": 0, "2": 1, "3": 2, "4": 3, "5": 4}},
+		{key: "float_slice", exp: map[string]int{"0": -1, "1": 0, "2": 1, "3": 2, "4": 3}},
+		{key: "int", exp: map[string]int{"0": 17}},
+		{key: "null", exp: map[string]int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			r, err := NewJSONReader(readerTestData)
+			assert.Nil(t, err)
+
+			v := r.GetMapStringInt(tc.key)
+			assert.Equal(t, tc.exp, v)
+		})
+	}
+}
+
 func TestToMapStringInt(t *testing.T) {
 	testCases := []struct {
 		label string
@@ -825,6 +1157,78 @@ func TestToFloat(t *testing.T) {
 	}
 }
 
+func TestGetNumber(t *testing.T) {
+	data := []byte(`{"id": 6754210771357157538, "price": 6.754210771357157538e18, "neg": -0.1000, "quoted": "12.50", "padded": " 12", "word": "twelve", "null": null, "list": [1, 2]}`)
+
+	testCases := []struct {
+		key string
+		exp json.Number
+	}{
+		{key: "id", exp: "6754210771357157538"},
+		{key: "price", exp: "6.754210771357157538e18"},
+		{key: "neg", exp: "-0.1000"},
+		{key: "quoted", exp: "12.50"},
+		{key: "padded", exp: ""},
+		{key: "word", exp: ""},
+		{key: "null", exp: ""},
+		{key: "list", exp: ""},
+		{key: "list.1", exp: "2"},
+		{key: "missing", exp: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			r, err := NewJSONReader(data)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.exp, r.GetNumber(tc.key))
+			assert.Equal(t, tc.exp, r.Get(tc.key).ToNumber())
+		})
+	}
+
+	t.Run("Root", func(t *testing.T) {
+		r, _ := NewJSONReader([]byte(` "12.5" `))
+		assert.Equal(t, json.Number("12.5"), r.ToNumber())
+
+		r, _ = NewJSONReader([]byte(` 1e400 `))
+		assert.Equal(t, json.Number("1e400"), r.ToNumber())
+	})
+
+	t.Run("Int64", func(t *testing.T) {
+		r, _ := NewJSONReader(data)
+		id, err := r.GetNumber("id").Int64()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(6754210771357157538), id)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		r, _ := NewJSONReader(data)
+		r.StrictStandards = true
+		assert.Equal(t, json.Number("-0.1000"), r.GetNumber("neg"))
+		assert.Equal(t, json.Number(""), r.GetNumber("quoted"))
+		assert.Equal(t, json.Number(""), r.GetNumber("missing"))
+		assert.EqualError(t, r.Err(), "key 'quoted' with string value '12.50' can not be converted to json.Number")
+	})
+
+	t.Run("Checked", func(t *testing.T) {
+		r, _ := NewJSONReader(data)
+		for _, tc := range testCases {
+			n, err := r.GetNumberE(tc.key)
+			assert.Equal(t, tc.exp, n, tc.key)
+			assert.Equal(t, tc.exp == "", err != nil, tc.key)
+		}
+
+		_, err := r.GetNumberE("word")
+		assert.EqualError(t, err, "key 'word' with string value 'twelve' can not be converted to json.Number")
+		_, err = r.GetNumberE("missing")
+		assert.ErrorIs(t, err, ErrNoSuchKey)
+
+		root, _ := NewJSONReader([]byte(` "12.5" `))
+		n, err := root.ToNumberE()
+		assert.Nil(t, err)
+		assert.Equal(t, json.Number("12.5"), n)
+	})
+}
+
 func TestGetFloatSlice(t *testing.T) {
 	t.Run("Missing Key", func(t *testing.T) {
 		r, err := NewJSONReader(readerTestData)
@@ -898,6 +1302,36 @@ func TestToFloatSlice(t *testing.T) {
 	}
 }
 
+func TestGetMapStringFloat(t *testing.T) {
+	t.Run("Missing Key", func(t *testing.T) {
+		r, err := NewJSONReader(readerTestData)
+		assert.Nil(t, err)
+
+		v := r.GetMapStringFloat("Invalid Key")
+		assert.Equal(t, map[string]float64(nil), v)
+	})
+
+	testCases := []struct {
+		key string
+		exp map[string]float64
+	}{
+		{key: "int_slice", exp: map[string]float64{"0": -1, "1": 0, "2": 1, "3": 2, "4": 3, "5": 4}},
+		{key: "float_slice", exp: map[string]float64{"0": -1.1, "1": 0, "2": 1.1, "3": 2.2, "4": 3.3}},
+		{key: "float", exp: map[string]float64{"0": 22.83}},
+		{key: "null", exp: map[string]float64{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			r, err := NewJSONReader(readerTestData)
+			assert.Nil(t, err)
+
+			v := r.GetMapStringFloat(tc.key)
+			assert.Equal(t, tc.exp, v)
+		})
+	}
+}
+
 func TestToMapStringFloat(t *testing.T) {
 	testCases := []struct {
 		label string
@@ -1082,6 +1516,37 @@ func TestToByteSlices(t *testing.T) {
 	}
 }
 
+func TestGetMapStringBytes(t *testing.T) {
+	t.Run("Missing Key", func(t *testing.T) {
+		r, err := NewJSONReader(readerTestData)
+		assert.Nil(t, err)
+
+		v := r.GetMapStringBytes("Invalid Key")
+		assert.Equal(t, map[string][]byte(nil), v)
+	})
+
+	testCases := []struct {
+		key string
+		exp map[string][]byte
+	}{
+		{key: "object", exp: map[string][]byte{"a": []byte("b"), "c": []byte("d")}},
+		{key: "objects.0", exp: map[string][]byte{"e": []byte("f"), "g": []byte("h")}},
+		{key: "complex.6", exp: map[string][]byte{"0": []byte("s")}},
+		{key: "string", exp: map[string][]byte{"0": []byte("some string")}},
+		{key: "null", exp: map[string][]byte{"0": []byte("null")}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			r, err := NewJSONReader(readerTestData)
+			assert.Nil(t, err)
+
+			v := r.GetMapStringBytes(tc.key)
+			assert.Equal(t, tc.exp, v)
+		})
+	}
+}
+
 func TestToMapStringBytes(t *testing.T) {
 	testCases := []struct {
 		label string
@@ -1679,13 +2144,13 @@ func TestStringInArray(t *testing.T) {
 
 func TestInt64LargeValue(t *testing.T) {
 	var expected int64 = 6754210771357157538
-	actual := toInt([]byte("6.754210771357157538e18"), JSONFloat, false)
+	actual := toInt([]byte("6.754210771357157538e18"), JSONFloat, false, TruncateNumbers)
 	assert.Equal(t, 6754210771357157376, actual)
 
-	actual = toInt([]byte("6.754210771357157538e17"), JSONInt, false)
+	actual = toInt([]byte("6.754210771357157538e17"), JSONInt, false, TruncateNumbers)
 	assert.Equal(t, 0, actual)
 
-	actual = toInt([]byte("6754210771357157538"), JSONInt, false)
+	actual = toInt([]byte("6754210771357157538"), JSONInt, false, TruncateNumbers)
 	assert.Equal(t, int(expected), actual)
 }
 
@@ -1738,3 +2203,275 @@ func TestToStringShouldntModifyInput(t *testing.T) {
 	assert.NotEqual(t, `https://www.mydomain.com/things/\b\b`, output)
 	assert.Equal(t, "https://www.mydomain.com/things/\b\b", output)
 }
+
+func TestUnescapeSlashes(t *testing.T) {
+	testCases := []struct {
+		input    string
+		quoted   bool
+		expected string
+		ok       bool
+	}{
+		{input: `https:\/\/a.com\/b"`, quoted: true, expected: `https://a.com/b`, ok: true},
+		{input: `say \"hi\""`, quoted: true, expected: `say "hi"`, ok: true},
+		{input: `no escapes"`, quoted: true, expected: `no escapes`, ok: true},
+		{input: `no escapes`, quoted: false, expected: `no escapes`, ok: true},
+		{input: `a\/b`, quoted: false, expected: `a/b`, ok: true},
+		{input: `tab\t"`, quoted: true, ok: false},
+		{input: `escaped end\"`, quoted: true, ok: false},
+		{input: `unterminated`, quoted: true, ok: false},
+	}
+
+	for _, tc := range testCases {
+		out := make([]byte, len(tc.input))
+		end, ok := unescapeSlashes(out, []byte(tc.input), tc.quoted)
+		assert.Equal(t, tc.ok, ok, tc.input)
+		if ok {
+			assert.Equal(t, tc.expected, string(out[:end]), tc.input)
+		}
+	}
+
+	// Falling back to the general path must produce the same result.
+	assert.Equal(t, "https://a.com/\t\"b\"", manualUnescapeString([]byte(`"https:\/\/a.com\/\t\"b\""`)))
+	assert.Equal(t, `a\`, manualUnescapeString([]byte(`"a\\"`)))
+}
+
+func TestGetSliceInto(t *testing.T) {
+	r, err := NewJSONReader(readerTestData)
+	assert.Nil(t, err)
+
+	strs := r.GetStringSliceInto("string_slice", []string{"first"})
+	assert.Equal(t, append([]string{"first"}, r.GetStringSlice("string_slice")...), strs)
+
+	strs = r.GetStringSliceInto("missing", strs[:0])
+	assert.Equal(t, []string{}, strs)
+	assert.Nil(t, r.GetStringSliceInto("missing", nil))
+
+	ints := make([]int, 0, 16)
+	ints = r.GetIntSliceInto("int_slice", ints)
+	ints = r.GetIntSliceInto("int", ints)
+	assert.Equal(t, append(r.GetIntSlice("int_slice"), r.GetInt("int")), ints)
+	assert.Equal(t, 16, cap(ints))
+
+	assert.Equal(t, r.GetBoolSlice("bool_slice"), r.GetBoolSliceInto("bool_slice", nil))
+	assert.Equal(t, r.GetFloatSlice("float_slice"), r.GetFloatSliceInto("float_slice", nil))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		ints = r.GetIntSliceInto("int_slice", ints[:0])
+	})
+	assert.Equal(t, 0.0, allocs)
+
+	r.StrictStandards = true
+	assert.Equal(t, []int{7}, r.GetIntSliceInto("object", []int{7}))
+	assert.NotNil(t, r.Err())
+}
+
+func TestUnescapeBuffers(t *testing.T) {
+	long := strings.Repeat(`é\n`, maxPooledBuffer/4)
+	out := manualUnescapeString([]byte(`"` + long + `"`))
+	assert.Equal(t, strings.Repeat("é\n", maxPooledBuffer/4), out)
+
+	// Strings must not share the pooled buffer.
+	a := manualUnescapeString([]byte(`"a\tb"`))
+	b := manualUnescapeString([]byte(`"c\td"`))
+	assert.Equal(t, "a\tb", a)
+	assert.Equal(t, "c\td", b)
+	assert.Equal(t, "\U0001F600", manualUnescapeString([]byte(`"\ud83d\ude00"`)))
+
+	// An unknown escape mustn't expose a byte of an earlier string left in the pooled buffer.
+	r, err := NewJSONReader([]byte(`{"p": "` + strings.Repeat("Z", 200) + `\n", "a": "ab\qcd"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, strings.Repeat("Z", 200)+"\n", r.GetString("p"))
+	assert.Equal(t, "ab\x00cd", r.GetString("a"))
+}
+
+func TestZeroCopyStrings(t *testing.T) {
+	data := []byte(`{"plain": "some string", "escaped": "a\tb", "space": " x", "empty": "", "int": 17, "list": ["a", "b\/c"], "nested": {"s": "deep"}}`)
+
+	r, err := NewJSONReader(data, WithZeroCopyStrings())
+	assert.Nil(t, err)
+	copied, err := NewJSONReader(data)
+	assert.Nil(t, err)
+
+	for _, k := range []string{"plain", "escaped", "space", "empty", "int", "missing", "nested.s"} {
+		assert.Equal(t, copied.GetString(k), r.GetString(k), k)
+	}
+	assert.Equal(t, []string{"a", "b/c"}, r.GetStringSlice("list"))
+	assert.Equal(t, "deep", r.Get("nested").GetString("s"))
+
+	if zeroCopyViews {
+		allocs := testing.AllocsPerRun(100, func() {
+			r.GetString("plain")
+		})
+		assert.Equal(t, 0.0, allocs)
+	}
+
+	root, err := NewJSONReader([]byte(`  "root"  `), WithZeroCopyStrings())
+	assert.Nil(t, err)
+	assert.Equal(t, "root", root.ToString())
+}
+
+func TestRawStrings(t *testing.T) {
+	data := []byte(`{"html": "a<b>", "quote": "say \"hi\"", "plain": "x", "list": ["b\/c"], "nested": {"s": "\t"}, "int": 17}`)
+
+	r, err := NewJSONReader(data, WithRawStrings())
+	assert.Nil(t, err)
+
+	assert.Equal(t, `a<b>`, r.GetString("html"))
+	assert.Equal(t, `say \"hi\"`, r.GetString("quote"))
+	assert.Equal(t, "x", r.GetString("plain"))
+	assert.Equal(t, "17", r.GetString("int"))
+	assert.Equal(t, []string{`b\/c`}, r.GetStringSlice("list"))
+	assert.Equal(t, `\t`, r.Get("nested").GetString("s"))
+	assert.Equal(t, map[string]string{"s": `\t`}, r.GetMapStringString("nested"))
+	assert.Equal(t, `a<b>`, r.GetInterface("html"))
+
+	root, err := NewJSONReader([]byte(` "a\"b" `), WithRawStrings(), WithZeroCopyStrings())
+	assert.Nil(t, err)
+	assert.Equal(t, `a\"b`, root.ToString())
+
+	decoded, err := NewJSONReader(data)
+	assert.Nil(t, err)
+	assert.Equal(t, "a<b>", decoded.GetString("html"))
+}
+
+func TestUnmarshalReader(t *testing.T) {
+	type Envelope struct {
+		Kind    string      `json:"kind"`
+		Payload *JSONReader `json:"payload"`
+		Meta    JSONReader  `json:"meta"`
+		Items   []*JSONReader
+	}
+
+	raw := []byte(`{"kind": "order", "payload": {"id": "17", "lines": [{"sku": "a"}, {"sku": "b"}]}, "meta": null, "Items": [1, {"a": true}]}`)
+
+	t.Run("Fields", func(t *testing.T) {
+		var e Envelope
+		assert.Nil(t, Unmarshal(raw, &e))
+		assert.Equal(t, "order", e.Kind)
+		assert.Equal(t, JSONObject, e.Payload.Type)
+		assert.Equal(t, 17, e.Payload.GetInt("id"))
+		assert.Equal(t, "b", e.Payload.GetString("lines.1.sku"))
+		assert.Equal(t, `{"id": "17", "lines": [{"sku": "a"}, {"sku": "b"}]}`, string(e.Payload.rawData))
+		assert.True(t, e.Meta.IsNull(""))
+		assert.Len(t, e.Items, 2)
+		assert.Equal(t, 1, e.Items[0].ToInt())
+		assert.True(t, e.Items[1].GetBool("a"))
+
+		// The reader holds a copy of its value.
+		raw[len(`{"kind": "order", "payload": {"id": "`)] = '9'
+		assert.Equal(t, 17, e.Payload.GetInt("id"))
+		raw[len(`{"kind": "order", "payload": {"id": "`)] = '1'
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		var e Envelope
+		assert.Nil(t, UnmarshalStrict(raw, &e))
+		assert.True(t, e.Payload.StrictStandards)
+		assert.Equal(t, 0, e.Payload.GetInt("id"))
+		assert.NotNil(t, e.Payload.Err())
+
+		var r JSONReader
+		assert.Nil(t, UnmarshalWithOptions([]byte(`{"n": 2.6}`), &r, Options{NumberConversion: RoundNumbers}))
+		assert.Equal(t, 3, r.GetInt("n"))
+	})
+
+	t.Run("Encoding JSON", func(t *testing.T) {
+		var e Envelope
+		assert.Nil(t, json.Unmarshal(raw, &e))
+		assert.Equal(t, "a", e.Payload.GetString("lines.0.sku"))
+		assert.Equal(t, JSONArray, e.Payload.TypeOf("lines"))
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		var r JSONReader
+		assert.Equal(t, ErrMalformedJSON, r.UnmarshalJSON([]byte(`abc`)))
+		assert.NotNil(t, r.UnmarshalJSON([]byte(`{"a": `)))
+		assert.True(t, r.Empty)
+
+		var e Envelope
+		assert.NotNil(t, Unmarshal([]byte(`{"payload": [1, }`), &e))
+	})
+}
+
+func TestClone(t *testing.T) {
+	reader, err := NewJSONReader([]byte(`{"port": "80", "hosts": ["a", "b"]}`))
+	assert.Nil(t, err)
+
+	strict := reader.Clone()
+	strict.StrictStandards = true
+	strict.Keys[0] = "changed"
+
+	assert.Equal(t, 80, reader.GetInt("port"))
+	assert.Nil(t, reader.Err())
+	assert.Equal(t, "port", reader.Keys[0])
+
+	assert.Equal(t, 0, strict.GetInt("port"))
+	assert.EqualError(t, strict.Err(), "key 'port' with string value '80' can not be converted to int")
+	assert.Equal(t, []string{"a", "b"}, strict.GetStringSlice("hosts"))
+
+	hosts := strict.Get("hosts").Clone()
+	assert.Equal(t, 0, hosts.GetInt("0"))
+	assert.EqualError(t, hosts.Err(), "key '0' with string value 'a' can not be converted to int")
+	assert.Len(t, strict.Err(), 1)
+}
+
+func TestReaderSettingOptions(t *testing.T) {
+	data := []byte(`{"port": "80", "ratio": 2.5, "nested": {"n": 1.5}}`)
+
+	reader, err := NewJSONReader(data, WithStrict(), WithNumberConversion(RoundNumbers), WithInvalidUTF8(KeepInvalidUTF8))
+	assert.Nil(t, err)
+	assert.True(t, reader.StrictStandards)
+
+	assert.Equal(t, 0, reader.GetInt("port"))
+	assert.EqualError(t, reader.Err(), "key 'port' with string value '80' can not be converted to int")
+
+	nested := reader.Get("nested")
+	assert.True(t, nested.StrictStandards)
+	assert.Equal(t, RoundNumbers, nested.NumberConversion)
+
+	reader, err = NewJSONReader(data, WithNumberConversion(RoundNumbers))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, reader.GetInt("ratio"))
+	assert.Equal(t, 80, reader.GetInt("port"))
+
+	reader, err = NewJSONReader([]byte("\"a\xffb\""), WithInvalidUTF8(KeepInvalidUTF8))
+	assert.Nil(t, err)
+	assert.Equal(t, "a\xffb", reader.ToString())
+
+	t.Run("NoCopy", func(t *testing.T) {
+		data := []byte(`{"a": [1, 2, 3], "b": "x"}`)
+		allocs := func(opts ...ReaderOption) float64 {
+			return testing.AllocsPerRun(10, func() {
+				_, _ = NewJSONReader(data, opts...)
+			})
+		}
+		assert.Less(t, allocs(WithNoCopy()), allocs())
+
+		reader, err := NewJSONReader(data, WithNoCopy())
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2, 3}, reader.GetIntSlice("a"))
+		assert.Equal(t, &data[22], &reader.SliceOf("b")[0])
+	})
+}
+
+func TestConcurrentReads(t *testing.T) {
+	reader, err := NewJSONReader([]byte(`{"items": [{"id": 1, "name": "a"}, {"id": "2", "name": "b"}]}`))
+	assert.Nil(t, err)
+	reader.StrictStandards = true
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, item := range reader.GetCollection("items") {
+				item.GetInt("id")
+				item.GetString("name")
+			}
+			reader.Get("items.1").GetInt("id")
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, reader.Err(), 16)
+}
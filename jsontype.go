@@ -0,0 +1,68 @@
+package gojson
+
+// JSONType is the type of a JSON value: one of JSONNull, JSONBool, JSONInt, JSONFloat, JSONString,
+// JSONArray, JSONObject, or JSONInvalid. The constants are untyped, so that they compare with both
+// the plain strings returned by GetJSONType and JSONReader.Type, and with a JSONType, as in:
+//
+//	switch reader.Kind() {
+//	case gojson.JSONObject:
+//		...
+//	case gojson.JSONArray:
+//		...
+//	}
+type JSONType string
+
+// IsScalar returns whether t is the type of a value which isn't an object or an array. Null is a
+// scalar.
+func (t JSONType) IsScalar() bool {
+	switch t {
+	case JSONNull, JSONBool, JSONInt, JSONFloat, JSONString:
+		return true
+	}
+
+	return false
+}
+
+// IsNumber returns whether t is JSONInt or JSONFloat.
+func (t JSONType) IsNumber() bool {
+	return t == JSONInt || t == JSONFloat
+}
+
+// IsContainer returns whether t is JSONObject or JSONArray.
+func (t JSONType) IsContainer() bool {
+	return t == JSONObject || t == JSONArray
+}
+
+// Kind returns the JSONType of the top-level data. An Empty reader is JSONInvalid.
+func (jr *JSONReader) Kind() JSONType {
+	if jr.Empty {
+		return JSONInvalid
+	}
+
+	return JSONType(jr.Type)
+}
+
+// IsObject returns whether the top-level data is an object.
+func (jr *JSONReader) IsObject() bool {
+	return jr.Kind() == JSONObject
+}
+
+// IsArray returns whether the top-level data is an array.
+func (jr *JSONReader) IsArray() bool {
+	return jr.Kind() == JSONArray
+}
+
+// IsScalar returns whether the top-level data is a scalar, including null.
+func (jr *JSONReader) IsScalar() bool {
+	return jr.Kind().IsScalar()
+}
+
+// IsNumber returns whether the top-level data is a number.
+func (jr *JSONReader) IsNumber() bool {
+	return jr.Kind().IsNumber()
+}
+
+// Kind returns the JSONType of the node.
+func (n *Node) Kind() JSONType {
+	return JSONType(n.p.dtype)
+}
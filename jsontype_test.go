@@ -0,0 +1,58 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONType(t *testing.T) {
+	testCases := []struct {
+		json      string
+		kind      JSONType
+		object    bool
+		array     bool
+		scalar    bool
+		number    bool
+		container bool
+	}{
+		{json: `{"a": 1}`, kind: JSONObject, object: true, container: true},
+		{json: `{}`, kind: JSONObject, object: true, container: true},
+		{json: `[1]`, kind: JSONArray, array: true, container: true},
+		{json: `"a"`, kind: JSONString, scalar: true},
+		{json: `12`, kind: JSONInt, scalar: true, number: true},
+		{json: `1.5`, kind: JSONFloat, scalar: true, number: true},
+		{json: `true`, kind: JSONBool, scalar: true},
+		{json: `null`, kind: JSONNull, scalar: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.json, func(t *testing.T) {
+			jr, err := NewJSONReader([]byte(tc.json))
+			assert.Nil(t, err)
+			assert.Equal(t, tc.kind, jr.Kind())
+			assert.Equal(t, tc.object, jr.IsObject())
+			assert.Equal(t, tc.array, jr.IsArray())
+			assert.Equal(t, tc.scalar, jr.IsScalar())
+			assert.Equal(t, tc.number, jr.IsNumber())
+			assert.Equal(t, tc.container, jr.Kind().IsContainer())
+
+			// The untyped constants compare with both the string and the JSONType.
+			assert.Equal(t, string(tc.kind), jr.Type)
+			assert.Equal(t, tc.kind, JSONType(GetJSONType([]byte(tc.json), 0)))
+			assert.Equal(t, tc.kind, jr.Root().Kind())
+		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		jr, _ := NewJSONReader([]byte(`{"a": 1}`))
+		missing := jr.Get("b")
+		assert.Equal(t, JSONType(JSONInvalid), missing.Kind())
+		assert.False(t, missing.IsObject())
+		assert.False(t, missing.IsScalar())
+		assert.False(t, missing.IsNumber())
+
+		assert.Equal(t, JSONType(JSONInt), jr.Get("a").Kind())
+		assert.True(t, jr.Get("a").IsNumber())
+	})
+}
@@ -0,0 +1,67 @@
+package gojson
+
+// KeyPathOption configures KeyPaths.
+type KeyPathOption func(*keyPathOptions)
+
+type keyPathOptions struct {
+	maxDepth   int
+	leavesOnly bool
+}
+
+// WithMaxPathDepth limits KeyPaths to paths of at most n segments. Top-level keys have a depth of 1.
+func WithMaxPathDepth(n int) KeyPathOption {
+	return func(o *keyPathOptions) {
+		o.maxDepth = n
+	}
+}
+
+// WithLeavesOnly limits KeyPaths to leaf nodes: scalars, and empty objects and arrays.
+func WithLeavesOnly() KeyPathOption {
+	return func(o *keyPathOptions) {
+		o.leavesOnly = true
+	}
+}
+
+// KeyPaths returns the key path of every node in the document, in document order, with each
+// parent listed before its children. Array elements are addressed by index. Every returned path
+// is valid for use with Get and the other accessors.
+//
+// Example:
+//
+//	r, _ := gojson.NewJSONReader([]byte(`{"a": {"b": [1, 2]}, "c": null}`))
+//	r.KeyPaths()                           // a, a.b, a.b.0, a.b.1, c
+//	r.KeyPaths(gojson.WithMaxPathDepth(2)) // a, a.b, c
+//	r.KeyPaths(gojson.WithLeavesOnly())    // a.b.0, a.b.1, c
+func (jr *JSONReader) KeyPaths(opts ...KeyPathOption) []string {
+	if jr.Empty || (jr.Type != JSONObject && jr.Type != JSONArray) {
+		return nil
+	}
+
+	var o keyPathOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var out []string
+
+	var walk func(p parsed, prefix string, depth int)
+	walk = func(p parsed, prefix string, depth int) {
+		if o.maxDepth > 0 && depth > o.maxDepth {
+			return
+		}
+
+		for _, k := range uniqueString(p.keys, true) {
+			c := p.children[k]
+			path := joinPath(prefix, k)
+
+			if !o.leavesOnly || len(c.keys) == 0 {
+				out = append(out, path)
+			}
+
+			walk(c, path, depth+1)
+		}
+	}
+
+	walk(*jr.getChildByKey(""), "", 1)
+	return out
+}
@@ -0,0 +1,44 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyPaths(t *testing.T) {
+	r, err := NewJSONReader([]byte(`{"a": {"b": [1, {"x": true}], "e": {}}, "c": null, "a": {"b": [], "d": "dup"}, "f": []}`))
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name     string
+		opts     []KeyPathOption
+		expected []string
+	}{
+		{name: "All", expected: []string{"a", "a.b", "a.d", "c", "f"}},
+		{name: "Depth 1", opts: []KeyPathOption{WithMaxPathDepth(1)}, expected: []string{"a", "c", "f"}},
+		{name: "Leaves Only", opts: []KeyPathOption{WithLeavesOnly()}, expected: []string{"a.b", "a.d", "c", "f"}},
+		{name: "Leaves To Depth 1", opts: []KeyPathOption{WithLeavesOnly(), WithMaxPathDepth(1)}, expected: []string{"c", "f"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, r.KeyPaths(tc.opts...))
+		})
+	}
+
+	r, err = NewJSONReader([]byte(`[{"id": 1, "tags": ["a", "b"], "meta": {"k": {"v": 0}}}, 2]`))
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"0", "0.id", "0.tags", "0.tags.0", "0.tags.1", "0.meta", "0.meta.k", "0.meta.k.v", "1"}, r.KeyPaths())
+	assert.Equal(t, []string{"0", "0.id", "0.tags", "0.meta", "1"}, r.KeyPaths(WithMaxPathDepth(2)))
+	assert.Equal(t, []string{"0.id", "0.tags.0", "0.tags.1", "0.meta.k.v", "1"}, r.KeyPaths(WithLeavesOnly()))
+
+	for _, path := range r.KeyPaths() {
+		assert.True(t, r.KeyExists(path), path)
+	}
+
+	scalar, _ := NewJSONReader([]byte(`"abc"`))
+	assert.Nil(t, scalar.KeyPaths())
+	assert.Nil(t, (&JSONReader{Empty: true}).KeyPaths())
+}
@@ -0,0 +1,146 @@
+package gojson
+
+import (
+	"context"
+	"fmt"
+)
+
+// ctxCheckInterval is the number of bytes scanned, or values decoded, between context checks.
+const ctxCheckInterval = 1 << 16
+
+// DefaultMaxDepth is the nesting depth enforced by NewJSONReader, Unmarshal, and IsJSON unless
+// configured otherwise. Without a limit, deeply nested input (e.g. 100k open brackets) can exhaust
+// the stack or time spent by the recursive scanner.
+var DefaultMaxDepth = 10000
+
+// DepthExceededError is returned when a document is nested deeper than the maximum depth.
+type DepthExceededError struct {
+	// MaxDepth is the maximum depth which was exceeded.
+	MaxDepth int
+
+	// Offset is the byte position of the bracket which exceeded the maximum depth.
+	Offset int
+}
+
+func (e *DepthExceededError) Error() string {
+	return fmt.Sprintf("json exceeds maximum depth of %d at position %d", e.MaxDepth, e.Offset)
+}
+
+// LimitError is returned when a document exceeds the MaxStringLength, MaxTokenSize, MaxDocumentSize or
+// MaxNodes limits set in Options, or the equivalent reader options, and when an HTTP body exceeds the
+// size accepted by DecodeRequest or DecodeResponse.
+type LimitError struct {
+	// Limit names the limit which was exceeded: "string length", "token size", "document size", "nodes"
+	// or "body size".
+	Limit string
+
+	// Max is the configured value of the limit.
+	Max int
+
+	// Offset is the byte position in the document at which the limit was exceeded.
+	Offset int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("json exceeds maximum %s of %d at position %d", e.Limit, e.Max, e.Offset)
+}
+
+// resolveMaxDepth returns the depth limit to enforce for a configured max depth, where zero selects
+// DefaultMaxDepth and a negative value selects no limit (0).
+func resolveMaxDepth(max int) int {
+	switch {
+	case max == 0:
+		return DefaultMaxDepth
+	case max < 0:
+		return 0
+	}
+
+	return max
+}
+
+// checkDepth returns a DepthExceededError if the document is nested deeper than max. A max of
+// zero or less means no limit.
+func checkDepth(b []byte, max int) error {
+	if max <= 0 {
+		return nil
+	}
+
+	return checkLimits(nil, b, Options{MaxDepth: max})
+}
+
+// checkLimits scans the document, without recursion, and returns an error for the first limit it
+// exceeds. Limits of zero or less are not enforced; MaxDepth must already be resolved. Malformed
+// documents are not reported here, they are left to the decoder. If ctx is not nil, it is checked
+// periodically so that very large documents can be abandoned.
+func checkLimits(ctx context.Context, b []byte, opts Options) error {
+	exceeds := func(n, max int) bool {
+		return max > 0 && n > max
+	}
+
+	// The document size is checked without a scan, so that oversized input is rejected immediately.
+	if exceeds(len(b), opts.MaxDocumentSize) {
+		return &LimitError{Limit: "document size", Max: opts.MaxDocumentSize, Offset: opts.MaxDocumentSize}
+	}
+
+	if opts.MaxDepth <= 0 && opts.MaxStringLength <= 0 && opts.MaxTokenSize <= 0 && opts.MaxNodes <= 0 {
+		return nil
+	}
+
+	depth, nodes, nextCheck := 0, 0, 0
+
+	for i := 0; i < len(b); i++ {
+		if ctx != nil && i >= nextCheck {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			nextCheck = i + ctxCheckInterval
+		}
+
+		start := i
+
+		switch b[i] {
+		case ' ', '\t', '\n', '\r', ',', ':':
+			continue
+		case ']', '}':
+			depth--
+			continue
+		case '"':
+			if i = stringEnd(b, i+1); i < 0 {
+				i = len(b)
+			}
+
+			if exceeds(i-start-1, opts.MaxStringLength) {
+				return &LimitError{Limit: "string length", Max: opts.MaxStringLength, Offset: start}
+			}
+			if exceeds(i-start-1, opts.MaxTokenSize) {
+				return &LimitError{Limit: "token size", Max: opts.MaxTokenSize, Offset: start}
+			}
+
+			// Keys are not values, and so are not counted as nodes.
+			if next := ltrim(b, i+1); next < len(b) && b[next] == ':' {
+				continue
+			}
+		case '[', '{':
+			depth++
+			if exceeds(depth, opts.MaxDepth) {
+				return &DepthExceededError{MaxDepth: opts.MaxDepth, Offset: i}
+			}
+		default:
+			// Numbers and constants run until the next delimiter.
+			for i+1 < len(b) && !isTermByte(b[i+1]) && !isWhitespace(b[i+1]) && b[i+1] != '"' {
+				i++
+			}
+
+			if exceeds(i-start+1, opts.MaxTokenSize) {
+				return &LimitError{Limit: "token size", Max: opts.MaxTokenSize, Offset: start}
+			}
+		}
+
+		nodes++
+		if exceeds(nodes, opts.MaxNodes) {
+			return &LimitError{Limit: "nodes", Max: opts.MaxNodes, Offset: start}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package gojson
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLimits(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		opts     Options
+		expected error
+	}{
+		{name: "No Limits", json: `[[[[{"a": "bcdef"}]]]]`},
+		{name: "Depth At Limit", json: `{"a": [[1]]}`, opts: Options{MaxDepth: 3}},
+		{name: "Depth Exceeded", json: `{"a": [[[1]]]}`, opts: Options{MaxDepth: 3}, expected: &DepthExceededError{MaxDepth: 3, Offset: 8}},
+		{name: "Depth Of Siblings", json: `[[1], [2], [3], {"a": 4}]`, opts: Options{MaxDepth: 2}},
+		{name: "Brackets In Strings", json: `{"a": "[[[[{{{{"}`, opts: Options{MaxDepth: 1}},
+		{name: "String At Limit", json: `{"a": "abc"}`, opts: Options{MaxStringLength: 3}},
+		{name: "String Exceeded", json: `{"a": "abcd"}`, opts: Options{MaxStringLength: 3}, expected: &LimitError{Limit: "string length", Max: 3, Offset: 6}},
+		{name: "Key Exceeded", json: `{"abcd": 1}`, opts: Options{MaxStringLength: 3}, expected: &LimitError{Limit: "string length", Max: 3, Offset: 1}},
+		{name: "Escaped Quotes", json: `{"a": "\"\""}`, opts: Options{MaxStringLength: 4}},
+		{name: "Nodes At Limit", json: `{"a": [1, true, null], "b": "c"}`, opts: Options{MaxNodes: 6}},
+		{name: "Nodes Exceeded", json: `{"a": [1, true, null], "b": "c", "d": -1.5e3}`, opts: Options{MaxNodes: 6}, expected: &LimitError{Limit: "nodes", Max: 6, Offset: 38}},
+		{name: "Scalar Root", json: `12345`, opts: Options{MaxNodes: 1}},
+		{name: "Tokens At Limit", json: `{"abc": [123, true, "def"]}`, opts: Options{MaxTokenSize: 4}},
+		{name: "String Token Exceeded", json: `{"a": "abcde"}`, opts: Options{MaxTokenSize: 4}, expected: &LimitError{Limit: "token size", Max: 4, Offset: 6}},
+		{name: "Number Token Exceeded", json: `{"a": [1, -1.5e3]}`, opts: Options{MaxTokenSize: 4}, expected: &LimitError{Limit: "token size", Max: 4, Offset: 10}},
+		{name: "Literal Token Exceeded", json: `[false]`, opts: Options{MaxTokenSize: 4}, expected: &LimitError{Limit: "token size", Max: 4, Offset: 1}},
+		{name: "Document At Limit", json: `[1, 2]`, opts: Options{MaxDocumentSize: 6}},
+		{name: "Document Exceeded", json: `[1, 2, 3]`, opts: Options{MaxDocumentSize: 6}, expected: &LimitError{Limit: "document size", Max: 6, Offset: 6}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, checkLimits(nil, []byte(tc.json), tc.opts))
+		})
+	}
+}
+
+func TestUnmarshalLimits(t *testing.T) {
+	var v map[string]interface{}
+
+	deep := []byte(strings.Repeat("[", 10000) + strings.Repeat("]", 10000))
+	err := UnmarshalWithOptions(deep, &v, Options{MaxDepth: 100})
+	assert.Equal(t, "json exceeds maximum depth of 100 at position 100", err.Error())
+
+	err = UnmarshalWithOptions([]byte(`{"a": "abcdef"}`), &v, Options{MaxStringLength: 5})
+	assert.IsType(t, &LimitError{}, err)
+
+	err = UnmarshalWithOptions([]byte(`{"a": "abcdef"}`), &v, Options{MaxStringLength: 6, MaxNodes: 2, MaxDepth: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "abcdef"}, v)
+}
+
+func TestMaxDepth(t *testing.T) {
+	deep := []byte(strings.Repeat("[", 100000) + strings.Repeat("]", 100000))
+	expected := &DepthExceededError{MaxDepth: DefaultMaxDepth, Offset: DefaultMaxDepth}
+
+	assert.False(t, IsJSON(deep))
+
+	reader, err := NewJSONReader(deep)
+	assert.Equal(t, expected, err)
+	assert.True(t, reader.Empty)
+
+	var v interface{}
+	assert.Equal(t, expected, Unmarshal(deep, &v))
+
+	nested := []byte(`{"a": {"b": {"c": [1]}}}`)
+	assert.True(t, IsJSON(nested))
+
+	reader, err = NewJSONReader(nested, WithMaxDepth(3))
+	assert.Equal(t, &DepthExceededError{MaxDepth: 3, Offset: 18}, err)
+	assert.True(t, reader.Empty)
+
+	reader, err = NewJSONReader(nested, WithMaxDepth(4))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, reader.GetIntSlice("a.b.c"))
+
+	reader, err = NewJSONReader(nested, WithMaxDepth(-1))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, reader.GetIntSlice("a.b.c"))
+
+	err = UnmarshalWithOptions(nested, &v, Options{MaxDepth: 2})
+	assert.Equal(t, &DepthExceededError{MaxDepth: 2, Offset: 12}, err)
+}
+
+func TestReaderLimits(t *testing.T) {
+	data := []byte(`{"name": "gojson", "count": 123456}`)
+
+	reader, err := NewJSONReader(data, WithMaxStringLength(5))
+	assert.Equal(t, &LimitError{Limit: "string length", Max: 5, Offset: 9}, err)
+	assert.True(t, reader.Empty)
+
+	reader, err = NewJSONReader(data, WithMaxTokenSize(6))
+	assert.Nil(t, err)
+	assert.Equal(t, 123456, reader.GetInt("count"))
+
+	reader, err = NewJSONReader(data, WithMaxTokenSize(5))
+	assert.Equal(t, &LimitError{Limit: "token size", Max: 5, Offset: 9}, err)
+	assert.True(t, reader.Empty)
+
+	reader, err = NewJSONReader(data, WithMaxDocumentSize(16))
+	assert.Equal(t, "json exceeds maximum document size of 16 at position 16", err.Error())
+	assert.True(t, reader.Empty)
+
+	_, err = ParseStrictRFC8259(data, WithMaxTokenSize(5))
+	assert.IsType(t, &LimitError{}, err)
+
+	var v map[string]interface{}
+	err = UnmarshalWithOptions(data, &v, Options{MaxTokenSize: 5})
+	assert.Equal(t, &LimitError{Limit: "token size", Max: 5, Offset: 9}, err)
+
+	err = UnmarshalWithOptions(data, &v, Options{MaxDocumentSize: len(data), MaxTokenSize: 6})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "gojson", "count": 123456}, v)
+}
+
+func TestUnmarshalContext(t *testing.T) {
+	type Row struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	raw := []byte(`[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]`)
+
+	var rows []Row
+	assert.Nil(t, UnmarshalContext(context.Background(), raw, &rows))
+	assert.Equal(t, []Row{{1, "a"}, {2, "b"}}, rows)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rows = nil
+	assert.Equal(t, context.Canceled, UnmarshalContext(ctx, raw, &rows))
+	assert.Nil(t, rows)
+}
@@ -3,6 +3,7 @@ package gojson
 import (
 	"bytes"
 	"errors"
+	"strconv"
 	"strings"
 )
 
@@ -11,6 +12,38 @@ var (
 	ErrMergeType = errors.New("only JSONObject can be merged")
 )
 
+// MergeStrategy controls how MergeJSON combines arrays, and handles nulls in the patch. The zero
+// MergeStrategy replaces arrays and copies nulls.
+type MergeStrategy struct {
+	Arrays ArrayMerge
+	Nulls  NullMerge
+}
+
+// ArrayMerge determines how an array in the patch is merged with an array in the base.
+type ArrayMerge int
+
+const (
+	// ReplaceArrays replaces the array in the base with the array in the patch.
+	ReplaceArrays ArrayMerge = iota
+
+	// ConcatArrays appends the elements of the array in the patch to the array in the base.
+	ConcatArrays
+)
+
+// NullMerge determines how a null in the patch is merged.
+type NullMerge int
+
+const (
+	// OverrideNulls replaces the value in the base with null, as with any other value.
+	OverrideNulls NullMerge = iota
+
+	// DeleteNulls removes the key from the base, as with a JSON Merge Patch (RFC 7386).
+	DeleteNulls
+
+	// IgnoreNulls leaves the value in the base untouched.
+	IgnoreNulls
+)
+
 // MergeJSON merges two JSONObject together and returns the combination.
 // Base and Patch must be type JSONObject.
 // When keys exist in the base but not in the patch, it will be retained.
@@ -18,7 +51,16 @@ var (
 // JSONArray, JSONString, JSONInt, JSONFloat, JSONNull in the patch will always override the base.
 // JSONObject in the patch will override anything but JSONObject in the base.
 // JSONObject in the patch AND JSONObject in the base will be merged together.
-func MergeJSON(base, patch []byte) ([]byte, error) {
+//
+// An optional MergeStrategy changes how arrays and nulls are merged. Only the first is used.
+//
+// Example, layering defaults, tenant config and request overrides:
+//
+//	strategy := gojson.MergeStrategy{Arrays: gojson.ConcatArrays, Nulls: gojson.DeleteNulls}
+//	config, err := gojson.MergeJSON(defaults, tenant, strategy)
+//	...
+//	config, err = gojson.MergeJSON(config, overrides, strategy)
+func MergeJSON(base, patch []byte, strategy ...MergeStrategy) ([]byte, error) {
 	a, err := NewJSONReader(base)
 	if err != nil {
 		return nil, err
@@ -33,41 +75,74 @@ func MergeJSON(base, patch []byte) ([]byte, error) {
 		return nil, ErrMergeType
 	}
 
-	p := merge(a.parsed, b.parsed)
+	var s MergeStrategy
+	if len(strategy) > 0 {
+		s = strategy[0]
+	}
+
+	p := merge(a.parsed, b.parsed, s)
+	if len(p) == 0 {
+		return []byte(`{}`), nil
+	}
+
 	return toByteString(p, a.Type, uniqueString(append(a.Keys, b.Keys...), false)), nil
 }
 
-func merge(a, b map[string]parsed) map[string]parsed {
+func merge(a, b map[string]parsed, s MergeStrategy) map[string]parsed {
 	// If B is empty, there's nothing to merge.
 	if len(b) == 0 {
 		return a
 	}
 
-	if len(a) == 0 {
-		return b
+	if a == nil {
+		a = make(map[string]parsed, len(b))
 	}
 
-	i, d := id(keys(a), keys(b))
+	for k, v := range b {
+		if v.dtype == JSONNull && s.Nulls != OverrideNulls {
+			if s.Nulls == DeleteNulls {
+				delete(a, k)
+			}
+			continue
+		}
 
-	// For each key in b not in a, add it to a
-	for _, k := range d {
-		a[k] = b[k]
+		base, isset := a[k]
+		switch {
+		case isset && base.dtype == JSONObject && v.dtype == JSONObject:
+			base.children = merge(base.children, v.children, s)
+			base.keys = uniqueString(append(base.keys, v.keys...), false)
+			a[k] = base
+		case isset && base.dtype == JSONArray && v.dtype == JSONArray && s.Arrays == ConcatArrays:
+			a[k] = concat(base, v)
+		case v.dtype == JSONObject && s.Nulls != OverrideNulls:
+			// The nulls within a new object are handled as if it were merged into an empty object.
+			v.children = merge(nil, v.children, s)
+			a[k] = v
+		default:
+			a[k] = v
+		}
 	}
 
-	// For each key that matches between a and b, merge them.
-	for _, k := range i {
+	return a
+}
 
-		if a[k].dtype == JSONObject && b[k].dtype == JSONObject {
-			p := a[k]
-			p.children = merge(a[k].children, b[k].children)
-			p.keys = uniqueString(append(p.keys, b[k].keys...), false)
-			a[k] = p
-			continue
-		}
+// concat appends the elements of the array b to the array a.
+func concat(a, b parsed) parsed {
+	children := make(map[string]parsed, len(a.keys)+len(b.keys))
+	keys := make([]string, 0, len(a.keys)+len(b.keys))
 
-		a[k] = b[k]
+	for _, k := range a.keys {
+		children[k] = a.children[k]
+		keys = append(keys, k)
 	}
 
+	for _, k := range b.keys {
+		i := strconv.Itoa(len(keys))
+		children[i] = b.children[k]
+		keys = append(keys, i)
+	}
+
+	a.children, a.keys = children, keys
 	return a
 }
 
@@ -85,19 +160,23 @@ func toByteString(p map[string]parsed, t string, keys []string) []byte {
 
 	i := 0
 	for _, k := range keys {
-		v := p[k]
+		v, isset := p[k]
+		if !isset {
+			// The key was deleted by the merge.
+			continue
+		}
 		buf := bytes.NewBuffer([]byte{})
 
 		switch t {
 		case JSONObject:
 			switch v.dtype {
 			case JSONObject, JSONArray:
-				if IsEmptyObject(v.bytes) {
+				if v.dtype == JSONObject && len(v.children) == 0 {
 					buf.WriteString(`"` + k + `":{}`)
 					contents[i] = buf.String()
 					break
 				}
-				if IsEmptyArray(v.bytes) {
+				if v.dtype == JSONArray && len(v.children) == 0 {
 					buf.WriteString(`"` + k + `":[]`)
 					contents[i] = buf.String()
 					break
@@ -122,12 +201,12 @@ func toByteString(p map[string]parsed, t string, keys []string) []byte {
 		case JSONArray:
 			switch v.dtype {
 			case JSONObject, JSONArray:
-				if IsEmptyObject(v.bytes) {
+				if v.dtype == JSONObject && len(v.children) == 0 {
 					buf.WriteString(`{}`)
 					contents[i] = buf.String()
 					break
 				}
-				if IsEmptyArray(v.bytes) {
+				if v.dtype == JSONArray && len(v.children) == 0 {
 					buf.WriteString(`[]`)
 					contents[i] = buf.String()
 					break
@@ -154,40 +233,7 @@ func toByteString(p map[string]parsed, t string, keys []string) []byte {
 		i++
 	}
 
-	return []byte(open + strings.Join(contents, ",") + close)
-}
-
-func keys(in map[string]parsed) []string {
-	out := make([]string, len(in))
-
-	i := 0
-	for k := range in {
-		out[i] = k
-		i++
-	}
-
-	return out
-}
-
-// id finds the common and not common elements between two slices of string.
-// Intersect is common between both.
-// Difference exists in b but not in a.
-func id(a, b []string) (intersect []string, difference []string) {
-	seen := make(map[string]bool)
-	for _, v := range a {
-		seen[v] = true
-	}
-
-	for _, v := range b {
-		if _, ok := seen[v]; ok {
-			intersect = append(intersect, v)
-			continue
-		}
-
-		difference = append(difference, v)
-	}
-
-	return intersect, difference
+	return []byte(open + strings.Join(contents[:i], ",") + close)
 }
 
 func uniqueString(in []string, allowEmpty bool) []string {
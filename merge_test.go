@@ -114,3 +114,44 @@ func TestMerge(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeStrategy(t *testing.T) {
+	testCases := []struct {
+		label    string
+		base     string
+		patch    string
+		strategy MergeStrategy
+		expected string
+	}{
+		{label: "Replace Arrays", base: `{"a":[1,2]}`, patch: `{"a":[3]}`, expected: `{"a":[3]}`},
+		{label: "Concat Arrays", base: `{"a":[1,2],"b":{"c":["x"]}}`, patch: `{"a":[3,{"d":4}],"b":{"c":["y"]}}`, strategy: MergeStrategy{Arrays: ConcatArrays}, expected: `{"a":[1,2,3,{"d":4}],"b":{"c":["x","y"]}}`},
+		{label: "Concat Empty Arrays", base: `{"a":[],"b":[1]}`, patch: `{"a":[1],"b":[]}`, strategy: MergeStrategy{Arrays: ConcatArrays}, expected: `{"a":[1],"b":[1]}`},
+		{label: "Concat Array With Scalar", base: `{"a":[1]}`, patch: `{"a":2}`, strategy: MergeStrategy{Arrays: ConcatArrays}, expected: `{"a":2}`},
+		{label: "Override Nulls", base: `{"a":1,"b":{"c":2}}`, patch: `{"a":null,"b":{"c":null},"d":null}`, expected: `{"a":null,"b":{"c":null},"d":null}`},
+		{label: "Delete Nulls", base: `{"a":1,"b":{"c":2,"d":3}}`, patch: `{"a":null,"b":{"c":null},"e":null,"f":{"g":null,"h":1}}`, strategy: MergeStrategy{Nulls: DeleteNulls}, expected: `{"b":{"d":3},"f":{"h":1}}`},
+		{label: "Delete Everything", base: `{"a":1,"b":{"c":2}}`, patch: `{"a":null,"b":{"c":null}}`, strategy: MergeStrategy{Nulls: DeleteNulls}, expected: `{"b":{}}`},
+		{label: "Delete Root Keys", base: `{"a":1}`, patch: `{"a":null}`, strategy: MergeStrategy{Nulls: DeleteNulls}, expected: `{}`},
+		{label: "Ignore Nulls", base: `{"a":1,"b":{"c":2}}`, patch: `{"a":null,"b":{"c":null,"d":3},"e":null}`, strategy: MergeStrategy{Nulls: IgnoreNulls}, expected: `{"a":1,"b":{"c":2,"d":3}}`},
+		{label: "Merge Into Empty Object", base: `{"a":{}}`, patch: `{"a":{"b":1}}`, expected: `{"a":{"b":1}}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.label, func(t *testing.T) {
+			out, err := MergeJSON([]byte(tc.base), []byte(tc.patch), tc.strategy)
+			assert.Nil(t, err)
+			assert.True(t, IsJSON(out), string(out))
+			assert.JSONEq(t, tc.expected, string(out))
+		})
+	}
+
+	t.Run("Layers", func(t *testing.T) {
+		strategy := MergeStrategy{Arrays: ConcatArrays, Nulls: DeleteNulls}
+
+		out, err := MergeJSON([]byte(`{"timeout":30,"plugins":["auth"],"debug":false}`), []byte(`{"plugins":["billing"],"limits":{"rps":10}}`), strategy)
+		assert.Nil(t, err)
+
+		out, err = MergeJSON(out, []byte(`{"debug":true,"limits":{"rps":null,"burst":5},"timeout":null}`), strategy)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"plugins":["auth","billing"],"debug":true,"limits":{"burst":5}}`, string(out))
+	})
+}
@@ -0,0 +1,143 @@
+package gojson
+
+import "strings"
+
+// Node is a value in the tree parsed by NewJSONReader, for tools such as linters and transformers
+// which need to walk a document directly rather than through interface{} values.
+//
+// The nodes reached from a single call to Root are created once, as they are first visited, and are
+// not safe for concurrent use. Their identity is stable, so they may be used as map keys to attach
+// metadata to the tree.
+//
+// Example, listing every key path holding null:
+//
+//	var walk func(n *gojson.Node)
+//	walk = func(n *gojson.Node) {
+//		if n.Type() == gojson.JSONNull {
+//			fmt.Println(n.Path())
+//		}
+//		for _, c := range n.Children() {
+//			walk(c)
+//		}
+//	}
+//	walk(reader.Root())
+type Node struct {
+	p      parsed
+	key    string
+	path   string
+	parent *Node
+
+	// children is populated by the first call to Children.
+	children []*Node
+	visited  bool
+}
+
+// Root returns the root node of the reader, or nil if the reader is Empty.
+func (jr *JSONReader) Root() *Node {
+	if jr.Empty {
+		return nil
+	}
+
+	p := jr.getChildByKey("")
+	if p.dtype != JSONObject && p.dtype != JSONArray {
+		// The scalar is held as the only child of the root, with its quotes removed.
+		if c, ok := jr.parsed["0"]; ok {
+			p = &c
+		}
+	}
+
+	return &Node{p: *p}
+}
+
+// Node returns the node at the given key path, or nil if the key doesn't exist. Use empty string ("")
+// to represent the root.
+func (jr *JSONReader) Node(key string) *Node {
+	n := jr.Root()
+	if n == nil || key == "" {
+		return n
+	}
+
+	for _, k := range strings.Split(key, ".") {
+		if n = n.Child(k); n == nil {
+			return nil
+		}
+	}
+
+	return n
+}
+
+// Type returns the JSON type of the node.
+func (n *Node) Type() string {
+	return n.p.dtype
+}
+
+// Key returns the key of the node within its parent, which for array elements is the index. The key
+// of the root is empty.
+func (n *Node) Key() string {
+	return n.key
+}
+
+// Path returns the key path of the node from the root, as accepted by the JSONReader functions.
+func (n *Node) Path() string {
+	return n.path
+}
+
+// Parent returns the parent of the node, or nil for the root.
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Children returns the members of an object, or the elements of an array, in document order. Scalars
+// have no children. As with the JSONReader functions, only the last of any duplicate keys is kept.
+func (n *Node) Children() []*Node {
+	if n.visited {
+		return n.children
+	}
+	n.visited = true
+
+	if n.p.dtype != JSONObject && n.p.dtype != JSONArray {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(n.p.keys))
+	for _, k := range n.p.keys {
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+
+		n.children = append(n.children, &Node{p: n.p.children[k], key: k, path: joinPath(n.path, k), parent: n})
+	}
+
+	return n.children
+}
+
+// Child returns the child with the given key or array index, or nil if there is none.
+func (n *Node) Child(key string) *Node {
+	for _, c := range n.Children() {
+		if c.key == key {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// Bytes returns a copy of the JSON encoding of the node, as RawBytes does.
+func (n *Node) Bytes() []byte {
+	return rawBytes(n.p)
+}
+
+// Reader returns a JSONReader holding the node, as Get does.
+func (n *Node) Reader() *JSONReader {
+	return readerFor(&n.p)
+}
+
+// Position returns the position of the node, if the reader was created with WithPositions.
+func (n *Node) Position() (Position, bool) {
+	if n.p.pos == nil {
+		return Position{}, false
+	}
+
+	return *n.p.pos, true
+}
@@ -0,0 +1,74 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNode(t *testing.T) {
+	jr, err := NewJSONReader([]byte(`{"name": "gojson", "tags": ["a", null], "meta": {"x": 1, "x": 2}}`), WithPositions())
+	assert.Nil(t, err)
+
+	root := jr.Root()
+	assert.Equal(t, JSONObject, root.Type())
+	assert.Equal(t, "", root.Key())
+	assert.Nil(t, root.Parent())
+
+	var keys []string
+	for _, c := range root.Children() {
+		keys = append(keys, c.Key())
+		assert.Same(t, root, c.Parent())
+	}
+	assert.Equal(t, []string{"name", "tags", "meta"}, keys)
+
+	t.Run("Traversal", func(t *testing.T) {
+		n := root.Child("tags").Child("1")
+		assert.Equal(t, JSONNull, n.Type())
+		assert.Equal(t, "1", n.Key())
+		assert.Equal(t, "tags.1", n.Path())
+		assert.Equal(t, "tags", n.Parent().Path())
+		assert.Nil(t, n.Children())
+		assert.Nil(t, n.Child("0"))
+
+		// Nodes are created once, so they can be used as map keys.
+		assert.Same(t, n, root.Child("tags").Child("1").Parent().Parent().Child("tags").Child("1"))
+		assert.Same(t, root.Child("tags"), root.Children()[1])
+	})
+
+	t.Run("Duplicate Keys", func(t *testing.T) {
+		children := jr.Node("meta").Children()
+		assert.Len(t, children, 1)
+		assert.Equal(t, "2", string(children[0].Bytes()))
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		n := jr.Node("name")
+		assert.Equal(t, `"gojson"`, string(n.Bytes()))
+		assert.Equal(t, "gojson", n.Reader().ToString())
+		assert.Equal(t, []string{"a", ""}, jr.Node("tags").Reader().ToStringSlice())
+
+		pos, ok := n.Position()
+		assert.True(t, ok)
+		assert.Equal(t, Position{Line: 1, Col: 10, Offset: 9, Length: 8}, pos)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		assert.Nil(t, jr.Node("tags.2"))
+		assert.Nil(t, jr.Node("name.first"))
+		assert.Nil(t, (&JSONReader{Empty: true}).Root())
+	})
+
+	t.Run("Scalar Root", func(t *testing.T) {
+		jr, err := NewJSONReader([]byte(`"text"`))
+		assert.Nil(t, err)
+
+		n := jr.Root()
+		assert.Equal(t, JSONString, n.Type())
+		assert.Equal(t, `"text"`, string(n.Bytes()))
+		assert.Nil(t, n.Children())
+
+		_, ok := n.Position()
+		assert.False(t, ok)
+	})
+}
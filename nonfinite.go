@@ -0,0 +1,122 @@
+package gojson
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+)
+
+// The literals NaN, Infinity and -Infinity are not JSON, but are written by JavaScript, Python and
+// others for the non-finite floats. With Options.NonFiniteNumbers, Unmarshal replaces each of them
+// with a float placeholder of the same length before decoding, and records the value it stood for by
+// its position in the document, so that the rest of the decoder need not know about them.
+
+// nonFiniteLiterals are the literals, and the placeholders they are replaced with, padded with
+// whitespace so that the positions of the values which follow are unchanged.
+var nonFiniteLiterals = []struct {
+	literal     string
+	placeholder string
+	value       float64
+}{
+	{"NaN", "0.0", math.NaN()},
+	{"Infinity", "0.0     ", math.Inf(1)},
+	{"-Infinity", "-0.0     ", math.Inf(-1)},
+}
+
+// nonFiniteNumbers holds the values of the non-finite literals replaced in doc, by their offset.
+type nonFiniteNumbers struct {
+	doc    []byte
+	values map[int]float64
+}
+
+// replaceNonFinite returns a copy of the document b with the non-finite literals outside of strings
+// replaced, and a record of their values. If there are none, b is returned as it is, with a nil
+// record.
+func replaceNonFinite(b []byte) ([]byte, *nonFiniteNumbers) {
+	var n *nonFiniteNumbers
+
+	inString := false
+	prev := byte(0)
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case 'N', 'I', '-':
+			if prev != 0 && prev != '[' && prev != ',' && prev != ':' {
+				break
+			}
+
+			for _, l := range nonFiniteLiterals {
+				end := i + len(l.literal)
+				if !bytes.HasPrefix(b[i:], []byte(l.literal)) || (end < len(b) && !isValueEnd(b[end])) {
+					continue
+				}
+
+				if n == nil {
+					b = append([]byte(nil), b...)
+					n = &nonFiniteNumbers{doc: b, values: make(map[int]float64)}
+				}
+
+				copy(b[i:], l.placeholder)
+				n.values[i] = l.value
+				i = end - 1
+				break
+			}
+		}
+
+		if !isWhitespace(c) {
+			prev = c
+		}
+	}
+
+	return b, n
+}
+
+// isValueEnd returns whether c may follow a literal.
+func isValueEnd(c byte) bool {
+	return isWhitespace(c) || c == ',' || c == ']' || c == '}'
+}
+
+// lookup returns the value of the non-finite literal which was replaced to give b. A nil record
+// holds no values.
+func (n *nonFiniteNumbers) lookup(b []byte) (float64, bool) {
+	if n == nil {
+		return 0, false
+	}
+
+	f, ok := n.values[offsetIn(n.doc, b)]
+	return f, ok
+}
+
+// setNonFinite stores the non-finite value f, found at the given offset, into p, which must be a float.
+func setNonFinite(f float64, p reflect.Value, offset int) error {
+	if p.Kind() != reflect.Float32 && p.Kind() != reflect.Float64 {
+		return &UnmarshalTypeError{Value: nonFiniteLiteral(f), Type: p.Type(), Offset: offset, nonFinite: true}
+	}
+
+	p.SetFloat(f)
+	return nil
+}
+
+// nonFiniteLiteral returns the literal for f.
+func nonFiniteLiteral(f float64) string {
+	for _, l := range nonFiniteLiterals {
+		if f == l.value {
+			return l.literal
+		}
+	}
+
+	return nonFiniteLiterals[0].literal
+}
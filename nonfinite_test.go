@@ -0,0 +1,114 @@
+package gojson
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonFiniteNumbers(t *testing.T) {
+	data := []byte(`{"nan": NaN, "inf": Infinity, "neg":-Infinity, "list": [1.5,NaN ,-Infinity], "text": "NaN, Infinity", "after": 3}`)
+	opts := Options{NonFiniteNumbers: true}
+
+	t.Run("Disabled", func(t *testing.T) {
+		var v map[string]interface{}
+		assert.Equal(t, ErrMalformedJSON, Unmarshal([]byte(`NaN`), &v))
+		assert.NotNil(t, Unmarshal(data, &v))
+	})
+
+	t.Run("Struct", func(t *testing.T) {
+		var v struct {
+			NaN   float64   `json:"nan"`
+			Inf   float32   `json:"inf"`
+			Neg   *float64  `json:"neg"`
+			List  []float64 `json:"list"`
+			Text  string    `json:"text"`
+			After int       `json:"after"`
+		}
+		assert.Nil(t, UnmarshalWithOptions(data, &v, opts))
+
+		assert.True(t, math.IsNaN(v.NaN))
+		assert.True(t, math.IsInf(float64(v.Inf), 1))
+		assert.True(t, math.IsInf(*v.Neg, -1))
+		if assert.Len(t, v.List, 3) {
+			assert.Equal(t, 1.5, v.List[0])
+			assert.True(t, math.IsNaN(v.List[1]))
+			assert.True(t, math.IsInf(v.List[2], -1))
+		}
+		assert.Equal(t, "NaN, Infinity", v.Text)
+		assert.Equal(t, 3, v.After)
+	})
+
+	t.Run("Interface", func(t *testing.T) {
+		var v map[string]interface{}
+		assert.Nil(t, UnmarshalWithOptions(data, &v, opts))
+
+		assert.True(t, math.IsNaN(v["nan"].(float64)))
+		assert.Equal(t, math.Inf(1), v["inf"])
+		assert.Equal(t, math.Inf(-1), v["neg"])
+		assert.Equal(t, math.Inf(-1), v["list"].([]interface{})[2])
+		assert.Equal(t, "NaN, Infinity", v["text"])
+
+		var root interface{}
+		assert.Nil(t, UnmarshalWithOptions([]byte(` -Infinity `), &root, opts))
+		assert.Equal(t, math.Inf(-1), root)
+	})
+
+	t.Run("BigFloat", func(t *testing.T) {
+		var v struct {
+			Inf big.Float `json:"inf"`
+		}
+		assert.Nil(t, UnmarshalWithOptions(data, &v, opts))
+		assert.True(t, v.Inf.IsInf())
+
+		var n struct {
+			NaN big.Float `json:"nan"`
+		}
+		assert.EqualError(t, UnmarshalWithOptions(data, &n, opts), "NaN can not be held by a big.Float")
+	})
+
+	t.Run("NotFloat", func(t *testing.T) {
+		var v struct {
+			Inf int `json:"inf"`
+		}
+		err := UnmarshalWithOptions(data, &v, opts)
+		var typeErr *UnmarshalTypeError
+		if assert.ErrorAs(t, err, &typeErr) {
+			assert.Equal(t, "Infinity", typeErr.Value)
+			assert.EqualError(t, err, "key 'inf' with non-finite value 'Infinity' can not be held by type 'int'")
+		}
+	})
+
+	t.Run("Original", func(t *testing.T) {
+		raw := []byte(`[NaN]`)
+		var v []float64
+		assert.Nil(t, UnmarshalWithOptions(raw, &v, opts))
+		assert.Equal(t, `[NaN]`, string(raw))
+	})
+}
+
+func TestReplaceNonFinite(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+		count    int
+	}{
+		{`[1, 2]`, `[1, 2]`, 0},
+		{`["NaN", "a\"NaN"]`, `["NaN", "a\"NaN"]`, 0},
+		{`[NaN,Infinity,-Infinity]`, `[0.0,0.0     ,-0.0     ]`, 3},
+		{`{"a":NaN}`, `{"a":0.0}`, 1},
+		{`[NaNa, -Infinityx]`, `[NaNa, -Infinityx]`, 0},
+	}
+
+	for _, tc := range tests {
+		out, n := replaceNonFinite([]byte(tc.in))
+		assert.Equal(t, tc.expected, string(out), tc.in)
+		if tc.count == 0 {
+			assert.Nil(t, n, tc.in)
+		} else if assert.NotNil(t, n, tc.in) {
+			assert.Len(t, n.values, tc.count, tc.in)
+		}
+	}
+}
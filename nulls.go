@@ -0,0 +1,30 @@
+package gojson
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+var (
+	typeJSONUnmarshaler   = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	typeGoJSONUnmarshaler = reflect.TypeOf((*GoJSONUnmarshaler)(nil)).Elem()
+	typeNullable          = reflect.TypeOf((*nullable)(nil)).Elem()
+	typeReader            = reflect.TypeOf(JSONReader{})
+)
+
+// acceptsNull returns whether a field of type t can hold null, for RejectNulls: pointers, interfaces,
+// maps and slices, whose zero value is nil, and types which decode null themselves, such as Optional
+// and types with an UnmarshalJSON method or a registered decoder.
+func acceptsNull(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return true
+	}
+
+	if _, ok := lookupDecoder(t); ok || t == typeReader {
+		return true
+	}
+
+	pt := reflect.PtrTo(t)
+	return pt.Implements(typeJSONUnmarshaler) || pt.Implements(typeGoJSONUnmarshaler) || pt.Implements(typeNullable)
+}
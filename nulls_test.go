@@ -0,0 +1,60 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRejectNulls(t *testing.T) {
+	type Inner struct {
+		N int `json:"n"`
+	}
+	type Record struct {
+		Count   int                    `json:"count"`
+		Name    string                 `json:"name"`
+		Role    string                 `json:"role,default=member"`
+		Inner   Inner                  `json:"inner"`
+		Ptr     *int                   `json:"ptr"`
+		Any     interface{}            `json:"any"`
+		List    []int                  `json:"list"`
+		Map     map[string]interface{} `json:"map"`
+		Opt     Optional[int]          `json:"opt"`
+		Raw     RawMessage             `json:"raw"`
+		Reader  JSONReader             `json:"reader"`
+		Numbers [2]int                 `json:"numbers"`
+	}
+
+	opts := Options{RejectNulls: true}
+
+	t.Run("Accepted", func(t *testing.T) {
+		var r Record
+		data := []byte(`{"role": null, "ptr": null, "any": null, "list": null, "map": null, "opt": null, "raw": null, "reader": null, "inner": {"n": 1}}`)
+		assert.Nil(t, UnmarshalWithOptions(data, &r, opts))
+		assert.Equal(t, "member", r.Role)
+		assert.True(t, r.Opt.Present)
+		assert.Equal(t, 1, r.Inner.N)
+	})
+
+	testCases := []struct {
+		Name string
+		Data string
+		Err  string
+	}{
+		{"Int", `{"count": null}`, "key 'count' for struct 'Record' is null, which type 'int' can not hold"},
+		{"String", `{"name": null}`, "key 'name' for struct 'Record' is null, which type 'string' can not hold"},
+		{"Struct", `{"inner": null}`, "key 'inner' for struct 'Record' is null, which type 'gojson.Inner' can not hold"},
+		{"Nested", `{"inner": {"n": null}}`, "key 'n' for struct 'Inner' is null, which type 'int' can not hold"},
+		{"Array", `{"numbers": null}`, "key 'numbers' for struct 'Record' is null, which type '[2]int' can not hold"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var r Record
+			assert.EqualError(t, UnmarshalWithOptions([]byte(tc.Data), &r, opts), tc.Err)
+
+			// Without the option, null leaves the zero value.
+			assert.Nil(t, Unmarshal([]byte(tc.Data), &r))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package gojson
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Null holds a value which may be null, in the manner of sql.NullString. Valid is true if the value
+// is not null. A Null field whose key is missing is left untouched, so Valid is false in both cases;
+// use Optional to tell them apart.
+//
+// Null and Optional are populated by Unmarshal itself, so a null value is accepted in any mode, while
+// a non-null value is decoded under the same rules (including StrictStandards) as a field of type T.
+//
+// Example:
+//
+//	var v struct {
+//		Age gojson.Null[int] `json:"age"`
+//	}
+//	err := gojson.UnmarshalStrict([]byte(`{"age": null}`), &v) // v.Age.Valid == false
+type Null[T any] struct {
+	Value T
+	Valid bool
+}
+
+// Optional holds a value which may be missing or null. Present is true if the key was found, and
+// Valid is true if it was found and not null. This allows PATCH style updates to distinguish a key
+// which was left out (leave the value as-is) from one set to null (clear the value).
+type Optional[T any] struct {
+	Value   T
+	Present bool
+	Valid   bool
+}
+
+// nullable is implemented by Null and Optional, which Unmarshal populates directly.
+type nullable interface {
+	// found records that the key was found, and whether its value was null. The wrapped value to
+	// decode into is returned, or the zero Value if there is nothing to decode.
+	found(null bool) reflect.Value
+}
+
+func (n *Null[T]) found(null bool) reflect.Value {
+	var zero T
+	n.Value, n.Valid = zero, !null
+	if null {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(&n.Value).Elem()
+}
+
+func (o *Optional[T]) found(null bool) reflect.Value {
+	var zero T
+	o.Value, o.Present, o.Valid = zero, true, !null
+	if null {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(&o.Value).Elem()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that encoding/json can populate a Null.
+func (n *Null[T]) UnmarshalJSON(b []byte) error {
+	return unmarshalNullable(b, n)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that encoding/json can populate an Optional.
+func (o *Optional[T]) UnmarshalJSON(b []byte) error {
+	return unmarshalNullable(b, o)
+}
+
+// MarshalJSON encodes the value, or null if it is not Valid.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte(`null`), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// MarshalJSON encodes the value, or null if it is not Valid.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Valid {
+		return []byte(`null`), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+func unmarshalNullable(b []byte, n nullable) (err error) {
+	defer PanicRecovery(&err)
+
+	u := unmarshaler{Options: DefaultOptions}
+	return u.unmarshalNullable(trim(b), GetJSONType(b, 0), n, tagOptions{})
+}
+
+// unmarshalNullable populates a Null or Optional.
+func (u *unmarshaler) unmarshalNullable(b []byte, t string, n nullable, opts tagOptions) error {
+	if t == JSONInvalid {
+		return ErrMalformedJSON
+	}
+
+	v := n.found(t == JSONNull)
+	if !v.IsValid() {
+		return nil
+	}
+
+	return u.unmarshalValue(b, t, resolvePtr(v), opts)
+}
@@ -0,0 +1,105 @@
+package gojson
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullAndOptional(t *testing.T) {
+	type Test struct {
+		Name  Optional[string] `json:"name"`
+		Age   Null[int]        `json:"age"`
+		Tags  Optional[[]int]  `json:"tags"`
+		Ptr   Null[*float64]   `json:"ptr"`
+		Inner Optional[struct {
+			A int `json:"a,required"`
+		}] `json:"inner"`
+	}
+
+	t.Run("Set", func(t *testing.T) {
+		var m Test
+		err := UnmarshalStrict([]byte(`{"name": "Bob", "age": 7, "tags": [1, 2], "ptr": 1.5, "inner": {"a": 3}}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, Optional[string]{Value: "Bob", Present: true, Valid: true}, m.Name)
+		assert.Equal(t, Null[int]{Value: 7, Valid: true}, m.Age)
+		assert.Equal(t, []int{1, 2}, m.Tags.Value)
+		assert.Equal(t, 1.5, *m.Ptr.Value)
+		assert.Equal(t, 3, m.Inner.Value.A)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		m := Test{Name: Optional[string]{Value: "old", Present: true, Valid: true}, Age: Null[int]{Value: 1, Valid: true}}
+		err := UnmarshalStrict([]byte(`{"name": null, "age": null, "inner": null}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, Optional[string]{Present: true}, m.Name)
+		assert.Equal(t, Null[int]{}, m.Age)
+		assert.True(t, m.Inner.Present)
+		assert.False(t, m.Inner.Valid)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		var m Test
+		err := Unmarshal([]byte(`{}`), &m)
+		assert.Nil(t, err)
+		assert.False(t, m.Name.Present)
+		assert.False(t, m.Age.Valid)
+	})
+
+	t.Run("Conversion Rules", func(t *testing.T) {
+		var m Test
+		err := Unmarshal([]byte(`{"age": "12"}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, Null[int]{Value: 12, Valid: true}, m.Age)
+
+		err = UnmarshalStrict([]byte(`{"age": "12"}`), &m)
+		assert.True(t, strings.HasPrefix(err.Error(), "strict standards error, expected int, got string"))
+
+		err = Unmarshal([]byte(`{"inner": {}}`), &m)
+		assert.Equal(t, "missing required keys 'a' for struct ''", err.Error())
+	})
+
+	t.Run("Root", func(t *testing.T) {
+		var n Null[string]
+		assert.Nil(t, Unmarshal([]byte(`"x"`), &n))
+		assert.Equal(t, Null[string]{Value: "x", Valid: true}, n)
+
+		assert.Nil(t, Unmarshal([]byte(`null`), &n))
+		assert.Equal(t, Null[string]{}, n)
+
+		var o Optional[int]
+		assert.NotNil(t, UnmarshalStrict([]byte(`"x"`), &o))
+	})
+
+	t.Run("Collections", func(t *testing.T) {
+		var s []Null[int]
+		assert.Nil(t, Unmarshal([]byte(`[1, null, 3]`), &s))
+		assert.Equal(t, []Null[int]{{Value: 1, Valid: true}, {}, {Value: 3, Valid: true}}, s)
+
+		var m map[string]Optional[bool]
+		assert.Nil(t, Unmarshal([]byte(`{"a": true, "b": null}`), &m))
+		assert.Equal(t, map[string]Optional[bool]{"a": {Value: true, Present: true, Valid: true}, "b": {Present: true}}, m)
+	})
+
+	t.Run("encoding/json", func(t *testing.T) {
+		var m struct {
+			A Optional[int] `json:"a"`
+			B Null[string]  `json:"b"`
+			C Optional[int] `json:"c"`
+		}
+		err := json.Unmarshal([]byte(`{"a": "5", "b": null}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, Optional[int]{Value: 5, Present: true, Valid: true}, m.A)
+		assert.Equal(t, Null[string]{}, m.B)
+		assert.False(t, m.C.Present)
+
+		b, err := json.Marshal(m)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"a":5,"b":null,"c":null}`, string(b))
+
+		err = json.Unmarshal([]byte(`{"a": [}`), &m)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,225 @@
+package gojson
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Options configures the behavior of UnmarshalWithOptions.
+type Options struct {
+	// StrictStandards enforces strict type association, as in UnmarshalStrict.
+	StrictStandards bool
+
+	// KeyConvention determines the JSON key expected for struct fields without a name in their tag.
+	KeyConvention KeyConvention
+
+	// Registry resolves the concrete types for interface fields tagged with a discriminator.
+	// DefaultRegistry is used when nil.
+	Registry *TypeRegistry
+
+	// MaxDepth limits the nesting depth of a document. Zero selects DefaultMaxDepth, and a negative
+	// value disables the limit. Documents exceeding it are rejected with a DepthExceededError.
+	MaxDepth int
+
+	// MaxStringLength and MaxNodes limit the encoded length of any string (including keys), and the
+	// total number of values in a document. Documents exceeding a limit are rejected with a LimitError
+	// before any decoding takes place. Zero means no limit.
+	MaxStringLength int
+	MaxNodes        int
+
+	// MaxTokenSize limits the encoded length of any single token: a string (including keys, and
+	// excluding its quotes), a number, or a literal. MaxDocumentSize limits the length of the whole
+	// document, and is checked before the document is scanned. Documents exceeding either are rejected
+	// with a LimitError. Zero means no limit.
+	MaxTokenSize    int
+	MaxDocumentSize int
+
+	// NumberConversion determines how a number with a fractional part (e.g. 173.22 or 4e-3) is
+	// decoded into an integer field.
+	NumberConversion NumberConversion
+
+	// InvalidUTF8 determines how invalid UTF-8, and escaped UTF-16 surrogates without their other half
+	// (e.g. "\ud800"), are handled in string values.
+	InvalidUTF8 InvalidUTF8
+
+	// BigNumbers decodes the numbers within interface{} values which an int or float64 can't hold
+	// as a *big.Int or *big.Float, rather than losing them: integers beyond the range of an int, and
+	// numbers with a fraction or exponent which are beyond the range of a float64, or have more than
+	// 15 significant digits.
+	BigNumbers bool
+
+	// NonFiniteNumbers accepts the literals NaN, Infinity and -Infinity, which aren't JSON, as the
+	// corresponding float64 values. They may be decoded into floats and interface{} values, and are
+	// rejected for any other type. Without it, a document containing them is malformed.
+	NonFiniteNumbers bool
+
+	// RejectNulls treats a null decoded into a struct field which can't hold it, such as an int, a
+	// string or a struct, as an error rather than leaving the field's zero value, so that an API which
+	// starts sending null for a field is noticed. Pointers, interfaces, maps and slices accept null, as
+	// do types which decode null themselves, such as Optional. A field's default replaces null first.
+	// StrictStandards rejects most of the same nulls as type mismatches, along with every other
+	// mismatch; RejectNulls may be used with or without it.
+	RejectNulls bool
+
+	// RelaxedQuotes accepts strings in single quotes, and object keys without quotes, as written by
+	// JavaScript (e.g. {name: 'Ann'}). It is independent of StrictStandards, which concerns the types
+	// of values rather than the syntax of the document.
+	RelaxedQuotes bool
+
+	// ExpandEnv, when set, expands the environment variables referred to within string values, as
+	// ${NAME} or $NAME, before the document is decoded. See EnvExpansion.
+	ExpandEnv *EnvExpansion
+
+	// AllowTrailingData decodes the top-level value of a document which is followed by more data, such
+	// as the first line of NDJSON, and ignores the rest, rather than returning a TrailingDataError.
+	AllowTrailingData bool
+
+	// OrderedObjects decodes the objects within interface{} values as Pairs, listing their members in
+	// document order, rather than as map[string]interface{}, so that they encode deterministically.
+	OrderedObjects bool
+
+	// Base64Bytes decodes every []byte as the base64 encoding of a string, as encoding/json does,
+	// rather than as the raw contents of the string. The base64 tag option does so for a single field.
+	Base64Bytes bool
+
+	// Encoding is the character encoding of the document, which is transcoded to UTF-8 before it is
+	// decoded. AutoEncoding, the default, detects UTF-16.
+	Encoding Encoding
+
+	// InternKeys decodes the keys of maps (including the objects of interface{} values and OrderedMap)
+	// as one string per distinct key, rather than a new string for every occurrence. This cuts the
+	// memory held by large arrays of similar objects, at the cost of a map lookup per key.
+	InternKeys bool
+}
+
+// DefaultOptions are the options used by Unmarshal and UnmarshalStrict (which additionally
+// sets StrictStandards). Since struct metadata is cached, DefaultOptions should only be
+// changed during program initialization.
+var DefaultOptions Options
+
+// NumberConversion determines how a number with a fractional part is converted to an integer.
+type NumberConversion int
+
+const (
+	// TruncateNumbers discards the fractional part, so that 173.92 becomes 173 and 4e-3 becomes 0.
+	TruncateNumbers NumberConversion = iota
+
+	// RoundNumbers rounds to the nearest integer, with halves rounded away from zero, so that 173.5
+	// becomes 174 and -173.5 becomes -174.
+	RoundNumbers
+
+	// RejectLossyNumbers treats a number with a fractional part, or one too large for an int, as an
+	// error. Unmarshal returns the error, while the JSONReader integer functions return 0 and record a
+	// ConversionError, reported by Err, as they do for values rejected under StrictStandards.
+	RejectLossyNumbers
+)
+
+// toInt converts f, parsed from b, to an int.
+func (c NumberConversion) toInt(f float64, b []byte) (int, error) {
+	switch c {
+	case RoundNumbers:
+		return int(math.Round(f)), nil
+	case RejectLossyNumbers:
+		if f != math.Trunc(f) || f < math.MinInt || f >= math.MaxInt {
+			return 0, fmt.Errorf("number '%s' can not be converted to an int without loss", truncate(b, 50))
+		}
+	}
+
+	return int(f), nil
+}
+
+// InvalidUTF8 determines how invalid UTF-8 and unpaired surrogates within string values are handled.
+// It doesn't apply to object keys.
+type InvalidUTF8 int
+
+const (
+	// ReplaceInvalidUTF8 replaces each invalid byte, and each unpaired surrogate, with the replacement
+	// character U+FFFD, as encoding/json does.
+	ReplaceInvalidUTF8 InvalidUTF8 = iota
+
+	// KeepInvalidUTF8 leaves invalid bytes untouched, and encodes unpaired surrogates as their three byte
+	// UTF-8 form (as WTF-8 does), so the original data can be recovered.
+	KeepInvalidUTF8
+
+	// RejectInvalidUTF8 treats invalid UTF-8 or an unpaired surrogate as an error. Unmarshal returns the
+	// error, while the JSONReader string functions return "" and record a ConversionError, reported by
+	// Err, as they do for lossy numbers under RejectLossyNumbers.
+	RejectInvalidUTF8
+)
+
+// KeyConvention is a naming convention used to derive JSON keys from Go field names.
+type KeyConvention int
+
+const (
+	// DefaultKeys matches untagged fields by their field name, its lowercase form, and its
+	// form with the first character lowered (e.g. DataString, datastring, dataString).
+	DefaultKeys KeyConvention = iota
+
+	// SnakeCase expects keys like user_id for a field named UserID.
+	SnakeCase
+
+	// KebabCase expects keys like user-id for a field named UserID.
+	KebabCase
+
+	// CamelCase expects keys like userId for a field named UserID.
+	CamelCase
+)
+
+// Keys returns the JSON keys expected for the given Go field name.
+func (c KeyConvention) Keys(name string) []string {
+	switch c {
+	case SnakeCase:
+		return []string{strings.Join(lowerWords(name), "_")}
+	case KebabCase:
+		return []string{strings.Join(lowerWords(name), "-")}
+	case CamelCase:
+		words := lowerWords(name)
+		for i := 1; i < len(words); i++ {
+			r := []rune(words[i])
+			r[0] = unicode.ToUpper(r[0])
+			words[i] = string(r)
+		}
+		return []string{strings.Join(words, "")}
+	}
+
+	return []string{name, strings.ToLower(name), firstCharLower(name)}
+}
+
+// lowerWords splits a Go identifier into its lowercase words. Runs of capitals are treated
+// as a single word (an acronym), ending before a capital which begins a new word, so that
+// HTTPServerID becomes http, server, id. Digits belong to the word they follow.
+func lowerWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+
+		switch {
+		case cur == '_':
+			if i > start {
+				words = append(words, strings.ToLower(string(runes[start:i])))
+			}
+			start = i + 1
+			continue
+		case i == start:
+			continue
+		case unicode.IsUpper(cur) && !unicode.IsUpper(prev):
+		case unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+		default:
+			continue
+		}
+
+		words = append(words, strings.ToLower(string(runes[start:i])))
+		start = i
+	}
+
+	if start < len(runes) {
+		words = append(words, strings.ToLower(string(runes[start:])))
+	}
+
+	return words
+}
@@ -0,0 +1,224 @@
+package gojson
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyConventionKeys(t *testing.T) {
+	testCases := []struct {
+		name  string
+		snake string
+		kebab string
+		camel string
+	}{
+		{name: "ID", snake: "id", kebab: "id", camel: "id"},
+		{name: "UserID", snake: "user_id", kebab: "user-id", camel: "userId"},
+		{name: "HTTPServerURL", snake: "http_server_url", kebab: "http-server-url", camel: "httpServerUrl"},
+		{name: "FirstName", snake: "first_name", kebab: "first-name", camel: "firstName"},
+		{name: "Version2Name", snake: "version2_name", kebab: "version2-name", camel: "version2Name"},
+		{name: "Already_Snake", snake: "already_snake", kebab: "already-snake", camel: "alreadySnake"},
+		{name: "X", snake: "x", kebab: "x", camel: "x"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, []string{tc.snake}, SnakeCase.Keys(tc.name))
+			assert.Equal(t, []string{tc.kebab}, KebabCase.Keys(tc.name))
+			assert.Equal(t, []string{tc.camel}, CamelCase.Keys(tc.name))
+		})
+	}
+
+	assert.Equal(t, []string{"DataString", "datastring", "dataString"}, DefaultKeys.Keys("DataString"))
+}
+
+func TestUnmarshalWithOptions(t *testing.T) {
+	type Embedded struct {
+		CreatedAt string
+	}
+
+	type Test struct {
+		Embedded
+		UserID    int
+		FirstName string `json:",omitempty"`
+		Nickname  string `json:"nick"`
+	}
+
+	t.Run("SnakeCase", func(t *testing.T) {
+		var m Test
+		err := UnmarshalWithOptions([]byte(`{"user_id": 1, "first_name": "Bob", "nick": "B", "created_at": "today"}`), &m, Options{KeyConvention: SnakeCase})
+		assert.Nil(t, err)
+		assert.Equal(t, Test{Embedded: Embedded{CreatedAt: "today"}, UserID: 1, FirstName: "Bob", Nickname: "B"}, m)
+	})
+
+	t.Run("KebabCase", func(t *testing.T) {
+		var m Test
+		err := UnmarshalWithOptions([]byte(`{"user-id": 1, "first-name": "Bob"}`), &m, Options{KeyConvention: KebabCase})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, m.UserID)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		var m Test
+		err := UnmarshalWithOptions([]byte(`{"user_id": "1", "first_name": "Bob"}`), &m, Options{StrictStandards: true, KeyConvention: SnakeCase})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Default Options", func(t *testing.T) {
+		DefaultOptions = Options{KeyConvention: SnakeCase}
+		defer func() { DefaultOptions = Options{} }()
+
+		var m Test
+		err := Unmarshal([]byte(`{"user_id": 1, "first_name": "Bob"}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, m.UserID)
+	})
+}
+
+func TestNumberConversion(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		conv     NumberConversion
+		expected int
+		err      bool
+	}{
+		{name: "Truncate Float", json: `173.92`, conv: TruncateNumbers, expected: 173},
+		{name: "Truncate Exponent", json: `4e-3`, conv: TruncateNumbers, expected: 0},
+		{name: "Truncate Negative", json: `-173.92`, conv: TruncateNumbers, expected: -173},
+		{name: "Round Down", json: `173.22`, conv: RoundNumbers, expected: 173},
+		{name: "Round Up", json: `173.92`, conv: RoundNumbers, expected: 174},
+		{name: "Round Half", json: `-173.5`, conv: RoundNumbers, expected: -174},
+		{name: "Round String", json: `"2.5"`, conv: RoundNumbers, expected: 3},
+		{name: "Reject Float", json: `173.22`, conv: RejectLossyNumbers, err: true},
+		{name: "Reject Exponent", json: `4e-3`, conv: RejectLossyNumbers, err: true},
+		{name: "Reject String", json: `"1.5"`, conv: RejectLossyNumbers, err: true},
+		{name: "Reject Overflow", json: `1e300`, conv: RejectLossyNumbers, err: true},
+		{name: "Reject Whole Float", json: `4e3`, conv: RejectLossyNumbers, expected: 4000},
+		{name: "Reject Int", json: `42`, conv: RejectLossyNumbers, expected: 42},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var v struct {
+				A int   `json:"a"`
+				B int64 `json:"b"`
+				C []int `json:"c"`
+			}
+			data := []byte(`{"a": ` + tc.json + `, "b": ` + tc.json + `, "c": [` + tc.json + `]}`)
+			err := UnmarshalWithOptions(data, &v, Options{NumberConversion: tc.conv})
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expected, v.A)
+				assert.Equal(t, int64(tc.expected), v.B)
+				assert.Equal(t, []int{tc.expected}, v.C)
+			}
+
+			jr, err := NewJSONReader(data)
+			assert.Nil(t, err)
+			jr.NumberConversion = tc.conv
+			if tc.err {
+				assert.NotPanics(t, func() {
+					assert.Equal(t, 0, jr.GetInt("a"))
+					assert.Equal(t, []int{0}, jr.GetIntSlice("c"))
+					assert.Equal(t, 0, jr.Get("c").GetInt("0"))
+				})
+
+				value := strings.Trim(tc.json, `"`)
+				typ := GetJSONType([]byte(tc.json), 0)
+				assert.Equal(t, ConversionErrors{
+					{Key: "a", Type: typ, Target: "int", Value: value},
+					{Key: "c.0", Type: typ, Target: "int", Value: value},
+					{Key: "c.0", Type: typ, Target: "int", Value: value},
+				}, jr.Err())
+			} else {
+				assert.Equal(t, tc.expected, jr.GetInt("a"))
+				assert.Equal(t, []int{tc.expected}, jr.GetIntSlice("c"))
+				assert.Nil(t, jr.Err())
+			}
+		})
+	}
+}
+
+func TestInvalidUTF8(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		policy   InvalidUTF8
+		expected string
+		err      bool
+	}{
+		{name: "Replace Valid", json: `"héllo 😀"`, expected: "héllo 😀"},
+		{name: "Replace Byte", json: "\"a\xffb\xfe\"", expected: "a�b�"},
+		{name: "Replace Lone High", json: `"a\ud83db"`, expected: "a�b"},
+		{name: "Replace Lone Low", json: `"a\ude00b"`, expected: "a�b"},
+		{name: "Replace High Before Escape", json: `"\ud83d\n\ude00"`, expected: "�\n�"},
+		{name: "Replace High Before Text", json: `"\ud83dxxde00"`, expected: "�xxde00"},
+		{name: "Replace Trailing High", json: `"ab\ud83d"`, expected: "ab�"},
+		{name: "Keep Byte", json: "\"a\xffb\"", policy: KeepInvalidUTF8, expected: "a\xffb"},
+		{name: "Keep Lone High", json: `"a\ud83db"`, policy: KeepInvalidUTF8, expected: "a\xed\xa0\xbdb"},
+		{name: "Keep Pair", json: `"😀"`, policy: KeepInvalidUTF8, expected: "😀"},
+		{name: "Reject Byte", json: "\"a\xffb\"", policy: RejectInvalidUTF8, err: true},
+		{name: "Reject Lone High", json: `"a\ud83db"`, policy: RejectInvalidUTF8, err: true},
+		{name: "Reject Only Surrogate", json: `"\ud800"`, policy: RejectInvalidUTF8, err: true},
+		{name: "Reject Valid", json: `"héllo 😀"`, policy: RejectInvalidUTF8, expected: "héllo 😀"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var v struct {
+				A string            `json:"a"`
+				B []string          `json:"b"`
+				C interface{}       `json:"c"`
+				D map[string]string `json:"d"`
+			}
+			data := []byte(`{"a": ` + tc.json + `, "b": [` + tc.json + `], "c": ` + tc.json + `, "d": {"k": ` + tc.json + `}}`)
+			err := UnmarshalWithOptions(data, &v, Options{InvalidUTF8: tc.policy})
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expected, v.A)
+				assert.Equal(t, []string{tc.expected}, v.B)
+				assert.Equal(t, tc.expected, v.C)
+				assert.Equal(t, map[string]string{"k": tc.expected}, v.D)
+			}
+
+			for _, opts := range [][]ReaderOption{nil, {WithZeroCopyStrings()}} {
+				jr, err := NewJSONReader(data, opts...)
+				assert.Nil(t, err)
+				jr.InvalidUTF8 = tc.policy
+				if tc.err {
+					assert.NotPanics(t, func() {
+						assert.Equal(t, "", jr.GetString("a"))
+						assert.Equal(t, []string{""}, jr.GetStringSlice("b"))
+						assert.Equal(t, "", jr.GetInterface("c"))
+						assert.Equal(t, "", jr.Get("d").GetString("k"))
+						assert.Equal(t, map[string]interface{}{"k": ""}, jr.GetMapStringInterface("d"))
+						assert.Nil(t, jr.GetOrderedMap("d"))
+						assert.Nil(t, jr.GetPairs("d"))
+					})
+
+					value := string(truncate([]byte(tc.json[1:len(tc.json)-1]), 50))
+					assert.Equal(t, ConversionErrors{
+						{Key: "a", Type: JSONString, Target: "string", Value: value},
+						{Key: "b.0", Type: JSONString, Target: "string", Value: value},
+						{Key: "c", Type: JSONString, Target: "string", Value: value},
+						{Key: "d.k", Type: JSONString, Target: "string", Value: value},
+						{Key: "d.k", Type: JSONString, Target: "string", Value: value},
+						{Key: "d", Type: JSONObject, Target: "OrderedMap", Value: `{"k": ` + tc.json + `}`},
+						{Key: "d", Type: JSONObject, Target: "Pairs", Value: `{"k": ` + tc.json + `}`},
+					}, jr.Err())
+				} else {
+					assert.Equal(t, tc.expected, jr.GetString("a"))
+					assert.Equal(t, []string{tc.expected}, jr.GetStringSlice("b"))
+					assert.Equal(t, tc.expected, jr.Get("d").GetString("k"))
+				}
+			}
+		})
+	}
+}
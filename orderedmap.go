@@ -0,0 +1,225 @@
+package gojson
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// OrderedMap is a JSON object which preserves the order of its keys, for tools such as serializers
+// and diff tools which must not reorder a document. Unmarshal populates an OrderedMap (or a field of
+// type OrderedMap) directly, as do GetOrderedMap and ToOrderedMap, and MarshalJSON writes the members
+// back in order.
+//
+// Values are decoded as with interface{} containers, except that nested objects are decoded as
+// *OrderedMap, so that their order is preserved too. As with a Go map, only the last of any duplicate
+// keys is kept, at the position of the first.
+//
+// Example:
+//
+//	var m gojson.OrderedMap
+//	err := gojson.Unmarshal([]byte(`{"b": 1, "a": {"d": 2, "c": 3}}`), &m)
+//	m.Keys                                      // [b a]
+//	m.Values["a"].(*gojson.OrderedMap).Keys     // [d c]
+//	out, _ := json.Marshal(m)                   // {"b":1,"a":{"d":2,"c":3}}
+type OrderedMap struct {
+	// Keys lists the keys of the object in document order.
+	Keys []string
+
+	// Values holds the value of each key.
+	Values map[string]interface{}
+}
+
+// Len returns the number of members.
+func (m *OrderedMap) Len() int {
+	return len(m.Keys)
+}
+
+// Get returns the value of key, and whether it exists.
+func (m *OrderedMap) Get(key string) (interface{}, bool) {
+	v, ok := m.Values[key]
+	return v, ok
+}
+
+// Set sets the value of key. A new key is added after the existing keys, while an existing key keeps
+// its position.
+func (m *OrderedMap) Set(key string, value interface{}) {
+	if m.Values == nil {
+		m.Values = make(map[string]interface{})
+	}
+
+	if _, ok := m.Values[key]; !ok {
+		m.Keys = append(m.Keys, key)
+	}
+	m.Values[key] = value
+}
+
+// Delete removes key, if it exists.
+func (m *OrderedMap) Delete(key string) {
+	if _, ok := m.Values[key]; !ok {
+		return
+	}
+
+	delete(m.Values, key)
+	for i, k := range m.Keys {
+		if k == key {
+			m.Keys = append(m.Keys[:i:i], m.Keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// MarshalJSON encodes the members in order. Values are encoded by encoding/json.
+func (m OrderedMap) MarshalJSON() ([]byte, error) {
+	out := []byte{'{'}
+	for i, k := range m.Keys {
+		if i > 0 {
+			out = append(out, ',')
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(m.Values[k])
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(append(append(out, key...), ':'), value...)
+	}
+
+	return append(out, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, so that encoding/json can populate an OrderedMap. Unmarshal
+// populates an OrderedMap itself, applying its options.
+func (m *OrderedMap) UnmarshalJSON(b []byte) (err error) {
+	defer PanicRecovery(&err)
+
+	u := unmarshaler{Options: DefaultOptions}
+	return u.unmarshalOrderedMap(trim(b), GetJSONType(b, 0), m)
+}
+
+// unmarshalOrderedMap replaces the contents of m with the members of b. As with a map container, array
+// elements are keyed by their index, and a scalar is keyed by 0. Null leaves m untouched.
+func (u *unmarshaler) unmarshalOrderedMap(b []byte, t string, m *OrderedMap) error {
+	switch {
+	case t == JSONInvalid:
+		return ErrMalformedJSON
+	case t == JSONNull:
+		return nil
+	case u.StrictStandards && t != JSONObject:
+		return u.strictError(b, JSONObject, t)
+	}
+
+	*m = OrderedMap{Keys: []string{}, Values: make(map[string]interface{})}
+
+	if t == JSONObject {
+		return eachMember(b, t, u.interner, func(k string, v []byte, vt string) error {
+			e, err := u.orderedValue(v, vt)
+			if err != nil {
+				return fieldError(err, k)
+			}
+			m.Set(k, e)
+			return nil
+		})
+	}
+
+	return EachElement(b, t, func(v []byte, vt string) error {
+		k := u.interner.index(m.Len())
+		e, err := u.orderedValue(v, vt)
+		if err != nil {
+			return fieldError(err, k)
+		}
+		m.Set(k, e)
+		return nil
+	})
+}
+
+// orderedValue decodes a value of an OrderedMap, as unmarshalInterface does, except that objects are
+// decoded as *OrderedMap.
+func (u *unmarshaler) orderedValue(b []byte, t string) (interface{}, error) {
+	switch t {
+	case JSONObject:
+		m := &OrderedMap{}
+		return m, u.unmarshalOrderedMap(b, t, m)
+	case JSONArray:
+		s := []interface{}{}
+		err := EachElement(b, t, func(v []byte, vt string) error {
+			e, err := u.orderedValue(v, vt)
+			if err != nil {
+				return fieldError(err, strconv.Itoa(len(s)))
+			}
+			s = append(s, e)
+			return nil
+		})
+		return s, err
+	}
+
+	return convertInternedIface(b, t, u.ifaceOptions())
+}
+
+// GetOrderedMap retrieves a given key as an OrderedMap, if it exists. Arrays are keyed by index, and
+// a scalar by 0, as with GetMapStringInterface.
+func (jr *JSONReader) GetOrderedMap(key string) *OrderedMap {
+	p := jr.getChildByKey(key)
+	if p == nil || !jr.strictContainer(key, p.bytes, p.dtype, JSONObject, "OrderedMap") {
+		return nil
+	}
+
+	m := &OrderedMap{Keys: []string{}, Values: make(map[string]interface{})}
+	u := unmarshaler{Options: Options{StrictStandards: jr.StrictStandards, NumberConversion: jr.NumberConversion, InvalidUTF8: jr.InvalidUTF8}}
+
+	switch p.dtype {
+	case JSONObject, JSONArray:
+		if err := u.unmarshalOrderedMap(p.bytes, p.dtype, m); err != nil {
+			jr.reject(key, p.bytes, p.dtype, "OrderedMap")
+			return nil
+		}
+	default:
+		m.Set("0", toIface(p.bytes, p.dtype, jr.StrictStandards))
+	}
+
+	return m
+}
+
+// ToOrderedMap returns all top-level data as an OrderedMap.
+func (jr *JSONReader) ToOrderedMap() *OrderedMap {
+	return jr.GetOrderedMap("")
+}
+
+// GetOrderedMapE retrieves a given key as an OrderedMap, returning an error if it does not exist.
+func (jr *JSONReader) GetOrderedMapE(key string) (*OrderedMap, error) {
+	if jr.Empty {
+		return nil, ErrEmpty
+	}
+
+	p := jr.getChildByKey(key)
+	if p == nil {
+		return nil, keyNotFound(key)
+	}
+
+	m := &OrderedMap{Keys: []string{}, Values: make(map[string]interface{})}
+	u := unmarshaler{Options: Options{NumberConversion: jr.NumberConversion, InvalidUTF8: jr.InvalidUTF8}}
+
+	switch p.dtype {
+	case JSONObject, JSONArray:
+		if err := u.unmarshalOrderedMap(p.bytes, p.dtype, m); err != nil {
+			return nil, fieldError(err, key)
+		}
+	default:
+		v, err := checkedIface(key, *p)
+		if err != nil {
+			return nil, err
+		}
+		m.Set("0", v)
+	}
+
+	return m, nil
+}
+
+// ToOrderedMapE returns all top-level data as an OrderedMap, returning ErrEmpty if the reader is empty.
+func (jr *JSONReader) ToOrderedMapE() (*OrderedMap, error) {
+	return jr.GetOrderedMapE("")
+}
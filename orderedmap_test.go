@@ -0,0 +1,100 @@
+package gojson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedMap(t *testing.T) {
+	data := []byte(`{"z": 1, "b": {"y": [1, {"q": true, "p": null}], "x": "s"}, "a": 2.5, "z": 3}`)
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		var m OrderedMap
+		assert.Nil(t, Unmarshal(data, &m))
+		assert.Equal(t, []string{"z", "b", "a"}, m.Keys)
+		assert.Equal(t, 3, m.Values["z"])
+		assert.Equal(t, 2.5, m.Values["a"])
+
+		b := m.Values["b"].(*OrderedMap)
+		assert.Equal(t, []string{"y", "x"}, b.Keys)
+		assert.Equal(t, []string{"q", "p"}, b.Values["y"].([]interface{})[1].(*OrderedMap).Keys)
+
+		out, err := json.Marshal(m)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"z":3,"b":{"y":[1,{"q":true,"p":null}],"x":"s"},"a":2.5}`, string(out))
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		var v struct {
+			Meta  OrderedMap  `json:"b"`
+			Empty *OrderedMap `json:"empty"`
+			Null  OrderedMap  `json:"null"`
+		}
+		assert.Nil(t, Unmarshal([]byte(`{"b": {"y": 1, "x": 2}, "empty": {}, "null": null}`), &v))
+		assert.Equal(t, []string{"y", "x"}, v.Meta.Keys)
+		assert.Equal(t, 0, v.Empty.Len())
+		assert.Nil(t, v.Null.Keys)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		var m OrderedMap
+		err := UnmarshalStrict([]byte(`[1, 2]`), &m)
+		assert.EqualError(t, err, "strict standards error, expected object, got array, offset 0")
+
+		assert.Nil(t, Unmarshal([]byte(`[1, 2]`), &m))
+		assert.Equal(t, []string{"0", "1"}, m.Keys)
+
+		err = UnmarshalStrict([]byte(`{"a": {"b": "\q"}}`), &m)
+		assert.EqualError(t, err, `invalid escape sequence in segment '"\q"'`)
+	})
+
+	t.Run("Encoding JSON", func(t *testing.T) {
+		var m OrderedMap
+		assert.Nil(t, json.Unmarshal([]byte(`{"b": 1, "a": 2}`), &m))
+		assert.Equal(t, []string{"b", "a"}, m.Keys)
+	})
+
+	t.Run("Reader", func(t *testing.T) {
+		jr, err := NewJSONReader(data)
+		assert.Nil(t, err)
+
+		assert.Equal(t, []string{"z", "b", "a"}, jr.ToOrderedMap().Keys)
+		assert.Equal(t, []string{"y", "x"}, jr.GetOrderedMap("b").Keys)
+		assert.Equal(t, &OrderedMap{Keys: []string{"0"}, Values: map[string]interface{}{"0": "s"}}, jr.GetOrderedMap("b.x"))
+		assert.Nil(t, jr.GetOrderedMap("missing"))
+
+		m, err := jr.GetOrderedMapE("b")
+		assert.Nil(t, err)
+		assert.Equal(t, jr.GetOrderedMap("b"), m)
+		m, err = jr.ToOrderedMapE()
+		assert.Nil(t, err)
+		assert.Equal(t, jr.ToOrderedMap(), m)
+
+		_, err = jr.GetOrderedMapE("missing")
+		assert.EqualError(t, err, "key 'missing' not found")
+		assert.ErrorIs(t, err, ErrNoSuchKey)
+
+		_, err = (&JSONReader{Empty: true}).ToOrderedMapE()
+		assert.Equal(t, ErrEmpty, err)
+	})
+
+	t.Run("Set and Delete", func(t *testing.T) {
+		var m OrderedMap
+		m.Set("a", 1)
+		m.Set("b", 2)
+		m.Set("a", 3)
+		m.Delete("missing")
+		assert.Equal(t, []string{"a", "b"}, m.Keys)
+
+		m.Delete("a")
+		v, ok := m.Get("b")
+		assert.Equal(t, []string{"b"}, m.Keys)
+		assert.Equal(t, 2, v)
+		assert.True(t, ok)
+
+		_, ok = m.Get("a")
+		assert.False(t, ok)
+	})
+}
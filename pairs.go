@@ -0,0 +1,162 @@
+package gojson
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// KV is a member of a JSON object: its key and its decoded value.
+type KV struct {
+	Key   string
+	Value interface{}
+}
+
+// Pairs is a JSON object as a list of its members in document order, for output which must be
+// deterministic, such as signed payloads and golden files, without sorting keys after the fact.
+// Unlike map[string]interface{} and OrderedMap, every member of an object with duplicate keys is kept.
+// GetPairs and ToPairs return Pairs, as does Unmarshal for the objects within interface{} values when
+// Options.OrderedObjects is set. Values are decoded as with interface{} containers, except that nested
+// objects are Pairs too. MarshalJSON writes the members back in order.
+//
+// Example:
+//
+//	pairs := reader.GetPairs("payload")
+//	for _, kv := range pairs {
+//		fmt.Println(kv.Key, kv.Value)
+//	}
+type Pairs []KV
+
+// Get returns the value of key, and whether it exists. The last of any duplicate keys is returned.
+func (p Pairs) Get(key string) (interface{}, bool) {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i].Key == key {
+			return p[i].Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Keys returns the keys of the members, in order.
+func (p Pairs) Keys() []string {
+	keys := make([]string, len(p))
+	for i, kv := range p {
+		keys[i] = kv.Key
+	}
+
+	return keys
+}
+
+// MarshalJSON encodes the members in order. Values are encoded by encoding/json.
+func (p Pairs) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+
+	out := []byte{'{'}
+	for i, kv := range p {
+		if i > 0 {
+			out = append(out, ',')
+		}
+
+		key, err := json.Marshal(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(append(append(out, key...), ':'), value...)
+	}
+
+	return append(out, '}'), nil
+}
+
+// convertPairs decodes the object b as Pairs.
+func convertPairs(b []byte, opts ifaceOptions) (Pairs, error) {
+	pairs := Pairs{}
+	err := eachMember(b, JSONObject, opts.keys, func(k string, v []byte, vt string) error {
+		e, err := convertInternedIface(v, vt, opts)
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, KV{Key: k, Value: e})
+		return nil
+	})
+
+	return pairs, err
+}
+
+// GetPairs retrieves a given key as Pairs, if it exists. Arrays are keyed by index, and a scalar by 0,
+// as with GetMapStringInterface.
+func (jr *JSONReader) GetPairs(key string) Pairs {
+	p := jr.getChildByKey(key)
+	if p == nil || !jr.strictContainer(key, p.bytes, p.dtype, JSONObject, "Pairs") {
+		return nil
+	}
+
+	if p.dtype != JSONObject && p.dtype != JSONArray {
+		return Pairs{{Key: "0", Value: toIface(p.bytes, p.dtype, jr.StrictStandards)}}
+	}
+
+	pairs, err := containerPairs(p, ifaceOptions{strict: jr.StrictStandards, policy: jr.InvalidUTF8, pairs: true})
+	if err != nil {
+		jr.reject(key, p.bytes, p.dtype, "Pairs")
+		return nil
+	}
+	return pairs
+}
+
+// containerPairs converts the object or array p to Pairs, keying array elements by index.
+func containerPairs(p *parsed, opts ifaceOptions) (Pairs, error) {
+	if p.dtype == JSONObject {
+		return convertPairs(p.bytes, opts)
+	}
+
+	pairs := Pairs{}
+	err := EachElement(p.bytes, p.dtype, func(v []byte, vt string) error {
+		e, err := convertInternedIface(v, vt, opts)
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, KV{Key: strconv.Itoa(len(pairs)), Value: e})
+		return nil
+	})
+
+	return pairs, err
+}
+
+// ToPairs returns all top-level data as Pairs.
+func (jr *JSONReader) ToPairs() Pairs {
+	return jr.GetPairs("")
+}
+
+// GetPairsE retrieves a given key as Pairs, returning an error if it does not exist.
+func (jr *JSONReader) GetPairsE(key string) (Pairs, error) {
+	if jr.Empty {
+		return nil, ErrEmpty
+	}
+
+	p := jr.getChildByKey(key)
+	if p == nil {
+		return nil, keyNotFound(key)
+	}
+
+	if p.dtype != JSONObject && p.dtype != JSONArray {
+		v, err := checkedIface(key, *p)
+		if err != nil {
+			return nil, err
+		}
+		return Pairs{{Key: "0", Value: v}}, nil
+	}
+
+	return containerPairs(p, ifaceOptions{policy: jr.InvalidUTF8, pairs: true})
+}
+
+// ToPairsE returns all top-level data as Pairs, returning ErrEmpty if the reader is empty.
+func (jr *JSONReader) ToPairsE() (Pairs, error) {
+	return jr.GetPairsE("")
+}
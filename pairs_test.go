@@ -0,0 +1,79 @@
+package gojson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPairs(t *testing.T) {
+	data := []byte(`{"z": 1, "a": {"y": [true, {"c": null, "b": "x"}], "x": 2.5}, "z": 3}`)
+
+	t.Run("Reader", func(t *testing.T) {
+		reader, err := NewJSONReader(data)
+		assert.Nil(t, err)
+
+		pairs := reader.ToPairs()
+		assert.Equal(t, []string{"z", "a", "z"}, pairs.Keys())
+
+		v, ok := pairs.Get("z")
+		assert.True(t, ok)
+		assert.Equal(t, 3, v)
+
+		_, ok = pairs.Get("missing")
+		assert.False(t, ok)
+
+		assert.Equal(t, Pairs{
+			{Key: "y", Value: []interface{}{true, Pairs{{Key: "c"}, {Key: "b", Value: "x"}}}},
+			{Key: "x", Value: 2.5},
+		}, reader.GetPairs("a"))
+
+		assert.Equal(t, Pairs{{Key: "0", Value: true}, {Key: "1", Value: Pairs{{Key: "c"}, {Key: "b", Value: "x"}}}}, reader.GetPairs("a.y"))
+		assert.Equal(t, Pairs{{Key: "0", Value: 2.5}}, reader.GetPairs("a.x"))
+		assert.Nil(t, reader.GetPairs("missing"))
+
+		for _, k := range []string{"a", "a.y", "a.x"} {
+			pairs, err := reader.GetPairsE(k)
+			assert.Nil(t, err)
+			assert.Equal(t, reader.GetPairs(k), pairs, k)
+		}
+		pairs, err = reader.ToPairsE()
+		assert.Nil(t, err)
+		assert.Equal(t, reader.ToPairs(), pairs)
+		_, err = reader.GetPairsE("missing")
+		assert.EqualError(t, err, "key 'missing' not found")
+
+		reader.StrictStandards = true
+		assert.Nil(t, reader.GetPairs("a.y"))
+		assert.EqualError(t, reader.Err(), "key 'a.y' with array value '[true, {\"c\": null, \"b\": \"x\"}]' can not be converted to Pairs")
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v interface{}
+		assert.Nil(t, UnmarshalWithOptions(data, &v, Options{OrderedObjects: true}))
+		assert.Equal(t, []string{"z", "a", "z"}, v.(Pairs).Keys())
+
+		var m map[string]interface{}
+		assert.Nil(t, UnmarshalWithOptions(data, &m, Options{OrderedObjects: true}))
+		assert.IsType(t, Pairs{}, m["a"])
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		reader, err := NewJSONReader(data)
+		assert.Nil(t, err)
+		expected := `{"z":1,"a":{"y":[true,{"c":null,"b":"x"}],"x":2.5},"z":3}`
+
+		b, err := json.Marshal(reader.ToPairs())
+		assert.Nil(t, err)
+		assert.Equal(t, expected, string(b))
+
+		b, err = AppendMarshal(nil, reader.ToPairs())
+		assert.Nil(t, err)
+		assert.Equal(t, expected, string(b))
+
+		b, err = json.Marshal(Pairs(nil))
+		assert.Nil(t, err)
+		assert.Equal(t, "null", string(b))
+	})
+}
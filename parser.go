@@ -3,8 +3,6 @@ package gojson
 import (
 	"errors"
 	"fmt"
-	"strconv"
-	"unsafe"
 )
 
 type parsed struct {
@@ -13,15 +11,28 @@ type parsed struct {
 	bytes    []byte
 	dtype    string
 	children map[string]parsed
+
+	// dups holds the earlier values of any duplicated keys of an object, in document order, as
+	// children only holds the last.
+	dups map[string][]parsed
+
+	// pos is the position of the value, if recorded by WithPositions.
+	pos *Position
 }
 
 var (
 	// ErrEmpty is returned when no input is provided.
 	ErrEmpty = errors.New("empty input value")
 
-	// ErrMalformedJSON is returned when input failed to parse.
+	// ErrMalformedJSON is returned when input failed to parse. The errors describing where parsing
+	// failed match it with errors.Is.
 	ErrMalformedJSON = errors.New("malformed json provided")
 
+	// ErrKeyNotFound is matched by errors.Is for the errors returned when a key path does not exist,
+	// by Extract and its variants, the checked accessors such as GetE, DecodeFirst, Document and
+	// Builder.
+	ErrKeyNotFound = errors.New("key not found")
+
 	period    = []byte{'.'}
 	exponent  = []byte{'e'}
 	exponentE = []byte{'E'}
@@ -33,18 +44,30 @@ func (jr *JSONReader) parse() error {
 		return ErrEmpty
 	}
 
+	if jr.positions != nil {
+		jr.positions.data = jr.rawData
+		jr.positions.base = skipWhitespace(jr.rawData, 0)
+	}
+
+	jr.doc = jr.rawData
 	jr.rawData = trim(jr.rawData)
 
-	p, _ := jr.parseValue(0)
+	p, _, err := jr.parseValue(0)
+	if err != nil {
+		return err
+	}
+
 	if p.dtype == "" {
 		return ErrMalformedJSON
 	}
 
 	jr.Type = p.dtype
+	jr.position = p.pos
 
 	if p.dtype == JSONArray || p.dtype == JSONObject {
 		jr.Keys = p.keys
 		jr.parsed = p.children
+		jr.dups = p.dups
 		return nil
 	}
 
@@ -64,22 +87,12 @@ func (jr *JSONReader) parseKey(start int) ([]byte, int) {
 	}
 
 	start++
-	end := start
 	keyStart := start
-	keyEnd := -1
-
-	escape := false
-	for found := false; !found && end <= len(jr.rawData)-1; end++ {
-		switch {
-		case escape:
-			escape = false
-		case jr.rawData[end] == '\\' && !escape:
-			escape = true
-		case jr.rawData[end] == '"' && !escape: // Found an ending string
-			keyEnd = end
-			found = true
-		}
+	keyEnd := stringEnd(jr.rawData, start)
+	if keyEnd < 0 {
+		return nil, -1
 	}
+	end := keyEnd + 1
 
 	// Advance past the key
 	found := false
@@ -98,40 +111,67 @@ func (jr *JSONReader) parseKey(start int) ([]byte, int) {
 	return jr.rawData[keyStart:keyEnd], end
 }
 
-// ParseKeyValue assumes we start at the beginning of a string.
-func (jr *JSONReader) parseKeyValue(current int) (parsed, int) {
+// ParseKeyValue assumes we start at the beginning of a string. As with parseValue, a position of -1
+// without an error means no member was found.
+func (jr *JSONReader) parseKeyValue(current int) (parsed, int, error) {
 	var key []byte
 	key, current = jr.parseKey(current)
 	if current < 0 {
-		return parsed{}, -1
+		return parsed{}, -1, nil
 	}
 
-	p, current := jr.parseValue(current)
-	if current < 0 {
-		return parsed{}, -1
+	if jr.observer != nil && jr.observer.OnKey != nil {
+		jr.observer.OnKey(string(key))
 	}
 
-	p.key = *(*string)(unsafe.Pointer(&key))
-	return p, current
+	p, current, err := jr.parseValue(current)
+	if current < 0 || err != nil {
+		return parsed{}, -1, err
+	}
+
+	p.key = jr.interner.view(key)
+	return p, current, nil
 }
 
-func (jr *JSONReader) parseValue(current int) (parsed, int) {
+// parseValue parses the value starting at current, returning the position following it. A position of
+// -1 without an error means there is no value at current, as at the end of an array. Malformed values
+// are reported as errors.
+func (jr *JSONReader) parseValue(current int) (parsed, int, error) {
 	var p parsed
+	var err error
 	current = ltrim(jr.rawData, current)
 
+	var pos *Position
+	if jr.positions != nil && current >= 0 && current < len(jr.rawData) {
+		pos = jr.positions.start(current)
+	}
+
 	switch GetJSONType(jr.rawData, current) {
 	case JSONFloat, JSONInt:
-		p, current = jr.parseNumber(current)
+		p, current, err = jr.parseNumber(current)
 	case JSONBool, JSONNull:
-		p, current = jr.parseConst(current)
+		p, current, err = jr.parseConst(current)
 	case JSONString:
-		p, current = jr.parseString(current)
+		p, current, err = jr.parseString(current)
 	case JSONObject:
-		p, current = jr.parseObject(current)
+		p, current, err = jr.parseObject(current)
 	case JSONArray:
-		p, current = jr.parseArray(current)
+		p, current, err = jr.parseArray(current)
 	default:
-		return p, -1
+		return p, -1, nil
+	}
+
+	if err != nil {
+		return parsed{}, -1, err
+	}
+
+	if pos != nil {
+		jr.positions.end(pos, current)
+		p.pos = pos
+	}
+
+	if jr.observer != nil && jr.observer.OnValueType != nil {
+		jr.observer.OnValueType(p.dtype)
 	}
 
 	// Consume the comma, ], or } following the value.
@@ -139,7 +179,7 @@ func (jr *JSONReader) parseValue(current int) (parsed, int) {
 	initial := current
 	current = findTerminator(jr.rawData, current)
 	if current < 0 {
-		panic(fmt.Errorf("expected ',', ']', or '}' at position %d", initial))
+		return parsed{}, -1, fmt.Errorf("expected ',', ']', or '}' at position %d", initial)
 	}
 
 	// Don't consume the ending ] or }, as they're not part of the value
@@ -147,42 +187,31 @@ func (jr *JSONReader) parseValue(current int) (parsed, int) {
 		current--
 	}
 
-	return p, current
+	return p, current, nil
 }
 
-func (jr *JSONReader) parseString(start int) (parsed, int) {
+func (jr *JSONReader) parseString(start int) (parsed, int, error) {
 	initial := start
 	if start < 0 {
 		jr.Empty = true
-		panic(fmt.Errorf(`invalid starting position in parseString`))
+		return parsed{}, -1, fmt.Errorf(`invalid starting position in parseString`)
 	}
 
 	if jr.rawData[start] != '"' {
 		jr.Empty = true
-		panic(fmt.Errorf(`expected '"', found '%s' at position %d`, string(jr.rawData[start]), start))
+		return parsed{}, -1, fmt.Errorf(`expected '"', found '%s' at position %d`, string(jr.rawData[start]), start)
 	}
 
 	start++
-	end := start
-	escape := false
-
-	for found := false; !found && end <= len(jr.rawData)-1; end++ {
-
-		switch {
-		case escape:
-			escape = false
-		case jr.rawData[end] == '\\' && !escape:
-			escape = true
-		case jr.rawData[end] == '"' && !escape: // Found an ending string
-			return parsed{bytes: jr.rawData[start:end], dtype: JSONString}, end + 1
-		}
+	if end := stringEnd(jr.rawData, start); end >= 0 {
+		return parsed{bytes: jr.rawData[start:end], dtype: JSONString}, end + 1, nil
 	}
 
 	jr.Empty = true
-	panic(fmt.Errorf(`unterminated string at starting position %d`, initial))
+	return parsed{}, -1, fmt.Errorf(`unterminated string at starting position %d`, initial)
 }
 
-func (jr *JSONReader) parseArray(current int) (parsed, int) {
+func (jr *JSONReader) parseArray(current int) (parsed, int, error) {
 	var p parsed
 	arrStart := current
 
@@ -195,7 +224,11 @@ func (jr *JSONReader) parseArray(current int) (parsed, int) {
 	var cp parsed
 
 	for ; value > 0; index++ {
-		cp, value = jr.parseValue(current)
+		var err error
+		cp, value, err = jr.parseValue(current)
+		if err != nil {
+			return parsed{}, -1, err
+		}
 		if value < 0 {
 			break
 		}
@@ -204,7 +237,7 @@ func (jr *JSONReader) parseArray(current int) (parsed, int) {
 			p.children = make(map[string]parsed)
 		}
 
-		sIndex := strconv.Itoa(index)
+		sIndex := jr.interner.index(index)
 		p.children[sIndex] = cp
 		p.keys = append(p.keys, sIndex)
 
@@ -220,17 +253,17 @@ func (jr *JSONReader) parseArray(current int) (parsed, int) {
 	current = ltrim(jr.rawData, current)
 	if current >= len(jr.rawData) || jr.rawData[current] != ']' {
 		jr.Empty = true
-		panic(fmt.Errorf("expected ']', found '%s' at position %d", string(jr.rawData[lastValid]), lastValid))
+		return parsed{}, -1, fmt.Errorf("expected ']', found '%s' at position %d", string(jr.rawData[lastValid]), lastValid)
 	}
 
 	current++
 	p.bytes = jr.rawData[arrStart:current]
 	p.dtype = JSONArray
 
-	return p, current
+	return p, current, nil
 }
 
-func (jr *JSONReader) parseObject(current int) (parsed, int) {
+func (jr *JSONReader) parseObject(current int) (parsed, int, error) {
 	var p parsed
 	objStart := current
 
@@ -242,7 +275,11 @@ func (jr *JSONReader) parseObject(current int) (parsed, int) {
 	var cp parsed
 
 	for value > 0 {
-		cp, value = jr.parseKeyValue(current)
+		var err error
+		cp, value, err = jr.parseKeyValue(current)
+		if err != nil {
+			return parsed{}, -1, err
+		}
 		if value < 0 {
 			break
 		}
@@ -251,6 +288,13 @@ func (jr *JSONReader) parseObject(current int) (parsed, int) {
 			p.children = make(map[string]parsed)
 		}
 
+		if prev, ok := p.children[cp.key]; ok {
+			if p.dups == nil {
+				p.dups = make(map[string][]parsed)
+			}
+			p.dups[cp.key] = append(p.dups[cp.key], prev)
+		}
+
 		p.children[cp.key] = cp
 		p.keys = append(p.keys, cp.key)
 
@@ -258,44 +302,48 @@ func (jr *JSONReader) parseObject(current int) (parsed, int) {
 		lastValid = value
 	}
 
+	if lastValid == len(jr.rawData) {
+		lastValid = len(jr.rawData) - 1
+	}
+
 	// Consume the }
 	current = ltrim(jr.rawData, current)
-	if jr.rawData[current] != '}' {
+	if current >= len(jr.rawData) || jr.rawData[current] != '}' {
 		jr.Empty = true
-		panic(fmt.Errorf("expected '}', found '%s' at position %d", string(jr.rawData[lastValid]), lastValid))
+		return parsed{}, -1, fmt.Errorf("expected '}', found '%s' at position %d", string(jr.rawData[lastValid]), lastValid)
 	}
 
 	current++
 	p.bytes = jr.rawData[objStart:current]
 	p.dtype = JSONObject
 
-	return p, current
+	return p, current, nil
 }
 
-func (jr *JSONReader) parseConst(start int) (parsed, int) {
+func (jr *JSONReader) parseConst(start int) (parsed, int, error) {
 	start = ltrim(jr.rawData, start)
 	initial := start
 	length := len(jr.rawData) - start
 
 	if length >= 4 {
 		if IsJSONTrue(jr.rawData[start : start+4]) {
-			return parsed{bytes: jr.rawData[start : start+4], dtype: JSONBool}, start + 4
+			return parsed{bytes: jr.rawData[start : start+4], dtype: JSONBool}, start + 4, nil
 		}
 
 		if IsJSONNull(jr.rawData[start : start+4]) {
-			return parsed{bytes: jr.rawData[start : start+4], dtype: JSONNull}, start + 4
+			return parsed{bytes: jr.rawData[start : start+4], dtype: JSONNull}, start + 4, nil
 		}
 
 		if length >= 5 && IsJSONFalse(jr.rawData[start:start+5]) {
-			return parsed{bytes: jr.rawData[start : start+5], dtype: JSONBool}, start + 5
+			return parsed{bytes: jr.rawData[start : start+5], dtype: JSONBool}, start + 5, nil
 		}
 	}
 
 	jr.Empty = true
-	panic(fmt.Errorf("expected const at position %d", initial))
+	return parsed{}, -1, fmt.Errorf("expected const at position %d", initial)
 }
 
-func (jr *JSONReader) parseNumber(start int) (parsed, int) {
+func (jr *JSONReader) parseNumber(start int) (parsed, int, error) {
 	start = ltrim(jr.rawData, start)
 	initial := start
 	end := start
@@ -316,9 +364,9 @@ func (jr *JSONReader) parseNumber(start int) (parsed, int) {
 
 	if IsJSONNumber(jr.rawData[start:end]) {
 		b := trim(jr.rawData[start:end])
-		return parsed{bytes: b, dtype: extractNumberType(b)}, end
+		return parsed{bytes: b, dtype: extractNumberType(b)}, end, nil
 	}
 
 	jr.Empty = true
-	panic(fmt.Errorf("expected number at position %d, found '%s'", initial, jr.rawData[start:end]))
+	return parsed{}, -1, fmt.Errorf("expected number at position %d, found '%s'", initial, jr.rawData[start:end])
 }
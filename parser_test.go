@@ -2,7 +2,6 @@ package gojson
 
 import (
 	"strconv"
-	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -76,15 +75,14 @@ func TestParse(t *testing.T) {
 
 	t.Run("ParseValue Error", func(t *testing.T) {
 		r := JSONReader{rawData: []byte(`["Missing close"`)}
+		assert.EqualError(t, r.parse(), `expected ']', found '"' at position 15`)
+		assert.True(t, r.Empty)
+	})
 
-		var err error
-		defer func() {
-			assert.True(t, r.Empty)
-			assert.True(t, strings.HasPrefix(err.Error(), `expected ']', found '"' at position 15`))
-		}()
-		defer PanicRecovery(&err)
-
-		r.parse()
+	t.Run("Unterminated Object", func(t *testing.T) {
+		r := JSONReader{rawData: []byte(`{"a": 1`)}
+		assert.EqualError(t, r.parse(), `expected '}', found '1' at position 6`)
+		assert.True(t, r.Empty)
 	})
 
 	t.Run("Malformed ByteString", func(t *testing.T) {
@@ -119,7 +117,8 @@ func TestParseKey(t *testing.T) {
 func TestParseKeyValue(t *testing.T) {
 	t.Run("Malformed Value", func(t *testing.T) {
 		r, _ := NewJSONReader([]byte(`{"a": b }`))
-		b, i := r.parseKeyValue(5)
+		b, i, err := r.parseKeyValue(5)
+		assert.Nil(t, err)
 		assert.Equal(t, parsed{}, b)
 		assert.Equal(t, -1, i)
 	})
@@ -127,66 +126,37 @@ func TestParseKeyValue(t *testing.T) {
 
 func TestParseString(t *testing.T) {
 	t.Run("Malformed Value", func(t *testing.T) {
-		var err error
-		var b parsed
-
-		defer func() {
-			assert.True(t, strings.HasPrefix(err.Error(), `expected '"', found 'T' at position 0`))
-			assert.Equal(t, parsed{}, b)
-		}()
-		defer PanicRecovery(&err)
-
 		r := JSONReader{rawData: []byte(`Totally not a string`)}
-		b, _ = r.parseString(0)
-
+		b, _, err := r.parseString(0)
+		assert.EqualError(t, err, `expected '"', found 'T' at position 0`)
+		assert.Equal(t, parsed{}, b)
 	})
 
 	t.Run("No Terminating Quote", func(t *testing.T) {
-		var err error
-		var b parsed
-
-		defer func() {
-			assert.True(t, strings.HasPrefix(err.Error(), "unterminated string at starting position 9"))
-			assert.Equal(t, parsed{}, b)
-		}()
-		defer PanicRecovery(&err)
-
 		r := JSONReader{rawData: []byte(`{ "key": "Totally no terminal quote}`)}
-		b, _ = r.parseString(9)
+		b, _, err := r.parseString(9)
+		assert.EqualError(t, err, "unterminated string at starting position 9")
+		assert.Equal(t, parsed{}, b)
 	})
 }
 
 func TestParseConst(t *testing.T) {
 	t.Run("Invalid Value", func(t *testing.T) {
-		var b parsed
-		var err error
 		r := JSONReader{rawData: []byte(`TotallyNotTrue`)}
-
-		defer func() {
-			assert.True(t, r.Empty)
-			assert.Equal(t, parsed{}, b)
-			assert.True(t, strings.HasPrefix(err.Error(), `expected const at position 0`))
-		}()
-		defer PanicRecovery(&err)
-
-		b, _ = r.parseConst(0)
+		b, _, err := r.parseConst(0)
+		assert.EqualError(t, err, `expected const at position 0`)
+		assert.True(t, r.Empty)
+		assert.Equal(t, parsed{}, b)
 	})
 }
 
 func TestParseNumber(t *testing.T) {
 	t.Run("Invalid Value", func(t *testing.T) {
-		var b parsed
-		var err error
 		r := JSONReader{rawData: []byte(`a43`)}
-
-		defer func() {
-			assert.True(t, r.Empty)
-			assert.Equal(t, parsed{}, b)
-			assert.True(t, strings.HasPrefix(err.Error(), `expected number at position 0, found 'a43'`))
-		}()
-		defer PanicRecovery(&err)
-
-		b, _ = r.parseNumber(0)
+		b, _, err := r.parseNumber(0)
+		assert.EqualError(t, err, `expected number at position 0, found 'a43'`)
+		assert.True(t, r.Empty)
+		assert.Equal(t, parsed{}, b)
 	})
 }
 
@@ -256,3 +226,31 @@ func TestParserConstants(t *testing.T) {
 		})
 	}
 }
+
+func TestParseObserver(t *testing.T) {
+	keys := map[string]int{}
+	types := map[string]int{}
+	var order []string
+
+	_, err := NewJSONReader([]byte(`{"a": 1, "b": [true, null, "x"], "c": {"a": 2.5, "d\"e": {}}}`), WithObserver(ParseObserver{
+		OnKey: func(key string) {
+			keys[key]++
+			order = append(order, "key:"+key)
+		},
+		OnValueType: func(dtype string) {
+			types[dtype]++
+			order = append(order, dtype)
+		},
+	}))
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]int{"a": 2, "b": 1, "c": 1, `d\"e`: 1}, keys)
+	assert.Equal(t, map[string]int{JSONObject: 3, JSONArray: 1, JSONInt: 1, JSONFloat: 1, JSONBool: 1, JSONNull: 1, JSONString: 1}, types)
+	assert.Equal(t, []string{"key:a", JSONInt, "key:b", JSONBool, JSONNull, JSONString, JSONArray}, order[:7])
+	assert.Equal(t, JSONObject, order[len(order)-1])
+
+	// Nil callbacks are allowed.
+	r, err := NewJSONReader([]byte(`[1, 2]`), WithObserver(ParseObserver{}))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(r.Keys))
+}
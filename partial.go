@@ -0,0 +1,38 @@
+package gojson
+
+import "fmt"
+
+// DecodeFirst decodes the value of the first member of the JSON object data with the given key into
+// v, as Unmarshal does, reading only as far as that member. The members before it are skipped over
+// without being decoded, and the rest of the document isn't read at all, so a large payload costs
+// little when only a key near its start is needed. The key is a single top-level key, not a key path,
+// and is compared with the unescaped keys of the document.
+//
+// Only the decoded value is validated: malformed JSON after the member goes unnoticed.
+//
+// Example:
+//
+//	var status string
+//	err := gojson.DecodeFirst(body, "status", &status)
+func DecodeFirst(data []byte, key string, v interface{}) error {
+	if t := GetJSONType(data, 0); t != JSONObject {
+		return fmt.Errorf("expected a JSON object, found JSON type '%s'", t)
+	}
+
+	it, err := NewRawIterator(data)
+	if err != nil {
+		return err
+	}
+
+	for {
+		k, b, _, _, err := it.Next()
+		switch {
+		case err == ErrEndOfInput:
+			return keyNotFound(key)
+		case err != nil:
+			return err
+		case k == key:
+			return Unmarshal(b, v)
+		}
+	}
+}
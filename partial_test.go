@@ -0,0 +1,71 @@
+package gojson
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeFirst(t *testing.T) {
+	data := []byte(` {"meta": {"status": "nested"}, "items": [1, {"status": "x"}], "status": "ok", "count": 3, "status": "dup"} `)
+
+	t.Run("Found", func(t *testing.T) {
+		var s string
+		assert.Nil(t, DecodeFirst(data, "status", &s))
+		assert.Equal(t, "ok", s)
+
+		var n int
+		assert.Nil(t, DecodeFirst(data, "count", &n))
+		assert.Equal(t, 3, n)
+
+		var items []interface{}
+		assert.Nil(t, DecodeFirst(data, "items", &items))
+		assert.Equal(t, []interface{}{1, map[string]interface{}{"status": "x"}}, items)
+
+		assert.Nil(t, DecodeFirst([]byte(`{"a\u0062": "escaped"}`), "ab", &s))
+		assert.Equal(t, "escaped", s)
+	})
+
+	t.Run("Unread Remainder", func(t *testing.T) {
+		var s string
+		assert.Nil(t, DecodeFirst([]byte(`{"status": "ok", "rest": [1, 2`), "status", &s))
+		assert.Equal(t, "ok", s)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		var s string
+		assert.EqualError(t, DecodeFirst(data, "missing", &s), "key 'missing' not found")
+		assert.EqualError(t, DecodeFirst([]byte(`{}`), "status", &s), "key 'status' not found")
+		assert.EqualError(t, DecodeFirst(data, "meta.status", &s), "key 'meta.status' not found")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		var s string
+		assert.EqualError(t, DecodeFirst([]byte(`["status"]`), "status", &s), "expected a JSON object, found JSON type 'array'")
+		assert.NotNil(t, DecodeFirst([]byte(`{"a": 1 "status": "ok"}`), "status", &s))
+		assert.NotNil(t, DecodeFirst([]byte(`{"a": [1] "status": "ok"}`), "status", &s))
+		assert.NotNil(t, DecodeFirst([]byte(`{"status": tru}`), "status", &s))
+	})
+}
+
+func BenchmarkDecodeFirst(b *testing.B) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"status": "ok", "items": [`)
+	for i := 0; i < 10000; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`{"id": 1, "name": "item", "tags": ["a", "b"]}`)
+	}
+	buf.WriteString(`]}`)
+	data := buf.Bytes()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var s string
+		if err := DecodeFirst(data, "status", &s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
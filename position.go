@@ -0,0 +1,136 @@
+package gojson
+
+import "fmt"
+
+// Position describes where a value appears in the document given to NewJSONReader.
+type Position struct {
+	// Line and Col are the 1-based line and column of the first byte of the value. Columns are
+	// counted in bytes.
+	Line int
+	Col  int
+
+	// Offset is the byte offset of the value in the document, and Length is its length in bytes,
+	// including the quotes of a string.
+	Offset int
+	Length int
+}
+
+// String returns the position as "line:col", suitable for diagnostics such as "config.json:42:7".
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Col)
+}
+
+// WithPositions directs NewJSONReader to record the position of every value as it is parsed, so that
+// it can be retrieved with Position. Positions are not recorded by default, as tracking lines adds
+// to the cost of parsing.
+func WithPositions() ReaderOption {
+	return func(jr *JSONReader) {
+		jr.positions = &positionTracker{line: 1}
+	}
+}
+
+// Position returns the position of the value at the given key path, relative to the document given to
+// NewJSONReader, even for readers returned by Get. Use empty string ("") for the root. ok is false if
+// the key doesn't exist, or if the reader was not created with WithPositions.
+//
+// Example:
+//
+//	jr, err := gojson.NewJSONReader(data, gojson.WithPositions())
+//	if jr.GetInt("server.port") < 1024 {
+//		pos, _ := jr.Position("server.port")
+//		return fmt.Errorf("config.json:%s: port must be at least 1024", pos)
+//	}
+func (jr *JSONReader) Position(key string) (pos Position, ok bool) {
+	p := jr.getChildByKey(key)
+	if p == nil || p.pos == nil {
+		return Position{}, false
+	}
+
+	return *p.pos, true
+}
+
+// positionTracker computes the line and column of values as they are parsed. Values are visited in
+// document order, so lines are counted incrementally.
+type positionTracker struct {
+	// data is the document before trimming, and base is the offset at which the trimmed data begins.
+	data []byte
+	base int
+
+	// offset is the position up to which lines have been counted, and lineStart is the offset of the
+	// line containing it.
+	offset    int
+	line      int
+	lineStart int
+}
+
+// start records the position of a value beginning at start within the trimmed data.
+func (t *positionTracker) start(start int) *Position {
+	offset := t.base + start
+	for ; t.offset < offset; t.offset++ {
+		if t.data[t.offset] == '\n' {
+			t.line++
+			t.lineStart = t.offset + 1
+		}
+	}
+
+	return &Position{Line: t.line, Col: offset - t.lineStart + 1, Offset: offset}
+}
+
+// end records the length of a value ending before end within the trimmed data.
+func (t *positionTracker) end(pos *Position, end int) {
+	end += t.base
+	for end > pos.Offset && isWhitespace(t.data[end-1]) {
+		end--
+	}
+
+	pos.Length = end - pos.Offset
+}
+
+// OffsetOf returns the byte offsets of the value at the given key path within the document given to
+// NewJSONReader, so that data[start:end] is its JSON encoding, including the quotes of a string. The
+// readers returned by Get give offsets within the same document. Use empty string ("") for the root.
+// start and end are -1 if the key doesn't exist.
+//
+// Unlike Position, OffsetOf doesn't need WithPositions. The offsets are those of the reader's copy of
+// the document, which only differ from the original if it was transcoded to UTF-8 (including the
+// removal of a byte order mark), or rewritten by WithRelaxedQuotes.
+//
+// Example:
+//
+//	start, end := jr.OffsetOf("payload")
+//	w.Write(data[start:end])
+func (jr *JSONReader) OffsetOf(key string) (start, end int) {
+	p, ok := jr.child(key)
+	if jr.Empty || !ok {
+		return -1, -1
+	}
+
+	// Strings within containers are held without their quotes, and may be empty, so they're located
+	// by their closing quote.
+	if p.dtype == JSONString && (len(p.bytes) == 0 || p.bytes[0] != '"') && cap(p.bytes) > len(p.bytes) {
+		end = offsetIn(jr.doc, p.bytes[len(p.bytes):len(p.bytes)+1])
+		if end < 0 {
+			return -1, -1
+		}
+		return end - len(p.bytes) - 1, end + 1
+	}
+
+	start = offsetIn(jr.doc, p.bytes)
+	if start < 0 {
+		return -1, -1
+	}
+
+	return start, start + len(p.bytes)
+}
+
+// SliceOf returns the JSON encoding of the value at the given key path, as OffsetOf locates it, or nil
+// if the key doesn't exist. Unlike RawBytes, no copy is made: the slice is a view of the reader's
+// copy of the document, for forwarding a subtree without copying it, and must not be modified.
+func (jr *JSONReader) SliceOf(key string) []byte {
+	start, end := jr.OffsetOf(key)
+	if start < 0 {
+		return nil
+	}
+
+	return jr.doc[start:end:end]
+}
@@ -0,0 +1,132 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition(t *testing.T) {
+	data := []byte("\n  {\n\t\"name\": \"gojson\",\n\t\"server\": {\"port\": 80 , \"hosts\": [\"a\", true]},\n\t\"ratio\": 1.5\n}\n")
+
+	jr, err := NewJSONReader(data, WithPositions())
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		Key      string
+		Expected Position
+	}{
+		{"", Position{Line: 2, Col: 3, Offset: 3, Length: 84}},
+		{"name", Position{Line: 3, Col: 10, Offset: 14, Length: 8}},
+		{"server", Position{Line: 4, Col: 12, Offset: 35, Length: 35}},
+		{"server.port", Position{Line: 4, Col: 21, Offset: 44, Length: 2}},
+		{"server.hosts", Position{Line: 4, Col: 35, Offset: 58, Length: 11}},
+		{"server.hosts.1", Position{Line: 4, Col: 41, Offset: 64, Length: 4}},
+		{"ratio", Position{Line: 5, Col: 11, Offset: 82, Length: 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Key, func(t *testing.T) {
+			pos, ok := jr.Position(tc.Key)
+			assert.True(t, ok)
+			assert.Equal(t, tc.Expected, pos)
+			assert.Equal(t, string(jr.RawBytes(tc.Key)), string(data[pos.Offset:pos.Offset+pos.Length]))
+		})
+	}
+
+	t.Run("Nested Readers", func(t *testing.T) {
+		pos, ok := jr.Get("server").Position("hosts.1")
+		assert.True(t, ok)
+		assert.Equal(t, "4:41", pos.String())
+
+		pos, ok = jr.Get("server.port").Position("")
+		assert.True(t, ok)
+		assert.Equal(t, "4:21", pos.String())
+
+		pos, ok = jr.GetCollection("server.hosts")[0].Position("")
+		assert.True(t, ok)
+		assert.Equal(t, "4:36", pos.String())
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, ok := jr.Position("server.missing")
+		assert.False(t, ok)
+	})
+
+	t.Run("Not Recorded", func(t *testing.T) {
+		jr, err := NewJSONReader(data)
+		assert.Nil(t, err)
+
+		_, ok := jr.Position("name")
+		assert.False(t, ok)
+	})
+
+	t.Run("Scalar Root", func(t *testing.T) {
+		jr, err := NewJSONReader([]byte(" \n 12 \n"), WithPositions())
+		assert.Nil(t, err)
+
+		pos, ok := jr.Position("")
+		assert.True(t, ok)
+		assert.Equal(t, Position{Line: 2, Col: 2, Offset: 3, Length: 2}, pos)
+
+		pos, ok = jr.Position("0")
+		assert.True(t, ok)
+		assert.Equal(t, Position{Line: 2, Col: 2, Offset: 3, Length: 2}, pos)
+	})
+}
+
+func TestOffsetOf(t *testing.T) {
+	data := []byte("\n  {\"name\": \"gojson\", \"empty\": \"\", \"esc\": \"a\\\"b\", \"server\": {\"port\": 80 , \"hosts\": [\"a\", true]}, \"ratio\": 1.5}\n")
+
+	jr, err := NewJSONReader(data)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		Key      string
+		Expected string
+	}{
+		{"", `{"name": "gojson", "empty": "", "esc": "a\"b", "server": {"port": 80 , "hosts": ["a", true]}, "ratio": 1.5}`},
+		{"name", `"gojson"`},
+		{"empty", `""`},
+		{"esc", `"a\"b"`},
+		{"server", `{"port": 80 , "hosts": ["a", true]}`},
+		{"server.port", `80`},
+		{"server.hosts", `["a", true]`},
+		{"server.hosts.0", `"a"`},
+		{"server.hosts.1", `true`},
+		{"ratio", `1.5`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Key, func(t *testing.T) {
+			start, end := jr.OffsetOf(tc.Key)
+			assert.Equal(t, tc.Expected, string(data[start:end]))
+			assert.Equal(t, tc.Expected, string(jr.SliceOf(tc.Key)))
+		})
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		start, end := jr.Get("server").OffsetOf("hosts.0")
+		assert.Equal(t, `"a"`, string(data[start:end]))
+
+		start, end = jr.GetCollection("server.hosts")[1].OffsetOf("")
+		assert.Equal(t, `true`, string(data[start:end]))
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		start, end := jr.OffsetOf("missing")
+		assert.Equal(t, -1, start)
+		assert.Equal(t, -1, end)
+		assert.Nil(t, jr.SliceOf("missing"))
+
+		start, _ = jr.Get("missing").OffsetOf("")
+		assert.Equal(t, -1, start)
+	})
+
+	t.Run("Scalar", func(t *testing.T) {
+		r, err := NewJSONReader([]byte(` "str" `))
+		assert.Nil(t, err)
+		assert.Equal(t, `"str"`, string(r.SliceOf("")))
+		assert.Equal(t, `"str"`, string(r.SliceOf("0")))
+	})
+}
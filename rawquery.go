@@ -0,0 +1,160 @@
+package gojson
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// KeyExistsRaw returns whether the key path exists in the JSON data, as Extract would find it, without
+// allocating. Only the values along the path, and those before them, are scanned; nothing is copied or
+// decoded. It suits decisions made on every request, such as routing, where building a JSONReader
+// would cost more than the decision is worth.
+//
+// Example:
+//
+//	if gojson.KeyExistsRaw(body, "order.coupon") {
+//		...
+//	}
+func KeyExistsRaw(data []byte, keyPath string) bool {
+	_, ok := findRaw(data, keyPath)
+	return ok
+}
+
+// ValueEqualsRaw returns whether the value at the key path in the JSON data is exactly the raw JSON
+// want, without allocating. The comparison is of bytes, so strings must include their quotes and
+// their escape sequences aren't decoded, and numbers must be written alike: []byte(`1`) doesn't match
+// 1.0. Whitespace around want is ignored.
+//
+// Example:
+//
+//	if gojson.ValueEqualsRaw(body, "type", []byte(`"refund"`)) {
+//		...
+//	}
+func ValueEqualsRaw(data []byte, keyPath string, want []byte) bool {
+	b, ok := findRaw(data, keyPath)
+	return ok && bytes.Equal(b, trim(want))
+}
+
+// findRaw returns the raw value at the key path, found as extractKeyPath finds it, without allocating.
+func findRaw(search []byte, path string) ([]byte, bool) {
+	if len(path) > 0 && path[0] == '.' {
+		path = path[1:]
+	}
+
+	start := 0
+	for path != "" {
+		var k string
+		k, path = splitPathKey(path)
+
+		start = ltrim(search, start)
+		if start >= len(search) {
+			return nil, false
+		}
+
+		var ok bool
+		switch search[start] {
+		case '{':
+			start, ok = rawMember(search, start+1, k)
+		case '[':
+			start, ok = rawElement(search, start+1, k)
+		}
+		if !ok {
+			return nil, false
+		}
+	}
+
+	b, _, _, err := extractValue(search, start)
+	return b, err == nil
+}
+
+// splitPathKey returns the first key of the key path, with any escaped periods left escaped, and the
+// rest of the path.
+func splitPathKey(path string) (string, string) {
+	for i := 0; i < len(path); i++ {
+		if path[i] == '.' && (i == 0 || path[i-1] != '\\') {
+			return path[:i], path[i+1:]
+		}
+	}
+
+	return path, ""
+}
+
+// pathKeyEqual returns whether the raw object key equals k, a key from a key path, in which "\." is a
+// period.
+func pathKeyEqual(key []byte, k string) bool {
+	j := 0
+	for i := 0; i < len(k); i++ {
+		if k[i] == '\\' && i+1 < len(k) && k[i+1] == '.' {
+			continue
+		}
+
+		if j >= len(key) || key[j] != k[i] {
+			return false
+		}
+		j++
+	}
+
+	return j == len(key)
+}
+
+// rawMember returns the position of the value of the member with key k, in the object whose members
+// begin at start.
+func rawMember(search []byte, start int, k string) (int, bool) {
+	for {
+		key, pos, err := extractKey(search, start)
+		if err != nil {
+			return 0, false
+		}
+
+		if pathKeyEqual(key, k) {
+			return pos, true
+		}
+
+		var ok bool
+		if start, ok = skipRawValue(search, pos); !ok {
+			return 0, false
+		}
+	}
+}
+
+// rawElement returns the position of the element with index k, in the array whose elements begin at
+// start.
+func rawElement(search []byte, start int, k string) (int, bool) {
+	if !isDecimalNumber([]byte(k)) {
+		return 0, false
+	}
+
+	idx, err := strconv.Atoi(k)
+	if err != nil {
+		return 0, false
+	}
+
+	for i := 0; i < idx; i++ {
+		var ok bool
+		if start, ok = skipRawValue(search, start); !ok {
+			return 0, false
+		}
+	}
+
+	if next := ltrim(search, start); next >= len(search) || search[next] == ']' {
+		return 0, false
+	}
+
+	return start, true
+}
+
+// skipRawValue returns the position following the value at start and the comma after it. ok is false
+// if the value is the last in its container, or is malformed.
+func skipRawValue(search []byte, start int) (int, bool) {
+	_, _, pos, err := extractValue(search, start)
+	if err != nil {
+		return 0, false
+	}
+
+	pos = ltrim(search, pos)
+	if pos >= len(search) || search[pos] != ',' {
+		return 0, false
+	}
+
+	return pos + 1, true
+}
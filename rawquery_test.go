@@ -0,0 +1,83 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var rawQueryData = []byte(` {
+	"type": "refund",
+	"amount": 12.50,
+	"user": {"id": 7, "roles": ["admin", "dev"], "a.b": true, "none": null},
+	"items": [{"sku": "x1"}, {"sku": "x2", "tags": []}],
+	"escaped": "say \"hi\""
+}`)
+
+func TestKeyExistsRaw(t *testing.T) {
+	testCases := []struct {
+		Path   string
+		Exists bool
+	}{
+		{"", true},
+		{"type", true},
+		{".type", true},
+		{"user.id", true},
+		{"user.roles.1", true},
+		{"user.roles.2", false},
+		{`user.a\.b`, true},
+		{"user.a", false},
+		{"user.none", true},
+		{"items.1.sku", true},
+		{"items.1.tags.0", false},
+		{"items.2", false},
+		{"items.x", false},
+		{"type.x", false},
+		{"missing", false},
+		{"user.missing", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Path, func(t *testing.T) {
+			assert.Equal(t, tc.Exists, KeyExistsRaw(rawQueryData, tc.Path))
+
+			_, _, err := Extract(rawQueryData, tc.Path)
+			assert.Equal(t, tc.Exists, err == nil)
+		})
+	}
+
+	assert.False(t, KeyExistsRaw(nil, ""))
+	assert.False(t, KeyExistsRaw([]byte(`{"a": [1, 2`), "a.5"))
+	assert.False(t, KeyExistsRaw([]byte(`{"a": tru, "b": 1}`), "b"))
+}
+
+func TestValueEqualsRaw(t *testing.T) {
+	assert.True(t, ValueEqualsRaw(rawQueryData, "type", []byte(`"refund"`)))
+	assert.True(t, ValueEqualsRaw(rawQueryData, "type", []byte(` "refund" `)))
+	assert.False(t, ValueEqualsRaw(rawQueryData, "type", []byte(`refund`)))
+	assert.True(t, ValueEqualsRaw(rawQueryData, "amount", []byte(`12.50`)))
+	assert.False(t, ValueEqualsRaw(rawQueryData, "amount", []byte(`12.5`)))
+	assert.True(t, ValueEqualsRaw(rawQueryData, "user.roles.0", []byte(`"admin"`)))
+	assert.True(t, ValueEqualsRaw(rawQueryData, "user.none", []byte(`null`)))
+	assert.True(t, ValueEqualsRaw(rawQueryData, "items.1.tags", []byte(`[]`)))
+	assert.True(t, ValueEqualsRaw(rawQueryData, "escaped", []byte(`"say \"hi\""`)))
+	assert.False(t, ValueEqualsRaw(rawQueryData, "missing", []byte(`null`)))
+}
+
+func TestRawQueryAllocations(t *testing.T) {
+	want := []byte(`"x2"`)
+	allocs := testing.AllocsPerRun(100, func() {
+		KeyExistsRaw(rawQueryData, `user.a\.b`)
+		ValueEqualsRaw(rawQueryData, "items.1.sku", want)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func BenchmarkValueEqualsRaw(b *testing.B) {
+	want := []byte(`"x2"`)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ValueEqualsRaw(rawQueryData, "items.1.sku", want)
+	}
+}
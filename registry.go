@@ -0,0 +1,146 @@
+package gojson
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultRegistry is the TypeRegistry used by Unmarshal, and by UnmarshalWithOptions when Options.Registry is nil.
+var DefaultRegistry = NewTypeRegistry()
+
+// TypeRegistry maps discriminator values onto the concrete types used to populate interface fields.
+//
+// An interface field tagged with a discriminator, e.g. `json:"media,discriminator=type"`, is populated
+// by reading the "type" key of the JSON object, and decoding the object into the concrete type registered
+// for that value. Without a discriminator, interface fields receive the generic decoding (map[string]interface{}
+// for objects).
+type TypeRegistry struct {
+	lock  sync.RWMutex
+	types map[reflect.Type]map[string]reflect.Type
+}
+
+// NewTypeRegistry returns an empty TypeRegistry.
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{types: make(map[reflect.Type]map[string]reflect.Type)}
+}
+
+// Register associates a discriminator value with a concrete type for a given interface. iface must be a
+// nil pointer to the interface, and concrete is an example value of the concrete type, which may be a
+// pointer. The concrete type must implement the interface.
+//
+// Example:
+//
+//	gojson.DefaultRegistry.Register((*Media)(nil), "video", &Video{})
+//	gojson.DefaultRegistry.Register((*interface{})(nil), "image", Image{})
+func (r *TypeRegistry) Register(iface interface{}, value string, concrete interface{}) error {
+	it := reflect.TypeOf(iface)
+	if it == nil || it.Kind() != reflect.Ptr || it.Elem().Kind() != reflect.Interface {
+		return fmt.Errorf("registered interface must be a pointer to an interface, found '%v'", it)
+	}
+	it = it.Elem()
+
+	ct := reflect.TypeOf(concrete)
+	if ct == nil || !ct.Implements(it) {
+		return fmt.Errorf("type '%v' does not implement interface '%v'", ct, it)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.types[it] == nil {
+		r.types[it] = make(map[string]reflect.Type)
+	}
+	r.types[it][value] = ct
+
+	return nil
+}
+
+// Lookup returns the concrete type registered for the given interface and discriminator value.
+func (r *TypeRegistry) Lookup(iface reflect.Type, value string) (reflect.Type, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	t, ok := r.types[iface][value]
+	return t, ok
+}
+
+// DecoderFunc converts a raw JSON value into a value of a registered type. b is the raw value (strings
+// retain their quotes) and t is its JSON type.
+type DecoderFunc func(b []byte, t string) (interface{}, error)
+
+var decoders = struct {
+	lock  sync.RWMutex
+	funcs map[reflect.Type]DecoderFunc
+
+	// count allows the common case, where no decoders are registered, to skip the lock.
+	count atomic.Int32
+}{funcs: make(map[reflect.Type]DecoderFunc)}
+
+// RegisterDecoder registers a function used by Unmarshal to decode every value of the given type, wherever
+// it appears (fields, pointers, slices, maps). This allows types such as decimal.Decimal or civil.Date to
+// be supported once, rather than wrapping them in an UnmarshalJSON on every struct which uses them.
+//
+// A registered decoder takes precedence over json.Unmarshaler, and is also given null values. The value
+// returned must be assignable to t. Passing a nil fn removes the decoder for t.
+//
+// Example:
+//
+//	gojson.RegisterDecoder(reflect.TypeOf(time.Duration(0)), func(b []byte, t string) (interface{}, error) {
+//		s, err := gojson.ExtractString(b, "")
+//		if err != nil {
+//			return nil, err
+//		}
+//		return time.ParseDuration(s)
+//	})
+func RegisterDecoder(t reflect.Type, fn DecoderFunc) {
+	decoders.lock.Lock()
+	defer decoders.lock.Unlock()
+
+	if fn == nil {
+		delete(decoders.funcs, t)
+	} else {
+		decoders.funcs[t] = fn
+	}
+
+	decoders.count.Store(int32(len(decoders.funcs)))
+}
+
+// lookupDecoder returns the decoder registered for the given type, if any.
+func lookupDecoder(t reflect.Type) (DecoderFunc, bool) {
+	if decoders.count.Load() == 0 {
+		return nil, false
+	}
+
+	decoders.lock.RLock()
+	defer decoders.lock.RUnlock()
+
+	fn, ok := decoders.funcs[t]
+	return fn, ok
+}
+
+// decodeRegistered populates p using the decoder registered for its type. ok is false if there is none.
+func decodeRegistered(b []byte, t string, p reflect.Value) (ok bool, err error) {
+	fn, ok := lookupDecoder(p.Type())
+	if !ok {
+		return false, nil
+	}
+
+	v, err := fn(b, t)
+	if err != nil {
+		return true, err
+	}
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		p.Set(reflect.Zero(p.Type()))
+	case rv.Type().AssignableTo(p.Type()):
+		p.Set(rv)
+	default:
+		return true, fmt.Errorf("decoder registered for '%v' returned incompatible type '%v'", p.Type(), rv.Type())
+	}
+
+	return true, nil
+}
@@ -0,0 +1,160 @@
+package gojson
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMedia interface {
+	Kind() string
+}
+
+type testVideo struct {
+	Type     string `json:"type"`
+	Duration int    `json:"duration"`
+}
+
+func (v testVideo) Kind() string { return "video" }
+
+type testImage struct {
+	Type  string `json:"type"`
+	Width int    `json:"width"`
+}
+
+func (i *testImage) Kind() string { return "image" }
+
+func TestTypeRegistry(t *testing.T) {
+	r := NewTypeRegistry()
+	assert.Nil(t, r.Register((*testMedia)(nil), "video", testVideo{}))
+	assert.Nil(t, r.Register((*testMedia)(nil), "image", &testImage{}))
+	assert.Nil(t, r.Register((*interface{})(nil), "video", &testVideo{}))
+
+	type Playlist struct {
+		Featured testMedia            `json:"featured,discriminator=type"`
+		Items    []testMedia          `json:"items,discriminator=type"`
+		ByName   map[string]testMedia `json:"by_name,discriminator=type"`
+		Cutlist  interface{}          `json:"cutlist,discriminator=type"`
+		Generic  interface{}          `json:"generic"`
+	}
+
+	data := []byte(`{
+		"featured": {"type": "video", "duration": 30},
+		"items": [{"type": "image", "width": 640}, {"type": "video", "duration": 5}, null],
+		"by_name": {"a": {"type": "image", "width": 1}},
+		"cutlist": {"type": "video", "duration": 7},
+		"generic": {"type": "video", "duration": 9}
+	}`)
+
+	t.Run("Registered Types", func(t *testing.T) {
+		var m Playlist
+		err := UnmarshalWithOptions(data, &m, Options{Registry: r})
+		assert.Nil(t, err)
+
+		assert.Equal(t, testVideo{Type: "video", Duration: 30}, m.Featured)
+		assert.Equal(t, &testImage{Type: "image", Width: 640}, m.Items[0])
+		assert.Equal(t, testVideo{Type: "video", Duration: 5}, m.Items[1])
+		assert.Nil(t, m.Items[2])
+		assert.Equal(t, &testImage{Type: "image", Width: 1}, m.ByName["a"])
+		assert.Equal(t, &testVideo{Type: "video", Duration: 7}, m.Cutlist)
+		assert.Equal(t, map[string]interface{}{"type": "video", "duration": 9}, m.Generic)
+	})
+
+	t.Run("Unregistered Value", func(t *testing.T) {
+		var m Playlist
+		err := UnmarshalWithOptions([]byte(`{"cutlist": {"type": "audio"}}`), &m, Options{Registry: r})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"type": "audio"}, m.Cutlist)
+
+		err = UnmarshalWithOptions([]byte(`{"cutlist": {"type": "audio"}}`), &m, Options{Registry: r, StrictStandards: true})
+		assert.Equal(t, "discriminator key 'type' has unregistered value 'audio' for interface 'interface {}'", err.Error())
+	})
+
+	t.Run("Default Registry", func(t *testing.T) {
+		var m Playlist
+		err := Unmarshal([]byte(`{"cutlist": {"type": "video", "duration": 7}}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"type": "video", "duration": 7}, m.Cutlist)
+	})
+
+	t.Run("Register Errors", func(t *testing.T) {
+		assert.Equal(t, "type 'gojson.testImage' does not implement interface 'gojson.testMedia'", r.Register((*testMedia)(nil), "x", testImage{}).Error())
+		assert.Equal(t, "registered interface must be a pointer to an interface, found 'gojson.testVideo'", r.Register(testVideo{}, "x", testVideo{}).Error())
+	})
+}
+
+type testCents int64
+
+type testDate struct {
+	Year, Month, Day int
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	RegisterDecoder(reflect.TypeOf(testCents(0)), func(b []byte, t string) (interface{}, error) {
+		f, err := ExtractFloat(b, "")
+		if err != nil {
+			return nil, err
+		}
+		return testCents(math.Round(f * 100)), nil
+	})
+	defer RegisterDecoder(reflect.TypeOf(testCents(0)), nil)
+
+	RegisterDecoder(reflect.TypeOf(testDate{}), func(b []byte, t string) (interface{}, error) {
+		if t == JSONNull {
+			return nil, nil
+		}
+
+		s, _ := ExtractString(b, "")
+
+		var d testDate
+		if _, err := fmt.Sscanf(s, "%d-%d-%d", &d.Year, &d.Month, &d.Day); err != nil {
+			return nil, fmt.Errorf("invalid date '%s'", s)
+		}
+		return d, nil
+	})
+	defer RegisterDecoder(reflect.TypeOf(testDate{}), nil)
+
+	type Order struct {
+		Total   testCents           `json:"total"`
+		Items   []testCents         `json:"items"`
+		Placed  *testDate           `json:"placed"`
+		History map[string]testDate `json:"history"`
+		Missing testDate            `json:"missing"`
+	}
+
+	t.Run("Fields, Slices, Maps, Pointers", func(t *testing.T) {
+		var m Order
+		err := Unmarshal([]byte(`{"total": "10.25", "items": [1.1, 2], "placed": "2019-01-15", "history": {"a": "2018-12-31"}, "missing": null}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, testCents(1025), m.Total)
+		assert.Equal(t, []testCents{110, 200}, m.Items)
+		assert.Equal(t, &testDate{2019, 1, 15}, m.Placed)
+		assert.Equal(t, map[string]testDate{"a": {2018, 12, 31}}, m.History)
+		assert.Equal(t, testDate{}, m.Missing)
+	})
+
+	t.Run("Root Value", func(t *testing.T) {
+		var d testDate
+		assert.Nil(t, Unmarshal([]byte(`"2020-02-29"`), &d))
+		assert.Equal(t, testDate{2020, 2, 29}, d)
+	})
+
+	t.Run("Decoder Error", func(t *testing.T) {
+		var m Order
+		err := Unmarshal([]byte(`{"placed": "yesterday"}`), &m)
+		assert.Equal(t, "invalid date 'yesterday'", err.Error())
+	})
+
+	t.Run("Incompatible Type", func(t *testing.T) {
+		RegisterDecoder(reflect.TypeOf(testCents(0)), func(b []byte, t string) (interface{}, error) {
+			return "nope", nil
+		})
+
+		var c testCents
+		err := Unmarshal([]byte(`1`), &c)
+		assert.Equal(t, "decoder registered for 'gojson.testCents' returned incompatible type 'string'", err.Error())
+	})
+}
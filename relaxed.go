@@ -0,0 +1,134 @@
+package gojson
+
+import "fmt"
+
+// WithRelaxedQuotes accepts strings in single quotes, and object keys without quotes, as written by
+// JavaScript and other sloppy producers (e.g. {name: 'Ann'}). The document is rewritten as JSON before
+// it is parsed, so the positions recorded by WithPositions are those of the rewritten document.
+// ParseStrictRFC8259 still rejects such documents.
+func WithRelaxedQuotes() ReaderOption {
+	return func(jr *JSONReader) {
+		jr.relaxedQuotes = true
+	}
+}
+
+// relaxQuotes rewrites the single-quoted strings and unquoted object keys in b as JSON strings. An
+// unquoted key is a run of letters, digits, '_' and '$' which doesn't start with a digit. Within a
+// single-quoted string, \' is a quote and a double quote needs no escape. A document needing no
+// changes is returned as it is.
+func relaxQuotes(b []byte) ([]byte, error) {
+	var out []byte
+	var stack []byte
+
+	expectKey := false
+	last := 0
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+
+		switch {
+		case c == '"':
+			end := skipString(b, i)
+			i = end - 1
+			expectKey = false
+			continue
+		case c == '{' || c == '[':
+			stack = append(stack, c)
+			expectKey = c == '{'
+			continue
+		case c == '}' || c == ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			expectKey = false
+			continue
+		case c == ',':
+			expectKey = len(stack) > 0 && stack[len(stack)-1] == '{'
+			continue
+		case c == '\'':
+			end, err := singleQuotedEnd(b, i)
+			if err != nil {
+				return nil, err
+			}
+
+			out = appendDoubleQuoted(append(out, b[last:i]...), b[i+1:end-1])
+			last = end
+			i = end - 1
+		case expectKey && isKeyStart(c):
+			end := i + 1
+			for end < len(b) && isKeyChar(b[end]) {
+				end++
+			}
+
+			out = append(append(append(append(out, b[last:i]...), '"'), b[i:end]...), '"')
+			last = end
+			i = end - 1
+		}
+
+		if !isWhitespace(c) {
+			expectKey = false
+		}
+	}
+
+	if out == nil {
+		return b, nil
+	}
+
+	return append(out, b[last:]...), nil
+}
+
+// skipString returns the position after the double-quoted string starting at position i of b.
+func skipString(b []byte, i int) int {
+	for i++; i < len(b); i++ {
+		switch b[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+
+	return len(b)
+}
+
+// singleQuotedEnd returns the position after the single-quoted string starting at position i of b.
+func singleQuotedEnd(b []byte, i int) (int, error) {
+	for j := i + 1; j < len(b); j++ {
+		switch b[j] {
+		case '\\':
+			j++
+		case '\'':
+			return j + 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated single-quoted string at position %d", i)
+}
+
+// appendDoubleQuoted appends s, the contents of a single-quoted string, as a double-quoted string.
+func appendDoubleQuoted(dst, s []byte) []byte {
+	dst = append(dst, '"')
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '"':
+			dst = append(dst, '\\', '"')
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == '\'':
+			dst = append(dst, '\'')
+			i++
+		case s[i] == '\\' && i+1 < len(s):
+			dst = append(dst, s[i], s[i+1])
+			i++
+		default:
+			dst = append(dst, s[i])
+		}
+	}
+
+	return append(dst, '"')
+}
+
+func isKeyStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isKeyChar(c byte) bool {
+	return isKeyStart(c) || (c >= '0' && c <= '9')
+}
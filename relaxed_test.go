@@ -0,0 +1,67 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelaxQuotes(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{`{"a": 1}`, `{"a": 1}`},
+		{`{a: 1, $b_2: [true]}`, `{"a": 1, "$b_2": [true]}`},
+		{`{'a': 'it\'s "here"'}`, `{"a": "it's \"here\""}`},
+		{`['x\n', "y'z", {k: 'v'}]`, `["x\n", "y'z", {"k": "v"}]`},
+		{`{"s": "{a: 'b'}", n: null}`, `{"s": "{a: 'b'}", "n": null}`},
+		{`[true, null, {nested: {deep: 1}}]`, `[true, null, {"nested": {"deep": 1}}]`},
+	}
+
+	for _, tc := range tests {
+		out, err := relaxQuotes([]byte(tc.in))
+		assert.Nil(t, err, tc.in)
+		assert.Equal(t, tc.expected, string(out), tc.in)
+	}
+
+	_, err := relaxQuotes([]byte(`{a: 'open}`))
+	assert.EqualError(t, err, "unterminated single-quoted string at position 4")
+}
+
+func TestRelaxedQuotes(t *testing.T) {
+	data := []byte(`{name: 'Ann', tags: ['a', "b"], 'nick': 'A "1"'}`)
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v struct {
+			Name string   `json:"name"`
+			Tags []string `json:"tags"`
+			Nick string   `json:"nick"`
+		}
+		assert.NotNil(t, Unmarshal(data, &v))
+
+		assert.Nil(t, UnmarshalWithOptions(data, &v, Options{RelaxedQuotes: true}))
+		assert.Equal(t, "Ann", v.Name)
+		assert.Equal(t, []string{"a", "b"}, v.Tags)
+		assert.Equal(t, `A "1"`, v.Nick)
+
+		assert.Nil(t, UnmarshalWithOptions(data, &v, Options{RelaxedQuotes: true, StrictStandards: true}))
+	})
+
+	t.Run("Reader", func(t *testing.T) {
+		reader, err := NewJSONReader(data, WithRelaxedQuotes())
+		assert.Nil(t, err)
+		assert.Equal(t, "Ann", reader.GetString("name"))
+		assert.Equal(t, "b", reader.GetString("tags.1"))
+		assert.Equal(t, `A "1"`, reader.GetString("nick"))
+
+		reader, err = NewJSONReader([]byte(`{a: 'open}`), WithRelaxedQuotes())
+		assert.NotNil(t, err)
+		assert.True(t, reader.Empty)
+	})
+
+	t.Run("StrictRFC8259", func(t *testing.T) {
+		_, err := ParseStrictRFC8259(data, WithRelaxedQuotes())
+		assert.NotNil(t, err)
+	})
+}
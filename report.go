@@ -0,0 +1,124 @@
+package gojson
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// UnmarshalReport lists the data which Unmarshal silently dropped or converted, to find data loss as
+// models drift from the documents they decode.
+type UnmarshalReport struct {
+	// Dropped holds the key paths of the object members with no matching struct field, and of the
+	// array elements beyond the length of a fixed-length array or tuple, in document order.
+	Dropped []string
+
+	// Coerced holds the values which were converted from another JSON type, in document order.
+	Coerced []Coercion
+}
+
+// Coercion describes a value decoded into a Go type which doesn't match its JSON type, such as the
+// string "17" into an int, or 1.5 into an int. Integers decoded into floats, and nulls, are not
+// coercions.
+type Coercion struct {
+	// Path is the key path of the value from the root, e.g. "items.3.count".
+	Path string
+
+	// From is the JSON type of the value.
+	From string
+
+	// To is the Go type the value was decoded into.
+	To reflect.Type
+
+	// Value is the raw value, truncated to 50 bytes.
+	Value string
+}
+
+// UnmarshalWithReport takes a json format byte string and extracts it into the given container, using
+// DefaultOptions, and reports the keys which were dropped and the values which were coerced. The
+// report covers the data decoded before any error. Types decoded by their own UnmarshalJSON or
+// UnmarshalGoJSON methods are not examined.
+//
+// Example:
+//
+//	report, err := gojson.UnmarshalWithReport(body, &order)
+//	if err != nil {
+//		return err
+//	}
+//	for _, k := range report.Dropped {
+//		log.Printf("order: no field for key %s", k)
+//	}
+func UnmarshalWithReport(raw []byte, v interface{}) (UnmarshalReport, error) {
+	u := unmarshaler{Options: DefaultOptions, report: &UnmarshalReport{}}
+	err := u.unmarshal(raw, v)
+	return *u.report, err
+}
+
+// enter records that the member with the given key is being decoded, when reporting. Each call is
+// paired with a call to leave.
+func (u *unmarshaler) enter(key string) {
+	if u.report != nil {
+		u.path = append(u.path, key)
+	}
+}
+
+// enterIndex records that the array element with the given index is being decoded, as enter does.
+func (u *unmarshaler) enterIndex(i int) {
+	if u.report != nil {
+		u.path = append(u.path, strconv.Itoa(i))
+	}
+}
+
+// leave records that the member most recently entered has been decoded.
+func (u *unmarshaler) leave() {
+	if u.report != nil {
+		u.path = u.path[:len(u.path)-1]
+	}
+}
+
+// drop reports that the member with the given key was dropped.
+func (u *unmarshaler) drop(key string) {
+	if u.report != nil {
+		u.report.Dropped = append(u.report.Dropped, joinPath(strings.Join(u.path, "."), key))
+	}
+}
+
+// coerce reports that the value b, of JSON type t, was decoded into p if its type doesn't match.
+func (u *unmarshaler) coerce(b []byte, t string, p reflect.Value) {
+	if u.report == nil || t == JSONNull {
+		return
+	}
+
+	switch p.Kind() {
+	case reflect.String:
+		if t == JSONString {
+			return
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if t == JSONInt {
+			return
+		}
+	case reflect.Float32, reflect.Float64:
+		if t == JSONInt || t == JSONFloat {
+			return
+		}
+	case reflect.Bool:
+		if t == JSONBool {
+			return
+		}
+	case reflect.Struct:
+		if t == JSONObject {
+			return
+		}
+	default:
+		return
+	}
+
+	u.report.Coerced = append(u.report.Coerced, Coercion{
+		Path:  strings.Join(u.path, "."),
+		From:  t,
+		To:    p.Type(),
+		Value: string(truncate(b, 50)),
+	})
+}
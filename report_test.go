@@ -0,0 +1,76 @@
+package gojson
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalWithReport(t *testing.T) {
+	type Line struct {
+		SKU      string  `json:"sku"`
+		Quantity int     `json:"quantity"`
+		Price    float64 `json:"price"`
+	}
+
+	type Order struct {
+		ID       int                   `json:"id"`
+		Paid     bool                  `json:"paid"`
+		Lines    []Line                `json:"lines"`
+		Totals   map[string]int        `json:"totals"`
+		Point    [2]int                `json:"point"`
+		Customer struct{ Name string } `json:"customer"`
+		Extra    interface{}           `json:"extra"`
+	}
+
+	raw := []byte(`{
+		"id": "17",
+		"paid": "true",
+		"lines": [{"sku": 12, "quantity": 1.5, "price": 3, "color": "red"}, {"sku": "b", "quantity": 2, "price": "4.5"}],
+		"totals": {"net": 10, "tax": "2"},
+		"point": [1, 2, 3],
+		"customer": "bob",
+		"extra": {"anything": "goes"},
+		"notes": "dropped",
+		"id_v2": 18
+	}`)
+
+	var o Order
+	report, err := UnmarshalWithReport(raw, &o)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 17, o.ID)
+	assert.Equal(t, 1, o.Lines[0].Quantity)
+	assert.Equal(t, []string{"lines.0.color", "point.2", "notes", "id_v2"}, report.Dropped)
+
+	intType, strType := reflect.TypeOf(0), reflect.TypeOf("")
+	assert.Equal(t, []Coercion{
+		{Path: "id", From: JSONString, To: intType, Value: `"17"`},
+		{Path: "paid", From: JSONString, To: reflect.TypeOf(true), Value: `"true"`},
+		{Path: "lines.0.sku", From: JSONInt, To: strType, Value: `12`},
+		{Path: "lines.0.quantity", From: JSONFloat, To: intType, Value: `1.5`},
+		{Path: "lines.1.price", From: JSONString, To: reflect.TypeOf(0.0), Value: `"4.5"`},
+		{Path: "totals.tax", From: JSONString, To: intType, Value: `"2"`},
+		{Path: "customer", From: JSONString, To: reflect.TypeOf(o.Customer), Value: `"bob"`},
+	}, report.Coerced)
+
+	t.Run("Clean", func(t *testing.T) {
+		var l Line
+		report, err := UnmarshalWithReport([]byte(`{"sku": "a", "quantity": 1, "price": 2, "ignored": null}`), &l)
+		assert.Nil(t, err)
+		assert.Equal(t, UnmarshalReport{Dropped: []string{"ignored"}}, report)
+
+		report, err = UnmarshalWithReport([]byte(`{"sku": "a", "quantity": 1, "price": 2}`), &l)
+		assert.Nil(t, err)
+		assert.Equal(t, UnmarshalReport{}, report)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var l []Line
+		report, err := UnmarshalWithReport([]byte(`[{"sku": 1, "x": 2}, {"quantity": 99999999999999999999}, {"sku": 2}]`), &l)
+		assert.NotNil(t, err)
+		assert.Equal(t, []string{"0.x"}, report.Dropped)
+		assert.Len(t, report.Coerced, 1)
+	})
+}
@@ -0,0 +1,418 @@
+package gojson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type rewriteAction int
+
+const (
+	// rewriteKeep emits the node as-is, descending into its children.
+	rewriteKeep rewriteAction = iota
+
+	// rewriteRemove drops the node (and its key, for object members) from the output.
+	rewriteRemove
+
+	// rewriteReplace emits the replacement bytes verbatim in place of the node.
+	rewriteReplace
+)
+
+// rewriter decides what to do with the node found at path. The replacement return value
+// is only consulted for rewriteReplace, and must be a valid JSON value.
+type rewriter func(path string, p parsed) (rewriteAction, []byte)
+
+// rewrite serializes the given node into buf, consulting fn for every descendant. The root
+// node itself is always emitted. Output is compact; strings and numbers are written exactly
+// as they appear in the source document.
+func rewrite(buf *bytes.Buffer, p parsed, path string, fn rewriter) {
+	switch p.dtype {
+	case JSONObject:
+		buf.WriteByte('{')
+		first := true
+		seen := make(map[string]bool, len(p.keys))
+		for _, k := range p.keys {
+			// The key list retains duplicate keys, but only the last value is kept in children.
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+
+			c := p.children[k]
+			cpath := joinPath(path, k)
+
+			action, replacement := fn(cpath, c)
+			if action == rewriteRemove {
+				continue
+			}
+
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+
+			buf.WriteByte('"')
+			buf.WriteString(k)
+			buf.WriteString(`":`)
+
+			if action == rewriteReplace {
+				buf.Write(replacement)
+				continue
+			}
+
+			rewrite(buf, c, cpath, fn)
+		}
+		buf.WriteByte('}')
+	case JSONArray:
+		buf.WriteByte('[')
+		first := true
+		for _, k := range p.keys {
+			c := p.children[k]
+			cpath := joinPath(path, k)
+
+			action, replacement := fn(cpath, c)
+			if action == rewriteRemove {
+				continue
+			}
+
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+
+			if action == rewriteReplace {
+				buf.Write(replacement)
+				continue
+			}
+
+			rewrite(buf, c, cpath, fn)
+		}
+		buf.WriteByte(']')
+	case JSONString:
+		buf.WriteByte('"')
+		buf.Write(p.bytes)
+		buf.WriteByte('"')
+	default:
+		buf.Write(p.bytes)
+	}
+}
+
+// joinPath appends a key to a period separated key path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// PruneWhere returns a copy of the document with every node matching the predicate removed.
+// The predicate receives the key path of the node, its value, and its JSON type. value is the
+// same data GetByteSlice would return: strings are given without their surrounding quotes.
+//
+// When a node is removed, its descendants are not visited. Object members are removed along
+// with their key, and array elements are removed entirely (later elements shift down).
+// The root of the document is never offered to the predicate.
+//
+// Example, removing all nulls and empty strings:
+//
+//	clean, err := r.PruneWhere(func(path string, value []byte, dtype string) bool {
+//		return dtype == gojson.JSONNull || (dtype == gojson.JSONString && len(value) == 0)
+//	})
+func (jr *JSONReader) PruneWhere(fn func(path string, value []byte, dtype string) bool) ([]byte, error) {
+	if jr.Empty {
+		return nil, ErrEmpty
+	}
+
+	// Scalar roots have nothing to prune. The root's rawData retains string quotes, so it is
+	// returned directly rather than passing through rewrite.
+	if jr.Type != JSONObject && jr.Type != JSONArray {
+		return append([]byte{}, trim(jr.rawData)...), nil
+	}
+
+	var buf bytes.Buffer
+	rewrite(&buf, *jr.getChildByKey(""), "", func(path string, p parsed) (rewriteAction, []byte) {
+		if fn(path, p.bytes, p.dtype) {
+			return rewriteRemove, nil
+		}
+		return rewriteKeep, nil
+	})
+
+	return buf.Bytes(), nil
+}
+
+// Delete returns a copy of the document with the given key paths removed. Paths that do not
+// exist are ignored.
+func (jr *JSONReader) Delete(keys ...string) ([]byte, error) {
+	remove := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		remove[k] = true
+	}
+
+	return jr.PruneWhere(func(path string, value []byte, dtype string) bool {
+		return remove[path]
+	})
+}
+
+// Project returns a new document holding only the given key paths, with their nesting preserved.
+// A "*" segment matches every child of an object or array, and the empty path selects the whole
+// document. Paths that do not exist are ignored. As with PruneWhere, array elements which are not
+// selected are removed entirely, and the output is compact.
+//
+// Example:
+//
+//	r, _ := gojson.NewJSONReader([]byte(`{"id": 1, "user": {"name": "Bob", "token": "x"}, "items": [{"sku": "a", "qty": 2}]}`))
+//	b, _ := r.Project("id", "user.name", "items.*.sku")
+//	// {"id":1,"user":{"name":"Bob"},"items":[{"sku":"a"}]}
+func (jr *JSONReader) Project(paths ...string) ([]byte, error) {
+	if jr.Empty {
+		return nil, ErrEmpty
+	}
+
+	for _, path := range paths {
+		if path == "" {
+			return jr.PruneWhere(func(string, []byte, string) bool { return false })
+		}
+	}
+
+	if jr.Type != JSONObject && jr.Type != JSONArray {
+		return nil, fmt.Errorf("key paths provided are invalid for JSON type '%s'", jr.Type)
+	}
+
+	// selected holds the paths to emit in full, and ancestors the paths leading to them.
+	selected := make(map[string]bool)
+	ancestors := make(map[string]bool)
+
+	for _, pattern := range paths {
+		for _, path := range jr.expandPath(pattern) {
+			selected[path] = true
+			for i := strings.LastIndexByte(path, '.'); i > 0; i = strings.LastIndexByte(path[:i], '.') {
+				ancestors[path[:i]] = true
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	rewrite(&buf, *jr.getChildByKey(""), "", func(path string, p parsed) (rewriteAction, []byte) {
+		switch {
+		case selected[path]:
+			return rewriteReplace, compact(p)
+		case ancestors[path]:
+			return rewriteKeep, nil
+		}
+		return rewriteRemove, nil
+	})
+
+	return buf.Bytes(), nil
+}
+
+// Project returns a new document holding only the given key paths of data. See JSONReader.Project.
+func Project(data []byte, paths ...string) ([]byte, error) {
+	r, err := NewJSONReader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Project(paths...)
+}
+
+// compact serializes the given node in full.
+func compact(p parsed) []byte {
+	var buf bytes.Buffer
+	rewrite(&buf, p, "", func(string, parsed) (rewriteAction, []byte) { return rewriteKeep, nil })
+	return buf.Bytes()
+}
+
+// Redact returns a copy of the document with the values at the given key paths replaced, such as
+// passwords and tokens which must not be logged or forwarded. A "*" segment matches any single key
+// or array index, and a "**" segment matches any number of them, so that "**.password" matches a
+// password key anywhere in the document.
+//
+// The replacement is encoded with encoding/json, so that "[REDACTED]" is written as a string. A
+// json.RawMessage is written as-is. If the replacement is nil, the matching nodes are removed, as
+// with Delete.
+//
+// Example:
+//
+//	b, err := r.Redact([]string{"users.*.ssn", "**.token"}, "[REDACTED]")
+func (jr *JSONReader) Redact(paths []string, replacement interface{}) ([]byte, error) {
+	if jr.Empty {
+		return nil, ErrEmpty
+	}
+
+	action := rewriteRemove
+	var value []byte
+
+	if replacement != nil {
+		var err error
+		if value, err = json.Marshal(replacement); err != nil {
+			return nil, err
+		}
+		action = rewriteReplace
+	}
+
+	// Scalar roots have no key paths to redact.
+	if jr.Type != JSONObject && jr.Type != JSONArray {
+		return append([]byte{}, trim(jr.rawData)...), nil
+	}
+
+	patterns := make([][]string, len(paths))
+	for i, p := range paths {
+		patterns[i] = strings.Split(p, ".")
+	}
+
+	var buf bytes.Buffer
+	rewrite(&buf, *jr.getChildByKey(""), "", func(path string, p parsed) (rewriteAction, []byte) {
+		segments := strings.Split(path, ".")
+		for _, pattern := range patterns {
+			if matchPath(pattern, segments) {
+				return action, value
+			}
+		}
+		return rewriteKeep, nil
+	})
+
+	return buf.Bytes(), nil
+}
+
+// Redact returns a copy of data with the values at the given key paths replaced. See JSONReader.Redact.
+func Redact(data []byte, paths []string, replacement interface{}) ([]byte, error) {
+	r, err := NewJSONReader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Redact(paths, replacement)
+}
+
+// Transform returns a copy of the document with the values at the given key path rewritten by fn,
+// such as to normalize dates or lower-case emails before a document is forwarded. As with Redact, a
+// "*" segment matches any single key or array index, and a "**" segment matches any number of them.
+// The empty path matches the root.
+//
+// fn receives the value and its JSON type, and returns the new value and its type. As with PruneWhere,
+// strings are given without their surrounding quotes, and are returned the same way: escapes are left
+// intact, and must be valid. An error is returned if fn fails, or if a value it returns is not valid
+// JSON of the type given. The descendants of a matching node are not visited.
+//
+// Example:
+//
+//	b, err := r.Transform("users.*.email", func(raw []byte, dtype string) ([]byte, string, error) {
+//		return bytes.ToLower(raw), dtype, nil
+//	})
+func (jr *JSONReader) Transform(path string, fn func(raw []byte, dtype string) ([]byte, string, error)) ([]byte, error) {
+	if jr.Empty {
+		return nil, ErrEmpty
+	}
+
+	transform := func(path string, p parsed) ([]byte, error) {
+		b, t, err := fn(p.bytes, p.dtype)
+		if err != nil {
+			return nil, fmt.Errorf("transform failed for key '%s': %w", path, err)
+		}
+
+		if t == JSONString {
+			b = append(append([]byte{'"'}, b...), '"')
+		}
+
+		if !isJSONType(b, t) {
+			return nil, fmt.Errorf("key '%s' was transformed to invalid JSON for type '%s'", path, t)
+		}
+
+		return b, nil
+	}
+
+	if path == "" {
+		root := jr.getChildByKey("")
+		if jr.Type != JSONObject && jr.Type != JSONArray {
+			// The scalar is held as the only child of the root, with its quotes removed.
+			c := jr.parsed["0"]
+			root = &c
+		}
+
+		return transform("", *root)
+	}
+
+	// Scalar roots have no key paths to transform.
+	if jr.Type != JSONObject && jr.Type != JSONArray {
+		return append([]byte{}, trim(jr.rawData)...), nil
+	}
+
+	pattern := strings.Split(path, ".")
+
+	var err error
+	var buf bytes.Buffer
+	rewrite(&buf, *jr.getChildByKey(""), "", func(path string, p parsed) (rewriteAction, []byte) {
+		if err != nil || !matchPath(pattern, strings.Split(path, ".")) {
+			return rewriteKeep, nil
+		}
+
+		var b []byte
+		if b, err = transform(path, p); err != nil {
+			return rewriteKeep, nil
+		}
+		return rewriteReplace, b
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Transform returns a copy of data with the values at the given key path rewritten by fn. See
+// JSONReader.Transform.
+func Transform(data []byte, path string, fn func(raw []byte, dtype string) ([]byte, string, error)) ([]byte, error) {
+	r, err := NewJSONReader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Transform(path, fn)
+}
+
+// isJSONType reports whether b is a valid JSON value of type t.
+func isJSONType(b []byte, t string) bool {
+	switch t {
+	case JSONString:
+		return IsJSONString(b)
+	case JSONInt, JSONFloat:
+		return IsJSONNumber(b)
+	case JSONBool:
+		return string(b) == "true" || string(b) == "false"
+	case JSONNull:
+		return string(b) == "null"
+	case JSONObject:
+		return IsJSONObject(b)
+	case JSONArray:
+		return IsJSONArray(b)
+	}
+
+	return false
+}
+
+// matchPath reports whether the segments of a key path match a pattern, in which "*" matches any one
+// segment, and "**" matches any number of segments.
+func matchPath(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchPath(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 || (pattern[0] != "*" && pattern[0] != segments[0]) {
+			return false
+		}
+
+		pattern, segments = pattern[1:], segments[1:]
+	}
+
+	return len(segments) == 0
+}
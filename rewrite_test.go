@@ -0,0 +1,248 @@
+package gojson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPruneWhere(t *testing.T) {
+	r, err := NewJSONReader([]byte(`{"id": 1, "name": "", "note": null, "internal": {"token": "abc"}, "tags": ["a", "", null, "b"], "nested": {"empty": "", "keep": 1.50, "esc": "a\"b"}}`))
+	assert.Nil(t, err)
+
+	t.Run("Nulls And Empty Strings", func(t *testing.T) {
+		b, err := r.PruneWhere(func(path string, value []byte, dtype string) bool {
+			return dtype == JSONNull || (dtype == JSONString && len(value) == 0)
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, `{"id":1,"internal":{"token":"abc"},"tags":["a","b"],"nested":{"keep":1.50,"esc":"a\"b"}}`, string(b))
+	})
+
+	t.Run("By Path", func(t *testing.T) {
+		var visited []string
+		b, err := r.PruneWhere(func(path string, value []byte, dtype string) bool {
+			visited = append(visited, path)
+			return path == "internal" || path == "tags.1"
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, `{"id":1,"name":"","note":null,"tags":["a",null,"b"],"nested":{"empty":"","keep":1.50,"esc":"a\"b"}}`, string(b))
+		assert.NotContains(t, visited, "internal.token")
+		assert.Contains(t, visited, "nested.esc")
+	})
+
+	t.Run("Output Is Valid JSON", func(t *testing.T) {
+		b, err := r.PruneWhere(func(string, []byte, string) bool { return false })
+		assert.Nil(t, err)
+		_, err = NewJSONReader(b)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Empty Reader", func(t *testing.T) {
+		_, err := (&JSONReader{Empty: true}).PruneWhere(func(string, []byte, string) bool { return true })
+		assert.Equal(t, ErrEmpty, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	r, err := NewJSONReader([]byte(`{"a": {"b": 1, "c": [1, 2, 3]}, "d": "x", "d": "y"}`))
+	assert.Nil(t, err)
+
+	b, err := r.Delete("a.b", "a.c.0", "missing.key")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"c":[2,3]},"d":"y"}`, string(b))
+
+	b, err = r.Delete()
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"b":1,"c":[1,2,3]},"d":"y"}`, string(b))
+
+	s, err := NewJSONReader([]byte(`"hello"`))
+	assert.Nil(t, err)
+	b, err = s.Delete("x")
+	assert.Nil(t, err)
+	assert.Equal(t, `"hello"`, string(b))
+}
+
+func TestProject(t *testing.T) {
+	data := []byte(`{"id": 1, "user": {"name": "Bob", "token": "x", "tags": ["a", "b"]}, "items": [{"sku": "a", "qty": 2}, {"sku": "b\"c", "qty": 3}], "note": null}`)
+
+	testCases := []struct {
+		label    string
+		paths    []string
+		expected string
+	}{
+		{label: "Top Level", paths: []string{"id", "note"}, expected: `{"id":1,"note":null}`},
+		{label: "Nested", paths: []string{"user.name", "user.tags"}, expected: `{"user":{"name":"Bob","tags":["a","b"]}}`},
+		{label: "Array Index", paths: []string{"items.1.sku"}, expected: `{"items":[{"sku":"b\"c"}]}`},
+		{label: "Wildcard", paths: []string{"items.*.qty"}, expected: `{"items":[{"qty":2},{"qty":3}]}`},
+		{label: "Overlapping", paths: []string{"user", "user.name"}, expected: `{"user":{"name":"Bob","token":"x","tags":["a","b"]}}`},
+		{label: "Document Order", paths: []string{"note", "id"}, expected: `{"id":1,"note":null}`},
+		{label: "Missing", paths: []string{"nope", "user.nope"}, expected: `{}`},
+		{label: "None", paths: nil, expected: `{}`},
+		{label: "Root", paths: []string{""}, expected: `{"id":1,"user":{"name":"Bob","token":"x","tags":["a","b"]},"items":[{"sku":"a","qty":2},{"sku":"b\"c","qty":3}],"note":null}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.label, func(t *testing.T) {
+			b, err := Project(data, tc.paths...)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, string(b))
+		})
+	}
+
+	t.Run("Array Root", func(t *testing.T) {
+		b, err := Project([]byte(`[{"a": 1, "b": 2}, {"a": 3}]`), "*.a")
+		assert.Nil(t, err)
+		assert.Equal(t, `[{"a":1},{"a":3}]`, string(b))
+	})
+
+	t.Run("Scalar Root", func(t *testing.T) {
+		b, err := Project([]byte(` "abc" `), "")
+		assert.Nil(t, err)
+		assert.Equal(t, `"abc"`, string(b))
+
+		_, err = Project([]byte(`"abc"`), "a")
+		assert.Equal(t, "key paths provided are invalid for JSON type 'string'", err.Error())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Project([]byte(`{"a": `), "a")
+		assert.NotNil(t, err)
+
+		_, err = (&JSONReader{Empty: true}).Project("a")
+		assert.Equal(t, ErrEmpty, err)
+	})
+}
+
+func TestRedact(t *testing.T) {
+	data := []byte(`{"token": "t0", "users": [{"name": "a", "ssn": "111", "auth": {"token": "t1"}}, {"name": "b", "ssn": "222"}], "meta": {"deep": {"token": "t2"}}}`)
+
+	testCases := []struct {
+		label       string
+		paths       []string
+		replacement interface{}
+		expected    string
+	}{
+		{label: "Wildcard", paths: []string{"users.*.ssn"}, replacement: "[REDACTED]", expected: `{"token":"t0","users":[{"name":"a","ssn":"[REDACTED]","auth":{"token":"t1"}},{"name":"b","ssn":"[REDACTED]"}],"meta":{"deep":{"token":"t2"}}}`},
+		{label: "Anywhere", paths: []string{"**.token"}, replacement: "***", expected: `{"token":"***","users":[{"name":"a","ssn":"111","auth":{"token":"***"}},{"name":"b","ssn":"222"}],"meta":{"deep":{"token":"***"}}}`},
+		{label: "Remove", paths: []string{"users.*.ssn", "meta"}, replacement: nil, expected: `{"token":"t0","users":[{"name":"a","auth":{"token":"t1"}},{"name":"b"}]}`},
+		{label: "Raw Replacement", paths: []string{"users.1"}, replacement: json.RawMessage(`null`), expected: `{"token":"t0","users":[{"name":"a","ssn":"111","auth":{"token":"t1"}},null],"meta":{"deep":{"token":"t2"}}}`},
+		{label: "Non-String Replacement", paths: []string{"users.0.auth"}, replacement: map[string]int{"n": 0}, expected: `{"token":"t0","users":[{"name":"a","ssn":"111","auth":{"n":0}},{"name":"b","ssn":"222"}],"meta":{"deep":{"token":"t2"}}}`},
+		{label: "Missing", paths: []string{"nope", "users.*.nope"}, replacement: "x", expected: `{"token":"t0","users":[{"name":"a","ssn":"111","auth":{"token":"t1"}},{"name":"b","ssn":"222"}],"meta":{"deep":{"token":"t2"}}}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.label, func(t *testing.T) {
+			b, err := Redact(data, tc.paths, tc.replacement)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, string(b))
+		})
+	}
+
+	t.Run("Scalar Root", func(t *testing.T) {
+		b, err := Redact([]byte(`"secret"`), []string{"**"}, "x")
+		assert.Nil(t, err)
+		assert.Equal(t, `"secret"`, string(b))
+	})
+
+	t.Run("Invalid Replacement", func(t *testing.T) {
+		_, err := Redact(data, []string{"token"}, func() {})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestTransform(t *testing.T) {
+	data := []byte(`{"users": [{"email": "A@B.COM", "score": 1.2345, "tags": ["x"]}, {"email": "C@D.com", "score": 2}], "total": 3.14159}`)
+
+	lower := func(raw []byte, dtype string) ([]byte, string, error) {
+		return bytes.ToLower(raw), dtype, nil
+	}
+
+	round := func(raw []byte, dtype string) ([]byte, string, error) {
+		f := toFloat(raw, dtype, false)
+		return []byte(strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)), JSONFloat, nil
+	}
+
+	testCases := []struct {
+		label    string
+		path     string
+		fn       func([]byte, string) ([]byte, string, error)
+		expected string
+	}{
+		{label: "Wildcard", path: "users.*.email", fn: lower, expected: `{"users":[{"email":"a@b.com","score":1.2345,"tags":["x"]},{"email":"c@d.com","score":2}],"total":3.14159}`},
+		{label: "Anywhere", path: "**.score", fn: round, expected: `{"users":[{"email":"A@B.COM","score":1.23,"tags":["x"]},{"email":"C@D.com","score":2}],"total":3.14159}`},
+		{label: "Single", path: "total", fn: round, expected: `{"users":[{"email":"A@B.COM","score":1.2345,"tags":["x"]},{"email":"C@D.com","score":2}],"total":3.14}`},
+		{label: "Change Type", path: "users.0.tags", fn: func(raw []byte, dtype string) ([]byte, string, error) {
+			return []byte(`x`), JSONString, nil
+		}, expected: `{"users":[{"email":"A@B.COM","score":1.2345,"tags":"x"},{"email":"C@D.com","score":2}],"total":3.14159}`},
+		{label: "Missing", path: "users.*.nope", fn: lower, expected: `{"users":[{"email":"A@B.COM","score":1.2345,"tags":["x"]},{"email":"C@D.com","score":2}],"total":3.14159}`},
+		{label: "Root", path: "", fn: func(raw []byte, dtype string) ([]byte, string, error) {
+			return []byte(`{}`), JSONObject, nil
+		}, expected: `{}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.label, func(t *testing.T) {
+			b, err := Transform(data, tc.path, tc.fn)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, string(b))
+		})
+	}
+
+	t.Run("Scalar Root", func(t *testing.T) {
+		b, err := Transform([]byte(`"ABC"`), "**", lower)
+		assert.Nil(t, err)
+		assert.Equal(t, `"ABC"`, string(b))
+
+		b, err = Transform([]byte(`"ABC"`), "", lower)
+		assert.Nil(t, err)
+		assert.Equal(t, `"abc"`, string(b))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := Transform(data, "users.*.email", func(raw []byte, dtype string) ([]byte, string, error) {
+			return nil, "", errors.New("bad email")
+		})
+		assert.EqualError(t, err, "transform failed for key 'users.0.email': bad email")
+
+		_, err = Transform(data, "total", func(raw []byte, dtype string) ([]byte, string, error) {
+			return []byte(`abc`), JSONInt, nil
+		})
+		assert.EqualError(t, err, "key 'total' was transformed to invalid JSON for type 'int'")
+
+		_, err = Transform(data, "total", func(raw []byte, dtype string) ([]byte, string, error) {
+			return []byte(`a"b`), JSONString, nil
+		})
+		assert.EqualError(t, err, "key 'total' was transformed to invalid JSON for type 'string'")
+	})
+}
+
+func TestMatchPath(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"a.b", "a.b", true},
+		{"a.b", "a.b.c", false},
+		{"a.*", "a.b", true},
+		{"a.*", "a", false},
+		{"**.c", "c", true},
+		{"**.c", "a.b.c", true},
+		{"**.c", "a.c.d", false},
+		{"a.**", "a", true},
+		{"a.**.d", "a.b.c.d", true},
+		{"*.**.d", "d", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern+" "+tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchPath(strings.Split(tc.pattern, "."), strings.Split(tc.path, ".")))
+		})
+	}
+}
@@ -0,0 +1,388 @@
+package gojson
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// SyntaxError is returned by ParseStrictRFC8259 for input which is not a JSON text as defined by RFC 8259.
+type SyntaxError struct {
+	// Offset is the byte offset of the first violation in the input.
+	Offset int
+
+	// Reason describes the violation.
+	Reason string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Reason, e.Offset)
+}
+
+// ParseStrictRFC8259 creates a JSONReader, as NewJSONReader does, from input which must be a JSON text
+// exactly as defined by RFC 8259, for services reading untrusted input. NewJSONReader tolerates some
+// malformed input, such as invalid escape sequences or trailing data, while ParseStrictRFC8259 rejects
+// anything outside the grammar with a *SyntaxError, including strings which are not valid UTF-8 and a
+// leading byte order mark. Documents nested deeper than the limit set by WithMaxDepth (DefaultMaxDepth
+// by default) are rejected with a *DepthExceededError, and those exceeding the other reader limits with
+// a *LimitError.
+//
+// ParseStrictRFC8259 never panics. Every error is returned, and the reader returned with an error is Empty.
+func ParseStrictRFC8259(data []byte, opts ...ReaderOption) (reader *JSONReader, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			reader, err = &JSONReader{Empty: true}, fmt.Errorf("%w: %v", ErrMalformedJSON, r)
+		}
+	}()
+
+	if len(data) == 0 {
+		return &JSONReader{Empty: true}, ErrEmpty
+	}
+
+	settings := &JSONReader{}
+	for _, opt := range opts {
+		opt(settings)
+	}
+
+	if err := checkLimits(nil, data, settings.limits()); err != nil {
+		return &JSONReader{Empty: true}, err
+	}
+
+	if err := validateRFC8259(data); err != nil {
+		return &JSONReader{Empty: true}, err
+	}
+
+	reader, err = NewJSONReader(data, opts...)
+	if err != nil {
+		return &JSONReader{Empty: true}, err
+	}
+
+	return reader, nil
+}
+
+// ValidateOption configures the IsJSON functions and GetJSONType.
+type ValidateOption func(*validateOptions)
+
+type validateOptions struct {
+	rfc8259 bool
+}
+
+// WithRFC8259 limits the IsJSON functions and GetJSONType to input which is valid under RFC 8259. By
+// default they accept literals in any case, such as NuLl and TRUE, escape sequences such as \B, and
+// form feeds as whitespace, while nested values are only partly validated by GetJSONType.
+//
+// Example:
+//
+//	gojson.IsJSONNull([]byte(`NuLl`))                      // true
+//	gojson.IsJSONNull([]byte(`NuLl`), gojson.WithRFC8259()) // false
+func WithRFC8259() ValidateOption {
+	return func(o *validateOptions) {
+		o.rfc8259 = true
+	}
+}
+
+// rfc8259 reports whether the options select RFC 8259 validation.
+func rfc8259(opts []ValidateOption) bool {
+	// Most calls have no options, and shouldn't pay for o escaping to the heap.
+	if len(opts) == 0 {
+		return false
+	}
+
+	var o validateOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.rfc8259
+}
+
+// isRFC8259 reports whether b holds a single value accepted by scan, surrounded only by whitespace.
+func isRFC8259(b []byte, scan func(*rfcScanner) error) bool {
+	if checkDepth(b, DefaultMaxDepth) != nil {
+		return false
+	}
+
+	s := rfcScanner{data: b}
+	s.space()
+	if s.pos >= len(s.data) || scan(&s) != nil {
+		return false
+	}
+
+	s.space()
+	return s.pos == len(s.data)
+}
+
+// isRFC8259Value reports whether a valid value begins at start, ignoring anything which follows it.
+func isRFC8259Value(search []byte, start int) bool {
+	if start < 0 || start >= len(search) || checkDepth(search[start:], DefaultMaxDepth) != nil {
+		return false
+	}
+
+	s := rfcScanner{data: search, pos: start}
+	s.space()
+	return s.value() == nil
+}
+
+// rfcOpening returns a scan which requires the value to begin with the byte c.
+func rfcOpening(c byte, scan func(*rfcScanner) error) func(*rfcScanner) error {
+	return func(s *rfcScanner) error {
+		if s.data[s.pos] != c {
+			return s.unexpected(fmt.Sprintf("%q", c))
+		}
+
+		return scan(s)
+	}
+}
+
+// validateRFC8259 checks that data is a JSON text as defined by RFC 8259. The nesting depth of data must
+// already have been limited, as nested values are validated recursively.
+func validateRFC8259(data []byte) error {
+	s := rfcScanner{data: data}
+
+	s.space()
+	if err := s.value(); err != nil {
+		return err
+	}
+
+	s.space()
+	if s.pos < len(s.data) {
+		return s.fail("unexpected data after top-level value")
+	}
+
+	return nil
+}
+
+// rfcScanner validates a document against the grammar of RFC 8259, one value at a time.
+type rfcScanner struct {
+	data []byte
+	pos  int
+}
+
+func (s *rfcScanner) fail(format string, args ...interface{}) error {
+	return &SyntaxError{Offset: s.pos, Reason: fmt.Sprintf(format, args...)}
+}
+
+// unexpected reports the byte at the current position, or the end of the input.
+func (s *rfcScanner) unexpected(expected string) error {
+	if s.pos >= len(s.data) {
+		return s.fail("unexpected end of input, expected %s", expected)
+	}
+
+	return s.fail("invalid character %q, expected %s", s.data[s.pos], expected)
+}
+
+// space skips the whitespace permitted between tokens, which unlike isWhitespace excludes form feeds.
+func (s *rfcScanner) space() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *rfcScanner) value() error {
+	if s.pos >= len(s.data) {
+		return s.unexpected("value")
+	}
+
+	switch c := s.data[s.pos]; {
+	case c == '{':
+		return s.object()
+	case c == '[':
+		return s.array()
+	case c == '"':
+		return s.string()
+	case c == '-' || isDigit(c):
+		return s.number()
+	case c == 't':
+		return s.literal("true")
+	case c == 'f':
+		return s.literal("false")
+	case c == 'n':
+		return s.literal("null")
+	}
+
+	return s.unexpected("value")
+}
+
+func (s *rfcScanner) object() error {
+	s.pos++
+	s.space()
+	if s.pos < len(s.data) && s.data[s.pos] == '}' {
+		s.pos++
+		return nil
+	}
+
+	for {
+		if s.pos >= len(s.data) || s.data[s.pos] != '"' {
+			return s.unexpected("object key")
+		}
+		if err := s.string(); err != nil {
+			return err
+		}
+
+		s.space()
+		if s.pos >= len(s.data) || s.data[s.pos] != ':' {
+			return s.unexpected("':'")
+		}
+		s.pos++
+
+		s.space()
+		if err := s.value(); err != nil {
+			return err
+		}
+
+		s.space()
+		if s.pos < len(s.data) {
+			switch s.data[s.pos] {
+			case ',':
+				s.pos++
+				s.space()
+				continue
+			case '}':
+				s.pos++
+				return nil
+			}
+		}
+
+		return s.unexpected("',' or '}'")
+	}
+}
+
+func (s *rfcScanner) array() error {
+	s.pos++
+	s.space()
+	if s.pos < len(s.data) && s.data[s.pos] == ']' {
+		s.pos++
+		return nil
+	}
+
+	for {
+		if err := s.value(); err != nil {
+			return err
+		}
+
+		s.space()
+		if s.pos < len(s.data) {
+			switch s.data[s.pos] {
+			case ',':
+				s.pos++
+				s.space()
+				continue
+			case ']':
+				s.pos++
+				return nil
+			}
+		}
+
+		return s.unexpected("',' or ']'")
+	}
+}
+
+func (s *rfcScanner) string() error {
+	s.pos++
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		switch {
+		case c == '"':
+			s.pos++
+			return nil
+		case c == '\\':
+			if err := s.escape(); err != nil {
+				return err
+			}
+		case c < 0x20:
+			return s.fail("invalid control character %q in string", c)
+		case c < utf8.RuneSelf:
+			s.pos++
+		default:
+			r, size := utf8.DecodeRune(s.data[s.pos:])
+			if r == utf8.RuneError && size == 1 {
+				return s.fail("invalid UTF-8 in string")
+			}
+			s.pos += size
+		}
+	}
+
+	return s.unexpected("'\"'")
+}
+
+func (s *rfcScanner) escape() error {
+	s.pos++
+	if s.pos >= len(s.data) {
+		return s.unexpected("escape sequence")
+	}
+
+	switch s.data[s.pos] {
+	case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+		s.pos++
+		return nil
+	case 'u':
+		s.pos++
+		for i := 0; i < 4; i++ {
+			if s.pos >= len(s.data) || !isHexDigit(s.data[s.pos]) {
+				return s.unexpected("hexadecimal digit")
+			}
+			s.pos++
+		}
+		return nil
+	}
+
+	return s.unexpected("escape sequence")
+}
+
+// number validates -?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?
+func (s *rfcScanner) number() error {
+	if s.data[s.pos] == '-' {
+		s.pos++
+	}
+
+	switch {
+	case s.pos < len(s.data) && s.data[s.pos] == '0':
+		s.pos++
+	case s.pos < len(s.data) && isOneToNine(s.data[s.pos]):
+		s.digits()
+	default:
+		return s.unexpected("digit")
+	}
+
+	if s.pos < len(s.data) && s.data[s.pos] == '.' {
+		s.pos++
+		if s.digits() == 0 {
+			return s.unexpected("digit")
+		}
+	}
+
+	if s.pos < len(s.data) && (s.data[s.pos] == 'e' || s.data[s.pos] == 'E') {
+		s.pos++
+		if s.pos < len(s.data) && (s.data[s.pos] == '+' || s.data[s.pos] == '-') {
+			s.pos++
+		}
+		if s.digits() == 0 {
+			return s.unexpected("digit")
+		}
+	}
+
+	return nil
+}
+
+// digits skips a run of digits, returning its length.
+func (s *rfcScanner) digits() int {
+	start := s.pos
+	for s.pos < len(s.data) && isDigit(s.data[s.pos]) {
+		s.pos++
+	}
+
+	return s.pos - start
+}
+
+func (s *rfcScanner) literal(lit string) error {
+	if len(s.data)-s.pos < len(lit) || string(s.data[s.pos:s.pos+len(lit)]) != lit {
+		return s.fail("invalid literal, expected %s", lit)
+	}
+
+	s.pos += len(lit)
+	return nil
+}
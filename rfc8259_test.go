@@ -0,0 +1,85 @@
+package gojson
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStrictRFC8259(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		expected error
+	}{
+		{name: "Object", json: ` {"a": [1, -0.5e+3, "b\\u00e9\\n", true, false, null], "c": {}} `},
+		{name: "Scalar", json: `"é"`},
+		{name: "Empty", json: ``, expected: ErrEmpty},
+		{name: "Trailing Data", json: `{"a": 1} {}`, expected: &SyntaxError{Offset: 9, Reason: "unexpected data after top-level value"}},
+		{name: "Trailing Comma", json: `[1, 2,]`, expected: &SyntaxError{Offset: 6, Reason: `invalid character ']', expected value`}},
+		{name: "Missing Colon", json: `{"a" 1}`, expected: &SyntaxError{Offset: 5, Reason: `invalid character '1', expected ':'`}},
+		{name: "Unterminated", json: `{"a": [1`, expected: &SyntaxError{Offset: 8, Reason: `unexpected end of input, expected ',' or ']'`}},
+		{name: "Unquoted Key", json: `{a: 1}`, expected: &SyntaxError{Offset: 1, Reason: `invalid character 'a', expected object key`}},
+		{name: "Invalid Escape", json: `"\B"`, expected: &SyntaxError{Offset: 2, Reason: `invalid character 'B', expected escape sequence`}},
+		{name: "Short Unicode Escape", json: `"\u00g0"`, expected: &SyntaxError{Offset: 5, Reason: `invalid character 'g', expected hexadecimal digit`}},
+		{name: "Control Character", json: "\"a\tb\"", expected: &SyntaxError{Offset: 2, Reason: `invalid control character '\t' in string`}},
+		{name: "Invalid UTF-8", json: "\"a\xffb\"", expected: &SyntaxError{Offset: 2, Reason: "invalid UTF-8 in string"}},
+		{name: "Byte Order Mark", json: "\xef\xbb\xbf{}", expected: &SyntaxError{Offset: 0, Reason: `invalid character 'ï', expected value`}},
+		{name: "Form Feed", json: "[\f1]", expected: &SyntaxError{Offset: 1, Reason: `invalid character '\f', expected value`}},
+		{name: "Leading Zero", json: `01`, expected: &SyntaxError{Offset: 1, Reason: "unexpected data after top-level value"}},
+		{name: "Leading Plus", json: `+1`, expected: &SyntaxError{Offset: 0, Reason: `invalid character '+', expected value`}},
+		{name: "Bare Decimal Point", json: `1.`, expected: &SyntaxError{Offset: 2, Reason: "unexpected end of input, expected digit"}},
+		{name: "Empty Exponent", json: `1e+`, expected: &SyntaxError{Offset: 3, Reason: "unexpected end of input, expected digit"}},
+		{name: "Bad Literal", json: `[tru]`, expected: &SyntaxError{Offset: 1, Reason: "invalid literal, expected true"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader, err := ParseStrictRFC8259([]byte(tc.json))
+			assert.Equal(t, tc.expected, err)
+			assert.Equal(t, tc.expected != nil, reader.Empty)
+		})
+	}
+
+	t.Run("Reader", func(t *testing.T) {
+		reader, err := ParseStrictRFC8259([]byte(`{"a": {"b": [1, 2]}}`))
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2}, reader.GetIntSlice("a.b"))
+	})
+
+	t.Run("Max Depth", func(t *testing.T) {
+		_, err := ParseStrictRFC8259([]byte(strings.Repeat("[", 4)+strings.Repeat("]", 4)), WithMaxDepth(3))
+		assert.Equal(t, &DepthExceededError{MaxDepth: 3, Offset: 3}, err)
+	})
+}
+
+// TestRFC8259Compliance runs the documents in testdata/jsontestsuite, which are named after the
+// cases of JSONTestSuite: y_ documents must be accepted and n_ documents rejected. i_ documents, whose
+// handling RFC 8259 leaves to the implementation, are rejected.
+func TestRFC8259Compliance(t *testing.T) {
+	files, err := filepath.Glob("testdata/jsontestsuite/*.json")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, files)
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			assert.Nil(t, err)
+
+			expected := strings.HasPrefix(name, "y_")
+			if !strings.HasPrefix(name, "i_") {
+				assert.Equal(t, expected, json.Valid(data), "encoding/json disagrees with the expected result")
+			}
+			assert.Equal(t, expected, IsJSON(data, WithRFC8259()))
+			assert.Equal(t, expected, GetJSONTypeStrict(data, 0, WithRFC8259()) != JSONInvalid)
+
+			_, err = ParseStrictRFC8259(data)
+			assert.Equal(t, expected, err == nil)
+		})
+	}
+}
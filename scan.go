@@ -0,0 +1,19 @@
+package gojson
+
+// The scanning primitives below are implemented in scan_swar.go, which examines eight bytes at a
+// time, and in scan_generic.go, a byte at a time. The generic versions are selected with the
+// gojson_noswar build tag, e.g. for comparison when benchmarking.
+
+// stringEnd returns the index of the quote which closes a string, given the index of the first
+// byte following its opening quote, or -1 if the string is unterminated.
+func stringEnd(b []byte, i int) int {
+	for {
+		i = indexQuoteOrEscape(b, i)
+		if i < 0 || b[i] == '"' {
+			return i
+		}
+
+		// Skip the escaped byte.
+		i += 2
+	}
+}
@@ -0,0 +1,21 @@
+//go:build gojson_noswar
+
+package gojson
+
+// skipWhitespace returns the index of the first non-whitespace byte at or after i, or len(b).
+func skipWhitespace(b []byte, i int) int {
+	for i < len(b) && isWhitespace(b[i]) {
+		i++
+	}
+	return i
+}
+
+// indexQuoteOrEscape returns the index of the first '"' or '\' at or after i, or -1.
+func indexQuoteOrEscape(b []byte, i int) int {
+	for ; i < len(b); i++ {
+		if b[i] == '"' || b[i] == '\\' {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,66 @@
+//go:build !gojson_noswar
+
+package gojson
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// SWAR (SIMD within a register) constants, repeating a byte across a 64 bit word.
+const (
+	swarLow7  = 0x7f7f7f7f7f7f7f7f
+	swarHigh  = 0x8080808080808080
+	swarSpace = 0x2020202020202020
+	swarTab   = 0x0909090909090909
+	swarLF    = 0x0a0a0a0a0a0a0a0a
+	swarFF    = 0x0c0c0c0c0c0c0c0c
+	swarCR    = 0x0d0d0d0d0d0d0d0d
+	swarQuote = 0x2222222222222222
+	swarSlash = 0x5c5c5c5c5c5c5c5c
+)
+
+// swarZero returns a word with the high bit set in exactly those bytes of w which are zero.
+func swarZero(w uint64) uint64 {
+	return ^(((w & swarLow7) + swarLow7) | w | swarLow7)
+}
+
+// skipWhitespace returns the index of the first non-whitespace byte at or after i, or len(b).
+func skipWhitespace(b []byte, i int) int {
+	// Most values are separated by a single space, or none at all.
+	if i < len(b) && !isWhitespace(b[i]) {
+		return i
+	}
+
+	for ; i+8 <= len(b); i += 8 {
+		w := binary.LittleEndian.Uint64(b[i:])
+		ws := swarZero(w^swarSpace) | swarZero(w^swarTab) | swarZero(w^swarLF) | swarZero(w^swarCR) | swarZero(w^swarFF)
+
+		if ws != swarHigh {
+			return i + bits.TrailingZeros64(^ws&swarHigh)/8
+		}
+	}
+
+	for i < len(b) && isWhitespace(b[i]) {
+		i++
+	}
+	return i
+}
+
+// indexQuoteOrEscape returns the index of the first '"' or '\' at or after i, or -1.
+func indexQuoteOrEscape(b []byte, i int) int {
+	for ; i+8 <= len(b); i += 8 {
+		w := binary.LittleEndian.Uint64(b[i:])
+
+		if m := swarZero(w^swarQuote) | swarZero(w^swarSlash); m != 0 {
+			return i + bits.TrailingZeros64(m)/8
+		}
+	}
+
+	for ; i < len(b); i++ {
+		if b[i] == '"' || b[i] == '\\' {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,58 @@
+package gojson
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipWhitespace(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		start    int
+		expected int
+	}{
+		{name: "Empty", input: "", expected: 0},
+		{name: "No Whitespace", input: "abc", expected: 0},
+		{name: "Short Run", input: " \t\r\nx", expected: 4},
+		{name: "All Whitespace", input: strings.Repeat(" \t\n\r\f", 5), expected: 25},
+		{name: "Word Boundary", input: strings.Repeat(" ", 8) + "x", expected: 8},
+		{name: "Within Second Word", input: strings.Repeat("\t", 13) + "x" + strings.Repeat(" ", 10), expected: 13},
+		{name: "From Offset", input: "x" + strings.Repeat(" ", 20) + "y", start: 1, expected: 21},
+		{name: "Non-Breaking Spaces", input: "  \u00a0\u00a0\u00a0\u00a0", expected: 2},
+		{name: "Past End", input: "   ", start: 3, expected: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, skipWhitespace([]byte(tc.input), tc.start))
+		})
+	}
+}
+
+func TestStringEnd(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{name: "Empty String", input: `"`, expected: 0},
+		{name: "Short", input: `abc"`, expected: 3},
+		{name: "Unterminated", input: `abc`, expected: -1},
+		{name: "Escaped Quote", input: `a\"b"`, expected: 4},
+		{name: "Escaped Backslash", input: `a\\"b"`, expected: 3},
+		{name: "Trailing Escape", input: `abc\`, expected: -1},
+		{name: "Long", input: strings.Repeat("x", 37) + `"`, expected: 37},
+		{name: "Escape At Word Boundary", input: strings.Repeat("x", 7) + `\"` + strings.Repeat("y", 9) + `"`, expected: 18},
+		{name: "High Bytes", input: strings.Repeat("é", 10) + `"`, expected: 20},
+		{name: "Long Unterminated", input: strings.Repeat("x", 40), expected: -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, stringEnd([]byte(tc.input), 0))
+		})
+	}
+}
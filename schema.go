@@ -0,0 +1,805 @@
+package gojson
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is a compiled JSON Schema (draft-07) which can validate raw JSON or a JSONReader.
+//
+// The following keywords are supported:
+//
+//	type, enum, const
+//	minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf
+//	minLength, maxLength, pattern
+//	items, additionalItems, minItems, maxItems, uniqueItems, contains
+//	properties, patternProperties, additionalProperties, required,
+//	minProperties, maxProperties, propertyNames, dependencies
+//	allOf, anyOf, oneOf, not, if, then, else
+//	$ref (local references only, e.g. "#/definitions/address"), definitions, $defs
+//
+// Annotation keywords such as title, description, default, examples, and format are accepted and ignored.
+type Schema struct {
+	root     *schemaNode
+	raw      *JSONReader
+	compiled map[string]*schemaNode
+}
+
+// SchemaViolation describes a single location in a document which failed validation.
+type SchemaViolation struct {
+	// Path is the JSON Pointer (RFC 6901) to the offending value. The document root is "".
+	Path string
+
+	// Keyword is the schema keyword which failed.
+	Keyword string
+
+	// Message is a human readable description of the failure.
+	Message string
+}
+
+func (v SchemaViolation) Error() string {
+	return fmt.Sprintf("'%s' failed schema keyword '%s': %s", v.Path, v.Keyword, v.Message)
+}
+
+// SchemaErrors is returned by Schema.Validate when the document does not conform to the schema.
+type SchemaErrors []SchemaViolation
+
+func (e SchemaErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = v.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+type schemaNode struct {
+	// always holds the result for boolean schemas (true / false).
+	always *bool
+
+	ref string
+
+	types []string
+	enum  []interface{}
+	cnst  *interface{}
+
+	minimum, maximum                   *float64
+	exclusiveMinimum, exclusiveMaximum *float64
+	multipleOf                         *float64
+
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+
+	items           *schemaNode
+	tupleItems      []*schemaNode
+	additionalItems *schemaNode
+	minItems        *int
+	maxItems        *int
+	uniqueItems     bool
+	contains        *schemaNode
+
+	properties           map[string]*schemaNode
+	patternProperties    []patternSchema
+	additionalProperties *schemaNode
+	required             []string
+	minProperties        *int
+	maxProperties        *int
+	propertyNames        *schemaNode
+	dependencies         map[string]*schemaNode
+	dependentKeys        map[string][]string
+
+	allOf, anyOf, oneOf []*schemaNode
+	not                 *schemaNode
+	ifSchema            *schemaNode
+	thenSchema          *schemaNode
+	elseSchema          *schemaNode
+}
+
+type patternSchema struct {
+	re     *regexp.Regexp
+	schema *schemaNode
+}
+
+// CompileSchema parses and compiles the given JSON Schema document.
+func CompileSchema(raw []byte) (s *Schema, err error) {
+	defer PanicRecovery(&err)
+
+	r, err := NewJSONReader(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Empty || (r.Type != JSONObject && r.Type != JSONBool) {
+		return nil, fmt.Errorf("schema must be a JSON object or boolean")
+	}
+
+	s = &Schema{raw: r, compiled: make(map[string]*schemaNode)}
+	s.root = s.compile(r.getChildByKey(""), "")
+
+	// Resolve every reference up front so that a bad or circular reference is a compile error.
+	pointers := make([]string, 0, len(s.compiled))
+	for pointer := range s.compiled {
+		pointers = append(pointers, pointer)
+	}
+	sort.Strings(pointers)
+
+	visiting, done := map[*schemaNode]bool{}, map[*schemaNode]bool{}
+	for _, pointer := range pointers {
+		if err := s.checkRefs(s.compiled[pointer], "", visiting, done); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// checkRefs resolves the references reachable from n without descending into the instance, through
+// $ref and the allOf, anyOf, oneOf, not, if, then and else keywords. Reaching n again that way is an
+// error, as validating it would never end, e.g. {"$ref": "#"}. ref is the last reference followed.
+func (s *Schema) checkRefs(n *schemaNode, ref string, visiting, done map[*schemaNode]bool) error {
+	if done[n] {
+		return nil
+	}
+	if visiting[n] {
+		return fmt.Errorf("circular schema reference '%s'", ref)
+	}
+	visiting[n] = true
+
+	if n.ref != "" {
+		r, err := s.resolve(n.ref)
+		if err != nil {
+			return err
+		}
+		if err := s.checkRefs(r, n.ref, visiting, done); err != nil {
+			return err
+		}
+	} else {
+		var children []*schemaNode
+		children = append(children, n.allOf...)
+		children = append(children, n.anyOf...)
+		children = append(children, n.oneOf...)
+		children = append(children, n.not, n.ifSchema, n.thenSchema, n.elseSchema)
+
+		for _, c := range children {
+			if c == nil {
+				continue
+			}
+			if err := s.checkRefs(c, ref, visiting, done); err != nil {
+				return err
+			}
+		}
+	}
+
+	delete(visiting, n)
+	done[n] = true
+	return nil
+}
+
+// Validate validates the given raw JSON against the schema. A nil return means the document is valid.
+// Otherwise the error is either a SchemaErrors listing every violation, or a parse error.
+func (s *Schema) Validate(data []byte) error {
+	r, err := NewJSONReader(data)
+	if err != nil {
+		return err
+	}
+
+	if r.Empty {
+		return ErrMalformedJSON
+	}
+
+	return s.ValidateReader(r)
+}
+
+// ValidateReader validates the root of the given JSONReader against the schema.
+func (s *Schema) ValidateReader(r *JSONReader) error {
+	var errs SchemaErrors
+	s.validate(s.root, r.getChildByKey(""), "", &errs)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// compile converts a parsed schema object into a schemaNode. pointer is the JSON Pointer of the node
+// within the schema document, used to cache compiled nodes for $ref resolution.
+func (s *Schema) compile(p *parsed, pointer string) *schemaNode {
+	if n, ok := s.compiled[pointer]; ok {
+		return n
+	}
+
+	n := &schemaNode{}
+	s.compiled[pointer] = n
+
+	if p.dtype == JSONBool {
+		b := toBool(p.bytes, p.dtype, false)
+		n.always = &b
+		return n
+	}
+
+	if p.dtype != JSONObject {
+		panic(fmt.Errorf("schema at '%s' must be an object or boolean", pointer))
+	}
+
+	child := func(key string) (*parsed, bool) {
+		c, ok := p.children[key]
+		return &c, ok
+	}
+
+	sub := func(key string) *schemaNode {
+		if c, ok := child(key); ok {
+			return s.compile(c, pointer+"/"+escapePointer(key))
+		}
+		return nil
+	}
+
+	subList := func(key string) []*schemaNode {
+		c, ok := child(key)
+		if !ok {
+			return nil
+		}
+		if c.dtype != JSONArray {
+			panic(fmt.Errorf("schema keyword '%s' at '%s' must be an array", key, pointer))
+		}
+
+		list := make([]*schemaNode, len(c.keys))
+		for i, k := range c.keys {
+			cc := c.children[k]
+			list[i] = s.compile(&cc, pointer+"/"+escapePointer(key)+"/"+k)
+		}
+		return list
+	}
+
+	number := func(key string) *float64 {
+		if c, ok := child(key); ok {
+			if c.dtype != JSONInt && c.dtype != JSONFloat {
+				panic(fmt.Errorf("schema keyword '%s' at '%s' must be a number", key, pointer))
+			}
+			f := toFloat(c.bytes, c.dtype, false)
+			return &f
+		}
+		return nil
+	}
+
+	integer := func(key string) *int {
+		if f := number(key); f != nil {
+			i := int(*f)
+			return &i
+		}
+		return nil
+	}
+
+	if c, ok := child("$ref"); ok {
+		n.ref = toString(c.bytes, c.dtype, false)
+	}
+
+	if c, ok := child("type"); ok {
+		switch c.dtype {
+		case JSONString:
+			n.types = []string{toString(c.bytes, c.dtype, false)}
+		case JSONArray:
+			for _, k := range c.keys {
+				n.types = append(n.types, toString(c.children[k].bytes, c.children[k].dtype, false))
+			}
+		}
+	}
+
+	if c, ok := child("enum"); ok {
+		for _, k := range c.keys {
+			n.enum = append(n.enum, schemaValue(c.children[k]))
+		}
+	}
+
+	if c, ok := child("const"); ok {
+		v := schemaValue(*c)
+		n.cnst = &v
+	}
+
+	n.minimum = number("minimum")
+	n.maximum = number("maximum")
+	n.exclusiveMinimum = number("exclusiveMinimum")
+	n.exclusiveMaximum = number("exclusiveMaximum")
+	n.multipleOf = number("multipleOf")
+	n.minLength = integer("minLength")
+	n.maxLength = integer("maxLength")
+	n.minItems = integer("minItems")
+	n.maxItems = integer("maxItems")
+	n.minProperties = integer("minProperties")
+	n.maxProperties = integer("maxProperties")
+
+	if c, ok := child("pattern"); ok {
+		n.pattern = regexp.MustCompile(toString(c.bytes, c.dtype, false))
+	}
+
+	if c, ok := child("items"); ok {
+		if c.dtype == JSONArray {
+			n.tupleItems = subList("items")
+		} else {
+			n.items = sub("items")
+		}
+	}
+
+	n.additionalItems = sub("additionalItems")
+	n.contains = sub("contains")
+
+	if c, ok := child("uniqueItems"); ok {
+		n.uniqueItems = toBool(c.bytes, c.dtype, false)
+	}
+
+	if c, ok := child("properties"); ok {
+		n.properties = make(map[string]*schemaNode, len(c.keys))
+		for _, k := range c.keys {
+			cc := c.children[k]
+			n.properties[k] = s.compile(&cc, pointer+"/properties/"+escapePointer(k))
+		}
+	}
+
+	if c, ok := child("patternProperties"); ok {
+		for _, k := range c.keys {
+			cc := c.children[k]
+			n.patternProperties = append(n.patternProperties, patternSchema{
+				re:     regexp.MustCompile(manualUnescapeString([]byte(k))),
+				schema: s.compile(&cc, pointer+"/patternProperties/"+escapePointer(k)),
+			})
+		}
+	}
+
+	n.additionalProperties = sub("additionalProperties")
+	n.propertyNames = sub("propertyNames")
+
+	if c, ok := child("required"); ok {
+		for _, k := range c.keys {
+			n.required = append(n.required, toString(c.children[k].bytes, c.children[k].dtype, false))
+		}
+	}
+
+	if c, ok := child("dependencies"); ok {
+		for _, k := range c.keys {
+			cc := c.children[k]
+			if cc.dtype == JSONArray {
+				if n.dependentKeys == nil {
+					n.dependentKeys = make(map[string][]string)
+				}
+				for _, dk := range cc.keys {
+					n.dependentKeys[k] = append(n.dependentKeys[k], toString(cc.children[dk].bytes, cc.children[dk].dtype, false))
+				}
+				continue
+			}
+
+			if n.dependencies == nil {
+				n.dependencies = make(map[string]*schemaNode)
+			}
+			n.dependencies[k] = s.compile(&cc, pointer+"/dependencies/"+escapePointer(k))
+		}
+	}
+
+	n.allOf = subList("allOf")
+	n.anyOf = subList("anyOf")
+	n.oneOf = subList("oneOf")
+	n.not = sub("not")
+	n.ifSchema = sub("if")
+	n.thenSchema = sub("then")
+	n.elseSchema = sub("else")
+
+	// Compile definitions so that references into them resolve to the same nodes.
+	for _, key := range []string{"definitions", "$defs"} {
+		if c, ok := child(key); ok {
+			for _, k := range c.keys {
+				cc := c.children[k]
+				s.compile(&cc, pointer+"/"+key+"/"+escapePointer(k))
+			}
+		}
+	}
+
+	return n
+}
+
+// resolve finds the schema node a local $ref points to.
+func (s *Schema) resolve(ref string) (*schemaNode, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported schema reference '%s': only local references are supported", ref)
+	}
+
+	pointer := ref[1:]
+	if n, ok := s.compiled[pointer]; ok {
+		return n, nil
+	}
+
+	p := s.raw.getChildByKey("")
+	if pointer != "" {
+		for _, k := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+			c, ok := p.children[unescapePointer(k)]
+			if !ok {
+				return nil, fmt.Errorf("unresolvable schema reference '%s'", ref)
+			}
+			p = &c
+		}
+	}
+
+	return s.compile(p, pointer), nil
+}
+
+func (s *Schema) validate(n *schemaNode, p *parsed, path string, errs *SchemaErrors) {
+	fail := func(keyword, format string, args ...interface{}) {
+		*errs = append(*errs, SchemaViolation{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n.always != nil {
+		if !*n.always {
+			fail("false", "no value is allowed")
+		}
+		return
+	}
+
+	if n.ref != "" {
+		r, err := s.resolve(n.ref)
+		if err != nil {
+			fail("$ref", err.Error())
+			return
+		}
+
+		// In draft-07, all other keywords are ignored when $ref is present.
+		s.validate(r, p, path, errs)
+		return
+	}
+
+	if len(n.types) > 0 {
+		matched := false
+		for _, t := range n.types {
+			if schemaTypeMatches(t, p) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			fail("type", "expected %s, found %s", strings.Join(n.types, " or "), schemaTypeName(p))
+		}
+	}
+
+	if n.enum != nil {
+		v := schemaValue(*p)
+		found := false
+		for _, e := range n.enum {
+			if schemaEqual(v, e) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			fail("enum", "value is not one of the enumerated values")
+		}
+	}
+
+	if n.cnst != nil && !schemaEqual(schemaValue(*p), *n.cnst) {
+		fail("const", "value does not match the constant value")
+	}
+
+	switch p.dtype {
+	case JSONInt, JSONFloat:
+		s.validateNumber(n, toFloat(p.bytes, p.dtype, false), fail)
+	case JSONString:
+		s.validateString(n, toString(p.bytes, p.dtype, false), fail)
+	case JSONArray:
+		s.validateArray(n, p, path, errs, fail)
+	case JSONObject:
+		s.validateObject(n, p, path, errs, fail)
+	}
+
+	for _, c := range n.allOf {
+		s.validate(c, p, path, errs)
+	}
+
+	if len(n.anyOf) > 0 {
+		matched := false
+		for _, c := range n.anyOf {
+			if s.valid(c, p, path) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			fail("anyOf", "value does not match any of the schemas")
+		}
+	}
+
+	if len(n.oneOf) > 0 {
+		matches := 0
+		for _, c := range n.oneOf {
+			if s.valid(c, p, path) {
+				matches++
+			}
+		}
+
+		if matches != 1 {
+			fail("oneOf", "value matches %d schemas, expected exactly 1", matches)
+		}
+	}
+
+	if n.not != nil && s.valid(n.not, p, path) {
+		fail("not", "value must not match the schema")
+	}
+
+	if n.ifSchema != nil {
+		if s.valid(n.ifSchema, p, path) {
+			if n.thenSchema != nil {
+				s.validate(n.thenSchema, p, path, errs)
+			}
+		} else if n.elseSchema != nil {
+			s.validate(n.elseSchema, p, path, errs)
+		}
+	}
+}
+
+// valid reports whether p matches n without recording any violations.
+func (s *Schema) valid(n *schemaNode, p *parsed, path string) bool {
+	var errs SchemaErrors
+	s.validate(n, p, path, &errs)
+	return len(errs) == 0
+}
+
+func (s *Schema) validateNumber(n *schemaNode, f float64, fail func(string, string, ...interface{})) {
+	if n.minimum != nil && f < *n.minimum {
+		fail("minimum", "%v is less than %v", f, *n.minimum)
+	}
+
+	if n.maximum != nil && f > *n.maximum {
+		fail("maximum", "%v is greater than %v", f, *n.maximum)
+	}
+
+	if n.exclusiveMinimum != nil && f <= *n.exclusiveMinimum {
+		fail("exclusiveMinimum", "%v is less than or equal to %v", f, *n.exclusiveMinimum)
+	}
+
+	if n.exclusiveMaximum != nil && f >= *n.exclusiveMaximum {
+		fail("exclusiveMaximum", "%v is greater than or equal to %v", f, *n.exclusiveMaximum)
+	}
+
+	if n.multipleOf != nil && *n.multipleOf != 0 {
+		q := f / *n.multipleOf
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			fail("multipleOf", "%v is not a multiple of %v", f, *n.multipleOf)
+		}
+	}
+}
+
+func (s *Schema) validateString(n *schemaNode, str string, fail func(string, string, ...interface{})) {
+	length := utf8.RuneCountInString(str)
+
+	if n.minLength != nil && length < *n.minLength {
+		fail("minLength", "length %d is less than %d", length, *n.minLength)
+	}
+
+	if n.maxLength != nil && length > *n.maxLength {
+		fail("maxLength", "length %d is greater than %d", length, *n.maxLength)
+	}
+
+	if n.pattern != nil && !n.pattern.MatchString(str) {
+		fail("pattern", "'%s' does not match pattern '%s'", str, n.pattern)
+	}
+}
+
+func (s *Schema) validateArray(n *schemaNode, p *parsed, path string, errs *SchemaErrors, fail func(string, string, ...interface{})) {
+	count := len(p.keys)
+
+	if n.minItems != nil && count < *n.minItems {
+		fail("minItems", "found %d items, expected at least %d", count, *n.minItems)
+	}
+
+	if n.maxItems != nil && count > *n.maxItems {
+		fail("maxItems", "found %d items, expected at most %d", count, *n.maxItems)
+	}
+
+	for i, k := range p.keys {
+		c := p.children[k]
+		cpath := path + "/" + k
+
+		switch {
+		case n.items != nil:
+			s.validate(n.items, &c, cpath, errs)
+		case n.tupleItems != nil && i < len(n.tupleItems):
+			s.validate(n.tupleItems[i], &c, cpath, errs)
+		case n.tupleItems != nil && n.additionalItems != nil:
+			s.validate(n.additionalItems, &c, cpath, errs)
+		}
+	}
+
+	if n.uniqueItems {
+		values := make([]interface{}, count)
+		for i, k := range p.keys {
+			values[i] = schemaValue(p.children[k])
+			for j := 0; j < i; j++ {
+				if schemaEqual(values[i], values[j]) {
+					fail("uniqueItems", "items %d and %d are equal", j, i)
+				}
+			}
+		}
+	}
+
+	if n.contains != nil {
+		found := false
+		for _, k := range p.keys {
+			c := p.children[k]
+			if s.valid(n.contains, &c, path+"/"+k) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			fail("contains", "no item matches the contains schema")
+		}
+	}
+}
+
+func (s *Schema) validateObject(n *schemaNode, p *parsed, path string, errs *SchemaErrors, fail func(string, string, ...interface{})) {
+	count := len(p.children)
+
+	if n.minProperties != nil && count < *n.minProperties {
+		fail("minProperties", "found %d properties, expected at least %d", count, *n.minProperties)
+	}
+
+	if n.maxProperties != nil && count > *n.maxProperties {
+		fail("maxProperties", "found %d properties, expected at most %d", count, *n.maxProperties)
+	}
+
+	for _, k := range n.required {
+		if _, ok := p.children[k]; !ok {
+			fail("required", "missing required property '%s'", k)
+		}
+	}
+
+	for _, k := range uniqueString(p.keys, true) {
+		c := p.children[k]
+		name := manualUnescapeString([]byte(k))
+		cpath := path + "/" + escapePointer(name)
+
+		if n.propertyNames != nil {
+			np := parsed{bytes: []byte(k), dtype: JSONString}
+			s.validate(n.propertyNames, &np, cpath, errs)
+		}
+
+		matched := false
+		if ps, ok := n.properties[name]; ok {
+			matched = true
+			s.validate(ps, &c, cpath, errs)
+		}
+
+		for _, pp := range n.patternProperties {
+			if pp.re.MatchString(name) {
+				matched = true
+				s.validate(pp.schema, &c, cpath, errs)
+			}
+		}
+
+		if !matched && n.additionalProperties != nil {
+			if n.additionalProperties.always != nil && !*n.additionalProperties.always {
+				*errs = append(*errs, SchemaViolation{Path: cpath, Keyword: "additionalProperties", Message: fmt.Sprintf("property '%s' is not allowed", name)})
+				continue
+			}
+			s.validate(n.additionalProperties, &c, cpath, errs)
+		}
+	}
+
+	keys := make([]string, 0, len(n.dependentKeys)+len(n.dependencies))
+	for k := range n.dependentKeys {
+		keys = append(keys, k)
+	}
+	for k := range n.dependencies {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, ok := p.children[k]; !ok {
+			continue
+		}
+
+		for _, dk := range n.dependentKeys[k] {
+			if _, ok := p.children[dk]; !ok {
+				fail("dependencies", "property '%s' requires property '%s'", k, dk)
+			}
+		}
+
+		if d, ok := n.dependencies[k]; ok {
+			s.validate(d, p, path, errs)
+		}
+	}
+}
+
+func schemaTypeMatches(t string, p *parsed) bool {
+	switch t {
+	case "null":
+		return p.dtype == JSONNull
+	case "boolean":
+		return p.dtype == JSONBool
+	case "string":
+		return p.dtype == JSONString
+	case "object":
+		return p.dtype == JSONObject
+	case "array":
+		return p.dtype == JSONArray
+	case "number":
+		return p.dtype == JSONInt || p.dtype == JSONFloat
+	case "integer":
+		if p.dtype == JSONInt {
+			return true
+		}
+		if p.dtype == JSONFloat {
+			f := toFloat(p.bytes, p.dtype, false)
+			return f == math.Trunc(f)
+		}
+	}
+
+	return false
+}
+
+func schemaTypeName(p *parsed) string {
+	switch p.dtype {
+	case JSONBool:
+		return "boolean"
+	case JSONInt:
+		return "integer"
+	case JSONFloat:
+		return "number"
+	default:
+		return p.dtype
+	}
+}
+
+// schemaValue converts a parsed node into a comparable Go value. All numbers become float64,
+// since JSON Schema considers 1 and 1.0 equal.
+func schemaValue(p parsed) interface{} {
+	switch p.dtype {
+	case JSONInt, JSONFloat:
+		return toFloat(p.bytes, p.dtype, false)
+	case JSONString:
+		return toString(p.bytes, p.dtype, false)
+	case JSONBool:
+		return toBool(p.bytes, p.dtype, false)
+	case JSONArray:
+		out := make([]interface{}, len(p.keys))
+		for i, k := range p.keys {
+			out[i] = schemaValue(p.children[k])
+		}
+		return out
+	case JSONObject:
+		out := make(map[string]interface{}, len(p.children))
+		for k, v := range p.children {
+			out[manualUnescapeString([]byte(k))] = schemaValue(v)
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func schemaEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// escapePointer escapes a single reference token for use in a JSON Pointer.
+func escapePointer(s string) string {
+	if !strings.ContainsAny(s, "~/") {
+		return s
+	}
+
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+// unescapePointer reverses escapePointer.
+func unescapePointer(s string) string {
+	if !strings.Contains(s, "~") {
+		return s
+	}
+
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+}
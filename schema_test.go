@@ -0,0 +1,165 @@
+package gojson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSchema = []byte(`{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type": "object",
+	"required": ["id", "name"],
+	"properties": {
+		"id": {"type": "integer", "minimum": 1},
+		"name": {"type": "string", "minLength": 2, "maxLength": 10, "pattern": "^[A-Z]"},
+		"score": {"type": "number", "exclusiveMaximum": 100, "multipleOf": 0.5},
+		"role": {"enum": ["admin", "user"]},
+		"version": {"const": 2},
+		"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true, "maxItems": 3},
+		"address": {"$ref": "#/definitions/address"},
+		"nullable": {"type": ["string", "null"]}
+	},
+	"additionalProperties": false,
+	"definitions": {
+		"address": {
+			"type": "object",
+			"properties": {
+				"zip": {"type": "string", "pattern": "^[0-9]{5}$"}
+			},
+			"required": ["zip"]
+		}
+	}
+}`)
+
+func TestSchemaValidate(t *testing.T) {
+	s, err := CompileSchema(testSchema)
+	assert.Nil(t, err)
+
+	t.Run("Valid", func(t *testing.T) {
+		err := s.Validate([]byte(`{"id": 1, "name": "Bob", "score": 99.5, "role": "admin", "version": 2.0, "tags": ["a", "b"], "address": {"zip": "12345"}, "nullable": null}`))
+		assert.Nil(t, err)
+	})
+
+	t.Run("Violations", func(t *testing.T) {
+		err := s.Validate([]byte(`{"id": 0, "name": "b", "score": 100, "role": "root", "version": 3, "tags": ["a", "a", 1, "c"], "address": {"zip": "1234"}, "extra": true}`))
+
+		var errs SchemaErrors
+		assert.True(t, errors.As(err, &errs))
+
+		found := map[string]string{}
+		for _, v := range errs {
+			found[v.Path+" "+v.Keyword] = v.Message
+		}
+
+		assert.Contains(t, found, "/id minimum")
+		assert.Contains(t, found, "/name minLength")
+		assert.Contains(t, found, "/name pattern")
+		assert.Contains(t, found, "/score exclusiveMaximum")
+		assert.Contains(t, found, "/role enum")
+		assert.Contains(t, found, "/version const")
+		assert.Contains(t, found, "/tags maxItems")
+		assert.Contains(t, found, "/tags uniqueItems")
+		assert.Contains(t, found, "/tags/2 type")
+		assert.Contains(t, found, "/address/zip pattern")
+		assert.Contains(t, found, "/extra additionalProperties")
+		assert.Equal(t, "expected string, found integer", found["/tags/2 type"])
+	})
+
+	t.Run("Missing Required", func(t *testing.T) {
+		err := s.Validate([]byte(`{"name": "Bob"}`))
+		assert.Equal(t, SchemaErrors{{Path: "", Keyword: "required", Message: "missing required property 'id'"}}, err)
+	})
+
+	t.Run("Wrong Root Type", func(t *testing.T) {
+		err := s.Validate([]byte(`[1, 2]`))
+		assert.Equal(t, "'' failed schema keyword 'type': expected object, found array", err.Error())
+	})
+
+	t.Run("Malformed Document", func(t *testing.T) {
+		err := s.Validate([]byte(`not json`))
+		assert.Equal(t, ErrMalformedJSON, err)
+	})
+
+	t.Run("Validate Reader", func(t *testing.T) {
+		r, err := NewJSONReader([]byte(`{"id": 7, "name": "Al"}`))
+		assert.Nil(t, err)
+		assert.Nil(t, s.ValidateReader(r))
+	})
+}
+
+func TestSchemaCombinators(t *testing.T) {
+	testCases := []struct {
+		schema string
+		data   string
+		valid  bool
+	}{
+		{schema: `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, data: `12`, valid: true},
+		{schema: `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, data: `1.5`, valid: false},
+		{schema: `{"oneOf": [{"minimum": 1}, {"maximum": 10}]}`, data: `5`, valid: false},
+		{schema: `{"oneOf": [{"minimum": 1}, {"maximum": 10}]}`, data: `50`, valid: true},
+		{schema: `{"allOf": [{"minimum": 1}, {"maximum": 10}]}`, data: `50`, valid: false},
+		{schema: `{"not": {"type": "null"}}`, data: `null`, valid: false},
+		{schema: `{"if": {"type": "string"}, "then": {"minLength": 3}, "else": {"minimum": 3}}`, data: `"ab"`, valid: false},
+		{schema: `{"if": {"type": "string"}, "then": {"minLength": 3}, "else": {"minimum": 3}}`, data: `4`, valid: true},
+		{schema: `{"contains": {"const": "x"}}`, data: `["a", "x"]`, valid: true},
+		{schema: `{"contains": {"const": "x"}}`, data: `["a", "b"]`, valid: false},
+		{schema: `{"items": [{"type": "string"}, {"type": "integer"}], "additionalItems": false}`, data: `["a", 1]`, valid: true},
+		{schema: `{"items": [{"type": "string"}, {"type": "integer"}], "additionalItems": false}`, data: `["a", 1, 2]`, valid: false},
+		{schema: `{"patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": false}`, data: `{"x-a": "b"}`, valid: true},
+		{schema: `{"patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": false}`, data: `{"y": "b"}`, valid: false},
+		{schema: `{"propertyNames": {"maxLength": 2}}`, data: `{"abc": 1}`, valid: false},
+		{schema: `{"dependencies": {"a": ["b"]}}`, data: `{"a": 1}`, valid: false},
+		{schema: `{"dependencies": {"a": ["b"]}}`, data: `{"a": 1, "b": 2}`, valid: true},
+		{schema: `{"type": "integer"}`, data: `2.0`, valid: true},
+		{schema: `true`, data: `{"anything": 1}`, valid: true},
+		{schema: `false`, data: `1`, valid: false},
+		{schema: `{"$defs": {"n": {"type": "null"}}, "$ref": "#/$defs/n"}`, data: `null`, valid: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.schema+" "+tc.data, func(t *testing.T) {
+			s, err := CompileSchema([]byte(tc.schema))
+			assert.Nil(t, err)
+			assert.Equal(t, tc.valid, s.Validate([]byte(tc.data)) == nil)
+		})
+	}
+}
+
+func TestCompileSchemaErrors(t *testing.T) {
+	_, err := CompileSchema([]byte(`"string"`))
+	assert.Equal(t, "schema must be a JSON object or boolean", err.Error())
+
+	_, err = CompileSchema([]byte(`{"$ref": "#/definitions/missing"}`))
+	assert.Equal(t, "unresolvable schema reference '#/definitions/missing'", err.Error())
+
+	_, err = CompileSchema([]byte(`{"$ref": "http://example.com/schema.json"}`))
+	assert.Equal(t, "unsupported schema reference 'http://example.com/schema.json': only local references are supported", err.Error())
+
+	_, err = CompileSchema([]byte(`{"minimum": "one"}`))
+	assert.NotNil(t, err)
+
+	_, err = CompileSchema([]byte(`{"$ref": "#"}`))
+	assert.EqualError(t, err, "circular schema reference '#'")
+
+	_, err = CompileSchema([]byte(`{"definitions": {"a": {"$ref": "#/definitions/b"}, "b": {"$ref": "#/definitions/a"}}, "$ref": "#/definitions/a"}`))
+	assert.EqualError(t, err, "circular schema reference '#/definitions/a'")
+
+	_, err = CompileSchema([]byte(`{"definitions": {"a": {"anyOf": [{"type": "null"}, {"$ref": "#/definitions/a"}]}}}`))
+	assert.EqualError(t, err, "circular schema reference '#/definitions/a'")
+}
+
+func TestSchemaRecursiveRef(t *testing.T) {
+	// A reference back to an enclosing schema is fine when it validates a child value.
+	s, err := CompileSchema([]byte(`{"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "#"}}}}`))
+	assert.Nil(t, err)
+
+	assert.Nil(t, s.Validate([]byte(`{"children": [{"children": []}, {}]}`)))
+	assert.NotNil(t, s.Validate([]byte(`{"children": [{"children": [1]}]}`)))
+}
+
+func TestEscapePointer(t *testing.T) {
+	assert.Equal(t, "a~1b~0c", escapePointer("a/b~c"))
+	assert.Equal(t, "a/b~c", unescapePointer("a~1b~0c"))
+}
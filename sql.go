@@ -0,0 +1,121 @@
+package gojson
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// JSONColumn holds the decoded value of a json or jsonb column, implementing sql.Scanner and
+// driver.Valuer. Scan decodes the column with Unmarshal, and Valid is false if the column is NULL.
+// As with the Null type of database/sql, V holds the value, since a field named Value would clash
+// with the Value method.
+//
+// Example:
+//
+//	var settings gojson.JSONColumn[Settings]
+//	err := db.QueryRow(`SELECT settings FROM users WHERE id = $1`, id).Scan(&settings)
+//
+//	_, err = db.Exec(`UPDATE users SET settings = $1 WHERE id = $2`, settings, id)
+type JSONColumn[T any] struct {
+	V     T
+	Valid bool
+}
+
+// Scan implements sql.Scanner, decoding a JSON column into V.
+func (c *JSONColumn[T]) Scan(src interface{}) error {
+	var zero T
+	c.V, c.Valid = zero, false
+
+	b, err := columnBytes(src, "JSONColumn")
+	if err != nil || b == nil {
+		return err
+	}
+
+	if err := Unmarshal(b, &c.V); err != nil {
+		return err
+	}
+
+	c.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer, encoding V with AppendMarshal, or giving NULL if it is not Valid.
+func (c JSONColumn[T]) Value() (driver.Value, error) {
+	if !c.Valid {
+		return nil, nil
+	}
+	return AppendMarshal(nil, c.V)
+}
+
+// Scan implements sql.Scanner, replacing the contents of the reader with a JSON column. A NULL column
+// gives an Empty reader. The reader's StrictStandards, NumberConversion and options are kept.
+//
+// Example:
+//
+//	var attrs gojson.JSONReader
+//	err := db.QueryRow(`SELECT attributes FROM products WHERE id = $1`, id).Scan(&attrs)
+//	color := attrs.GetString("color")
+func (jr *JSONReader) Scan(src interface{}) error {
+	b, err := columnBytes(src, "JSONReader")
+	if err != nil {
+		return err
+	}
+
+	settings := *jr
+	keep := func(r *JSONReader) {
+		r.StrictStandards, r.NumberConversion, r.InvalidUTF8, r.observer = settings.StrictStandards, settings.NumberConversion, settings.InvalidUTF8, settings.observer
+		r.maxDepth, r.maxStringLength, r.maxTokenSize, r.maxDocumentSize = settings.maxDepth, settings.maxStringLength, settings.maxTokenSize, settings.maxDocumentSize
+		if settings.positions != nil {
+			WithPositions()(r)
+		}
+	}
+
+	if b == nil {
+		*jr = JSONReader{Empty: true}
+		keep(jr)
+		return nil
+	}
+
+	r, err := NewJSONReader(b, keep)
+	if err != nil {
+		return err
+	}
+
+	*jr = *r
+	return nil
+}
+
+// Value implements driver.Valuer, giving the reader's JSON, or NULL if the reader is Empty.
+func (jr *JSONReader) Value() (driver.Value, error) {
+	if jr.Empty {
+		return nil, nil
+	}
+
+	// A reader returned by Get for a string holds its escaped contents without the quotes, which are
+	// put back. The contents can't start with an unescaped quote, so one is the root's own.
+	if jr.Type == JSONString && (len(jr.rawData) == 0 || jr.rawData[0] != '"') {
+		b := make([]byte, 0, len(jr.rawData)+2)
+		return append(append(append(b, '"'), jr.rawData...), '"'), nil
+	}
+
+	b := make([]byte, len(jr.rawData))
+	copy(b, jr.rawData)
+	return b, nil
+}
+
+// columnBytes returns the JSON held by a column, or nil if the column is NULL. Drivers may reuse the
+// memory of a []byte after Scan returns, so it is copied.
+func columnBytes(src interface{}, into string) ([]byte, error) {
+	switch v := src.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		b := make([]byte, len(v))
+		copy(b, v)
+		return b, nil
+	}
+
+	return nil, fmt.Errorf("cannot scan column of type %T into %s", src, into)
+}
@@ -0,0 +1,127 @@
+package gojson
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ sql.Scanner   = (*JSONColumn[int])(nil)
+	_ driver.Valuer = JSONColumn[int]{}
+	_ sql.Scanner   = (*JSONReader)(nil)
+	_ driver.Valuer = (*JSONReader)(nil)
+)
+
+func TestJSONColumn(t *testing.T) {
+	type settings struct {
+		Theme string `json:"theme"`
+		Size  int    `json:"size"`
+	}
+
+	t.Run("Scan", func(t *testing.T) {
+		src := []byte(`{"theme": "dark", "size": 12}`)
+
+		var c JSONColumn[settings]
+		assert.Nil(t, c.Scan(src))
+		assert.Equal(t, JSONColumn[settings]{V: settings{"dark", 12}, Valid: true}, c)
+
+		assert.Nil(t, c.Scan(`{"theme": "light"}`))
+		assert.Equal(t, JSONColumn[settings]{V: settings{Theme: "light"}, Valid: true}, c)
+
+		assert.Nil(t, c.Scan(nil))
+		assert.Equal(t, JSONColumn[settings]{}, c)
+	})
+
+	t.Run("Scan Errors", func(t *testing.T) {
+		var c JSONColumn[settings]
+		assert.EqualError(t, c.Scan(12), "cannot scan column of type int into JSONColumn")
+		assert.NotNil(t, c.Scan([]byte(`{"theme": `)))
+		assert.False(t, c.Valid)
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := JSONColumn[settings]{V: settings{"dark", 12}, Valid: true}.Value()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(`{"theme":"dark","size":12}`), v)
+
+		// Strings are written as AppendMarshal writes them, without escaping HTML.
+		v, err = JSONColumn[settings]{V: settings{"<dark>", 12}, Valid: true}.Value()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(`{"theme":"<dark>","size":12}`), v)
+
+		v, err = JSONColumn[settings]{}.Value()
+		assert.Nil(t, err)
+		assert.Nil(t, v)
+	})
+}
+
+func TestJSONReaderScan(t *testing.T) {
+	t.Run("Scan", func(t *testing.T) {
+		src := []byte(`{"color": "red", "sizes": [1, 2]}`)
+
+		var jr JSONReader
+		assert.Nil(t, jr.Scan(src))
+		src[2] = 'x'
+
+		assert.Equal(t, "red", jr.GetString("color"))
+		assert.Equal(t, []int{1, 2}, jr.GetIntSlice("sizes"))
+
+		v, err := jr.Value()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(`{"color": "red", "sizes": [1, 2]}`), v)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		jr, err := NewJSONReader([]byte(`{"a": "x\"y\u00e9", "b": "", "c": "hello"}`))
+		assert.Nil(t, err)
+
+		for key, expected := range map[string]string{"a": `x"yé`, "b": "", "c": "hello"} {
+			v, err := jr.Get(key).Value()
+			assert.Nil(t, err)
+
+			var scanned JSONReader
+			assert.Nil(t, scanned.Scan(v), key)
+			assert.Equal(t, expected, scanned.ToString(), key)
+			assert.Equal(t, expected, scanned.GetString(""), key)
+		}
+
+		root, err := NewJSONReader([]byte(`"hello"`))
+		assert.Nil(t, err)
+		v, err := root.Value()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(`"hello"`), v)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		jr, err := NewJSONReader([]byte(`{"a": 1}`))
+		assert.Nil(t, err)
+
+		assert.Nil(t, jr.Scan(nil))
+		assert.True(t, jr.Empty)
+		assert.Equal(t, 0, jr.GetInt("a"))
+
+		v, err := jr.Value()
+		assert.Nil(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("Settings", func(t *testing.T) {
+		jr := JSONReader{StrictStandards: true}
+		WithMaxTokenSize(4)(&jr)
+
+		assert.Nil(t, jr.Scan(`{"a": "1"}`))
+		assert.True(t, jr.StrictStandards)
+		assert.Equal(t, 0, jr.GetInt("a"))
+		assert.NotNil(t, jr.Err())
+
+		assert.Equal(t, &LimitError{Limit: "token size", Max: 4, Offset: 6}, jr.Scan(`{"a": "12345"}`))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		var jr JSONReader
+		assert.EqualError(t, jr.Scan(1.5), "cannot scan column of type float64 into JSONReader")
+	})
+}
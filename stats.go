@@ -0,0 +1,100 @@
+package gojson
+
+// Stats profiles a document, for capacity planning and for finding where a payload's size comes from.
+// Sizes are of the encoded JSON, so strings are measured with their escape sequences. Duplicate keys
+// are counted once, as the JSONReader functions only see the last of them.
+type Stats struct {
+	// Nodes counts the values of each JSON type (JSONObject, JSONString, ...), including the root.
+	Nodes map[string]int
+
+	// MaxDepth is the deepest nesting of objects and arrays. A scalar document has a depth of 0, and
+	// {"a": [1]} has a depth of 2.
+	MaxDepth int
+
+	// LargestArray is the length of the longest array.
+	LargestArray int
+
+	// StringBytes is the total length of every string value, and KeyBytes of every object key, without
+	// their quotes.
+	StringBytes int
+	KeyBytes    int
+
+	// Sizes holds the encoded size of each top-level member of an object, or element of an array.
+	Sizes map[string]int
+}
+
+// Stats returns the Stats of the reader's document. An Empty reader gives zero Stats.
+//
+// Example, finding the largest top-level keys:
+//
+//	stats := reader.Stats()
+//	for key, size := range stats.Sizes {
+//		if size > 64<<10 {
+//			log.Printf("%s is %d bytes", key, size)
+//		}
+//	}
+func (jr *JSONReader) Stats() Stats {
+	s := Stats{Nodes: make(map[string]int), Sizes: make(map[string]int)}
+	if jr.Empty {
+		return s
+	}
+
+	if jr.Type != JSONObject && jr.Type != JSONArray {
+		// The scalar is held as the only child of the root, with its quotes removed.
+		s.add(jr.parsed["0"], 0)
+		return s
+	}
+
+	root, _ := jr.child("")
+	s.add(root, 0)
+
+	for k, c := range root.children {
+		s.Sizes[k] = encodedSize(c)
+	}
+
+	return s
+}
+
+// add counts p, found at the given depth, and its descendants.
+func (s *Stats) add(p parsed, depth int) {
+	s.Nodes[p.dtype]++
+
+	switch p.dtype {
+	case JSONString:
+		s.StringBytes += encodedSize(p) - 2
+		return
+	case JSONObject:
+		for k := range p.children {
+			s.KeyBytes += len(k)
+		}
+	case JSONArray:
+		if len(p.children) > s.LargestArray {
+			s.LargestArray = len(p.children)
+		}
+	default:
+		return
+	}
+
+	depth++
+	if depth > s.MaxDepth {
+		s.MaxDepth = depth
+	}
+
+	for _, c := range p.children {
+		s.add(c, depth)
+	}
+}
+
+// encodedSize returns the length of the JSON encoding of p, as rawBytes would return it.
+func encodedSize(p parsed) int {
+	b := trim(p.bytes)
+	if p.dtype != JSONString {
+		return len(b)
+	}
+
+	if len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
+		return len(b)
+	}
+
+	return len(b) + 2
+}
@@ -0,0 +1,61 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	r, err := NewJSONReader([]byte(`{"id": 17, "name": "a\"b", "tags": ["x", "yz", null], "owner": {"ok": true, "scores": [1.5, [2]]}, "empty": {}}`))
+	assert.Nil(t, err)
+
+	assert.Equal(t, Stats{
+		Nodes:        map[string]int{JSONObject: 3, JSONArray: 3, JSONString: 3, JSONInt: 2, JSONFloat: 1, JSONBool: 1, JSONNull: 1},
+		MaxDepth:     4,
+		LargestArray: 3,
+		StringBytes:  7,
+		KeyBytes:     28,
+		Sizes:        map[string]int{"id": 2, "name": 6, "tags": 17, "owner": 34, "empty": 2},
+	}, r.Stats())
+
+	t.Run("Array", func(t *testing.T) {
+		r, _ := NewJSONReader([]byte(` [ "abc", {"a": 1} ] `))
+		s := r.Stats()
+		assert.Equal(t, map[string]int{"0": 5, "1": 8}, s.Sizes)
+		assert.Equal(t, 2, s.MaxDepth)
+		assert.Equal(t, 2, s.LargestArray)
+	})
+
+	t.Run("Scalar", func(t *testing.T) {
+		r, _ := NewJSONReader([]byte(`"abc"`))
+		assert.Equal(t, Stats{Nodes: map[string]int{JSONString: 1}, StringBytes: 3, Sizes: map[string]int{}}, r.Stats())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		r, _ := NewJSONReader(nil)
+		assert.Equal(t, Stats{Nodes: map[string]int{}, Sizes: map[string]int{}}, r.Stats())
+	})
+
+	t.Run("Large", func(t *testing.T) {
+		r, _ := NewJSONReader([]byte(largeJSONTestBlob))
+		s := r.Stats()
+
+		total := 0
+		for _, n := range s.Nodes {
+			total += n
+		}
+		assert.Equal(t, total, countNodes(r))
+		assert.Equal(t, len(r.Keys), len(s.Sizes))
+	})
+}
+
+// countNodes counts the nodes of r with Walk.
+func countNodes(r *JSONReader) int {
+	n := 0
+	r.Walk(func(string, *JSONReader) (bool, error) {
+		n++
+		return true, nil
+	})
+	return n
+}
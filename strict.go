@@ -0,0 +1,116 @@
+package gojson
+
+import "sync"
+
+// With StrictStandards set, the JSONReader accessors apply the same type association as UnmarshalStrict:
+// a string is only read from a JSON string, an int from a JSON int, a float from a JSON float, and a
+// bool from a JSON bool. Slices are only read from arrays, and maps from objects, while null reads as
+// an empty result. Rather than panicking, or casting the value anyway, the accessors return the zero
+// value and record a ConversionError, which is reported by Err.
+
+// Err returns the ConversionErrors recorded by the accessors of a reader with StrictStandards set, or nil
+// if every value read so far had the expected type. Numbers rejected under RejectLossyNumbers, and
+// strings rejected under RejectInvalidUTF8, are recorded in the same way. Readers returned by Get and
+// GetCollection share the errors of the reader they came from, with key paths given from its root.
+// Values are recorded safely by every goroutine reading the readers, and Err may be called from any
+// of them.
+//
+// Example:
+//
+//	jr.StrictStandards = true
+//	port := jr.GetInt("server.port")
+//	hosts := jr.GetStringSlice("server.hosts")
+//	if err := jr.Err(); err != nil {
+//		return err
+//	}
+func (jr *JSONReader) Err() error {
+	if jr.errs == nil {
+		return nil
+	}
+
+	jr.errs.lock.Lock()
+	defer jr.errs.lock.Unlock()
+
+	if len(jr.errs.list) == 0 {
+		return nil
+	}
+
+	return append(ConversionErrors(nil), jr.errs.list...)
+}
+
+// conversionLog holds the values rejected under StrictStandards, RejectLossyNumbers or RejectInvalidUTF8.
+// It is shared by a reader and the readers returned by its Get and GetCollection, which may be used
+// from several goroutines at once.
+type conversionLog struct {
+	lock sync.Mutex
+	list ConversionErrors
+}
+
+// add records the error e.
+func (l *conversionLog) add(e *ConversionError) {
+	l.lock.Lock()
+	l.list = append(l.list, e)
+	l.lock.Unlock()
+}
+
+// strictValue reports whether a value of type t may be read as the Go type target, which under
+// StrictStandards is only the case for values of type want. A rejected value is recorded, with its key
+// path given as its parent and key, so that the path is only built when it is needed.
+func (jr *JSONReader) strictValue(parent, key string, b []byte, t, want, target string) bool {
+	if !jr.StrictStandards || t == want {
+		return true
+	}
+
+	// Get returns an Empty reader for a key which doesn't exist, which has nothing to convert.
+	if jr.Empty {
+		return false
+	}
+
+	jr.reject(joinPath(parent, key), b, t, target)
+	return false
+}
+
+// strictContainer reports whether a value of type t may be read as the slice or map type target, which
+// under StrictStandards is only the case for values of type want. Null is not read, but isn't an error.
+func (jr *JSONReader) strictContainer(key string, b []byte, t, want, target string) bool {
+	if !jr.StrictStandards || t == want {
+		return true
+	}
+
+	if t != JSONNull && !jr.Empty {
+		jr.reject(key, b, t, target)
+	}
+
+	return false
+}
+
+// reject records a value which could not be read under StrictStandards, RejectLossyNumbers or
+// RejectInvalidUTF8.
+func (jr *JSONReader) reject(key string, b []byte, t, target string) {
+	if jr.errs == nil {
+		jr.errs = new(conversionLog)
+	}
+
+	if key == "" {
+		key = jr.path
+	} else {
+		key = joinPath(jr.path, key)
+	}
+
+	jr.errs.add(&ConversionError{Key: key, Type: t, Target: target, Value: string(truncate(b, 50))})
+}
+
+// inherit configures r, returned by Get or GetCollection for the value at key, to convert values as jr
+// does, and to share its document and errors.
+func (jr *JSONReader) inherit(r *JSONReader, key string) {
+	r.StrictStandards, r.NumberConversion, r.InvalidUTF8, r.zeroCopyStrings = jr.StrictStandards, jr.NumberConversion, jr.InvalidUTF8, jr.zeroCopyStrings
+	r.doc, r.rawStrings = jr.doc, jr.rawStrings
+	if !jr.StrictStandards && jr.NumberConversion != RejectLossyNumbers && jr.InvalidUTF8 != RejectInvalidUTF8 {
+		return
+	}
+
+	if jr.errs == nil {
+		jr.errs = new(conversionLog)
+	}
+	r.errs, r.path = jr.errs, joinPath(jr.path, key)
+}
@@ -0,0 +1,111 @@
+package gojson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictReader(t *testing.T) {
+	data := []byte(`{
+		"name": "gojson",
+		"port": "8080",
+		"ratio": 1,
+		"enabled": true,
+		"ints": [1, "2", 3.5],
+		"object": {"a": 1, "b": 2},
+		"nothing": null,
+		"items": [{"id": 1}, {"id": "2"}]
+	}`)
+
+	newReader := func() *JSONReader {
+		jr, err := NewJSONReader(data)
+		assert.Nil(t, err)
+		jr.StrictStandards = true
+		return jr
+	}
+
+	t.Run("Matching Types", func(t *testing.T) {
+		jr := newReader()
+		assert.Equal(t, "gojson", jr.GetString("name"))
+		assert.Equal(t, true, jr.GetBool("enabled"))
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, jr.Get("object").ToMapStringInt())
+		assert.Equal(t, 1, jr.GetInt("items.0.id"))
+		assert.Nil(t, jr.GetIntSlice("nothing"))
+		assert.Nil(t, jr.GetIntSlice("missing"))
+		assert.Equal(t, 0, jr.GetInt("missing"))
+		assert.Nil(t, jr.Err())
+	})
+
+	t.Run("Scalars", func(t *testing.T) {
+		jr := newReader()
+		assert.Equal(t, 0, jr.GetInt("port"))
+		assert.Equal(t, 0.0, jr.GetFloat("ratio"))
+		assert.Equal(t, "", jr.GetString("enabled"))
+		assert.Equal(t, false, jr.GetBool("name"))
+		assert.Equal(t, "", jr.GetString("nothing"))
+
+		var errs ConversionErrors
+		assert.True(t, errors.As(jr.Err(), &errs))
+		assert.Equal(t, ConversionErrors{
+			{Key: "port", Type: JSONString, Target: "int", Value: "8080"},
+			{Key: "ratio", Type: JSONInt, Target: "float64", Value: "1"},
+			{Key: "enabled", Type: JSONBool, Target: "string", Value: "true"},
+			{Key: "name", Type: JSONString, Target: "bool", Value: "gojson"},
+			{Key: "nothing", Type: JSONNull, Target: "string", Value: "null"},
+		}, errs)
+	})
+
+	t.Run("Elements", func(t *testing.T) {
+		jr := newReader()
+		assert.Equal(t, []int{1, 0, 0}, jr.GetIntSlice("ints"))
+		assert.EqualError(t, jr.Err(), "key 'ints.1' with string value '2' can not be converted to int; key 'ints.2' with float value '3.5' can not be converted to int")
+	})
+
+	t.Run("Coercions", func(t *testing.T) {
+		jr := newReader()
+		assert.Nil(t, jr.GetIntSlice("object"))
+		assert.Nil(t, jr.GetStringSlice("name"))
+		assert.Nil(t, jr.GetInterfaceSlice("object"))
+		assert.Nil(t, jr.GetMapStringInterface("ints"))
+		assert.Nil(t, jr.GetByteSlices("object"))
+		assert.Nil(t, jr.GetCollection("object"))
+		assert.Equal(t, map[string]string{}, jr.Get("ints").ToMapStringString())
+
+		var errs ConversionErrors
+		assert.True(t, errors.As(jr.Err(), &errs))
+		assert.Len(t, errs, 7)
+		assert.Equal(t, &ConversionError{Key: "object", Type: JSONObject, Target: "[]int", Value: `{"a": 1, "b": 2}`}, errs[0])
+		assert.Equal(t, &ConversionError{Key: "ints", Type: JSONArray, Target: "map[string]string", Value: `[1, "2", 3.5]`}, errs[6])
+	})
+
+	t.Run("Nested Readers", func(t *testing.T) {
+		jr := newReader()
+		for _, item := range jr.GetCollection("items") {
+			item.GetInt("id")
+		}
+		jr.Get("object").GetString("a")
+
+		assert.EqualError(t, jr.Err(), "key 'items.1.id' with string value '2' can not be converted to int; key 'object.a' with int value '1' can not be converted to string")
+		assert.Equal(t, jr.Err(), jr.Get("object").Err())
+	})
+
+	t.Run("Nested Maps", func(t *testing.T) {
+		jr := newReader()
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, jr.GetMapStringInt("object"))
+		assert.Equal(t, map[string]string{"a": "", "b": ""}, jr.GetMapStringString("object"))
+		assert.Equal(t, map[string]bool{}, jr.GetMapStringBool("ints"))
+
+		assert.EqualError(t, jr.Err(), "key 'object.a' with int value '1' can not be converted to string; key 'object.b' with int value '2' can not be converted to string; key 'ints' with array value '[1, \"2\", 3.5]' can not be converted to map[string]bool")
+	})
+
+	t.Run("Not Strict", func(t *testing.T) {
+		jr, err := NewJSONReader(data)
+		assert.Nil(t, err)
+		assert.Equal(t, 8080, jr.GetInt("port"))
+		assert.Equal(t, []int{1, 2, 3}, jr.GetIntSlice("ints"))
+		assert.Equal(t, []int{1, 2}, jr.GetIntSlice("object"))
+		assert.Nil(t, jr.Err())
+	})
+}
@@ -1,6 +1,7 @@
 package gojson
 
 import (
+	"encoding/json"
 	"reflect"
 	"strings"
 	"sync"
@@ -23,6 +24,15 @@ type StructKey struct {
 
 	// Path maintains the path we need to traverse through struct keys to resolve embeded keys.
 	Path []int
+
+	// Validations holds the constraints declared on the field's tag, evaluated after the field is decoded.
+	Validations []Validation
+
+	// Quoted is true if the field carries the ",string" tag option, meaning its value is encoded inside a JSON string.
+	Quoted bool
+
+	// opts holds the remaining tag options, which are passed along while decoding the field.
+	opts tagOptions
 }
 
 // StructDescriptor holds parsed metadata about a given struct.
@@ -33,6 +43,41 @@ type StructDescriptor struct {
 
 	// KeyMap links alternate names for a field onto a "primary" field.
 	KeyMap map[string]string
+
+	// FoldedKeys maps the lowercase form of each key onto its entry in Keys, for case-insensitive matching.
+	FoldedKeys map[string]string
+
+	// NormalizedKeys maps the registered key normalizer's form of each key onto its entry in Keys.
+	NormalizedKeys map[string]string
+
+	// DefaultKeys holds the primary names of the fields with a default tag option.
+	DefaultKeys []string
+
+	// Fields holds the primary names of the fields in declaration order, with embedded structs
+	// expanded in place, for decoding tuples.
+	Fields []string
+
+	// Remain is the map field with the inline (or remain) tag option, which collects the members
+	// not claimed by any other field, or nil if there is none.
+	Remain *StructKey
+}
+
+// Lookup resolves a JSON key to its entry in Keys. An exact match is preferred, followed by
+// a match through the registered key normalizer (see RegisterKeyNormalizer), and finally a
+// case-insensitive match, mirroring encoding/json.
+func (d *StructDescriptor) Lookup(key string) (string, bool) {
+	if _, ok := d.Keys[key]; ok {
+		return key, true
+	}
+
+	if d.NormalizedKeys != nil {
+		if k, ok := d.NormalizedKeys[sdc.Normalize(key)]; ok {
+			return k, true
+		}
+	}
+
+	k, ok := d.FoldedKeys[strings.ToLower(key)]
+	return k, ok
 }
 
 // NonEmpty returns true if a key is required to be NonEmpty
@@ -59,128 +104,236 @@ func (d *StructDescriptor) Required(key string) bool {
 
 // Struct Descriptor Cache
 // We store the already-processed structs to keep from having to re-process them if
-// they come through more than once.
+// they come through more than once. Repeated decodes of a struct type therefore skip
+// reflecting over its fields and parsing its tags, as with encoding/json's field cache.
 var sdc structDescriptorCache
 
 type structDescriptorCache struct {
-	store  map[reflect.Type]*StructDescriptor
-	lock   sync.Mutex
-	usable bool
-}
+	// store maps a descriptorKey onto its *StructDescriptor. Reads do not lock.
+	store sync.Map
 
-func (c *structDescriptorCache) Init() {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-
-	if c.usable {
-		return
-	}
+	lock       sync.RWMutex
+	normalizer func(string) string
+}
 
-	c.store = make(map[reflect.Type]*StructDescriptor)
-	c.usable = true
+// Struct keys differ by key convention, so descriptors are cached per type and convention.
+type descriptorKey struct {
+	t reflect.Type
+	c KeyConvention
 }
 
-func (c *structDescriptorCache) Get(t reflect.Type) *StructDescriptor {
-	if !c.usable {
-		c.Init()
+func (c *structDescriptorCache) Get(t reflect.Type, kc KeyConvention) *StructDescriptor {
+	if sd, ok := c.store.Load(descriptorKey{t, kc}); ok {
+		return sd.(*StructDescriptor)
 	}
 
-	c.lock.Lock()
-	defer c.lock.Unlock()
+	return nil
+}
 
-	if sd, ok := c.store[t]; ok {
-		return sd
-	}
+// Set caches the descriptor for a type, returning the cached descriptor. If another goroutine
+// cached a descriptor for the type first, that descriptor is kept and returned.
+func (c *structDescriptorCache) Set(t reflect.Type, kc KeyConvention, sd *StructDescriptor) *StructDescriptor {
+	actual, _ := c.store.LoadOrStore(descriptorKey{t, kc}, sd)
+	return actual.(*StructDescriptor)
+}
 
-	return nil
+// Reset removes all cached descriptors.
+func (c *structDescriptorCache) Reset() {
+	c.store.Range(func(k, _ interface{}) bool {
+		c.store.Delete(k)
+		return true
+	})
 }
 
-func (c *structDescriptorCache) Set(t reflect.Type, sd *StructDescriptor) {
-	if !c.usable {
-		c.Init()
+// Normalize applies the registered key normalizer to the given key.
+func (c *structDescriptorCache) Normalize(key string) string {
+	c.lock.RLock()
+	fn := c.normalizer
+	c.lock.RUnlock()
+
+	if fn == nil {
+		return key
 	}
 
-	c.lock.Lock()
-	defer c.lock.Unlock()
+	return fn(key)
+}
 
-	c.store[t] = sd
+// RegisterKeyNormalizer sets a function used to match JSON keys to struct fields when no
+// exact match exists. Both the JSON key and each struct key are passed through fn, and
+// the keys match if the results are equal. This allows, for example, "user_id", "userId"
+// and "UserID" to all populate the same field without tagging each one.
+//
+// Passing nil removes the normalizer. Since struct metadata is cached, the normalizer should
+// be registered once during program initialization.
+func RegisterKeyNormalizer(fn func(key string) string) {
+	sdc.lock.Lock()
+	sdc.normalizer = fn
+	sdc.lock.Unlock()
+
+	sdc.Reset()
 }
 
-func getStructInfo(t reflect.Type) *StructDescriptor {
-	if c := sdc.Get(t); c != nil {
+func getStructInfo(t reflect.Type, kc KeyConvention) *StructDescriptor {
+	if c := sdc.Get(t, kc); c != nil {
 		return c
 	}
 
+	return sdc.Set(t, kc, newStructInfo(t, kc, map[reflect.Type]bool{t: true}))
+}
+
+// newStructInfo builds the descriptor for a struct type. visiting holds the types of the structs
+// enclosing t, so that a struct embedding a pointer to itself is not expanded endlessly. Since the
+// descriptor of an embedded struct depends on the structs enclosing it, only the outermost
+// descriptor is cached.
+func newStructInfo(t reflect.Type, kc KeyConvention, visiting map[reflect.Type]bool) *StructDescriptor {
 	d := &StructDescriptor{}
 	d.Keys = make(map[string]StructKey, t.NumField())
 	d.KeyMap = make(map[string]string)
-	d.RequiredKeys = make([]string, t.NumField())
-	d.NonEmptyKeys = make([]string, t.NumField())
-
-	rc := 0
-	nc := 0
+	d.FoldedKeys = make(map[string]string)
+	d.RequiredKeys = make([]string, 0, t.NumField())
+	d.NonEmptyKeys = make([]string, 0, t.NumField())
 
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
 
-		// Skip non-exported fields.
-		if f.PkgPath != "" {
-			continue
-		}
+		// Expand embeded (anonymous) structs, and pointers to them. As with encoding/json, the fields
+		// of an embedded struct are promoted unless it is given a name by its tag, and a nil pointer
+		// is only allocated once one of its fields is decoded.
+		if et := embeddedStruct(&f); et != nil {
+			if visiting[et] {
+				continue
+			}
 
-		// Expand embeded (anonymous) structs.
-		if f.Anonymous && f.Type.Kind() == reflect.Struct {
-			expanded := getStructInfo(f.Type)
+			visiting[et] = true
+			expanded := newStructInfo(et, kc, visiting)
+			delete(visiting, et)
 
-			if len(expanded.RequiredKeys) > 0 {
-				d.RequiredKeys = append(d.RequiredKeys, expanded.RequiredKeys...)
-				rc += len(expanded.RequiredKeys)
-			}
-			if len(expanded.NonEmptyKeys) > 0 {
-				d.NonEmptyKeys = append(d.NonEmptyKeys, expanded.NonEmptyKeys...)
-				nc += len(expanded.NonEmptyKeys)
+			d.RequiredKeys = append(d.RequiredKeys, expanded.RequiredKeys...)
+			d.NonEmptyKeys = append(d.NonEmptyKeys, expanded.NonEmptyKeys...)
+			d.DefaultKeys = append(d.DefaultKeys, expanded.DefaultKeys...)
+			d.Fields = append(d.Fields, expanded.Fields...)
+
+			if expanded.Remain != nil && d.Remain == nil {
+				r := *expanded.Remain
+				r.Path = append([]int{i}, r.Path...)
+				d.Remain = &r
 			}
 
+			// Promoted fields never replace the struct's own fields, or those promoted from a
+			// shallower depth.
 			for n, k := range expanded.Keys {
 				k.Path = append([]int{i}, k.Path...)
+				if c, ok := d.Keys[n]; ok && len(c.Path) < len(k.Path) {
+					continue
+				}
 				d.Keys[n] = k
 			}
 
+			for f, n := range expanded.FoldedKeys {
+				if _, ok := d.FoldedKeys[f]; !ok {
+					d.FoldedKeys[f] = n
+				}
+			}
+
 			continue
 		}
 
-		names, required, nonempty := getTags(&f, "json")
+		// Skip non-exported fields. The exported fields of an unexported embedded struct are still
+		// promoted above.
+		if f.PkgPath != "" {
+			continue
+		}
+
+		names, opts := getTags(&f, "json", kc)
 		if len(names) == 0 {
 			continue
 		}
 
-		if required || nonempty {
-			d.RequiredKeys[rc] = names[0]
-			rc++
+		// The first map field with the inline option collects the unclaimed members, and has no key
+		// of its own.
+		if opts.Inline && f.Type.Kind() == reflect.Map && f.Type.Key().Kind() == reflect.String {
+			if d.Remain == nil {
+				d.Remain = &StructKey{Type: f.Type, Kind: f.Type.Kind(), Name: names[0], Index: i, opts: opts}
+			}
+			continue
+		}
+
+		if opts.Required || opts.NonEmpty {
+			d.RequiredKeys = append(d.RequiredKeys, names[0])
 		}
 
-		if nonempty {
-			d.NonEmptyKeys[nc] = names[0]
-			nc++
+		if opts.NonEmpty {
+			d.NonEmptyKeys = append(d.NonEmptyKeys, names[0])
 		}
 
+		if opts.Default != nil {
+			d.DefaultKeys = append(d.DefaultKeys, names[0])
+		}
+
+		d.Fields = append(d.Fields, names[0])
+
 		for _, n := range names {
 			d.Keys[n] = StructKey{
-				Type:  f.Type,
-				Kind:  f.Type.Kind(),
-				Name:  names[0],
-				Index: i,
+				Type:        f.Type,
+				Kind:        f.Type.Kind(),
+				Name:        names[0],
+				Index:       i,
+				Validations: opts.Validations,
+				Quoted:      opts.Quoted,
+				opts:        opts,
+			}
+
+			// The first field claiming a folded key wins, as with encoding/json.
+			if _, ok := d.FoldedKeys[strings.ToLower(n)]; !ok {
+				d.FoldedKeys[strings.ToLower(n)] = n
 			}
 		}
 	}
 
-	d.RequiredKeys = d.RequiredKeys[:rc]
-	d.NonEmptyKeys = d.NonEmptyKeys[:nc]
+	sdc.lock.RLock()
+	normalizer := sdc.normalizer
+	sdc.lock.RUnlock()
+
+	if normalizer != nil {
+		d.NormalizedKeys = make(map[string]string, len(d.Keys))
+		for n := range d.Keys {
+			if _, ok := d.NormalizedKeys[normalizer(n)]; !ok || n == d.Keys[n].Name {
+				d.NormalizedKeys[normalizer(n)] = n
+			}
+		}
+	}
 
-	sdc.Set(t, d)
 	return d
 }
 
+// embeddedStruct returns the type of the struct whose fields are promoted by f: that of an embedded
+// struct, or of an embedded pointer to an exported struct, which is not named by its tag. It returns
+// nil for any other field.
+func embeddedStruct(f *reflect.StructField) reflect.Type {
+	if !f.Anonymous {
+		return nil
+	}
+
+	if name := strings.Split(fieldTag(f), ",")[0]; name != "" {
+		return nil
+	}
+
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		// A nil pointer to an unexported struct type cannot be allocated.
+		if f.PkgPath != "" {
+			return nil
+		}
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return t
+}
+
 func firstCharLower(s string) string {
 	if len(s) == 0 {
 		return s
@@ -191,11 +344,74 @@ func firstCharLower(s string) string {
 	return string(unicode.ToLower(rune(s[0]))) + string(s[1:])
 }
 
+// tagOptions holds the non-name options found in a json or gojson struct tag.
+type tagOptions struct {
+	Required    bool
+	NonEmpty    bool
+	Quoted      bool
+	Validations []Validation
+
+	// Tuple is true if a JSON array is decoded into the fields of a struct by position.
+	Tuple bool
+
+	// Base64 is true if a []byte field holds the base64 decoding of a string, as with encoding/json.
+	Base64 bool
+
+	// Inline is true if a map field collects the members not claimed by the other fields.
+	Inline bool
+
+	// Converter names the conversion returned by fieldConverter which decodes the field, such as
+	// unixsec.
+	Converter string
+
+	// Discriminator is the key consulted to choose a registered concrete type for an interface field.
+	Discriminator string
+
+	// Default is the JSON value decoded into the field when its key is missing or null, and
+	// DefaultType is its JSON type.
+	Default     []byte
+	DefaultType string
+}
+
+// defaultValue converts the value of a default tag option to JSON. A value which is not valid JSON,
+// such as default=none, is treated as a string.
+func defaultValue(s string) ([]byte, string) {
+	b := []byte(s)
+	if t := GetJSONTypeStrict(b, 0); t != JSONInvalid {
+		return b, t
+	}
+
+	b, _ = json.Marshal(s)
+	return b, JSONString
+}
+
+// fieldTag returns the tag gojson reads for the StructField: the gojson tag if there is one, and
+// the json tag otherwise. A gojson tag without a name takes its name from the json tag, so that
+// options which only matter to Unmarshal can be kept out of the json tag:
+//
+//	UserID int `json:"user_id,omitempty" gojson:",required"`
+func fieldTag(f *reflect.StructField) string {
+	tag, ok := f.Tag.Lookup("gojson")
+	if !ok || tag == "" {
+		return f.Tag.Get("json")
+	}
+
+	if strings.HasPrefix(tag, ",") {
+		if name := strings.Split(f.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			return name + tag
+		}
+	}
+
+	return tag
+}
+
 // Parse the StructField looking for json tags. If there are no tags, fall back to
-// the lowercase of the field name.
-func getTags(f *reflect.StructField, key string) ([]string, bool, bool) {
+// the keys given by the key convention.
+func getTags(f *reflect.StructField, key string, kc KeyConvention) ([]string, tagOptions) {
+	var opts tagOptions
+
 	if len(f.Tag.Get(`json`)) == 0 && len(f.Tag.Get(`gojson`)) == 0 {
-		return []string{f.Name, strings.ToLower(f.Name), firstCharLower(f.Name)}, false, false
+		return kc.Keys(f.Name), opts
 	}
 
 	// We allow gojson tags to be used to separate behavior from encoding/json.
@@ -208,34 +424,70 @@ func getTags(f *reflect.StructField, key string) ([]string, bool, bool) {
 	//  }
 	//
 	//  Product would be unmarshalled into the struct as `product`, but json.Marshal would omit it.
-	tagSource := "json"
-	if f.Tag.Get("gojson") != "" {
-		tagSource = "gojson"
-	}
+	tag := fieldTag(f)
 
 	// If the tag consists of ONLY a dash, ignore it.
-	if f.Tag.Get(tagSource) == `-` {
-		return []string(nil), false, false
+	if tag == `-` {
+		return []string(nil), opts
 	}
 
-	keys := strings.Split(f.Tag.Get(tagSource), `,`)
+	keys := strings.Split(tag, `,`)
 	final := make([]string, len(keys))
 
 	count := 0
-	required := false
-	nonempty := false
-	for _, k := range keys {
+	for i, k := range keys {
 		if strings.ToLower(k) == `omitempty` || k == `` {
 			continue
 		}
 
+		// Converters are only recognized as options, so that keys such as bytesize still work.
+		if fieldConverter(strings.ToLower(k)) != nil && i > 0 {
+			opts.Converter = strings.ToLower(k)
+			continue
+		}
+
 		if strings.ToLower(k) == `required` {
-			required = true
+			opts.Required = true
 			continue
 		}
 
 		if strings.ToLower(k) == `nonempty` {
-			nonempty = true
+			opts.NonEmpty = true
+			continue
+		}
+
+		if k == `string` {
+			opts.Quoted = true
+			continue
+		}
+
+		if strings.ToLower(k) == `tuple` {
+			opts.Tuple = true
+			continue
+		}
+
+		if strings.ToLower(k) == `base64` {
+			opts.Base64 = true
+			continue
+		}
+
+		if strings.ToLower(k) == `inline` || strings.ToLower(k) == `remain` {
+			opts.Inline = true
+			continue
+		}
+
+		if strings.HasPrefix(k, `default=`) {
+			opts.Default, opts.DefaultType = defaultValue(strings.TrimPrefix(k, `default=`))
+			continue
+		}
+
+		if strings.HasPrefix(k, `discriminator=`) {
+			opts.Discriminator = strings.TrimPrefix(k, `discriminator=`)
+			continue
+		}
+
+		if v, ok := parseValidation(k); ok {
+			opts.Validations = append(opts.Validations, v)
 			continue
 		}
 
@@ -245,12 +497,16 @@ func getTags(f *reflect.StructField, key string) ([]string, bool, bool) {
 
 	final = final[:count]
 	if len(final) == 0 {
-		return []string{strings.ToLower(f.Name)}, false, false
+		name := strings.ToLower(f.Name)
+		if kc != DefaultKeys {
+			name = kc.Keys(f.Name)[0]
+		}
+		return []string{name}, opts
 	}
 
 	if len(final) == 1 && final[0] == "-" {
-		return []string{}, false, false
+		return []string{}, tagOptions{}
 	}
 
-	return final, required, nonempty
+	return final, opts
 }
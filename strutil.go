@@ -0,0 +1,115 @@
+package gojson
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// The string helpers below are those used internally by gojson, exported for writing custom
+// UnmarshalJSON and UnmarshalGoJSON methods, which receive the raw bytes of their value.
+
+// Unquote returns the contents of the JSON string b, with its escape sequences decoded. Surrounding
+// whitespace is ignored. Invalid UTF-8, and unpaired surrogates, are replaced with U+FFFD.
+//
+// Example:
+//
+//	func (t *Title) UnmarshalJSON(b []byte) error {
+//		s, err := gojson.Unquote(b)
+//		if err != nil {
+//			return err
+//		}
+//		*t = Title(strings.TrimSpace(s))
+//		return nil
+//	}
+func Unquote(b []byte) (string, error) {
+	b = trim(b)
+	if t := GetJSONType(b, 0); t != JSONString {
+		return "", fmt.Errorf("expected a JSON string, found JSON type '%s'", t)
+	}
+
+	if !IsJSONString(b) {
+		return "", fmt.Errorf("invalid JSON string in segment '%s'", Truncate(b, 50))
+	}
+
+	return manualUnescapeString(b), nil
+}
+
+// EscapeString returns s escaped for use inside a JSON string, without surrounding quotes, as
+// AppendString writes it.
+func EscapeString(s string) []byte {
+	b := AppendString(make([]byte, 0, len(s)+2), s)
+	return b[1 : len(b)-1]
+}
+
+// DecodeUnicodeEscapes returns a copy of b, the contents of a JSON string, with each \uXXXX escape
+// sequence replaced by the UTF-8 encoding of its character. Surrogate pairs are combined, and unpaired
+// surrogates become U+FFFD. Other escape sequences, including \\, are left as they are. An error is
+// returned for a \u which isn't followed by four hexadecimal digits.
+func DecodeUnicodeEscapes(b []byte) ([]byte, error) {
+	out := make([]byte, 0, len(b))
+
+	for i := 0; i < len(b); i++ {
+		if b[i] != '\\' || i == len(b)-1 {
+			out = append(out, b[i])
+			continue
+		}
+
+		if b[i+1] != 'u' {
+			out = append(out, b[i], b[i+1])
+			i++
+			continue
+		}
+
+		r, ok := hexRune(b, i)
+		if !ok {
+			return nil, fmt.Errorf("invalid unicode escape at position '%d' in segment '%s'", i, Truncate(b, 50))
+		}
+		i += 5
+
+		if utf16.IsSurrogate(r) {
+			if r2, ok := hexRune(b, i+1); ok && r < 0xDC00 {
+				if c := utf16.DecodeRune(r, r2); c != utf8.RuneError {
+					r = c
+					i += 6
+				}
+			}
+		}
+
+		out = utf8.AppendRune(out, r)
+	}
+
+	return out, nil
+}
+
+// hexRune parses the \uXXXX escape sequence at position i of b.
+func hexRune(b []byte, i int) (rune, bool) {
+	if i+6 > len(b) || b[i] != '\\' || b[i+1] != 'u' {
+		return 0, false
+	}
+
+	for _, c := range b[i+2 : i+6] {
+		if !isHexDigit(c) {
+			return 0, false
+		}
+	}
+
+	r, err := strconv.ParseUint(string(b[i+2:i+6]), 16, 32)
+	return rune(r), err == nil
+}
+
+// Truncate returns the first max bytes of b, for quoting a value in an error message, shortened
+// further if needed so as not to split a UTF-8 encoded character.
+func Truncate(b []byte, max int) []byte {
+	if len(b) <= max {
+		return b
+	}
+
+	end := max
+	for end > 0 && end > max-utf8.UTFMax && !utf8.RuneStart(b[end]) {
+		end--
+	}
+
+	return b[:end]
+}
@@ -0,0 +1,79 @@
+package gojson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnquote(t *testing.T) {
+	s, err := Unquote([]byte(` "a\"b\\c\n\u00e9\ud83d\ude00" `))
+	assert.Nil(t, err)
+	assert.Equal(t, "a\"b\\c\né\U0001F600", s)
+
+	s, err = Unquote([]byte(`""`))
+	assert.Nil(t, err)
+	assert.Equal(t, "", s)
+
+	_, err = Unquote([]byte(`17`))
+	assert.EqualError(t, err, "expected a JSON string, found JSON type 'int'")
+
+	_, err = Unquote([]byte(`"a\qb"`))
+	assert.EqualError(t, err, `invalid JSON string in segment '"a\qb"'`)
+
+	_, err = Unquote([]byte(`"abc`))
+	assert.NotNil(t, err)
+}
+
+func TestEscapeString(t *testing.T) {
+	assert.Equal(t, []byte(`a\"b\\c\n\u0001é`), EscapeString("a\"b\\c\n\x01é"))
+	assert.Equal(t, []byte(``), EscapeString(""))
+
+	// The escaped form unquotes to the original.
+	s, err := Unquote(append(append([]byte(`"`), EscapeString("tab\there  ")...), '"'))
+	assert.Nil(t, err)
+	assert.Equal(t, "tab\there  ", s)
+}
+
+func TestDecodeUnicodeEscapes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		in       string
+		expected string
+		err      bool
+	}{
+		{name: "None", in: `plain`, expected: `plain`},
+		{name: "BMP", in: `caf\u00e9`, expected: "café"},
+		{name: "Pair", in: `\ud83d\ude00!`, expected: "\U0001F600!"},
+		{name: "Upper Hex", in: `\u00E9`, expected: "é"},
+		{name: "Lone High", in: `a\ud83db`, expected: "a�b"},
+		{name: "High Then BMP", in: `\ud83dA`, expected: "�A"},
+		{name: "High Then Escape", in: `\ud83d\u0041`, expected: "�A"},
+		{name: "Lone Low", in: `\ude00`, expected: "�"},
+		{name: "Other Escapes", in: `a\n\"\\u0041`, expected: `a\n\"\\u0041`},
+		{name: "Trailing Backslash", in: `a\`, expected: `a\`},
+		{name: "Short", in: `\u00e`, err: true},
+		{name: "Not Hex", in: `\u00g9`, err: true},
+		{name: "Sign", in: `\u+0e9`, err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := DecodeUnicodeEscapes([]byte(tc.in))
+			if tc.err {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, string(out))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, []byte("abc"), Truncate([]byte("abc"), 5))
+	assert.Equal(t, []byte("ab"), Truncate([]byte("abc"), 2))
+	assert.Equal(t, []byte("a"), Truncate([]byte("aé"), 2))
+	assert.Equal(t, []byte("aé"), Truncate([]byte("aéb"), 3))
+	assert.Equal(t, []byte(""), Truncate([]byte("\U0001F600"), 3))
+}
@@ -0,0 +1,65 @@
+package gojson
+
+import "fmt"
+
+// TrailingDataError is returned when a document holds more than whitespace after its top-level value,
+// as in {"a": "b"} garbage, or in a stream of values such as NDJSON. Options.AllowTrailingData, and
+// WithTrailingData for readers, ignore it instead.
+type TrailingDataError struct {
+	// Offset is the byte position in the document of the first byte after the top-level value which
+	// isn't whitespace.
+	Offset int
+
+	// Data is the trailing data, truncated to 50 bytes.
+	Data string
+}
+
+func (e *TrailingDataError) Error() string {
+	return fmt.Sprintf("unexpected data after the top-level value at position %d in segment '%s'", e.Offset, e.Data)
+}
+
+// WithTrailingData ignores the data after the top-level value of the document given to NewJSONReader,
+// rather than returning a TrailingDataError.
+func WithTrailingData() ReaderOption {
+	return func(jr *JSONReader) {
+		jr.trailingData = true
+	}
+}
+
+// checkTrailing returns b up to the end of its top-level value, and an error if anything but whitespace
+// follows it and allow is false. A document whose first value is malformed is returned as it is, to be
+// reported by the parser.
+func checkTrailing(b []byte, allow bool) ([]byte, error) {
+	start := ltrim(b, 0)
+	if start >= len(b) {
+		return b, nil
+	}
+
+	// extractNumber reads up to a terminator, so a number is taken to end at whitespace.
+	end := start
+	if c := b[start]; isDigit(c) || c == '-' {
+		for end < len(b) && !isWhitespace(b[end]) && !isTermByte(b[end]) {
+			end++
+		}
+
+		if !IsJSONNumber(b[start:end]) {
+			return b, nil
+		}
+	} else {
+		var err error
+		if _, _, end, err = extractValue(b, start); err != nil {
+			return b, nil
+		}
+	}
+
+	pos := ltrim(b, end)
+	if pos < 0 || pos >= len(b) {
+		return b, nil
+	}
+
+	if allow {
+		return b[:end], nil
+	}
+
+	return b, &TrailingDataError{Offset: pos, Data: string(Truncate(b[pos:], 50))}
+}
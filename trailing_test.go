@@ -0,0 +1,63 @@
+package gojson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrailingData(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected *TrailingDataError
+	}{
+		{`{"a": "b"}`, nil},
+		{" [1, 2] \n\t", nil},
+		{`12x`, nil},
+		{`{"a": "b"} trailing garbage`, &TrailingDataError{Offset: 11, Data: "trailing garbage"}},
+		{`[1, 2] x`, &TrailingDataError{Offset: 7, Data: "x"}},
+		{`"s" x`, &TrailingDataError{Offset: 4, Data: "x"}},
+		{`12 x`, &TrailingDataError{Offset: 3, Data: "x"}},
+		{`-1.5e3 7`, &TrailingDataError{Offset: 7, Data: "7"}},
+		{`true x`, &TrailingDataError{Offset: 5, Data: "x"}},
+		{`null,`, &TrailingDataError{Offset: 4, Data: ","}},
+		{"{\"a\":1}\n{\"b\":2}\n", &TrailingDataError{Offset: 8, Data: "{\"b\":2}\n"}},
+	}
+
+	for _, tc := range tests {
+		var v interface{}
+		err := Unmarshal([]byte(tc.in), &v)
+		_, rerr := NewJSONReader([]byte(tc.in))
+
+		if tc.expected == nil {
+			var te *TrailingDataError
+			assert.False(t, errors.As(err, &te), tc.in)
+			assert.False(t, errors.As(rerr, &te), tc.in)
+			continue
+		}
+
+		assert.Equal(t, tc.expected, err, tc.in)
+		assert.Equal(t, tc.expected, rerr, tc.in)
+	}
+
+	t.Run("Allowed", func(t *testing.T) {
+		data := []byte("{\"a\": 1}\n{\"a\": 2}\n")
+
+		var v struct {
+			A int `json:"a"`
+		}
+		err := UnmarshalWithOptions(data, &v, Options{AllowTrailingData: true})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, v.A)
+
+		var n int
+		assert.Nil(t, UnmarshalWithOptions([]byte(`42 43`), &n, Options{AllowTrailingData: true}))
+		assert.Equal(t, 42, n)
+
+		reader, err := NewJSONReader(data, WithTrailingData())
+		assert.Nil(t, err)
+		assert.Equal(t, 1, reader.GetInt("a"))
+		assert.Equal(t, []string{"a"}, reader.Keys)
+	})
+}
@@ -0,0 +1,246 @@
+package typegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// commonInitialisms are written in upper case in field and type names, as golint suggests, so that
+// "asset_id" becomes AssetID and "componentUri" becomes ComponentURI.
+var commonInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true, "EOF": true,
+	"GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true,
+	"LHS": true, "QPS": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true, "SMTP": true,
+	"SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true, "UDP": true, "UI": true,
+	"UID": true, "URI": true, "URL": true, "UTF8": true, "UUID": true, "VM": true, "XML": true,
+	"XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// GenerateStructs infers the Type of each sample, merges them, and returns the Go definitions of the
+// result as Generate does. Every sample should be an example of the same document.
+func GenerateStructs(name string, samples ...[]byte) ([]byte, error) {
+	var t *Type
+	for i, s := range samples {
+		st, err := InferSchema(s)
+		if err != nil {
+			return nil, fmt.Errorf("sample %d: %w", i, err)
+		}
+		t = Merge(t, st)
+	}
+
+	if t == nil {
+		return nil, fmt.Errorf("no samples provided")
+	}
+
+	return Generate(name, t)
+}
+
+// Generate returns formatted Go type definitions for t, without a package clause. The root type is
+// named name, and each nested object is given a struct named after its parent and its key, so that
+// the object at "items.data" of a Response is a ResponseItemsData.
+//
+// Nullable values become pointers, Optional members are tagged omitempty, and positions which only
+// held null, or held mixed kinds, become interface{}. Objects with no members become
+// map[string]interface{}. A member with the empty key is left out, since no json tag can name it.
+func Generate(name string, t *Type) ([]byte, error) {
+	g := generator{names: make(map[string]bool)}
+
+	name = g.typeName(goName(name))
+	g.pending = append(g.pending, pendingType{name: name, path: "", t: t})
+
+	for len(g.pending) > 0 {
+		p := g.pending[0]
+		g.pending = g.pending[1:]
+		g.define(p)
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated types: %w", err)
+	}
+
+	return src, nil
+}
+
+// pendingType is a named type yet to be written.
+type pendingType struct {
+	name string
+	path string
+	t    *Type
+}
+
+type generator struct {
+	buf     bytes.Buffer
+	names   map[string]bool
+	pending []pendingType
+}
+
+// define writes the definition of a named type.
+func (g *generator) define(p pendingType) {
+	if g.buf.Len() > 0 {
+		g.buf.WriteString("\n")
+	}
+
+	if p.path == "" {
+		fmt.Fprintf(&g.buf, "// %s models the document.\n", p.name)
+	} else {
+		fmt.Fprintf(&g.buf, "// %s models the value at %s.\n", p.name, p.path)
+	}
+
+	members := namedFields(p.t)
+	if p.t.Kind != Object || len(members) == 0 {
+		fmt.Fprintf(&g.buf, "type %s %s\n", p.name, g.goType(p.name+"Item", p.path, p.t, false))
+		return
+	}
+
+	fmt.Fprintf(&g.buf, "type %s struct {\n", p.name)
+
+	fields := make(map[string]bool, len(members))
+	for _, f := range members {
+		fieldName := uniqueName(goName(f.Key), fields)
+
+		opts := ""
+		if f.Optional {
+			opts = ",omitempty"
+		}
+
+		tag := `json:` + strconv.Quote(f.Key+opts)
+		if strings.Contains(tag, "`") {
+			tag = strconv.Quote(tag)
+		} else {
+			tag = "`" + tag + "`"
+		}
+
+		fmt.Fprintf(&g.buf, "\t%s %s %s\n", fieldName, g.goType(p.name+fieldName, joinPath(p.path, f.Key), f.Type, true), tag)
+	}
+
+	g.buf.WriteString("}\n")
+}
+
+// goType returns the Go type of t. A nested object is queued as a struct named name. nullable
+// controls whether a Nullable value is made a pointer, which isn't done for the root type.
+func (g *generator) goType(name, path string, t *Type, nullable bool) string {
+	if t == nil {
+		return "interface{}"
+	}
+
+	var s string
+	switch t.Kind {
+	case Bool:
+		s = "bool"
+	case Int:
+		s = "int"
+	case Float:
+		s = "float64"
+	case String:
+		s = "string"
+	case Array:
+		return "[]" + g.goType(name, joinPath(path, "*"), t.Elem, true)
+	case Object:
+		if len(namedFields(t)) == 0 {
+			return "map[string]interface{}"
+		}
+
+		s = g.typeName(name)
+		g.pending = append(g.pending, pendingType{name: s, path: path, t: t})
+	default:
+		return "interface{}"
+	}
+
+	if nullable && t.Nullable {
+		return "*" + s
+	}
+
+	return s
+}
+
+// namedFields returns the members of t which can be given a field. An empty json tag name falls
+// back to the Go field name, so a member with the empty key has no field that would receive it.
+func namedFields(t *Type) []*Field {
+	var fields []*Field
+	for _, f := range t.Fields {
+		if f.Key != "" {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// typeName reserves a type name, adding a numeric suffix if it is already in use.
+func (g *generator) typeName(name string) string {
+	return uniqueName(name, g.names)
+}
+
+// uniqueName returns name, or name with a numeric suffix if it is already in use, and records it.
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+
+	used[unique] = true
+	return unique
+}
+
+// goName converts a key to an exported Go identifier. The key is split into words at punctuation
+// and at changes from lower to upper case, and each word is capitalized.
+func goName(key string) string {
+	var words []string
+	var word []rune
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+
+	for _, r := range key {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && len(word) > 0 && !unicode.IsUpper(word[len(word)-1]):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	var b strings.Builder
+	for _, w := range words {
+		if upper := strings.ToUpper(w); commonInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+
+		rs := []rune(w)
+		rs[0] = unicode.ToUpper(rs[0])
+		b.WriteString(string(rs))
+	}
+
+	name := b.String()
+	switch {
+	case name == "":
+		return "Field"
+	case unicode.IsDigit([]rune(name)[0]):
+		return "X" + name
+	}
+
+	return name
+}
+
+// joinPath appends a key to a key path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,111 @@
+package typegen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateStructs(t *testing.T) {
+	first := []byte(`{"count": 2, "items": [{"id": "a", "content_source": "x", "data": {"componentUri": "u", "assets": [{"asset_id": 1}]}}, {"id": "b", "position": 3, "data": {"componentUri": null, "assets": []}}], "meta": {}, "2fa": true, "score": 1}`)
+	second := []byte(`{"count": 1, "items": [], "meta": null, "score": 1.5, "odd` + "`" + `key": "x"}`)
+
+	src, err := GenerateStructs("response", first, second)
+	assert.Nil(t, err)
+	assert.Equal(t, "// Response models the document.\n"+
+		"type Response struct {\n"+
+		"\tCount  int                    `json:\"count\"`\n"+
+		"\tItems  []ResponseItems        `json:\"items\"`\n"+
+		"\tMeta   map[string]interface{} `json:\"meta\"`\n"+
+		"\tX2fa   bool                   `json:\"2fa,omitempty\"`\n"+
+		"\tScore  float64                `json:\"score\"`\n"+
+		"\tOddKey string                 \"json:\\\"odd`key,omitempty\\\"\"\n"+
+		"}\n"+
+		"\n"+
+		"// ResponseItems models the value at items.*.\n"+
+		"type ResponseItems struct {\n"+
+		"\tID            string            `json:\"id\"`\n"+
+		"\tContentSource string            `json:\"content_source,omitempty\"`\n"+
+		"\tData          ResponseItemsData `json:\"data\"`\n"+
+		"\tPosition      int               `json:\"position,omitempty\"`\n"+
+		"}\n"+
+		"\n"+
+		"// ResponseItemsData models the value at items.*.data.\n"+
+		"type ResponseItemsData struct {\n"+
+		"\tComponentURI *string                   `json:\"componentUri\"`\n"+
+		"\tAssets       []ResponseItemsDataAssets `json:\"assets\"`\n"+
+		"}\n"+
+		"\n"+
+		"// ResponseItemsDataAssets models the value at items.*.data.assets.*.\n"+
+		"type ResponseItemsDataAssets struct {\n"+
+		"\tAssetID int `json:\"asset_id\"`\n"+
+		"}\n", string(src))
+
+	checkCompiles(t, src)
+
+	_, err = GenerateStructs("Response")
+	assert.EqualError(t, err, "no samples provided")
+
+	_, err = GenerateStructs("Response", first, []byte(`{`))
+	assert.EqualError(t, err, "sample 1: malformed json provided")
+}
+
+func TestGenerate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sample   string
+		expected string
+	}{
+		{"Scalar", `"a"`, "// Doc models the document.\ntype Doc string\n"},
+		{"Empty Object", `{}`, "// Doc models the document.\ntype Doc map[string]interface{}\n"},
+		{"Array", `[{"a": 1}]`, "// Doc models the document.\ntype Doc []DocItem\n\n// DocItem models the value at *.\ntype DocItem struct {\n\tA int `json:\"a\"`\n}\n"},
+		{"Empty Key", `{"":1}`, "// Doc models the document.\ntype Doc map[string]interface{}\n"},
+		{"Empty Key Beside Members", `{"": 1, "a": 2}`, "// Doc models the document.\ntype Doc struct {\n\tA int `json:\"a\"`\n}\n"},
+		{"Nested Empty Key", `{"a": {"": 1}}`, "// Doc models the document.\ntype Doc struct {\n\tA map[string]interface{} `json:\"a\"`\n}\n"},
+		{"Name Clash", `{"a_b": {"c": 1}, "aB": {"d": 1}}`, "// Doc models the document.\ntype Doc struct {\n\tAB  DocAB  `json:\"a_b\"`\n\tAB2 DocAB2 `json:\"aB\"`\n}\n\n// DocAB models the value at a_b.\ntype DocAB struct {\n\tC int `json:\"c\"`\n}\n\n// DocAB2 models the value at aB.\ntype DocAB2 struct {\n\tD int `json:\"d\"`\n}\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src, err := GenerateStructs("Doc", []byte(tc.sample))
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, string(src))
+			checkCompiles(t, src)
+		})
+	}
+}
+
+func TestGoName(t *testing.T) {
+	testCases := map[string]string{
+		"asset_id":       "AssetID",
+		"componentUri":   "ComponentURI",
+		"call-to-action": "CallToAction",
+		"HTMLBody":       "HTMLBody",
+		"user.email":     "UserEmail",
+		"9lives":         "X9lives",
+		"$":              "Field",
+		"héllo_wörld":    "HélloWörld",
+	}
+
+	for in, expected := range testCases {
+		assert.Equal(t, expected, goName(in), in)
+	}
+}
+
+// checkCompiles type checks the generated source.
+func checkCompiles(t *testing.T, src []byte) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "generated.go", append([]byte("package generated\n\n"), src...), parser.ParseComments)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	conf := types.Config{Importer: importer.Default()}
+	_, err = conf.Check("generated", fset, []*ast.File{f}, nil)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,208 @@
+// Package typegen infers the shape of JSON documents from samples, and generates Go struct
+// definitions with json tags from the result, saving the hand-typing of types for large API
+// responses.
+//
+// Example:
+//
+//	src, err := typegen.GenerateStructs("Response", sample1, sample2)
+//
+// gives:
+//
+//	// Response models the document.
+//	type Response struct {
+//		Count int             `json:"count"`
+//		Items []ResponseItems `json:"items"`
+//	}
+//
+//	// ResponseItems models the value at items.*.
+//	type ResponseItems struct {
+//		ID       string `json:"id"`
+//		Position int    `json:"position,omitempty"`
+//	}
+package typegen
+
+import (
+	"github.com/btm6084/gojson"
+)
+
+// Kind is the kind of value found at a position in the samples.
+type Kind int
+
+const (
+	// Null is the kind of a position which only held null, or an array which was always empty.
+	Null Kind = iota
+	Bool
+	Int
+	Float
+	String
+	Array
+	Object
+
+	// Mixed is the kind of a position which held values of incompatible kinds, such as a string in
+	// one sample and an object in another.
+	Mixed
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Null:
+		return "null"
+	case Bool:
+		return "bool"
+	case Int:
+		return "int"
+	case Float:
+		return "float"
+	case String:
+		return "string"
+	case Array:
+		return "array"
+	case Object:
+		return "object"
+	}
+
+	return "mixed"
+}
+
+// Type describes the values found at a position in the samples.
+type Type struct {
+	Kind Kind
+
+	// Nullable is true if null was found alongside values of Kind.
+	Nullable bool
+
+	// Elem describes the elements of an Array, and is nil if every array was empty.
+	Elem *Type
+
+	// Fields holds the members of an Object, in the order they were first found.
+	Fields []*Field
+}
+
+// Field is a member of an Object.
+type Field struct {
+	// Key is the key of the member.
+	Key string
+
+	// Type describes the values of the member.
+	Type *Type
+
+	// Optional is true if the member was missing from some of the objects.
+	Optional bool
+}
+
+// InferSchema returns the Type of the document. Integers and floats found at the same position are
+// combined as Float, and null combined with any other kind marks the Type Nullable. The elements of
+// an array are combined into a single Type, so a member missing from some of the objects in an
+// array is Optional.
+func InferSchema(data []byte) (*Type, error) {
+	if !gojson.IsJSON(data) {
+		return nil, gojson.ErrMalformedJSON
+	}
+
+	r, err := gojson.NewJSONReader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return infer(r.Root()), nil
+}
+
+// infer returns the Type of n.
+func infer(n *gojson.Node) *Type {
+	switch n.Type() {
+	case gojson.JSONBool:
+		return &Type{Kind: Bool}
+	case gojson.JSONInt:
+		return &Type{Kind: Int}
+	case gojson.JSONFloat:
+		return &Type{Kind: Float}
+	case gojson.JSONString:
+		return &Type{Kind: String}
+	case gojson.JSONArray:
+		t := &Type{Kind: Array}
+		for _, c := range n.Children() {
+			t.Elem = Merge(t.Elem, infer(c))
+		}
+		return t
+	case gojson.JSONObject:
+		t := &Type{Kind: Object, Fields: []*Field{}}
+		for _, c := range n.Children() {
+			t.Fields = append(t.Fields, &Field{Key: c.Key(), Type: infer(c)})
+		}
+		return t
+	}
+
+	return &Type{Kind: Null}
+}
+
+// Merge returns the Type describing the values of both a and b, such as the types of one position in
+// two samples. Either may be nil. Neither a nor b is modified.
+func Merge(a, b *Type) *Type {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	}
+
+	switch {
+	case a.Kind == Null:
+		t := *b
+		t.Nullable = b.Kind != Null
+		return &t
+	case b.Kind == Null:
+		t := *a
+		t.Nullable = true
+		return &t
+	}
+
+	t := &Type{Kind: a.Kind, Nullable: a.Nullable || b.Nullable}
+
+	switch {
+	case a.Kind == b.Kind && a.Kind == Array:
+		t.Elem = Merge(a.Elem, b.Elem)
+	case a.Kind == b.Kind && a.Kind == Object:
+		t.Fields = mergeFields(a.Fields, b.Fields)
+	case a.Kind == b.Kind:
+	case (a.Kind == Int || a.Kind == Float) && (b.Kind == Int || b.Kind == Float):
+		t.Kind = Float
+	default:
+		t.Kind = Mixed
+	}
+
+	return t
+}
+
+// mergeFields combines the members of two objects. Members missing from either are Optional.
+func mergeFields(a, b []*Field) []*Field {
+	found := make(map[string]*Field, len(b))
+	for _, f := range b {
+		found[f.Key] = f
+	}
+
+	out := make([]*Field, 0, len(a)+len(b))
+	merged := make(map[string]bool, len(a))
+	for _, f := range a {
+		m := *f
+		if o, ok := found[f.Key]; ok {
+			m.Type = Merge(f.Type, o.Type)
+			m.Optional = f.Optional || o.Optional
+		} else {
+			m.Optional = true
+		}
+
+		merged[f.Key] = true
+		out = append(out, &m)
+	}
+
+	for _, f := range b {
+		if !merged[f.Key] {
+			m := *f
+			m.Optional = true
+			out = append(out, &m)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,62 @@
+package typegen
+
+import (
+	"testing"
+
+	"github.com/btm6084/gojson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferSchema(t *testing.T) {
+	typ, err := InferSchema([]byte(`{"id": 1, "name": "a", "tags": [], "scores": [1, 2.5], "owner": {"id": "x"}, "parent": null, "mixed": [1, "a"], "items": [{"a": 1}, {"a": null, "b": true}]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, Object, typ.Kind)
+
+	kinds := make(map[string]string)
+	for _, f := range typ.Fields {
+		kinds[f.Key] = f.Type.Kind.String()
+	}
+	assert.Equal(t, map[string]string{"id": "int", "name": "string", "tags": "array", "scores": "array", "owner": "object", "parent": "null", "mixed": "array", "items": "array"}, kinds)
+
+	field := func(t *Type, key string) *Field {
+		for _, f := range t.Fields {
+			if f.Key == key {
+				return f
+			}
+		}
+		return nil
+	}
+
+	assert.Nil(t, field(typ, "tags").Type.Elem)
+	assert.Equal(t, Float, field(typ, "scores").Type.Elem.Kind)
+	assert.Equal(t, Mixed, field(typ, "mixed").Type.Elem.Kind)
+
+	items := field(typ, "items").Type.Elem
+	assert.Equal(t, Object, items.Kind)
+	assert.Equal(t, &Field{Key: "a", Type: &Type{Kind: Int, Nullable: true}}, field(items, "a"))
+	assert.Equal(t, &Field{Key: "b", Type: &Type{Kind: Bool}, Optional: true}, field(items, "b"))
+
+	_, err = InferSchema([]byte(`{"a": `))
+	assert.Equal(t, gojson.ErrMalformedJSON, err)
+}
+
+func TestMerge(t *testing.T) {
+	a, _ := InferSchema([]byte(`{"a": 1, "b": "x", "c": {"d": 1}}`))
+	b, _ := InferSchema([]byte(`{"a": 1.5, "b": null, "e": [true]}`))
+
+	m := Merge(a, b)
+	assert.Equal(t, []*Field{
+		{Key: "a", Type: &Type{Kind: Float}},
+		{Key: "b", Type: &Type{Kind: String, Nullable: true}},
+		{Key: "c", Type: &Type{Kind: Object, Fields: []*Field{{Key: "d", Type: &Type{Kind: Int}}}}, Optional: true},
+		{Key: "e", Type: &Type{Kind: Array, Elem: &Type{Kind: Bool}}, Optional: true},
+	}, m.Fields)
+
+	// The inputs are untouched.
+	assert.Equal(t, Int, a.Fields[0].Type.Kind)
+	assert.False(t, a.Fields[2].Optional)
+
+	assert.Equal(t, Mixed, Merge(&Type{Kind: String}, &Type{Kind: Object}).Kind)
+	assert.Equal(t, &Type{Kind: Null}, Merge(&Type{Kind: Null}, &Type{Kind: Null}))
+	assert.Equal(t, a, Merge(nil, a))
+}
@@ -1,13 +1,14 @@
 package gojson
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
-	"strings"
-
-	"github.com/spf13/cast"
 )
 
 type result struct {
@@ -33,32 +34,329 @@ type PostUnmarshaler interface {
 	PostUnmarshalJSON([]byte, error) error
 }
 
+// RawMessage is a raw encoded JSON value. As with encoding/json, a field of type RawMessage (or
+// json.RawMessage, which it is an alias of) receives the untouched bytes of its value, including
+// any whitespace within it, so that decoding a sub-document can be deferred or the sub-document
+// forwarded as-is.
+type RawMessage = json.RawMessage
+
+// UnmarshalTypeError is returned by Unmarshal when a number is out of the range of the integer it is
+// decoded into, e.g. 300 into an int8, or when a non-finite number is decoded into anything but a float.
+type UnmarshalTypeError struct {
+	// Field is the key path of the value, e.g. "items.3.count".
+	Field string
+
+	// Value is the raw value, truncated to 50 bytes.
+	Value string
+
+	// Type is the Go type the value could not be decoded into.
+	Type reflect.Type
+
+	// Offset is the byte offset of the value, as described by Unmarshal.
+	Offset int
+
+	// nonFinite is set for a non-finite number, which is never out of range but can't be held.
+	nonFinite bool
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	if e.nonFinite {
+		return fmt.Sprintf("key '%s' with non-finite value '%s' can not be held by type '%s'", e.Field, e.Value, e.Type)
+	}
+
+	return fmt.Sprintf("key '%s' with value '%s' is out of range for type '%s'", e.Field, e.Value, e.Type)
+}
+
+// StrictStandardsError is returned under strict standards when the JSON type of a value doesn't match
+// the Go type it is decoded into, e.g. an int for a string field.
+type StrictStandardsError struct {
+	// Field is the key path of the value, e.g. "items.3.count".
+	Field string
+
+	// StructField is the name of the struct field the value was decoded into, if it is a struct field
+	// or within one, e.g. "Count".
+	StructField string
+
+	// Offset is the byte offset of the value, as described by Unmarshal.
+	Offset int
+
+	// Expected is the JSON type the Go type requires, one of "string", "int", "float", "bool", "array"
+	// or "object".
+	Expected string
+
+	// Got is the JSON type of the value.
+	Got string
+}
+
+func (e *StrictStandardsError) Error() string {
+	msg := fmt.Sprintf("strict standards error, expected %s, got %s", e.Expected, e.Got)
+	if e.StructField != "" {
+		msg += fmt.Sprintf(" for field '%s'", e.StructField)
+	}
+	if e.Field != "" {
+		msg += fmt.Sprintf(" at path '%s'", e.Field)
+	}
+	if e.Offset >= 0 {
+		msg += fmt.Sprintf(", offset %d", e.Offset)
+	}
+
+	return msg
+}
+
+// UnmarshalerError is returned by Unmarshal when the UnmarshalJSON method of a value fails. Err is the
+// error returned by the method, and is matched by errors.Is and errors.As.
+type UnmarshalerError struct {
+	// Field is the key path of the value, e.g. "items.2.data.title".
+	Field string
+
+	// Offset is the byte offset of the value, as described by Unmarshal.
+	Offset int
+
+	// Type is the Go type whose UnmarshalJSON method failed.
+	Type reflect.Type
+
+	Err error
+}
+
+func (e *UnmarshalerError) Error() string {
+	name := e.Type.Name()
+	if name == "" {
+		name = e.Type.String()
+	}
+
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %s", name, e.Err)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", e.Field, name, e.Err)
+}
+
+func (e *UnmarshalerError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError prefixes the Field of an UnmarshalerError, UnmarshalTypeError, StrictStandardsError,
+// RequiredFieldError or NonEmptyFieldError with the key of the container member it was found in, so
+// that the full path is built as the error is returned to the root.
+func fieldError(err error, key string) error {
+	var ue *UnmarshalerError
+	var te *UnmarshalTypeError
+	var se *StrictStandardsError
+	var re *RequiredFieldError
+	var ne *NonEmptyFieldError
+	switch {
+	case errors.As(err, &ue):
+		ue.Field = prefixField(key, ue.Field)
+	case errors.As(err, &te):
+		te.Field = prefixField(key, te.Field)
+	case errors.As(err, &se):
+		se.Field = prefixField(key, se.Field)
+	case errors.As(err, &re):
+		re.Field = prefixField(key, re.Field)
+	case errors.As(err, &ne):
+		ne.Field = prefixField(key, ne.Field)
+	}
+
+	return err
+}
+
+func prefixField(key, field string) string {
+	if field == "" {
+		return key
+	}
+
+	return key + "." + field
+}
+
+// unmarshalerError wraps an error returned by the UnmarshalJSON method of p, which was given b, in an
+// UnmarshalerError.
+func (u *unmarshaler) unmarshalerError(b []byte, p reflect.Value, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &UnmarshalerError{Offset: u.offset(b), Type: p.Type(), Err: err}
+}
+
+// strictError returns a StrictStandardsError for the value b, of JSON type t, where the JSON type
+// expected was required.
+func (u *unmarshaler) strictError(b []byte, expected, t string) error {
+	return &StrictStandardsError{Offset: u.offset(b), Expected: expected, Got: t}
+}
+
+// offset returns the byte offset of b within the document being decoded, or -1 if b is not part of it.
+func (u *unmarshaler) offset(b []byte) int {
+	return offsetIn(u.doc, b)
+}
+
 // UnmarshalStrict takes a json format byte string and extracts it into the given container using
 // strict standards for type association.
 func UnmarshalStrict(raw []byte, v interface{}) (err error) {
-	u := unmarshaler{StrictStandards: true}
+	u := unmarshaler{Options: DefaultOptions}
+	u.StrictStandards = true
 	return u.unmarshal(raw, v)
 }
 
 // Unmarshal takes a json format byte string and extracts it into the given container.
+//
+// Errors about a value, such as UnmarshalTypeError, give its key path from the root as Field, where
+// the root is "", and its byte offset within raw as Offset. Offset is -1 if the value didn't come from
+// raw, such as a field's default. If raw had to be rewritten before decoding, such as from UTF-16 or
+// by Options.ExpandEnv, Offset is within the rewritten document.
 func Unmarshal(raw []byte, v interface{}) (err error) {
-	u := unmarshaler{StrictStandards: false}
+	u := unmarshaler{Options: DefaultOptions}
+	return u.unmarshal(raw, v)
+}
+
+// UnmarshalWithOptions takes a json format byte string and extracts it into the given container,
+// using the given options in place of DefaultOptions.
+//
+// Example, populating `UserID int` from the key `user_id` without a tag:
+//
+//	err := gojson.UnmarshalWithOptions(data, &v, gojson.Options{KeyConvention: gojson.SnakeCase})
+func UnmarshalWithOptions(raw []byte, v interface{}, opts Options) (err error) {
+	u := unmarshaler{Options: opts}
 	return u.unmarshal(raw, v)
 }
 
+// UnmarshalContext takes a json format byte string and extracts it into the given container, using
+// DefaultOptions. The context is checked periodically during the decode, and ctx.Err() is returned
+// if it is canceled or its deadline passes before the decode completes. Set the limits in
+// DefaultOptions (MaxDepth, MaxStringLength, MaxTokenSize, MaxDocumentSize, MaxNodes) to reject
+// pathological documents up front.
+func UnmarshalContext(ctx context.Context, raw []byte, v interface{}) (err error) {
+	u := unmarshaler{Options: DefaultOptions, ctx: ctx}
+	return u.unmarshal(raw, v)
+}
+
+// UnmarshalArray decodes each element of the JSON array data with fn, for heterogeneous arrays which
+// shouldn't be decoded into []interface{}. fn receives the JSON type and raw bytes of the element, and
+// returns its decoded value, typically by calling Unmarshal with a container chosen by the type.
+// Null gives a nil slice. An error from fn is returned with the index of the element. For arrays with
+// a fixed layout, the tuple tag option decodes each position into a struct field instead.
+//
+// Example:
+//
+//	values, err := gojson.UnmarshalArray(data, func(dtype string, raw []byte) (interface{}, error) {
+//		switch dtype {
+//		case gojson.JSONObject:
+//			var c Component
+//			return c, gojson.Unmarshal(raw, &c)
+//		case gojson.JSONArray:
+//			var tags []string
+//			return tags, gojson.Unmarshal(raw, &tags)
+//		}
+//		return gojson.NewJSONReader(raw)
+//	})
+func UnmarshalArray(data []byte, fn func(dtype string, raw []byte) (interface{}, error)) ([]interface{}, error) {
+	if !IsJSON(data) {
+		return nil, ErrMalformedJSON
+	}
+
+	b := trim(data)
+	t := GetJSONType(b, 0)
+	switch t {
+	case JSONNull:
+		return nil, nil
+	case JSONArray:
+	default:
+		return nil, fmt.Errorf("expected a JSON array, found JSON type '%s'", t)
+	}
+
+	out := []interface{}{}
+	err := EachElement(b, t, func(v []byte, vt string) error {
+		e, err := fn(vt, v)
+		if err != nil {
+			return fmt.Errorf("array element %d: %w", len(out), err)
+		}
+		out = append(out, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 type unmarshaler struct {
-	StrictStandards bool
+	Options
+
+	// ctx, if set, is checked every ctxCheckInterval decoded values. steps counts the values decoded.
+	ctx   context.Context
+	steps int
+
+	// violations collects the tag validation failures found during the unmarshal.
+	violations ValidationErrors
+
+	// interner interns the keys of decoded maps, if Options.InternKeys is set.
+	interner *keyInterner
+
+	// doc is the document passed to unmarshal, for locating the values within it.
+	doc []byte
+
+	// nonFinite records the non-finite literals replaced in doc, if Options.NonFiniteNumbers is set.
+	nonFinite *nonFiniteNumbers
+
+	// report, if set by UnmarshalWithReport, collects the dropped and coerced values. path is the key
+	// path of the value being decoded, and is only tracked when reporting.
+	report *UnmarshalReport
+	path   []string
 }
 
 func (u *unmarshaler) unmarshal(raw []byte, v interface{}) (err error) {
+	defer func() {
+		if err == nil && len(u.violations) > 0 {
+			err = u.violations
+		}
+	}()
 	defer PanicRecovery(&err)
 
+	original := raw
+	if raw, err = toUTF8(raw, u.Encoding); err != nil {
+		return err
+	}
+
+	if u.RelaxedQuotes {
+		if raw, err = relaxQuotes(raw); err != nil {
+			return err
+		}
+	}
+
+	if u.ExpandEnv != nil {
+		raw = u.ExpandEnv.expand(raw)
+	}
+
+	if u.NonFiniteNumbers {
+		raw, u.nonFinite = replaceNonFinite(raw)
+	}
+
+	// Offsets are within the document passed in, unless it had to be rewritten, such as by expanding
+	// environment variables, in which case they're within the rewritten document.
+	u.doc = raw
+	if offsetIn(original, raw) >= 0 {
+		u.doc = original
+	}
+
+	if raw, err = checkTrailing(raw, u.AllowTrailingData); err != nil {
+		return err
+	}
 	raw = trim(raw)
 
 	if len(raw) == 0 {
 		return fmt.Errorf("empty json value provided")
 	}
 
+	if u.InternKeys && u.interner == nil {
+		u.interner = newKeyInterner()
+	}
+
+	limits := u.Options
+	limits.MaxDepth = resolveMaxDepth(limits.MaxDepth)
+	if err := checkLimits(u.ctx, raw, limits); err != nil {
+		return err
+	}
+
 	p := reflect.ValueOf(v)
 	if p.Kind() != reflect.Ptr {
 		return fmt.Errorf("supplied container (v) must be a pointer")
@@ -69,11 +367,28 @@ func (u *unmarshaler) unmarshal(raw []byte, v interface{}) (err error) {
 		return fmt.Errorf("unsettable value provided to Unmarshal")
 	}
 
-	// Check if p implements the json.Unmarshaler interface.
+	if ok, err := decodeRegistered(raw, GetJSONType(raw, 0), p); ok {
+		return err
+	}
+
+	// Check if p implements the GoJSONUnmarshaler or json.Unmarshaler interface.
 	if p.CanAddr() && p.Addr().NumMethod() > 0 {
 		if u, ok := p.Addr().Interface().(PostUnmarshaler); ok {
 			defer func() { err = u.PostUnmarshalJSON(raw, err) }()
 		}
+		if u, ok := p.Addr().Interface().(GoJSONUnmarshaler); ok {
+			err = u.UnmarshalGoJSON(raw)
+			return
+		}
+		if n, ok := p.Addr().Interface().(nullable); ok {
+			return u.unmarshalNullable(raw, GetJSONType(raw, 0), n, tagOptions{})
+		}
+		if m, ok := p.Addr().Interface().(*OrderedMap); ok {
+			return u.unmarshalOrderedMap(raw, GetJSONType(raw, 0), m)
+		}
+		if r, ok := p.Addr().Interface().(*JSONReader); ok {
+			return u.unmarshalReader(raw, GetJSONType(raw, 0), r)
+		}
 		if u, ok := p.Addr().Interface().(json.Unmarshaler); ok {
 			err = u.UnmarshalJSON(raw)
 			return
@@ -87,40 +402,114 @@ func (u *unmarshaler) unmarshal(raw []byte, v interface{}) (err error) {
 		return
 	}
 
+	return u.unmarshalValue(raw, t, p, tagOptions{})
+}
+
+// Extract the byte string into the given container based on its kind. opts holds the tag options
+// of the struct field being populated, which also apply to the members of a slice or map field.
+func (u *unmarshaler) unmarshalValue(b []byte, t string, p reflect.Value, opts tagOptions) error {
+	if u.ctx != nil && u.steps%ctxCheckInterval == 0 {
+		if err := u.ctx.Err(); err != nil {
+			return err
+		}
+	}
+	u.steps++
+
+	if ok, err := decodeRegistered(b, t, p); ok {
+		return err
+	}
+
+	// The fast paths don't report coercions, or handle non-finite numbers.
+	if u.report == nil && u.nonFinite == nil {
+		if ok, err := u.decodeFast(b, t, p, opts); ok {
+			return err
+		}
+	}
+
 	switch p.Kind() {
 	case reflect.Map:
-		err = u.unmarshalMap(raw, t, p)
-		return err
+		return u.unmarshalMap(b, t, p, opts)
 	case reflect.Slice:
-		err = u.unmarshalSlice(raw, t, p)
-		return err
+		return u.unmarshalSlice(b, t, p, opts)
+	case reflect.Array:
+		return u.unmarshalArray(b, t, p, opts)
 	case reflect.Struct:
-		err = u.unmarshalStruct(raw, t, p)
-		return err
-	case reflect.Interface:
-		v := reflect.ValueOf(toIface(raw, t, u.StrictStandards))
-		if v.IsValid() {
-			p.Set(v)
+		if p.CanAddr() {
+			if n, ok := p.Addr().Interface().(nullable); ok {
+				return u.unmarshalNullable(b, t, n, opts)
+			}
+			if m, ok := p.Addr().Interface().(*OrderedMap); ok {
+				return u.unmarshalOrderedMap(b, t, m)
+			}
+			if r, ok := p.Addr().Interface().(*JSONReader); ok {
+				return u.unmarshalReader(b, t, r)
+			}
 		}
+		return u.unmarshalStruct(b, t, p, opts)
+	case reflect.Interface:
+		return u.unmarshalInterface(b, t, p, opts)
 	default:
-		err = u.setValue(raw, t, p)
-		if err != nil {
-			return err
+		return u.setValue(b, t, p)
+	}
+}
+
+// Extract the byte string into an interface container. If a discriminator is given and the value is
+// an object, the concrete type registered for the discriminator's value is populated. Otherwise, the
+// value is decoded generically, as with toIface.
+func (u *unmarshaler) unmarshalInterface(b []byte, t string, p reflect.Value, opts tagOptions) error {
+	if opts.Discriminator != "" && t == JSONObject {
+		value, err := ExtractString(b, opts.Discriminator)
+		if err != nil && u.StrictStandards {
+			return fmt.Errorf("discriminator key '%s' not found for interface '%v'", opts.Discriminator, p.Type())
+		}
+
+		registry := u.Registry
+		if registry == nil {
+			registry = DefaultRegistry
+		}
+
+		ct, ok := registry.Lookup(p.Type(), value)
+		switch {
+		case ok && ct.Kind() == reflect.Ptr:
+			c := reflect.New(ct.Elem())
+			if err := u.unmarshalValue(b, t, c.Elem(), tagOptions{}); err != nil {
+				return err
+			}
+			p.Set(c)
+			return nil
+		case ok:
+			c := reflect.New(ct).Elem()
+			if err := u.unmarshalValue(b, t, c, tagOptions{}); err != nil {
+				return err
+			}
+			p.Set(c)
+			return nil
+		case err == nil && u.StrictStandards:
+			return fmt.Errorf("discriminator key '%s' has unregistered value '%s' for interface '%v'", opts.Discriminator, value, p.Type())
 		}
 	}
 
-	return err
+	iface, err := convertInternedIface(b, t, u.ifaceOptions())
+	if err != nil {
+		return err
+	}
+
+	if v := reflect.ValueOf(iface); v.IsValid() {
+		p.Set(v)
+	}
+
+	return nil
 }
 
 // Extract the byte string into a slice container.
-func (u *unmarshaler) unmarshalSlice(b []byte, t string, p reflect.Value) (err error) {
+func (u *unmarshaler) unmarshalSlice(b []byte, t string, p reflect.Value, opts tagOptions) (err error) {
 	// Check if p implements the json.Unmarshaler interface.
 	if p.CanAddr() && p.Addr().NumMethod() > 0 {
 		if u, ok := p.Addr().Interface().(PostUnmarshaler); ok {
 			defer func() { err = u.PostUnmarshalJSON(b, err) }()
 		}
-		if u, ok := p.Addr().Interface().(json.Unmarshaler); ok {
-			err = u.UnmarshalJSON(b)
+		if m, ok := p.Addr().Interface().(json.Unmarshaler); ok {
+			err = u.unmarshalerError(b, p, m.UnmarshalJSON(b))
 			return
 		}
 	}
@@ -129,17 +518,27 @@ func (u *unmarshaler) unmarshalSlice(b []byte, t string, p reflect.Value) (err e
 		return nil
 	}
 
-	if u.StrictStandards && t != JSONArray {
-		err = fmt.Errorf("strict standards: attempt to unmarshal JSON value with type '%s' into slice", t)
-		return
-	}
-
 	childType := p.Type().Elem().Kind()
 
+	if u.StrictStandards {
+		// Under strict standards, a byte slice holds the contents of a string, as it does for
+		// GetByteSlice, and any other slice requires an array.
+		switch {
+		case childType == reflect.Uint8 && t != JSONString:
+			return u.strictError(b, JSONString, t)
+		case childType != reflect.Uint8 && t != JSONArray:
+			return u.strictError(b, JSONArray, t)
+		}
+	}
+
 	// ByteSlices are exceptionally hard to extract byte-by-byte given the difficulty
 	// of finding the correct position in the RawData, so we circumvent that problem by
 	// short-circuiting and treating it as if the whole array were an elemental type.
 	if childType == reflect.Uint8 {
+		if opts.Base64 || u.Base64Bytes {
+			return u.unmarshalBase64(b, t, p)
+		}
+
 		if t == JSONString && len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
 			b = b[1 : len(b)-1]
 		}
@@ -148,7 +547,10 @@ func (u *unmarshaler) unmarshalSlice(b []byte, t string, p reflect.Value) (err e
 	}
 
 	// Count the member elements so that we can know how big to size our slice.
-	length := countMembers(b, t)
+	length, err := countMembers(b, t)
+	if err != nil {
+		return err
+	}
 
 	if length < 1 {
 		return nil
@@ -156,8 +558,12 @@ func (u *unmarshaler) unmarshalSlice(b []byte, t string, p reflect.Value) (err e
 
 	slice := reflect.MakeSlice(p.Type(), length, length)
 
-	// Switch on the child type
+	// Switch on the child type. A scalar is the only element, and starts at 0.
 	start := 1
+	if t != JSONObject && t != JSONArray {
+		start = 0
+	}
+
 	i := 0
 	for start < len(b) {
 		var v []byte
@@ -199,37 +605,14 @@ func (u *unmarshaler) unmarshalSlice(b []byte, t string, p reflect.Value) (err e
 			return err
 		}
 
-		sliceMember := slice.Index(i)
-		child := resolvePtr(sliceMember)
+		child := resolvePtr(slice.Index(i))
 
-		switch child.Kind() {
-		case reflect.Map:
-			err = u.unmarshalMap(v, vt, child)
-			if err != nil {
-				return err
-			}
-		case reflect.Slice:
-			err = u.unmarshalSlice(v, vt, child)
-			if err != nil {
-				return err
-			}
-		case reflect.Struct:
-			err = u.unmarshalStruct(v, vt, child)
-			if err != nil {
-				return err
-			}
-		case reflect.Interface:
-			if v := reflect.ValueOf(toIface(v, vt, u.StrictStandards)); v.IsValid() {
-				child.Set(v)
-			} else {
-				child.Set(reflect.New(p.Type().Elem()).Elem())
-			}
-		default:
-			err = u.setValue(v, vt, child)
-			if err != nil {
-				return err
-			}
+		u.enterIndex(i)
+		err = u.unmarshalValue(v, vt, child, opts)
+		if err != nil {
+			return fieldError(err, strconv.Itoa(i))
 		}
+		u.leave()
 
 		i++
 	}
@@ -238,15 +621,83 @@ func (u *unmarshaler) unmarshalSlice(b []byte, t string, p reflect.Value) (err e
 	return err
 }
 
+// unmarshalBase64 extracts the base64 encoded string b into the byte slice p, as encoding/json does.
+func (u *unmarshaler) unmarshalBase64(b []byte, t string, p reflect.Value) error {
+	if t != JSONString {
+		return fmt.Errorf("attempt to unmarshal JSON value with type '%s' into base64 []byte", t)
+	}
+
+	s, err := convertString(b, t, u.StrictStandards, KeepInvalidUTF8)
+	if err != nil {
+		return err
+	}
+
+	out, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("invalid base64 value '%s' for []byte: %w", truncate([]byte(s), 50), err)
+	}
+
+	p.SetBytes(out)
+	return nil
+}
+
+// Extract the byte string into a fixed-length array container. As with encoding/json, extra elements
+// are ignored, and missing elements are set to the zero value.
+func (u *unmarshaler) unmarshalArray(b []byte, t string, p reflect.Value, opts tagOptions) (err error) {
+	// Check if p implements the json.Unmarshaler interface.
+	if p.CanAddr() && p.Addr().NumMethod() > 0 {
+		if u, ok := p.Addr().Interface().(PostUnmarshaler); ok {
+			defer func() { err = u.PostUnmarshalJSON(b, err) }()
+		}
+		if m, ok := p.Addr().Interface().(json.Unmarshaler); ok {
+			err = u.unmarshalerError(b, p, m.UnmarshalJSON(b))
+			return
+		}
+	}
+
+	if t == JSONNull {
+		return nil
+	}
+
+	if u.StrictStandards && t != JSONArray {
+		err = u.strictError(b, JSONArray, t)
+		return
+	}
+
+	p.Set(reflect.Zero(p.Type()))
+
+	i := 0
+	return EachElement(b, t, func(v []byte, vt string) error {
+		if i >= p.Len() {
+			if u.report != nil {
+				u.drop(strconv.Itoa(i))
+			}
+			i++
+			return nil
+		}
+
+		child := resolvePtr(p.Index(i))
+		i++
+
+		u.enterIndex(i - 1)
+		if err := u.unmarshalValue(v, vt, child, opts); err != nil {
+			return fieldError(err, strconv.Itoa(i-1))
+		}
+		u.leave()
+
+		return nil
+	})
+}
+
 // Extract the byte string into a map container.
-func (u *unmarshaler) unmarshalMap(b []byte, t string, p reflect.Value) (err error) {
+func (u *unmarshaler) unmarshalMap(b []byte, t string, p reflect.Value, opts tagOptions) (err error) {
 	// Check if p implements the json.Unmarshaler interface.
 	if p.CanAddr() && p.Addr().NumMethod() > 0 {
 		if u, ok := p.Addr().Interface().(PostUnmarshaler); ok {
 			defer func() { err = u.PostUnmarshalJSON(b, err) }()
 		}
-		if u, ok := p.Addr().Interface().(json.Unmarshaler); ok {
-			err = u.UnmarshalJSON(b)
+		if m, ok := p.Addr().Interface().(json.Unmarshaler); ok {
+			err = u.unmarshalerError(b, p, m.UnmarshalJSON(b))
 			return
 		}
 	}
@@ -256,7 +707,7 @@ func (u *unmarshaler) unmarshalMap(b []byte, t string, p reflect.Value) (err err
 	}
 
 	if u.StrictStandards && t != JSONObject {
-		err = fmt.Errorf("strict standards: attempt to unmarshal JSON value with type '%s' into map", t)
+		err = u.strictError(b, JSONObject, t)
 		return
 	}
 
@@ -265,7 +716,8 @@ func (u *unmarshaler) unmarshalMap(b []byte, t string, p reflect.Value) (err err
 	// ByteSlices are exceptionally hard to extract byte-by-byte given the difficulty
 	// of finding the correct position in the RawData, so we circumvent that problem by
 	// short-circuiting and treating it as if the whole array were an elemental type.
-	if childType == reflect.Uint8 {
+	// Under strict standards, the members of the object are decoded as bytes instead.
+	if childType == reflect.Uint8 && !u.StrictStandards {
 		if t == JSONString && len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
 			b = b[1 : len(b)-1]
 		}
@@ -289,8 +741,12 @@ func (u *unmarshaler) unmarshalMap(b []byte, t string, p reflect.Value) (err err
 
 	newMap := reflect.MakeMap(p.Type())
 
-	// Switch on the child type
+	// Switch on the child type. A scalar is the only element, and starts at 0.
 	start := 1
+	if t != JSONObject && t != JSONArray {
+		start = 0
+	}
+
 	i := 0
 	for start < len(b) {
 		var v []byte
@@ -300,7 +756,7 @@ func (u *unmarshaler) unmarshalMap(b []byte, t string, p reflect.Value) (err err
 
 		switch t {
 		case JSONObject:
-			v, k, vt, pos, err = extractObjectMember(b, start)
+			v, k, vt, pos, err = extractInternedMember(b, start, u.interner)
 			if err != nil {
 				return err
 			}
@@ -319,7 +775,7 @@ func (u *unmarshaler) unmarshalMap(b []byte, t string, p reflect.Value) (err err
 			if pos >= len(b) || start < 0 {
 				return fmt.Errorf("expected value terminator ('}', ']' or ',') at position '%d' in segment '%s'", pos, truncate(b, 50))
 			}
-			k = cast.ToString(i)
+			k = u.interner.index(i)
 		default:
 			v, vt, pos, err = extractValue(b, 0)
 			if err != nil {
@@ -327,7 +783,7 @@ func (u *unmarshaler) unmarshalMap(b []byte, t string, p reflect.Value) (err err
 			}
 
 			start = pos
-			k = cast.ToString(i)
+			k = u.interner.index(i)
 		}
 
 		key := reflect.ValueOf(k)
@@ -335,39 +791,13 @@ func (u *unmarshaler) unmarshalMap(b []byte, t string, p reflect.Value) (err err
 		mapElement := reflect.New(p.Type().Elem()).Elem()
 		child := resolvePtr(mapElement)
 
-		switch child.Kind() {
-		case reflect.Map:
-			err = u.unmarshalMap(v, vt, child)
-			if err != nil {
-				return err
-			}
-			newMap.SetMapIndex(key, mapElement)
-
-		case reflect.Slice:
-			err = u.unmarshalSlice(v, vt, child)
-			if err != nil {
-				return err
-			}
-			newMap.SetMapIndex(key, mapElement)
-		case reflect.Struct:
-			err = u.unmarshalStruct(v, vt, child)
-			if err != nil {
-				return err
-			}
-			newMap.SetMapIndex(key, mapElement)
-		case reflect.Interface:
-			if v := reflect.ValueOf(toIface(v, vt, u.StrictStandards)); v.IsValid() {
-				newMap.SetMapIndex(key, v)
-			} else {
-				newMap.SetMapIndex(key, mapElement)
-			}
-		default:
-			err = u.setValue(v, vt, child)
-			if err != nil {
-				return err
-			}
-			newMap.SetMapIndex(key, mapElement)
+		u.enter(k)
+		err = u.unmarshalValue(v, vt, child, opts)
+		if err != nil {
+			return fieldError(err, k)
 		}
+		u.leave()
+		newMap.SetMapIndex(key, mapElement)
 
 		i++
 	}
@@ -376,42 +806,55 @@ func (u *unmarshaler) unmarshalMap(b []byte, t string, p reflect.Value) (err err
 	return nil
 }
 
-// Extract the byte string into a struct container.
-func (u *unmarshaler) unmarshalStruct(b []byte, t string, p reflect.Value) (err error) {
-	// Check if p implements the json.Unmarshaler interface.
+// Extract the byte string into a struct container. With the tuple tag option, a JSON array is
+// extracted into the fields of the struct by position.
+func (u *unmarshaler) unmarshalStruct(b []byte, t string, p reflect.Value, opts tagOptions) (err error) {
+	if p.Type() == typeBigFloat && p.CanAddr() {
+		return u.unmarshalBigFloat(b, t, p)
+	}
+
+	// Check if p implements the GoJSONUnmarshaler or json.Unmarshaler interface.
 	if p.CanAddr() && p.Addr().NumMethod() > 0 {
 		if u, ok := p.Addr().Interface().(PostUnmarshaler); ok {
 			defer func() { err = u.PostUnmarshalJSON(b, err) }()
 		}
-		if u, ok := p.Addr().Interface().(json.Unmarshaler); ok {
-			return u.UnmarshalJSON(b)
+		if u, ok := p.Addr().Interface().(GoJSONUnmarshaler); ok {
+			return u.UnmarshalGoJSON(b)
+		}
+		if m, ok := p.Addr().Interface().(json.Unmarshaler); ok {
+			return u.unmarshalerError(b, p, m.UnmarshalJSON(b))
 		}
 	}
 
-	info := getStructInfo(p.Type())
+	info := getStructInfo(p.Type(), u.KeyConvention)
 	keys := info.Keys
 
+	if opts.Tuple && t == JSONArray {
+		return u.unmarshalTuple(b, t, p, info)
+	}
+
 	if t != JSONObject {
 		if u.StrictStandards {
-			err = fmt.Errorf("attempt to unmarshal JSON value with type '%s' into struct", t)
+			err = u.strictError(b, JSONObject, t)
 			return
 		}
 
 		if len(info.RequiredKeys) > 0 {
-			err = fmt.Errorf("missing required keys '%s' for struct '%s'", strings.Join(info.RequiredKeys, ","), p.Type().Name())
+			err = &RequiredFieldError{Struct: p.Type().Name(), Keys: append([]string(nil), info.RequiredKeys...), empty: true}
 			return
 		}
 
+		u.coerce(b, t, p)
 		return nil
 	}
 
 	if IsEmptyObject(b) || IsEmptyArray(b) {
 		if len(info.RequiredKeys) > 0 {
-			err = fmt.Errorf("missing required keys '%s' for struct '%s'", strings.Join(info.RequiredKeys, ","), p.Type().Name())
+			err = &RequiredFieldError{Struct: p.Type().Name(), Keys: append([]string(nil), info.RequiredKeys...), empty: true}
 			return
 		}
 
-		return nil
+		return u.applyDefaults(p, info, nil)
 	}
 
 	// Extract the Data
@@ -425,81 +868,242 @@ func (u *unmarshaler) unmarshalStruct(b []byte, t string, p reflect.Value) (err
 		required[k] = false
 	}
 
+	// found tracks the fields with defaults which were given a value.
+	var found map[string]bool
+	if len(info.DefaultKeys) > 0 {
+		found = make(map[string]bool, len(info.DefaultKeys))
+	}
+
+	// Once every field is found, the remaining keys are only read when reporting the dropped keys, or
+	// collecting them in an inline map.
 	count := len(keys)
-	for start < len(b) && count > 0 {
-		v, k, vt, pos, eErr := extractKeyValue(b, start)
+	for start < len(b) && (count > 0 || u.report != nil || info.Remain != nil) {
+		v, jk, vt, pos, eErr := extractKeyValue(b, start)
 		start = pos
 		if eErr != nil {
 			err = eErr
 			return err
 		}
 
-		if _, isset := required[k]; isset {
-			required[k] = true
+		// Fall back to normalized or case-insensitive matching when there is no exact match.
+		k, ok := info.Lookup(jk)
+		if !ok && info.Remain != nil {
+			u.enter(jk)
+			if err = u.unmarshalRemain(jk, v, vt, p, *info.Remain); err != nil {
+				return err
+			}
+			u.leave()
+			continue
+		}
+		if !ok {
+			u.drop(jk)
+			continue
 		}
 
-		if _, ok := keys[k]; !ok {
+		if count == 0 {
 			continue
 		}
 
-		// If we're dealing with an embeded struct, make sure we're expanding properly.
-		var f reflect.Value
-		if len(keys[k].Path) > 0 {
-			f = p
-			// Follow the path through the parent nodes until we hit the bottom.
-			for _, i := range keys[k].Path {
-				f = resolvePtr(f.Field(i))
-			}
-			f = resolvePtr(f.Field(keys[k].Index))
-		} else {
-			f = resolvePtr(p.Field(keys[k].Index))
+		// Required keys are tracked by their primary name, so that an alternate key also satisfies them.
+		if _, isset := required[keys[k].Name]; isset {
+			required[keys[k].Name] = true
 		}
 
-		if info.NonEmpty(k) && isZeroValue(v, vt) {
-			return fmt.Errorf("nonempty key '%s' for struct '%s' has %s zero value", keys[k].Name, p.Type().Name(), vt)
+		if keys[k].opts.Default != nil {
+			found[keys[k].Name] = true
 		}
 
-		switch f.Kind() {
-		case reflect.Map:
-			err = u.unmarshalMap(v, vt, f)
-			if err != nil {
-				return err
-			}
-		case reflect.Slice:
-			err = u.unmarshalSlice(v, vt, f)
-			if err != nil {
-				return err
-			}
-		case reflect.Struct:
-			err = u.unmarshalStruct(v, vt, f)
-			if err != nil {
-				return err
-			}
-		case reflect.Interface:
-			v := reflect.ValueOf(toIface(v, vt, u.StrictStandards))
-			if v.IsValid() {
-				f.Set(v)
-			}
-		default:
-			err = u.setValue(v, vt, f)
-			if err != nil {
-				return err
-			}
+		u.enter(jk)
+		if err = u.unmarshalField(v, vt, p, keys[k]); err != nil {
+			return err
 		}
+		u.leave()
 
 		count--
 	}
 
 	for _, k := range info.RequiredKeys {
 		if !required[k] {
-			err = fmt.Errorf("required key '%s' for struct '%s' was not found", k, p.Type().Name())
+			err = &RequiredFieldError{Struct: p.Type().Name(), Keys: []string{k}}
 			return
 		}
 	}
 
+	return u.applyDefaults(p, info, found)
+}
+
+// unmarshalTuple extracts a JSON array into the fields of a struct by position, for fields with the
+// tuple tag option. As with arrays, extra elements are ignored. The fields of missing elements are
+// treated as missing keys.
+func (u *unmarshaler) unmarshalTuple(b []byte, t string, p reflect.Value, info *StructDescriptor) error {
+	found := make(map[string]bool, len(info.Fields))
+
+	i := 0
+	err := EachElement(b, t, func(v []byte, vt string) error {
+		if i >= len(info.Fields) {
+			if u.report != nil {
+				u.drop(strconv.Itoa(i))
+			}
+			i++
+			return nil
+		}
+
+		name := info.Fields[i]
+		found[name] = true
+		i++
+
+		u.enterIndex(i - 1)
+		defer u.leave()
+		return u.unmarshalField(v, vt, p, info.Keys[name])
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range info.RequiredKeys {
+		if !found[k] {
+			return &RequiredFieldError{Struct: p.Type().Name(), Keys: []string{k}}
+		}
+	}
+
+	return u.applyDefaults(p, info, found)
+}
+
+// unmarshalRemain decodes the member with key jk, which no field claimed, into the inline map field of
+// the struct p described by key.
+func (u *unmarshaler) unmarshalRemain(jk string, v []byte, vt string, p reflect.Value, key StructKey) error {
+	m := structField(p, key)
+	if m.IsNil() {
+		m.Set(reflect.MakeMap(m.Type()))
+	}
+
+	e := reflect.New(m.Type().Elem()).Elem()
+	if err := u.unmarshalValue(v, vt, e, key.opts); err != nil {
+		return fieldError(err, jk)
+	}
+
+	m.SetMapIndex(reflect.ValueOf(jk).Convert(m.Type().Key()), e)
 	return nil
 }
 
+// unmarshalField extracts the byte string into the field of the struct p described by key, applying
+// the field's tag options.
+func (u *unmarshaler) unmarshalField(v []byte, vt string, p reflect.Value, key StructKey) error {
+	// A null value is replaced by the field's default, if it has one.
+	if key.opts.Default != nil && vt == JSONNull {
+		v, vt = key.opts.Default, key.opts.DefaultType
+	}
+
+	if u.RejectNulls && vt == JSONNull && !acceptsNull(key.Type) {
+		return fmt.Errorf("key '%s' for struct '%s' is null, which type '%v' can not hold", key.Name, p.Type().Name(), key.Type)
+	}
+
+	f := structField(p, key)
+
+	if key.opts.NonEmpty && isZeroValue(v, vt) {
+		return &NonEmptyFieldError{Field: key.Name, Key: key.Name, Struct: p.Type().Name(), Type: vt}
+	}
+
+	if key.Quoted && vt != JSONNull {
+		var ok bool
+		if v, vt, ok = u.unquoteStringOption(v, vt, f.Kind()); !ok {
+			return fmt.Errorf("key '%s' for struct '%s' has invalid ,string value %s", key.Name, p.Type().Name(), truncate(v, 50))
+		}
+	}
+
+	if conv := key.opts.Converter; conv != "" && vt != JSONNull {
+		if err := fieldConverter(conv)(u, v, vt, f); err != nil {
+			return fmt.Errorf("key '%s' for struct '%s' has invalid %s value %s: %w", key.Name, p.Type().Name(), conv, truncate(v, 50), err)
+		}
+	} else if err := u.unmarshalValue(v, vt, f, key.opts); err != nil {
+		var se *StrictStandardsError
+		if errors.As(err, &se) && se.StructField == "" {
+			se.StructField = structFieldName(p.Type(), key)
+		}
+		return fieldError(err, key.Name)
+	}
+
+	if len(key.Validations) > 0 && vt != JSONNull {
+		u.validate(key, f, p.Type())
+	}
+
+	return nil
+}
+
+// applyDefaults decodes the default tag option of each field whose key was not found.
+func (u *unmarshaler) applyDefaults(p reflect.Value, info *StructDescriptor, found map[string]bool) error {
+	for _, name := range info.DefaultKeys {
+		if found[name] {
+			continue
+		}
+
+		key := info.Keys[name]
+		if err := u.unmarshalValue(key.opts.Default, key.opts.DefaultType, structField(p, key), key.opts); err != nil {
+			return fmt.Errorf("invalid default for key '%s' for struct '%s': %w", name, p.Type().Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// structField returns the field of the struct p described by key, following the path through any
+// embedded structs.
+func structField(p reflect.Value, key StructKey) reflect.Value {
+	f := p
+	for _, i := range key.Path {
+		f = resolvePtr(f.Field(i))
+	}
+	return resolvePtr(f.Field(key.Index))
+}
+
+// structFieldName returns the name of the field of the struct type t described by key.
+func structFieldName(t reflect.Type, key StructKey) string {
+	for _, i := range key.Path {
+		t = t.Field(i).Type
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+	}
+	return t.Field(key.Index).Name
+}
+
+// unquoteStringOption implements the ",string" tag option, as found in encoding/json. The value of a
+// string, boolean, or numeric field is encoded inside a JSON string, which is unwrapped here. Fields
+// of any other kind are unaffected. Outside of strict standards, a value which is not wrapped in a
+// string is accepted as-is.
+func (u *unmarshaler) unquoteStringOption(b []byte, t string, k reflect.Kind) ([]byte, string, bool) {
+	var expected []string
+
+	switch k {
+	case reflect.String:
+		expected = []string{JSONString}
+	case reflect.Bool:
+		expected = []string{JSONBool}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		expected = []string{JSONInt}
+	case reflect.Float32, reflect.Float64:
+		expected = []string{JSONInt, JSONFloat}
+	default:
+		return b, t, true
+	}
+
+	if t != JSONString {
+		return b, t, !u.StrictStandards
+	}
+
+	inner := []byte(manualUnescapeString(b))
+	it := GetJSONType(inner, 0)
+
+	for _, e := range expected {
+		if it == e && IsJSON(inner) {
+			return inner, it, true
+		}
+	}
+
+	return b, t, false
+}
+
 func isZeroValue(v []byte, t string) bool {
 	switch t {
 	case JSONBool:
@@ -559,60 +1163,134 @@ func (u *unmarshaler) setValue(b []byte, t string, p reflect.Value) (err error)
 		if u, ok := p.Addr().Interface().(PostUnmarshaler); ok {
 			defer func() { err = u.PostUnmarshalJSON(b, err) }()
 		}
-		if u, ok := p.Addr().Interface().(json.Unmarshaler); ok {
-			err = u.UnmarshalJSON(b)
+		if m, ok := p.Addr().Interface().(json.Unmarshaler); ok {
+			err = u.unmarshalerError(b, p, m.UnmarshalJSON(b))
 			return
 		}
 	}
 
+	if f, ok := u.nonFinite.lookup(b); ok {
+		return setNonFinite(f, p, u.offset(b))
+	}
+
+	u.coerce(b, t, p)
+
 	switch p.Kind() {
 	// Common Types First
 	case reflect.String:
 		if u.StrictStandards && t != JSONString {
-			panic(fmt.Errorf("strict standards error, expected string, got %s", t))
+			return u.strictError(b, JSONString, t)
 		}
-		p.SetString(toString(b, t, u.StrictStandards))
-		return nil
+		s, err := convertString(b, t, u.StrictStandards, u.InvalidUTF8)
+		p.SetString(s)
+		return err
 	case reflect.Int:
 		if u.StrictStandards && t != JSONInt {
-			panic(fmt.Errorf("strict standards error, expected int, got %s", t))
+			return u.strictError(b, JSONInt, t)
 		}
-		p.SetInt(int64(toInt(b, t, u.StrictStandards)))
-		return nil
+		return u.setInt(b, t, p)
 	case reflect.Float64, reflect.Float32:
 		if u.StrictStandards && t != JSONFloat {
-			panic(fmt.Errorf("strict standards error, expected float, got %s", t))
+			return u.strictError(b, JSONFloat, t)
 		}
-		p.SetFloat(toFloat(b, t, u.StrictStandards))
-		return nil
+		f, err := convertFloat(b, t, u.StrictStandards)
+		if err == nil && p.OverflowFloat(f) {
+			return &UnmarshalTypeError{Value: string(truncate(b, 50)), Type: p.Type(), Offset: u.offset(b)}
+		}
+		p.SetFloat(f)
+		return err
 	case reflect.Bool:
 		if u.StrictStandards && t != JSONBool {
-			panic(fmt.Errorf("strict standards error, expected bool, got %s", t))
+			return u.strictError(b, JSONBool, t)
 		}
-		p.SetBool(toBool(b, t, u.StrictStandards))
-		return nil
+		v, err := convertBool(b, t, u.StrictStandards)
+		p.SetBool(v)
+		return err
 
 	// Less Common Types
 	case reflect.Uint8, reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		if u.StrictStandards && t != JSONInt {
-			panic(fmt.Errorf("strict standards error, expected int, got %s", t))
+			return u.strictError(b, JSONInt, t)
 		}
-		p.SetUint(uint64(toInt(b, t, u.StrictStandards)))
-		return nil
+		return u.setInt(b, t, p)
 	case reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
 		if u.StrictStandards && t != JSONInt {
-			panic(fmt.Errorf("strict standards error, expected int, got %s", t))
+			return u.strictError(b, JSONInt, t)
 		}
-		p.SetInt(int64(toInt(b, t, u.StrictStandards)))
-		return nil
+		return u.setInt(b, t, p)
 
 	default:
-		// Invalid, Complex64, Complex128, Array, Chan, Func
+		// Invalid, Complex64, Complex128, Chan, Func
 		err = fmt.Errorf("Unmarshal: Invalid Container Type '%s'", p.Kind())
 		return
 	}
 }
 
+// setInt decodes a number into the signed or unsigned integer p. A value outside the range of p's
+// type is reported as an UnmarshalTypeError, rather than wrapping.
+func (u *unmarshaler) setInt(b []byte, t string, p reflect.Value) error {
+	unsigned := p.Kind() >= reflect.Uint && p.Kind() <= reflect.Uint64
+	i, ui, ok, err := u.intValue(b, t, p.Type().Bits(), unsigned)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &UnmarshalTypeError{Value: string(truncate(b, 50)), Type: p.Type(), Offset: u.offset(b)}
+	}
+
+	if unsigned {
+		p.SetUint(ui)
+	} else {
+		p.SetInt(i)
+	}
+
+	return nil
+}
+
+// intValue decodes a number as an integer of the given size, returning false if it is out of range.
+// Signed values are returned as i, and unsigned values as ui.
+func (u *unmarshaler) intValue(b []byte, t string, bits int, unsigned bool) (i int64, ui uint64, ok bool, err error) {
+	n, nt := b, t
+	if t == JSONString {
+		n = trimString(b)
+		nt = GetJSONType(n, 0)
+	}
+
+	switch nt {
+	case JSONInt:
+		if unsigned && n[0] != '-' {
+			ui, err = strconv.ParseUint(string(n), 10, bits)
+		} else {
+			i, err = strconv.ParseInt(string(n), 10, bits)
+		}
+
+		switch {
+		case err == nil && (!unsigned || i >= 0):
+			return i, ui, true, nil
+		case err == nil || errors.Is(err, strconv.ErrRange):
+			return 0, 0, false, nil
+		}
+	case JSONFloat:
+		f, err := strconv.ParseFloat(string(n), 64)
+		if err == nil && (f < math.MinInt64 || f >= math.MaxInt64) {
+			return 0, 0, false, nil
+		}
+	}
+
+	// Anything else is converted as before, and its result checked.
+	n64, err := convertInt(b, t, u.StrictStandards, u.NumberConversion)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	i = int64(n64)
+	if unsigned {
+		return 0, uint64(i), i >= 0 && (bits == 64 || i < 1<<bits), nil
+	}
+
+	return i, 0, bits == 64 || (i >= -1<<(bits-1) && i < 1<<(bits-1)), nil
+}
+
 // For objects and arrays, parse the data and collect information about each member element for further processing.
 func getNodeList(b []byte, t string) ([]result, error) {
 	start := 0
@@ -1,11 +1,14 @@
 package gojson
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -193,7 +196,7 @@ func TestUnmarshal(t *testing.T) {
 
 		var m A
 		err := UnmarshalStrict([]byte(input), &m)
-		assert.True(t, strings.HasPrefix(err.Error(), "attempt to unmarshal JSON value with type 'string' into struct"))
+		assert.Equal(t, &TrailingDataError{Offset: 3, Data: `: "test value", "b": 762}`}, err)
 	})
 
 	t.Run("Struct Invalid JSON", func(t *testing.T) {
@@ -206,7 +209,7 @@ func TestUnmarshal(t *testing.T) {
 
 		var m A
 		err := Unmarshal([]byte(input), &m)
-		assert.Nil(t, err)
+		assert.Equal(t, &TrailingDataError{Offset: 3, Data: `: "test value", "b": 762}`}, err)
 	})
 
 	t.Run("Struct", func(t *testing.T) {
@@ -570,7 +573,7 @@ func TestUnmarshal(t *testing.T) {
 		}
 		data := `[[ a b c d ]]`
 		err := UnmarshalStrict([]byte(data), &m)
-		assert.True(t, strings.HasPrefix(err.Error(), "strict standards: attempt to unmarshal JSON value with type 'array' into map"))
+		assert.True(t, strings.HasPrefix(err.Error(), "strict standards error, expected object, got array"))
 	})
 
 	t.Run("Unmarshal Map, Struct Error", func(t *testing.T) {
@@ -838,7 +841,7 @@ func TestUnmarshal(t *testing.T) {
 		}
 		data := `[[ a b c d ]]`
 		err := UnmarshalStrict([]byte(data), &m)
-		assert.True(t, strings.HasPrefix(err.Error(), "attempt to unmarshal JSON value with type 'array' into struct"))
+		assert.True(t, strings.HasPrefix(err.Error(), "strict standards error, expected object, got array"))
 	})
 
 	t.Run("Unmarshal Slice, Struct Error", func(t *testing.T) {
@@ -1131,13 +1134,13 @@ func TestUnmarshal(t *testing.T) {
 			Expected string
 		}{
 			{`[[]`, `expected value terminator ('}', ']' or ',') at position '3' in segment '[[]'`},
-			{`[]]`, `invalid character ']' at position '1' in segment '[]]'`},
-			{`[]}`, `invalid character ']' at position '1' in segment '[]}'`},
+			{`[]]`, `unexpected data after the top-level value at position 2 in segment ']'`},
+			{`[]}`, `unexpected data after the top-level value at position 2 in segment '}'`},
 			{`[{}`, `expected value terminator ('}', ']' or ',') at position '3' in segment '[{}'`},
 			{`{[]`, `expected object key at position 1 in segment '{[]'`},
 			{`{{}`, `expected object key at position 1 in segment '{{}'`},
-			{`{}]`, `expected object key at position 1 in segment '{}]'`},
-			{`{}}`, `expected object key at position 1 in segment '{}}'`},
+			{`{}]`, `unexpected data after the top-level value at position 2 in segment ']'`},
+			{`{}}`, `unexpected data after the top-level value at position 2 in segment '}'`},
 		}
 
 		for i, tc := range testCases {
@@ -1174,21 +1177,21 @@ func TestUnmarshal(t *testing.T) {
 		var m map[string]interface{}
 
 		err := Unmarshal([]byte(`{"a": false, "b": [], "c": 17.9} ]`), &m)
-		assert.True(t, strings.HasPrefix(err.Error(), `expected object key at position 32 in segment '{"a": false, "b": [], "c": 17.9} ]'`))
+		assert.True(t, strings.HasPrefix(err.Error(), `unexpected data after the top-level value at position 33 in segment ']'`))
 	})
 
 	t.Run("Extra Close", func(t *testing.T) {
 		var m map[string]interface{}
 
 		err := Unmarshal([]byte(`{"a": "b"}}`), &m)
-		assert.True(t, strings.HasPrefix(err.Error(), `expected object key at position 10 in segment '{"a": "b"}}'`))
+		assert.True(t, strings.HasPrefix(err.Error(), `unexpected data after the top-level value at position 10 in segment '}'`))
 	})
 
 	t.Run("Valid JSON that Terminates Early", func(t *testing.T) {
 		var m map[string]interface{}
 
 		err := Unmarshal([]byte(`["a", {"b":4}, false]  ]`), &m)
-		assert.True(t, strings.HasPrefix(err.Error(), `invalid character ']' at position '23' in segment '["a", {"b":4}, false]  ]'`))
+		assert.True(t, strings.HasPrefix(err.Error(), `unexpected data after the top-level value at position 23 in segment ']'`))
 	})
 
 	t.Run("Null Value into Interface", func(t *testing.T) {
@@ -1276,7 +1279,7 @@ func TestUnmarshal(t *testing.T) {
 		var m testType
 
 		err := Unmarshal([]byte(`{"not_required": "Hello!"}`), &m)
-		assert.Equal(t, err, errors.New(`required key 'is_required' for struct 'testType' was not found`))
+		assert.EqualError(t, err, `required key 'is_required' for struct 'testType' was not found`)
 	})
 
 	t.Run("NonEmpty Field Does Not Exist", func(t *testing.T) {
@@ -1287,7 +1290,7 @@ func TestUnmarshal(t *testing.T) {
 		var m testType
 
 		err := Unmarshal([]byte(`{"is_not_right_key": "Hello!"}`), &m)
-		assert.Equal(t, err, errors.New(`required key 'not_empty' for struct 'testType' was not found`))
+		assert.EqualError(t, err, `required key 'not_empty' for struct 'testType' was not found`)
 	})
 
 	t.Run("NonEmpty Field Exists But Is Empty String", func(t *testing.T) {
@@ -1298,7 +1301,7 @@ func TestUnmarshal(t *testing.T) {
 		var m testType
 
 		err := Unmarshal([]byte(`{"is_empty": ""}`), &m)
-		assert.Equal(t, err, errors.New(`nonempty key 'is_empty' for struct 'testType' has string zero value`))
+		assert.EqualError(t, err, `nonempty key 'is_empty' for struct 'testType' has string zero value`)
 	})
 
 	t.Run("NonEmpty Field Exists But Is Empty Int", func(t *testing.T) {
@@ -1309,7 +1312,7 @@ func TestUnmarshal(t *testing.T) {
 		var m testType
 
 		err := Unmarshal([]byte(`{"is_empty": 0}`), &m)
-		assert.Equal(t, err, errors.New(`nonempty key 'is_empty' for struct 'testType' has int zero value`))
+		assert.EqualError(t, err, `nonempty key 'is_empty' for struct 'testType' has int zero value`)
 	})
 
 	t.Run("NonEmpty Field Exists But Is Empty Float", func(t *testing.T) {
@@ -1320,10 +1323,10 @@ func TestUnmarshal(t *testing.T) {
 		var m testType
 
 		err := Unmarshal([]byte(`{"is_empty": 0}`), &m)
-		assert.Equal(t, errors.New(`nonempty key 'is_empty' for struct 'testType' has int zero value`), err)
+		assert.EqualError(t, err, `nonempty key 'is_empty' for struct 'testType' has int zero value`)
 
 		err = Unmarshal([]byte(`{"is_empty": 0.0}`), &m)
-		assert.Equal(t, errors.New(`nonempty key 'is_empty' for struct 'testType' has float zero value`), err)
+		assert.EqualError(t, err, `nonempty key 'is_empty' for struct 'testType' has float zero value`)
 	})
 
 	t.Run("NonEmpty Field Exists But Is Empty Array", func(t *testing.T) {
@@ -1334,10 +1337,10 @@ func TestUnmarshal(t *testing.T) {
 		var m testType
 
 		err := Unmarshal([]byte(`{"is_empty": []}`), &m)
-		assert.Equal(t, errors.New(`nonempty key 'is_empty' for struct 'testType' has array zero value`), err)
+		assert.EqualError(t, err, `nonempty key 'is_empty' for struct 'testType' has array zero value`)
 
 		err = Unmarshal([]byte(`{"is_empty": [    ]}`), &m)
-		assert.Equal(t, errors.New(`nonempty key 'is_empty' for struct 'testType' has array zero value`), err)
+		assert.EqualError(t, err, `nonempty key 'is_empty' for struct 'testType' has array zero value`)
 	})
 
 	t.Run("NonEmpty Field Exists But Is Empty Array", func(t *testing.T) {
@@ -1348,10 +1351,10 @@ func TestUnmarshal(t *testing.T) {
 		var m testType
 
 		err := Unmarshal([]byte(`{"is_empty": {}}`), &m)
-		assert.Equal(t, errors.New(`nonempty key 'is_empty' for struct 'testType' has object zero value`), err)
+		assert.EqualError(t, err, `nonempty key 'is_empty' for struct 'testType' has object zero value`)
 
 		err = Unmarshal([]byte(`{"is_empty": {    }}`), &m)
-		assert.Equal(t, errors.New(`nonempty key 'is_empty' for struct 'testType' has object zero value`), err)
+		assert.EqualError(t, err, `nonempty key 'is_empty' for struct 'testType' has object zero value`)
 	})
 
 	// See definition for ImplementsPostUnmarshalerValid type at the top of the file.
@@ -1475,21 +1478,92 @@ func TestUnmarshalStrict(t *testing.T) {
 		assert.True(t, strings.HasPrefix(err.Error(), "strict standards error, expected string, got int"))
 	})
 
+	t.Run("Position", func(t *testing.T) {
+		type Item struct {
+			Count int `json:"count"`
+		}
+		var m struct {
+			A     string `json:"a"`
+			Items []Item `json:"items"`
+		}
+
+		err := UnmarshalStrict([]byte(`{"a": 7}`), &m)
+		assert.Equal(t, &StrictStandardsError{Field: "a", StructField: "A", Offset: 6, Expected: JSONString, Got: JSONInt}, err)
+		assert.EqualError(t, err, "strict standards error, expected string, got int for field 'A' at path 'a', offset 6")
+
+		// Offsets are within the document passed in, including its byte order mark.
+		err = UnmarshalStrict([]byte("\xEF\xBB\xBF{\"a\": 7}"), &m)
+		assert.EqualError(t, err, "strict standards error, expected string, got int for field 'A' at path 'a', offset 9")
+
+		// A rewritten document is located within the rewrite.
+		opts := DefaultOptions
+		opts.StrictStandards = true
+		opts.ExpandEnv = &EnvExpansion{Lookup: func(string) (string, bool) { return "value", true }}
+		err = UnmarshalWithOptions([]byte(`{"items": [{"name": "$N"}], "a": 7}`), &m, opts)
+		assert.EqualError(t, err, "strict standards error, expected string, got int for field 'A' at path 'a', offset 36")
+
+		err = UnmarshalStrict([]byte(`{"a": "x", "items": [{"count": 1}, {"count": 1.5}]}`), &m)
+		assert.EqualError(t, err, "strict standards error, expected int, got float for field 'Count' at path 'items.1.count', offset 45")
+
+		var n int
+		err = UnmarshalStrict([]byte(` "7"`), &n)
+		assert.EqualError(t, err, "strict standards error, expected int, got string, offset 1")
+	})
+
 	t.Run("Array Into Struct", func(t *testing.T) {
 		var m struct{ A string }
 
 		err := UnmarshalStrict([]byte(`[42]`), &m)
-		assert.Equal(t, errors.New("attempt to unmarshal JSON value with type 'array' into struct"), err)
+		assert.Equal(t, &StrictStandardsError{Offset: 0, Expected: JSONObject, Got: JSONArray}, err)
 	})
 
 	t.Run("Array Into Map", func(t *testing.T) {
 		var m map[string]int
 
 		err := UnmarshalStrict([]byte(`[42]`), &m)
-		assert.Equal(t, errors.New("strict standards: attempt to unmarshal JSON value with type 'array' into map"), err)
+		assert.Equal(t, &StrictStandardsError{Offset: 0, Expected: JSONObject, Got: JSONArray}, err)
 	})
 }
 
+func TestUnmarshalStrictContainers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		target   interface{}
+		expected interface{}
+		err      string
+	}{
+		{name: "Slice Element", json: `[1, "2"]`, target: new([]int), err: "strict standards error, expected int, got string at path '1', offset 4"},
+		{name: "Map Value", json: `{"a": 1, "b": "2"}`, target: new(map[string]int), err: "strict standards error, expected int, got string at path 'b', offset 14"},
+		{name: "Nested Slice", json: `[[1], ["2"]]`, target: new([][]int), err: "strict standards error, expected int, got string at path '1.0', offset 7"},
+		{name: "Slice From Object", json: `{"a": 1}`, target: new([]int), err: "strict standards error, expected array, got object, offset 0"},
+		{name: "Map From Array", json: `[1]`, target: new(map[string]int), err: "strict standards error, expected object, got array, offset 0"},
+		{name: "Bytes From String", json: `"abc"`, target: new([]byte), expected: []byte("abc")},
+		{name: "Bytes From Array", json: `[1, 2]`, target: new([]byte), err: "strict standards error, expected string, got array, offset 0"},
+		{name: "Map Of Bytes", json: `{"a": "abc"}`, target: new(map[string][]byte), expected: map[string][]byte{"a": []byte("abc")}},
+		{name: "Map Of Uint8", json: `{"a": 1, "b": 2}`, target: new(map[string]uint8), expected: map[string]uint8{"a": 1, "b": 2}},
+		{name: "Slice Field", json: `{"a": "x"}`, target: new(struct{ A []int }), err: "strict standards error, expected array, got string for field 'A' at path 'A', offset 6"},
+		{name: "Map Field", json: `{"m": [1]}`, target: new(struct{ M map[string]int }), err: "strict standards error, expected object, got array for field 'M' at path 'M', offset 6"},
+		{name: "Array Field", json: `{"a": {"b": 1}}`, target: new(struct{ A [2]int }), err: "strict standards error, expected array, got object for field 'A' at path 'A', offset 6"},
+		{name: "Struct Element", json: `[{}, 7]`, target: new([]struct{ A int }), err: "strict standards error, expected object, got int at path '1', offset 5"},
+		{name: "Map Of Uint8 Mismatch", json: `{"a": "1"}`, target: new(map[string]uint8), err: "strict standards error, expected int, got string at path 'a', offset 6"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := UnmarshalStrict([]byte(tc.json), tc.target)
+			if tc.err != "" {
+				// Errors are returned as-is, rather than recovered from a panic.
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, reflect.ValueOf(tc.target).Elem().Interface())
+		})
+	}
+}
+
 func TestUnmarshalEscapedBackslash(t *testing.T) {
 	data := `{"results":[{"keywords":"\\","canonical":"nbc-world_of_dance:srank_world_finale_front_row-hulu2"}]}`
 
@@ -1540,7 +1614,153 @@ func TestUnmarshalEmbededStructs(t *testing.T) {
 	assert.JSONEq(t, expected, string(s))
 }
 
+func TestUnmarshalEmbeddedPointers(t *testing.T) {
+	type Audit struct {
+		CreatedBy string `json:"created_by"`
+		UpdatedBy string `json:"updated_by"`
+	}
+
+	type Meta struct {
+		*Audit
+		Version int    `json:"version"`
+		Name    string `json:"name"`
+	}
+
+	type hidden struct {
+		Hidden string `json:"hidden"`
+	}
+
+	type Named struct {
+		Value string `json:"value"`
+	}
+
+	type Test struct {
+		*Meta
+		hidden
+		*Named `json:"named"`
+		Name   string `json:"name"`
+	}
+
+	testCases := []struct {
+		label string
+		data  string
+	}{
+		{"Promoted", `{"version": 2, "created_by": "bob", "name": "outer", "hidden": "h", "named": {"value": "v"}}`},
+		{"No Relevant Keys", `{"name": "outer", "value": "ignored"}`},
+		{"Only Outer Pointer", `{"version": 3}`},
+		{"Null", `{"created_by": null}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.label, func(t *testing.T) {
+			var expected, actual Test
+			assert.Nil(t, json.Unmarshal([]byte(tc.data), &expected))
+			assert.Nil(t, Unmarshal([]byte(tc.data), &actual))
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("Allocation", func(t *testing.T) {
+		var m Test
+		assert.Nil(t, Unmarshal([]byte(`{"name": "outer"}`), &m))
+		assert.Nil(t, m.Meta)
+		assert.Nil(t, m.Named)
+		assert.Equal(t, "outer", m.Name)
+
+		assert.Nil(t, Unmarshal([]byte(`{"version": 1}`), &m))
+		assert.NotNil(t, m.Meta)
+		assert.Nil(t, m.Audit)
+
+		assert.Nil(t, Unmarshal([]byte(`{"updated_by": "amy"}`), &m))
+		assert.Equal(t, "amy", m.UpdatedBy)
+		assert.Equal(t, 1, m.Version)
+	})
+
+	t.Run("Recursive", func(t *testing.T) {
+		type Node struct {
+			*Node
+			Value int `json:"value"`
+		}
+
+		var m Node
+		assert.Nil(t, Unmarshal([]byte(`{"value": 1}`), &m))
+		assert.Equal(t, 1, m.Value)
+		assert.Nil(t, m.Node)
+	})
+
+	t.Run("Required", func(t *testing.T) {
+		type ID struct {
+			ID int `json:"id,required"`
+		}
+
+		type Test struct {
+			*ID
+			Name string `json:"name"`
+		}
+
+		var m Test
+		assert.EqualError(t, Unmarshal([]byte(`{"name": "x"}`), &m), "required key 'id' for struct 'Test' was not found")
+		assert.Nil(t, Unmarshal([]byte(`{"id": 7}`), &m))
+		assert.Equal(t, 7, m.ID.ID)
+	})
+}
+
 func TestUnmarshalGoJSONTags(t *testing.T) {
+	t.Run("Options", func(t *testing.T) {
+		type Meta struct {
+			Source string `json:"source"`
+		}
+		type Example struct {
+			ID    int               `json:"user_id,omitempty" gojson:",required"`
+			Count int               `json:"count" gojson:",string"`
+			Role  string            `json:"role" gojson:",default=member"`
+			Name  string            `json:"name" gojson:",nonempty"`
+			Alias string            `json:"-" gojson:",required"`
+			Meta  Meta              `json:"meta"`
+			Extra map[string]string `json:"-" gojson:",inline"`
+		}
+
+		var e Example
+		err := Unmarshal([]byte(`{"user_id": 7, "count": "12", "name": "x", "alias": "a", "meta": {"source": "y"}, "other": "z"}`), &e)
+		assert.Nil(t, err)
+		assert.Equal(t, Example{ID: 7, Count: 12, Role: "member", Name: "x", Alias: "a", Meta: Meta{Source: "y"}, Extra: map[string]string{"other": "z"}}, e)
+
+		assert.EqualError(t, Unmarshal([]byte(`{"count": "1", "name": "x"}`), &e), "required key 'user_id' for struct 'Example' was not found")
+		assert.EqualError(t, Unmarshal([]byte(`{"user_id": 1, "name": ""}`), &e), "nonempty key 'name' for struct 'Example' has string zero value")
+
+		m, err := json.Marshal(Example{Count: 3, Role: "admin"})
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"count": 3, "role": "admin", "name": "", "meta": {"source": ""}}`, string(m))
+	})
+
+	t.Run("Nameless Options", func(t *testing.T) {
+		type Example struct {
+			ID     int    `gojson:",required"`
+			Name   string `json:",nonempty"`
+			UserID int    `json:",required"`
+		}
+
+		var e Example
+		assert.Nil(t, Unmarshal([]byte(`{"id": 1, "name": "x", "userid": 2}`), &e))
+		assert.Equal(t, Example{ID: 1, Name: "x", UserID: 2}, e)
+
+		err := Unmarshal([]byte(`{"name": "x", "userid": 2}`), &e)
+		var re *RequiredFieldError
+		if assert.ErrorAs(t, err, &re) {
+			assert.Equal(t, []string{"id"}, re.Keys)
+		}
+
+		err = Unmarshal([]byte(`{"id": 1, "name": "", "userid": 2}`), &e)
+		var ne *NonEmptyFieldError
+		if assert.ErrorAs(t, err, &ne) {
+			assert.Equal(t, "name", ne.Key)
+		}
+
+		// The key convention names the field, and the options still apply.
+		err = UnmarshalWithOptions([]byte(`{"id": 1, "name": "x", "userid": 2}`), &e, Options{KeyConvention: SnakeCase})
+		assert.EqualError(t, err, "required key 'user_id' for struct 'Example' was not found")
+	})
+
 	t.Run("Mixed Tags", func(t *testing.T) {
 		type Example struct {
 			GoJSON        string `gojson:"gojson"`
@@ -1637,3 +1857,603 @@ func TestUnmarshalKeyEncoding(t *testing.T) {
 
 	assert.Equal(t, object, out)
 }
+
+func TestUnmarshalCaseInsensitiveKey(t *testing.T) {
+	type Test struct {
+		UserID   int    `json:"userId,required"`
+		Name     string `json:"name"`
+		Nickname string `json:"NickName"`
+	}
+
+	var t1 Test
+	err := Unmarshal([]byte(`{"USERID": 1, "Name": "Bob", "nickname": "B"}`), &t1)
+	assert.Nil(t, err)
+	assert.Equal(t, Test{UserID: 1, Name: "Bob", Nickname: "B"}, t1)
+
+	var t2 Test
+	err = Unmarshal([]byte(`{"name": "Bob"}`), &t2)
+	assert.Equal(t, "required key 'userId' for struct 'Test' was not found", err.Error())
+}
+
+func TestUnmarshalKeyNormalizer(t *testing.T) {
+	type Test struct {
+		UserID    int    `json:"userId"`
+		FirstName string `json:"firstName"`
+	}
+
+	RegisterKeyNormalizer(func(key string) string {
+		return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	})
+	defer RegisterKeyNormalizer(nil)
+
+	var t1 Test
+	err := Unmarshal([]byte(`{"user_id": 1, "first-name": "Bob"}`), &t1)
+	assert.Nil(t, err)
+	assert.Equal(t, Test{UserID: 1, FirstName: "Bob"}, t1)
+
+	RegisterKeyNormalizer(nil)
+
+	var t2 Test
+	err = Unmarshal([]byte(`{"user_id": 1, "first-name": "Bob"}`), &t2)
+	assert.Nil(t, err)
+	assert.Equal(t, Test{}, t2)
+}
+
+func TestUnmarshalStringOption(t *testing.T) {
+	type Test struct {
+		ID      int64   `json:"id,string"`
+		Price   float64 `json:"price,string"`
+		Active  bool    `json:"active,string"`
+		Name    string  `json:"name,string"`
+		Count   *uint   `json:"count,string,omitempty"`
+		Ignored []int   `json:"ignored,string"`
+	}
+
+	t.Run("Quoted Values", func(t *testing.T) {
+		var m Test
+		err := UnmarshalStrict([]byte(`{"id": "9007199254740993", "price": "1.5", "active": "true", "name": "\"Bob\"", "count": "3", "ignored": [1]}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(9007199254740993), m.ID)
+		assert.Equal(t, 1.5, m.Price)
+		assert.True(t, m.Active)
+		assert.Equal(t, "Bob", m.Name)
+		assert.Equal(t, uint(3), *m.Count)
+		assert.Equal(t, []int{1}, m.Ignored)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		var m Test
+		err := Unmarshal([]byte(`{"id": null}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), m.ID)
+	})
+
+	t.Run("Unquoted Values", func(t *testing.T) {
+		var m Test
+		err := Unmarshal([]byte(`{"id": 7, "active": true}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(7), m.ID)
+		assert.True(t, m.Active)
+
+		err = UnmarshalStrict([]byte(`{"id": 7}`), &m)
+		assert.Equal(t, "key 'id' for struct 'Test' has invalid ,string value 7", err.Error())
+	})
+
+	t.Run("Invalid Quoted Value", func(t *testing.T) {
+		var m Test
+		err := Unmarshal([]byte(`{"id": "seven"}`), &m)
+		assert.Equal(t, `key 'id' for struct 'Test' has invalid ,string value "seven"`, err.Error())
+
+		err = Unmarshal([]byte(`{"id": "1.5"}`), &m)
+		assert.NotNil(t, err)
+
+		err = Unmarshal([]byte(`{"name": "Bob"}`), &m)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Not An Alternate Key", func(t *testing.T) {
+		var m Test
+		err := Unmarshal([]byte(`{"string": "5"}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), m.ID)
+	})
+}
+
+func TestUnmarshalDefaultOption(t *testing.T) {
+	type Inner struct {
+		Level string `json:"level,default=info"`
+	}
+
+	type Test struct {
+		Inner
+		Retries int      `json:"retries,default=3"`
+		Ratio   float64  `json:"ratio,default=0.5"`
+		Verbose bool     `json:"verbose,default=true"`
+		Mode    string   `json:"mode,alt,default=fast"`
+		Quoted  string   `json:"quoted,default=\"x\\ty\""`
+		Limit   *int     `json:"limit,default=10"`
+		Tags    []string `json:"tags,default=[\"a\"]"`
+		Name    string   `json:"name,required,default=unused"`
+	}
+
+	t.Run("Missing", func(t *testing.T) {
+		var m Test
+		err := Unmarshal([]byte(`{"name": "n"}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, m.Retries)
+		assert.Equal(t, 0.5, m.Ratio)
+		assert.True(t, m.Verbose)
+		assert.Equal(t, "fast", m.Mode)
+		assert.Equal(t, "x\ty", m.Quoted)
+		assert.Equal(t, 10, *m.Limit)
+		assert.Equal(t, []string{"a"}, m.Tags)
+		assert.Equal(t, "info", m.Level)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		var m Test
+		err := Unmarshal([]byte(`{"name": "n", "retries": null, "limit": null, "level": null}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, m.Retries)
+		assert.Equal(t, 10, *m.Limit)
+		assert.Equal(t, "info", m.Level)
+	})
+
+	t.Run("Present", func(t *testing.T) {
+		var m Test
+		err := Unmarshal([]byte(`{"name": "n", "retries": 0, "verbose": false, "alt": "slow", "level": "debug", "tags": []}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, m.Retries)
+		assert.False(t, m.Verbose)
+		assert.Equal(t, "slow", m.Mode)
+		assert.Equal(t, "debug", m.Level)
+		assert.Equal(t, 0.5, m.Ratio)
+	})
+
+	t.Run("Required Still Applies", func(t *testing.T) {
+		var m Test
+		err := Unmarshal([]byte(`{"retries": 1}`), &m)
+		assert.Equal(t, "required key 'name' for struct 'Test' was not found", err.Error())
+	})
+
+	t.Run("Empty Object", func(t *testing.T) {
+		var m struct {
+			Retries int `json:"retries,default=3"`
+		}
+		err := Unmarshal([]byte(`{}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, m.Retries)
+	})
+
+	t.Run("Invalid Default", func(t *testing.T) {
+		type Bad struct {
+			Retries int      `json:"retries,default=3"`
+			Blocked chan int `json:"blocked,default=1"`
+		}
+		var m Bad
+		err := Unmarshal([]byte(`{}`), &m)
+		assert.Equal(t, "invalid default for key 'blocked' for struct 'Bad': Unmarshal: Invalid Container Type 'chan'", err.Error())
+	})
+}
+
+func TestUnmarshalRawMessage(t *testing.T) {
+	type Test struct {
+		Object  RawMessage            `json:"object"`
+		String  json.RawMessage       `json:"string"`
+		Null    RawMessage            `json:"null"`
+		Pointer *RawMessage           `json:"pointer"`
+		Slice   []RawMessage          `json:"slice"`
+		Map     map[string]RawMessage `json:"map"`
+	}
+
+	data := []byte(`{"object": {"a": [1, 2.50], "b": "c\"d"}, "string": "x\u0041", "null": null, "pointer": 1e3, "slice": [true, {"e": null}], "map": {"k": [ 1 ]}}`)
+
+	var m Test
+	err := UnmarshalStrict(data, &m)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a": [1, 2.50], "b": "c\"d"}`, string(m.Object))
+	assert.Equal(t, `"x\u0041"`, string(m.String))
+	assert.Equal(t, `null`, string(m.Null))
+	assert.Equal(t, `1e3`, string(*m.Pointer))
+	assert.Equal(t, []RawMessage{RawMessage(`true`), RawMessage(`{"e": null}`)}, m.Slice)
+	assert.Equal(t, map[string]RawMessage{"k": RawMessage(`[ 1 ]`)}, m.Map)
+
+	// The raw bytes are a copy, and don't alias the input.
+	data[13] = 'z'
+	assert.Equal(t, `{"a": [1, 2.50], "b": "c\"d"}`, string(m.Object))
+
+	var root RawMessage
+	err = Unmarshal([]byte(` [1, 2] `), &root)
+	assert.Nil(t, err)
+	assert.Equal(t, `[1, 2]`, string(root))
+}
+
+func TestUnmarshalArray(t *testing.T) {
+	type Point struct {
+		X int `json:"x"`
+	}
+
+	type Test struct {
+		Ints    [4]int      `json:"ints"`
+		Strings [2]string   `json:"strings"`
+		Points  [2]*Point   `json:"points"`
+		Nested  [2][2]int   `json:"nested"`
+		Null    [2]int      `json:"null"`
+		Slices  [][2]string `json:"slices"`
+	}
+
+	data := []byte(`{"ints": [1, 2], "strings": ["a", "b", "c"], "points": [{"x": 1}, {"x": 2}], "nested": [[1, 2], [3]], "null": null, "slices": [["a"], ["b", "c"]]}`)
+
+	m := Test{Ints: [4]int{9, 9, 9, 9}, Null: [2]int{5, 6}}
+	err := UnmarshalStrict(data, &m)
+	assert.Nil(t, err)
+	assert.Equal(t, [4]int{1, 2, 0, 0}, m.Ints)
+	assert.Equal(t, [2]string{"a", "b"}, m.Strings)
+	assert.Equal(t, 2, m.Points[1].X)
+	assert.Equal(t, [2][2]int{{1, 2}, {3, 0}}, m.Nested)
+	assert.Equal(t, [2]int{5, 6}, m.Null)
+	assert.Equal(t, [][2]string{{"a", ""}, {"b", "c"}}, m.Slices)
+
+	var root [3]float64
+	err = Unmarshal([]byte(`[1.5, "2"]`), &root)
+	assert.Nil(t, err)
+	assert.Equal(t, [3]float64{1.5, 2, 0}, root)
+
+	// As with slices, a scalar is treated as a single element, unless strict standards are in effect.
+	var scalar [2]int
+	err = Unmarshal([]byte(`7`), &scalar)
+	assert.Nil(t, err)
+	assert.Equal(t, [2]int{7, 0}, scalar)
+
+	err = UnmarshalStrict([]byte(`7`), &scalar)
+	assert.EqualError(t, err, "strict standards error, expected array, got int, offset 0")
+
+	// The result matches encoding/json.
+	var expected, actual Test
+	assert.Nil(t, json.Unmarshal(data, &expected))
+	assert.Nil(t, Unmarshal(data, &actual))
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnmarshalTupleOption(t *testing.T) {
+	type LatLng struct {
+		Lat float64
+		Lng float64
+	}
+
+	type Sample struct {
+		Time  int64   `json:"t,required"`
+		Value float64 `json:"v,default=-1"`
+		Label string  `json:"label,oneof=ok|bad"`
+	}
+
+	type Test struct {
+		Location LatLng            `json:"loc,tuple"`
+		Path     []LatLng          `json:"path,tuple"`
+		Samples  []Sample          `json:"samples,tuple"`
+		Named    map[string]LatLng `json:"named,tuple"`
+		Plain    LatLng            `json:"plain"`
+		Pointer  *LatLng           `json:"ptr,tuple"`
+	}
+
+	data := []byte(`{
+		"loc": [51.5, -0.12, 99],
+		"path": [[1, 2], [3, 4]],
+		"samples": [[100, 1.5, "ok"], [200, null], [300]],
+		"named": {"home": [5, 6]},
+		"plain": [7, 8],
+		"ptr": {"lat": 9, "lng": 10}
+	}`)
+
+	var m Test
+	err := Unmarshal(data, &m)
+	assert.Nil(t, err)
+	assert.Equal(t, LatLng{51.5, -0.12}, m.Location)
+	assert.Equal(t, []LatLng{{1, 2}, {3, 4}}, m.Path)
+	assert.Equal(t, []Sample{{100, 1.5, "ok"}, {200, -1, ""}, {300, -1, ""}}, m.Samples)
+	assert.Equal(t, map[string]LatLng{"home": {5, 6}}, m.Named)
+	assert.Equal(t, LatLng{}, m.Plain)
+	assert.Equal(t, LatLng{9, 10}, *m.Pointer)
+
+	t.Run("Strict", func(t *testing.T) {
+		var m struct {
+			Location LatLng `json:"loc,tuple"`
+		}
+		err := UnmarshalStrict([]byte(`{"loc": [1.5, "2"]}`), &m)
+		assert.True(t, strings.HasPrefix(err.Error(), "strict standards error, expected float, got string"))
+	})
+
+	t.Run("Tag Options", func(t *testing.T) {
+		var m struct {
+			Samples []Sample `json:"samples,tuple"`
+		}
+		err := Unmarshal([]byte(`{"samples": [[]]}`), &m)
+		assert.EqualError(t, err, "required key 't' for struct 'Sample' was not found")
+
+		err = Unmarshal([]byte(`{"samples": [[1, 2, "maybe"]]}`), &m)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "label")
+	})
+}
+
+func TestUnmarshalBase64(t *testing.T) {
+	type Test struct {
+		Raw     []byte            `json:"raw"`
+		Encoded []byte            `json:"encoded,base64"`
+		Map     map[string][]byte `json:"map,base64"`
+		Null    []byte            `json:"null,base64"`
+	}
+
+	data := []byte(`{"raw": "aGk=", "encoded": "aGk\/Pz8=", "map": {"a": "AAE="}, "null": null}`)
+
+	var m Test
+	assert.Nil(t, Unmarshal(data, &m))
+	assert.Equal(t, []byte("aGk="), m.Raw)
+	assert.Equal(t, []byte("hi???"), m.Encoded)
+	assert.Equal(t, map[string][]byte{"a": {0, 1}}, m.Map)
+	assert.Nil(t, m.Null)
+
+	// With Base64Bytes, every []byte is decoded, which matches encoding/json.
+	var expected, actual Test
+	assert.Nil(t, json.Unmarshal(data, &expected))
+	assert.Nil(t, UnmarshalWithOptions(data, &actual, Options{Base64Bytes: true}))
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, []byte("hi"), actual.Raw)
+
+	// The encoded form round trips through AppendMarshal.
+	out, err := AppendMarshal(nil, actual)
+	assert.Nil(t, err)
+	var again Test
+	assert.Nil(t, UnmarshalWithOptions(out, &again, Options{Base64Bytes: true}))
+	assert.Equal(t, actual, again)
+
+	err = Unmarshal([]byte(`{"encoded": "not base64!"}`), &m)
+	assert.EqualError(t, err, "invalid base64 value 'not base64!' for []byte: illegal base64 data at input byte 3")
+
+	err = Unmarshal([]byte(`{"encoded": [1, 2]}`), &m)
+	assert.EqualError(t, err, "attempt to unmarshal JSON value with type 'array' into base64 []byte")
+}
+
+func TestUnmarshalHeterogeneousArray(t *testing.T) {
+	type Point struct {
+		X int `json:"x"`
+	}
+
+	decode := func(dtype string, raw []byte) (interface{}, error) {
+		switch dtype {
+		case JSONObject:
+			var p Point
+			return p, Unmarshal(raw, &p)
+		case JSONArray:
+			var tags []string
+			return tags, Unmarshal(raw, &tags)
+		case JSONString:
+			var s string
+			return s, Unmarshal(raw, &s)
+		}
+		return string(raw), nil
+	}
+
+	values, err := UnmarshalArray([]byte(`[{"x": 1}, ["a", "b"], "c\"d", 7, null]`), decode)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{Point{X: 1}, []string{"a", "b"}, `c"d`, "7", "null"}, values)
+
+	values, err = UnmarshalArray([]byte(`[]`), decode)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{}, values)
+
+	values, err = UnmarshalArray([]byte(`null`), decode)
+	assert.Nil(t, err)
+	assert.Nil(t, values)
+
+	_, err = UnmarshalArray([]byte(`{"x": 1}`), decode)
+	assert.EqualError(t, err, "expected a JSON array, found JSON type 'object'")
+
+	_, err = UnmarshalArray([]byte(`[1, `), decode)
+	assert.Equal(t, ErrMalformedJSON, err)
+
+	_, err = UnmarshalArray([]byte(`[{"x": 1}, {"x": 2}]`), func(dtype string, raw []byte) (interface{}, error) {
+		var p Point
+		if err := Unmarshal(raw, &p); err != nil {
+			return nil, err
+		}
+		if p.X > 1 {
+			return nil, errors.New("x out of range")
+		}
+		return p, nil
+	})
+	assert.EqualError(t, err, "array element 1: x out of range")
+}
+
+func TestUnmarshalerErrorLocation(t *testing.T) {
+	type Item struct {
+		Data TestComponentItemData `json:"data"`
+	}
+
+	type Test struct {
+		Items []Item                     `json:"items"`
+		Named map[string]pointerReceiver `json:"named"`
+	}
+
+	data := []byte(`  {"items": [{"data": {"title": "a"}}, {"data": {"title": 7}}]}`)
+
+	var m Test
+	err := Unmarshal(data, &m)
+	assert.EqualError(t, err, "items.1.data.title: ComponentTitle: ComponentTitle: No extraction policy for type 'int'")
+
+	var ue *UnmarshalerError
+	if assert.True(t, errors.As(err, &ue)) {
+		assert.Equal(t, "items.1.data.title", ue.Field)
+		assert.Equal(t, bytes.Index(data, []byte(`7}`)), ue.Offset)
+		assert.Equal(t, reflect.TypeOf(ComponentTitle{}), ue.Type)
+		assert.EqualError(t, ue.Err, "ComponentTitle: No extraction policy for type 'int'")
+	}
+
+	err = Unmarshal([]byte(`{"named": {"a": {"A": "b"}}}`), &m)
+	assert.EqualError(t, err, "named.a: pointerReceiver: pointerReceiver Unmarshaler Called")
+
+	// The root value is not wrapped, as its location is already known.
+	var root pointerReceiver
+	err = Unmarshal([]byte(`{"A": "b"}`), &root)
+	assert.EqualError(t, err, "pointerReceiver Unmarshaler Called")
+}
+
+func TestUnmarshalIntegerOverflow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		target   interface{}
+		expected interface{}
+		field    string
+	}{
+		{name: "Int8 Max", json: `127`, target: new(int8), expected: int8(127)},
+		{name: "Int8 Overflow", json: `300`, target: new(int8)},
+		{name: "Int8 Underflow", json: `-129`, target: new(int8)},
+		{name: "Int16 String", json: `"40000"`, target: new(int16)},
+		{name: "Int32 Float", json: `3e9`, target: new(int32)},
+		{name: "Int64 Overflow", json: `9223372036854775808`, target: new(int64)},
+		{name: "Int Float Overflow", json: `1e300`, target: new(int)},
+		{name: "Uint Negative", json: `-1`, target: new(uint)},
+		{name: "Uint Negative Float", json: `-2.5`, target: new(uint)},
+		{name: "Uint Negative Zero", json: `-0`, target: new(uint), expected: uint(0)},
+		{name: "Uint8 Overflow", json: `256`, target: new(uint8)},
+		{name: "Uint64 Max", json: `18446744073709551615`, target: new(uint64), expected: uint64(18446744073709551615)},
+		{name: "Uint64 Overflow", json: `18446744073709551616`, target: new(uint64)},
+		{name: "Float32 Max", json: `3.4e38`, target: new(float32), expected: float32(3.4e38)},
+		{name: "Float32 Overflow", json: `1e39`, target: new(float32)},
+		{name: "Float32 Underflow", json: `-1e39`, target: new(float32)},
+		{name: "Slice", json: `[1, 2, 300]`, target: new([]int8), field: "2"},
+		{name: "Fast Slice", json: `[1, 2, 1e100]`, target: new([]int), field: "2"},
+		{name: "Array", json: `[1, -1]`, target: new([2]uint), field: "1"},
+		{name: "Map", json: `{"a": 1, "b": 70000}`, target: new(map[string]uint16), field: "b"},
+		{name: "Fast Map", json: `{"a": [1], "b": [2, 1e100]}`, target: new(map[string][]int), field: "b.1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, strict := range []bool{false, true} {
+				err := UnmarshalWithOptions([]byte(tc.json), tc.target, Options{StrictStandards: strict})
+				if tc.expected != nil {
+					assert.Nil(t, err)
+					assert.Equal(t, tc.expected, reflect.ValueOf(tc.target).Elem().Interface())
+					continue
+				}
+
+				// Strict standards rejects strings and floats before their range is checked.
+				if strict && (strings.Contains(tc.json, `"`) || strings.ContainsAny(tc.json, ".e")) {
+					assert.NotNil(t, err)
+					continue
+				}
+
+				var te *UnmarshalTypeError
+				if assert.True(t, errors.As(err, &te), "%v", err) {
+					assert.Equal(t, tc.field, te.Field)
+				}
+			}
+		})
+	}
+
+	t.Run("Field Path", func(t *testing.T) {
+		var m struct {
+			Items []struct {
+				Counts map[string]int8 `json:"counts"`
+			} `json:"items"`
+		}
+
+		err := Unmarshal([]byte(`{"items": [{"counts": {"a": 1}}, {"counts": {"a": 1, "b": 300}}]}`), &m)
+		assert.EqualError(t, err, "key 'items.1.counts.b' with value '300' is out of range for type 'int8'")
+
+		var te *UnmarshalTypeError
+		assert.True(t, errors.As(err, &te))
+		assert.Equal(t, reflect.TypeOf(int8(0)), te.Type)
+		assert.Equal(t, 58, te.Offset)
+	})
+}
+
+func TestStructDescriptorCache(t *testing.T) {
+	type Test struct {
+		ID   int    `json:"id,required"`
+		Name string `json:"name,alias,nonempty"`
+	}
+
+	tt := reflect.TypeOf(Test{})
+	d := getStructInfo(tt, DefaultKeys)
+	assert.Same(t, d, getStructInfo(tt, DefaultKeys))
+	assert.NotSame(t, d, getStructInfo(tt, SnakeCase))
+
+	// Concurrent decodes share the cached descriptor.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			var m Test
+			assert.Nil(t, Unmarshal([]byte(fmt.Sprintf(`{"id": %d, "alias": "n"}`, i)), &m))
+			assert.Equal(t, Test{ID: i, Name: "n"}, m)
+		}(i)
+	}
+	wg.Wait()
+
+	var m Test
+	err := Unmarshal([]byte(`{"id": 1, "alias": ""}`), &m)
+	assert.Equal(t, "nonempty key 'name' for struct 'Test' has string zero value", err.Error())
+
+	sdc.Reset()
+	assert.Nil(t, sdc.Get(tt, DefaultKeys))
+	assert.NotSame(t, d, getStructInfo(tt, DefaultKeys))
+}
+
+func TestUnmarshalInline(t *testing.T) {
+	type Base struct {
+		Kind  string            `json:"kind"`
+		Other map[string]string `json:",remain"`
+	}
+
+	type Extension struct {
+		ID    int                    `json:"id"`
+		Name  string                 `json:"name"`
+		Extra map[string]interface{} `json:",inline"`
+	}
+
+	t.Run("Collects Unclaimed Keys", func(t *testing.T) {
+		var v Extension
+		assert.Nil(t, Unmarshal([]byte(`{"id": 7, "x-color": "red", "name": "seven", "x-tags": [1, 2], "Extra": 3}`), &v))
+		assert.Equal(t, 7, v.ID)
+		assert.Equal(t, "seven", v.Name)
+		assert.Equal(t, map[string]interface{}{"x-color": "red", "x-tags": []interface{}{1, 2}, "Extra": 3}, v.Extra)
+	})
+
+	t.Run("Nothing Unclaimed", func(t *testing.T) {
+		var v Extension
+		assert.Nil(t, Unmarshal([]byte(`{"id": 7}`), &v))
+		assert.Nil(t, v.Extra)
+	})
+
+	t.Run("Typed Values", func(t *testing.T) {
+		var v Base
+		assert.Nil(t, Unmarshal([]byte(`{"kind": "a", "b": "c", "d": 5}`), &v))
+		assert.Equal(t, map[string]string{"b": "c", "d": "5"}, v.Other)
+
+		err := UnmarshalStrict([]byte(`{"kind": "a", "d": 5}`), &v)
+		assert.EqualError(t, err, "strict standards error, expected string, got int at path 'd', offset 19")
+	})
+
+	t.Run("Embedded", func(t *testing.T) {
+		var v struct {
+			Base
+			Size int `json:"size"`
+		}
+		assert.Nil(t, Unmarshal([]byte(`{"kind": "a", "size": 2, "b": "c"}`), &v))
+		assert.Equal(t, 2, v.Size)
+		assert.Equal(t, map[string]string{"b": "c"}, v.Other)
+	})
+
+	t.Run("Not Dropped", func(t *testing.T) {
+		var v Extension
+		report, err := UnmarshalWithReport([]byte(`{"id": 7, "x-color": "red"}`), &v)
+		assert.Nil(t, err)
+		assert.Empty(t, report.Dropped)
+		assert.Equal(t, "red", v.Extra["x-color"])
+	})
+}
@@ -14,6 +14,96 @@ func Indent(b []byte) *bytes.Buffer {
 	return &i
 }
 
+// Minify returns a copy of the JSON document with all insignificant whitespace removed. Key order,
+// duplicate keys, string escapes, and number formatting are preserved exactly.
+func Minify(b []byte) ([]byte, error) {
+	return reformat(b, "")
+}
+
+// Prettify returns a copy of the JSON document with each object member and array element on its
+// own line, indented by indent per level of nesting, or by a tab if indent is empty. Empty objects
+// and arrays remain on one line. Key order, duplicate keys, string escapes, and number formatting are preserved exactly.
+func Prettify(b []byte, indent string) ([]byte, error) {
+	if indent == "" {
+		indent = "\t"
+	}
+	return reformat(b, indent)
+}
+
+// reformat rewrites the whitespace of a JSON document. An empty indent produces compact output.
+func reformat(b []byte, indent string) ([]byte, error) {
+	b = trim(b)
+	if len(b) == 0 {
+		return nil, ErrEmpty
+	}
+
+	if !IsJSON(b) {
+		return nil, ErrMalformedJSON
+	}
+
+	out := make([]byte, 0, len(b))
+	depth := 0
+
+	newline := func() {
+		out = append(out, '\n')
+		for i := 0; i < depth; i++ {
+			out = append(out, indent...)
+		}
+	}
+
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+
+		switch c {
+		case ' ', '\t', '\n', '\r', '\f':
+			continue
+		case '"':
+			start := i
+			for i++; i < len(b) && b[i] != '"'; i++ {
+				if b[i] == '\\' {
+					i++
+				}
+			}
+			out = append(out, b[start:i+1]...)
+			continue
+		}
+
+		if indent == "" {
+			out = append(out, c)
+			continue
+		}
+
+		switch c {
+		case '{', '[':
+			out = append(out, c)
+
+			// Keep empty objects and arrays on one line.
+			next := ltrim(b, i+1)
+			if b[next] == '}' || b[next] == ']' {
+				out = append(out, b[next])
+				i = next
+				continue
+			}
+
+			depth++
+			newline()
+		case '}', ']':
+			depth--
+			newline()
+			out = append(out, c)
+		case ',':
+			out = append(out, c)
+			newline()
+		case ':':
+			out = append(out, ':', ' ')
+		default:
+			out = append(out, c)
+		}
+	}
+
+	return out, nil
+}
+
 // PanicRecovery returns a general use Panic Recovery function to capture panics
 // and returns them as errors. A pointer to the error to populate will be passed
 // in via the err parameter. err must be addressable.
@@ -53,7 +143,7 @@ func panicOrigin(raw []byte) string {
 	return strings.TrimSpace(string(stack[8]))
 }
 
-// Truncate returns a truncated byte slice if the length of the original slice is greater
+// truncate returns a truncated byte slice if the length of the original slice is greater
 // than a given max.
 func truncate(b []byte, max int) []byte {
 	if len(b) <= max {
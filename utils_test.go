@@ -52,3 +52,58 @@ func TestPanicRecoveryInterface(t *testing.T) {
 
 	panic(things)
 }
+
+func TestMinify(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		expected string
+		err      error
+	}{
+		{name: "Object", json: "{\n\t\"b\" : 1.50,\r\n \"a\": [ 1e3 , -0.0, true ] }", expected: `{"b":1.50,"a":[1e3,-0.0,true]}`},
+		{name: "Duplicate Keys", json: `{ "a": 1, "a": 2 }`, expected: `{"a":1,"a":2}`},
+		{name: "Strings Untouched", json: `[ "a b", "\" ,: [", "\\", "\u00e9\n" ]`, expected: `["a b","\" ,: [","\\","\u00e9\n"]`},
+		{name: "Empty Containers", json: `{ "a": { }, "b": [ ] }`, expected: `{"a":{},"b":[]}`},
+		{name: "Scalar", json: `  17  `, expected: `17`},
+		{name: "Empty", json: ` `, err: ErrEmpty},
+		{name: "Malformed", json: `{"a": }`, err: ErrMalformedJSON},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Minify([]byte(tc.json))
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.expected, string(actual))
+			}
+		})
+	}
+}
+
+func TestPrettify(t *testing.T) {
+	actual, err := Prettify([]byte(`{"b":1.50,"a":[1e3, {"x": "y,z"}, [], {}],"c":{ }}`), "  ")
+	assert.Nil(t, err)
+	assert.Equal(t, `{
+  "b": 1.50,
+  "a": [
+    1e3,
+    {
+      "x": "y,z"
+    },
+    [],
+    {}
+  ],
+  "c": {}
+}`, string(actual))
+
+	actual, err = Prettify([]byte(`[1,2]`), "")
+	assert.Nil(t, err)
+	assert.Equal(t, "[\n\t1,\n\t2\n]", string(actual))
+
+	actual, err = Prettify([]byte(`"a"`), "  ")
+	assert.Nil(t, err)
+	assert.Equal(t, `"a"`, string(actual))
+
+	_, err = Prettify([]byte(`[1,`), "  ")
+	assert.Equal(t, ErrMalformedJSON, err)
+}
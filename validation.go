@@ -0,0 +1,180 @@
+package gojson
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validation is a single constraint declared on a struct field's json or gojson tag.
+// Validations are evaluated by Unmarshal after the field has been decoded.
+//
+// Supported rules:
+//
+//	min=N       numeric fields must be >= N
+//	max=N       numeric fields must be <= N
+//	minlen=N    strings (in runes), slices, arrays, and maps must have a length >= N
+//	maxlen=N    strings (in runes), slices, arrays, and maps must have a length <= N
+//	pattern=RE  strings must match the regular expression RE
+//	oneof=A|B|C the value, formatted as a string, must be one of the pipe separated options
+//
+// Example:
+//
+//	type Person struct {
+//		Age   int    `json:"age,min=0,max=150"`
+//		Name  string `json:"name,required,minlen=1,maxlen=64"`
+//		Role  string `json:"role,oneof=admin|user|guest"`
+//		Email string `json:"email,pattern=^[^@]+@[^@]+$"`
+//	}
+//
+// Since tag options are comma separated, patterns may not contain a comma.
+type Validation struct {
+	// Rule is the name of the constraint (min, max, minlen, maxlen, pattern, oneof).
+	Rule string
+
+	// Param is the raw parameter given to the rule.
+	Param string
+
+	number  float64
+	pattern *regexp.Regexp
+	options []string
+}
+
+// ValidationError describes a single field which failed a tag validation during Unmarshal.
+type ValidationError struct {
+	// Struct is the name of the struct type containing the field.
+	Struct string
+
+	// Key is the primary JSON key of the field.
+	Key string
+
+	// Rule and Param identify the constraint which was violated.
+	Rule  string
+	Param string
+
+	// Value is the decoded value which failed validation.
+	Value interface{}
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("key '%s' for struct '%s' failed validation '%s=%s' with value '%v'", e.Key, e.Struct, e.Rule, e.Param, e.Value)
+}
+
+// ValidationErrors is returned by Unmarshal when one or more fields failed their tag validations.
+// Every violation found in the document is reported, not just the first.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = v.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// parseValidation inspects a single tag option and returns the Validation it describes, if any.
+// Malformed parameters cause a panic, which Unmarshal reports as an error.
+func parseValidation(opt string) (Validation, bool) {
+	eq := strings.IndexByte(opt, '=')
+	if eq < 0 {
+		return Validation{}, false
+	}
+
+	v := Validation{Rule: strings.ToLower(opt[:eq]), Param: opt[eq+1:]}
+
+	switch v.Rule {
+	case "min", "max", "minlen", "maxlen":
+		n, err := strconv.ParseFloat(v.Param, 64)
+		if err != nil {
+			panic(fmt.Errorf("invalid parameter '%s' for validation '%s'", v.Param, v.Rule))
+		}
+		v.number = n
+	case "pattern":
+		re, err := regexp.Compile(v.Param)
+		if err != nil {
+			panic(fmt.Errorf("invalid parameter '%s' for validation '%s': %w", v.Param, v.Rule, err))
+		}
+		v.pattern = re
+	case "oneof":
+		v.options = strings.Split(v.Param, "|")
+	default:
+		return Validation{}, false
+	}
+
+	return v, true
+}
+
+// valid reports whether the given decoded value satisfies the validation.
+func (v Validation) valid(p reflect.Value) bool {
+	switch v.Rule {
+	case "min", "max":
+		var n float64
+		switch p.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = float64(p.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = float64(p.Uint())
+		case reflect.Float32, reflect.Float64:
+			n = p.Float()
+		default:
+			panic(fmt.Errorf("validation '%s' requires a numeric field, found '%s'", v.Rule, p.Kind()))
+		}
+
+		if v.Rule == "min" {
+			return n >= v.number
+		}
+		return n <= v.number
+	case "minlen", "maxlen":
+		var n int
+		switch p.Kind() {
+		case reflect.String:
+			n = utf8.RuneCountInString(p.String())
+		case reflect.Slice, reflect.Array, reflect.Map:
+			n = p.Len()
+		default:
+			panic(fmt.Errorf("validation '%s' requires a string, slice, array, or map field, found '%s'", v.Rule, p.Kind()))
+		}
+
+		if v.Rule == "minlen" {
+			return float64(n) >= v.number
+		}
+		return float64(n) <= v.number
+	case "pattern":
+		if p.Kind() != reflect.String {
+			panic(fmt.Errorf("validation '%s' requires a string field, found '%s'", v.Rule, p.Kind()))
+		}
+		return v.pattern.MatchString(p.String())
+	case "oneof":
+		s := fmt.Sprint(p.Interface())
+		for _, o := range v.options {
+			if s == o {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// validate runs every validation declared for the given struct key against its decoded value,
+// recording any violations on the unmarshaler.
+func (u *unmarshaler) validate(k StructKey, f reflect.Value, parent reflect.Type) {
+	for _, v := range k.Validations {
+		if v.valid(f) {
+			continue
+		}
+
+		u.violations = append(u.violations, ValidationError{
+			Struct: parent.Name(),
+			Key:    k.Name,
+			Rule:   v.Rule,
+			Param:  v.Param,
+			Value:  f.Interface(),
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package gojson
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validatedPerson struct {
+	Age   int      `json:"age,min=0,max=150"`
+	Score float64  `json:"score,min=0.5"`
+	Name  string   `json:"name,required,minlen=2,maxlen=8"`
+	Role  string   `json:"role,oneof=admin|user|guest"`
+	Email string   `json:"email,pattern=^[^@]+@[^@]+$"`
+	Tags  []string `json:"tags,maxlen=2"`
+}
+
+func TestUnmarshalValidation(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		var m validatedPerson
+		err := Unmarshal([]byte(`{"age": 42, "score": 1.5, "name": "Bob", "role": "admin", "email": "bob@example.com", "tags": ["a"]}`), &m)
+		assert.Nil(t, err)
+		assert.Equal(t, 42, m.Age)
+		assert.Equal(t, "Bob", m.Name)
+	})
+
+	t.Run("All Violations Reported", func(t *testing.T) {
+		var m validatedPerson
+		err := Unmarshal([]byte(`{"age": 200, "score": 0.1, "name": "B", "role": "root", "email": "bob", "tags": ["a", "b", "c"]}`), &m)
+
+		var verrs ValidationErrors
+		assert.True(t, errors.As(err, &verrs))
+		assert.Len(t, verrs, 6)
+		assert.Equal(t, ValidationError{Struct: "validatedPerson", Key: "age", Rule: "max", Param: "150", Value: 200}, verrs[0])
+		assert.Equal(t, "score", verrs[1].Key)
+		assert.Equal(t, "minlen", verrs[2].Rule)
+		assert.Equal(t, "oneof", verrs[3].Rule)
+		assert.Equal(t, "pattern", verrs[4].Rule)
+		assert.Equal(t, "maxlen", verrs[5].Rule)
+
+		// The struct is still populated.
+		assert.Equal(t, 200, m.Age)
+		assert.Equal(t, "root", m.Role)
+	})
+
+	t.Run("Error Message", func(t *testing.T) {
+		var m validatedPerson
+		err := Unmarshal([]byte(`{"age": -1, "name": "Bob"}`), &m)
+		assert.Equal(t, "key 'age' for struct 'validatedPerson' failed validation 'min=0' with value '-1'", err.Error())
+	})
+
+	t.Run("Missing And Null Keys Are Not Validated", func(t *testing.T) {
+		var m validatedPerson
+		err := Unmarshal([]byte(`{"name": "Bob", "role": null}`), &m)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Rune Length", func(t *testing.T) {
+		var m validatedPerson
+		err := Unmarshal([]byte(`{"name": "ééééééé"}`), &m)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Nested Structs", func(t *testing.T) {
+		var m struct {
+			People []validatedPerson `json:"people"`
+		}
+		err := Unmarshal([]byte(`{"people": [{"name": "Bob", "age": 151}, {"name": "Al", "age": 151}]}`), &m)
+
+		var verrs ValidationErrors
+		assert.True(t, errors.As(err, &verrs))
+		assert.Len(t, verrs, 2)
+	})
+
+	t.Run("Misconfigured Tag", func(t *testing.T) {
+		var m struct {
+			Name string `json:"name,min=1"`
+		}
+		err := Unmarshal([]byte(`{"name": "Bob"}`), &m)
+		assert.True(t, strings.HasPrefix(err.Error(), "validation 'min' requires a numeric field, found 'string'"))
+	})
+
+	t.Run("Invalid Parameter", func(t *testing.T) {
+		var m struct {
+			Age int `json:"age,min=abc"`
+		}
+		err := Unmarshal([]byte(`{"age": 1}`), &m)
+		assert.True(t, strings.HasPrefix(err.Error(), "invalid parameter 'abc' for validation 'min'"))
+	})
+}
+
+func TestParseValidation(t *testing.T) {
+	v, ok := parseValidation("oneof=a|b|c")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "b", "c"}, v.options)
+
+	_, ok = parseValidation("omitempty")
+	assert.False(t, ok)
+
+	_, ok = parseValidation("unknown=1")
+	assert.False(t, ok)
+}
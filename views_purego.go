@@ -0,0 +1,28 @@
+//go:build purego
+
+package gojson
+
+// zeroCopyViews is whether bytesToString shares the memory of its argument.
+const zeroCopyViews = false
+
+// bytesToString returns a copy of b as a string. Without unsafe, strings can't share the memory of
+// the document, so WithZeroCopyStrings has no effect.
+func bytesToString(b []byte) string {
+	return string(b)
+}
+
+// offsetIn returns the byte offset of b within doc, or -1 if b is not part of it. Without unsafe, b is
+// located by its capacity, which holds for the values sliced from the document by the parser and
+// scanner, and confirmed by comparing the address of its first byte.
+func offsetIn(doc, b []byte) int {
+	if len(b) == 0 || len(doc) == 0 {
+		return -1
+	}
+
+	off := cap(doc) - cap(b)
+	if off < 0 || off >= len(doc) || &doc[off] != &b[0] {
+		return -1
+	}
+
+	return off
+}
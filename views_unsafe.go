@@ -0,0 +1,32 @@
+//go:build !purego
+
+package gojson
+
+import "unsafe"
+
+// The helpers below let strings and offsets refer to the document without copying it. They are
+// implemented with unsafe here, and without it in views_purego.go, which the purego build tag selects
+// for environments such as TinyGo and WebAssembly plugins where unsafe is unavailable.
+
+// zeroCopyViews is whether bytesToString shares the memory of its argument.
+const zeroCopyViews = true
+
+// bytesToString returns a string sharing the memory of b, which must not be modified afterwards.
+func bytesToString(b []byte) string {
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// offsetIn returns the byte offset of b within doc, or -1 if b is not part of it.
+func offsetIn(doc, b []byte) int {
+	if len(b) == 0 || len(doc) == 0 {
+		return -1
+	}
+
+	start := uintptr(unsafe.Pointer(&doc[0]))
+	pos := uintptr(unsafe.Pointer(&b[0]))
+	if pos < start || pos >= start+uintptr(len(doc)) {
+		return -1
+	}
+
+	return int(pos - start)
+}
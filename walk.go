@@ -0,0 +1,58 @@
+package gojson
+
+// Walk performs a depth-first traversal of the document in document order, calling fn for every node
+// with its key path and a JSONReader holding it, as returned by Get. The root is visited first, with
+// an empty path. If fn returns false, the children of the node are skipped. If fn returns an error,
+// the walk stops and the error is returned. Duplicate keys are visited once.
+//
+// Example, collecting the strings which need trimming:
+//
+//	trimmed := map[string]string{}
+//	err := r.Walk(func(path string, node *gojson.JSONReader) (bool, error) {
+//		if node.Type == gojson.JSONString {
+//			if s := node.ToString(); s != strings.TrimSpace(s) {
+//				trimmed[path] = strings.TrimSpace(s)
+//			}
+//		}
+//		return path != "metadata", nil
+//	})
+func (jr *JSONReader) Walk(fn func(path string, node *JSONReader) (descend bool, err error)) error {
+	if jr.Empty {
+		return nil
+	}
+
+	root := jr.getChildByKey("")
+	if jr.Type != JSONObject && jr.Type != JSONArray {
+		// The scalar is held as the only child of the root, with its quotes removed.
+		c := jr.parsed["0"]
+		_, err := fn("", readerFor(&c))
+		return err
+	}
+
+	descend, err := fn("", readerFor(root))
+	if err != nil || !descend {
+		return err
+	}
+
+	return walk(*root, "", fn)
+}
+
+func walk(p parsed, prefix string, fn func(path string, node *JSONReader) (bool, error)) error {
+	for _, k := range uniqueString(p.keys, true) {
+		c := p.children[k]
+		path := joinPath(prefix, k)
+
+		descend, err := fn(path, readerFor(&c))
+		if err != nil {
+			return err
+		}
+
+		if descend {
+			if err := walk(c, path, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
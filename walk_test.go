@@ -0,0 +1,96 @@
+package gojson
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalk(t *testing.T) {
+	jr, err := NewJSONReader([]byte(`{"a": {"b": [1, " two "]}, "c": null, "d": {"e": "f"}, "c": 2}`))
+	assert.Nil(t, err)
+
+	t.Run("All Nodes", func(t *testing.T) {
+		var paths, types []string
+		err := jr.Walk(func(path string, node *JSONReader) (bool, error) {
+			paths = append(paths, path)
+			types = append(types, node.Type)
+			return true, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"", "a", "a.b", "a.b.0", "a.b.1", "c", "d", "d.e"}, paths)
+		assert.Equal(t, []string{JSONObject, JSONObject, JSONArray, JSONInt, JSONString, JSONInt, JSONObject, JSONString}, types)
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		trimmed := map[string]string{}
+		err := jr.Walk(func(path string, node *JSONReader) (bool, error) {
+			if node.Type == JSONString {
+				if s := node.ToString(); s != strings.TrimSpace(s) {
+					trimmed[path] = strings.TrimSpace(s)
+				}
+			}
+			return true, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"a.b.1": "two"}, trimmed)
+	})
+
+	t.Run("Skip Descent", func(t *testing.T) {
+		var paths []string
+		err := jr.Walk(func(path string, node *JSONReader) (bool, error) {
+			paths = append(paths, path)
+			return path != "a", nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"", "a", "c", "d", "d.e"}, paths)
+
+		paths = nil
+		err = jr.Walk(func(path string, node *JSONReader) (bool, error) {
+			paths = append(paths, path)
+			return false, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{""}, paths)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		stop := errors.New("stop")
+
+		var paths []string
+		err := jr.Walk(func(path string, node *JSONReader) (bool, error) {
+			paths = append(paths, path)
+			if path == "a.b.0" {
+				return false, stop
+			}
+			return true, nil
+		})
+		assert.Equal(t, stop, err)
+		assert.Equal(t, []string{"", "a", "a.b", "a.b.0"}, paths)
+	})
+
+	t.Run("Scalar Root", func(t *testing.T) {
+		jr, err := NewJSONReader([]byte(`"text"`))
+		assert.Nil(t, err)
+
+		var values []string
+		err = jr.Walk(func(path string, node *JSONReader) (bool, error) {
+			values = append(values, path+"="+node.ToString())
+			return true, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"=text"}, values)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		called := false
+		err := (&JSONReader{Empty: true}).Walk(func(path string, node *JSONReader) (bool, error) {
+			called = true
+			return true, nil
+		})
+		assert.Nil(t, err)
+		assert.False(t, called)
+	})
+}